@@ -0,0 +1,50 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/gorilla/mux"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"net/http"
+	"strconv"
+)
+
+func (g *Gateway) handleGETDirectory(w http.ResponseWriter, r *http.Request) {
+	var (
+		vendorsOnly bool
+		err         error
+	)
+	if v := r.URL.Query().Get("vendors"); v != "" {
+		vendorsOnly, err = strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, wrapError(fmt.Errorf("invalid vendors: %s", err)), http.StatusBadRequest)
+			return
+		}
+	}
+
+	entries, err := g.node.GetDirectory(vendorsOnly)
+	if err != nil {
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+	sanitizedJSONResponse(w, entries)
+}
+
+func (g *Gateway) handleGETDirectoryListings(w http.ResponseWriter, r *http.Request) {
+	pid, err := peer.IDB58Decode(mux.Vars(r)["peerID"])
+	if err != nil {
+		http.Error(w, wrapError(fmt.Errorf("invalid peer id: %s", err.Error())), http.StatusBadRequest)
+		return
+	}
+
+	index, err := g.node.GetCrawledListings(pid)
+	if errors.Is(err, coreiface.ErrNotFound) {
+		http.Error(w, wrapError(err), http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+	sanitizedJSONResponse(w, index)
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/models"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDirectoryHandlers(t *testing.T) {
+	entries := []models.DirectoryEntry{
+		{
+			PeerID:       "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub",
+			Vendor:       true,
+			ListingCount: 1,
+			LastCrawled:  time.Now(),
+			Profile:      &models.Profile{Name: "Ron Swanson", Vendor: true},
+		},
+	}
+	index := models.ListingIndex{
+		{
+			Slug:  "ron-swanson-shirt",
+			Title: "Ron Swanson Shirt",
+		},
+	}
+
+	runAPITests(t, apiTests{
+		{
+			name:   "Get directory",
+			path:   "/v1/ob/directory",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getDirectoryFunc = func(vendorsOnly bool) ([]models.DirectoryEntry, error) {
+					if vendorsOnly {
+						return nil, errors.New("incorrect vendors option")
+					}
+					return entries, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(entries)
+			},
+		},
+		{
+			name:   "Get directory vendors only",
+			path:   "/v1/ob/directory?vendors=true",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getDirectoryFunc = func(vendorsOnly bool) ([]models.DirectoryEntry, error) {
+					if !vendorsOnly {
+						return nil, errors.New("incorrect vendors option")
+					}
+					return entries, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(entries)
+			},
+		},
+		{
+			name:   "Get directory invalid vendors option",
+			path:   "/v1/ob/directory?vendors=abc",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getDirectoryFunc = func(vendorsOnly bool) ([]models.DirectoryEntry, error) {
+					return entries, nil
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "invalid vendors: strconv.ParseBool: parsing "abc": invalid syntax"}`)), nil
+			},
+		},
+		{
+			name:   "Get directory listings",
+			path:   "/v1/ob/directory/QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub/listings",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getCrawledListingsFunc = func(peerID peer.ID) (models.ListingIndex, error) {
+					if peerID.Pretty() != "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub" {
+						return nil, errors.New("incorrect peer ID")
+					}
+					return index, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(index)
+			},
+		},
+		{
+			name:   "Get directory listings not crawled",
+			path:   "/v1/ob/directory/QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub/listings",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getCrawledListingsFunc = func(peerID peer.ID) (models.ListingIndex, error) {
+					return nil, fmt.Errorf("%w: store not crawled", coreiface.ErrNotFound)
+				}
+			},
+			statusCode: http.StatusNotFound,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "not found: store not crawled"}`)), nil
+			},
+		},
+		{
+			name:   "Get directory listings invalid peer ID",
+			path:   "/v1/ob/directory/abc/listings",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getCrawledListingsFunc = func(peerID peer.ID) (models.ListingIndex, error) {
+					return index, nil
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "invalid peer id: length greater than remaining number of bytes in buffer"}`)), nil
+			},
+		},
+	})
+}
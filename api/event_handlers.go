@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"net/http"
+	"time"
+)
+
+// eventStreamKeepAliveInterval is how often a comment is written to idle
+// event streams so that proxies do not time out the connection.
+const eventStreamKeepAliveInterval = time.Second * 30
+
+// eventStream is a server-sent event stream registered with the hub.
+type eventStream struct {
+	send chan *wsEvent
+}
+
+// handleGETEvents streams the messages sent to the websocket as server-sent
+// events. Notifications carry their ID as the event ID so that a client
+// reconnecting with the Last-Event-ID header is first sent the notifications
+// it missed.
+func (g *Gateway) handleGETEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, wrapError(errors.New("streaming unsupported")), http.StatusInternalServerError)
+		return
+	}
+
+	// Register before loading the missed notifications so nothing is
+	// dropped in between. Duplicates are filtered out below.
+	stream := &eventStream{send: make(chan *wsEvent, 256)}
+	g.hub.registerStream <- stream
+	defer func() { g.hub.unregisterStream <- stream }()
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("lastEventID")
+	}
+
+	replayed := make(map[string]bool)
+	var missed [][]byte
+	var missedIDs []string
+	if lastEventID != "" {
+		records, err := g.node.GetNotificationsAfter(lastEventID)
+		if errors.Is(err, coreiface.ErrNotFound) {
+			// The notification may have been deleted. There is nothing
+			// to resume from so the client just receives new events.
+			log.Debugf("Event stream resumed from unknown notification %s", lastEventID)
+		} else if err != nil {
+			http.Error(w, wrapError(err), http.StatusInternalServerError)
+			return
+		}
+		for _, record := range records {
+			out, err := json.Marshal(struct {
+				Notification json.RawMessage `json:"notification"`
+			}{record.Notification})
+			if err != nil {
+				http.Error(w, wrapError(err), http.StatusInternalServerError)
+				return
+			}
+			missed = append(missed, out)
+			missedIDs = append(missedIDs, record.ID)
+			replayed[record.ID] = true
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for i, data := range missed {
+		if err := writeServerSentEvent(w, missedIDs[i], data); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(eventStreamKeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case e, ok := <-stream.send:
+			if !ok {
+				return
+			}
+			if e.id != "" && replayed[e.id] {
+				delete(replayed, e.id)
+				continue
+			}
+			if err := writeServerSentEvent(w, e.id, e.payload); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-g.shutdown:
+			return
+		}
+	}
+}
+
+// writeServerSentEvent writes a single event to the stream. The data must
+// not contain newlines, which holds for compact JSON.
+func writeServerSentEvent(w http.ResponseWriter, id string, data []byte) error {
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "data: %s\n\n", data)
+	return err
+}
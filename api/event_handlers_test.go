@@ -0,0 +1,187 @@
+package api
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type serverSentEvent struct {
+	id   string
+	data string
+}
+
+// readServerSentEvent reads the next event from the stream, skipping
+// comments.
+func readServerSentEvent(t *testing.T, reader *bufio.Reader) serverSentEvent {
+	var (
+		event = serverSentEvent{}
+		done  = make(chan error)
+	)
+	go func() {
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				done <- err
+				return
+			}
+			line = strings.TrimSuffix(line, "\n")
+			switch {
+			case line == "" && event.data != "":
+				done <- nil
+				return
+			case strings.HasPrefix(line, "id: "):
+				event.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				event.data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timed out waiting on event")
+	}
+	return event
+}
+
+func newEventsTestServer(t *testing.T, node *mockNode) (*Gateway, *httptest.Server) {
+	g := &Gateway{
+		node: node,
+		config: &GatewayConfig{
+			Username: "alice",
+			Password: "1c8bfe8f801d79745c4631d09fff36c82aa37fc4cce4fc946683d7b336b63032",
+		},
+		hub:      newHub(),
+		shutdown: make(chan struct{}),
+	}
+	go g.hub.run()
+
+	r := g.newV1Router()
+	r.Use(g.AuthenticationMiddleware)
+	return g, httptest.NewServer(r)
+}
+
+func openEventStream(t *testing.T, ts *httptest.Server, lastEventID string) (*http.Response, *bufio.Reader) {
+	req, err := http.NewRequest("GET", ts.URL+"/v1/ob/events", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth("alice", "letmein")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, res.StatusCode)
+	}
+	if res.Header.Get("Content-Type") != "text/event-stream" {
+		t.Errorf("Incorrect content type: %s", res.Header.Get("Content-Type"))
+	}
+	return res, bufio.NewReader(res.Body)
+}
+
+type testNotification struct {
+	ID   string `json:"notificationID"`
+	Type string `json:"type"`
+}
+
+func TestGateway_handleGETEvents(t *testing.T) {
+	node := &mockNode{
+		getNotificationsAfterFunc: func(notificationID string) ([]models.NotificationRecord, error) {
+			if notificationID != "a" {
+				return nil, fmt.Errorf("%w: notification not found", coreiface.ErrNotFound)
+			}
+			return []models.NotificationRecord{
+				{ID: "b", Notification: []byte("{\n    \"notificationID\": \"b\",\n    \"type\": \"NewOrder\"\n}")},
+				{ID: "c", Notification: []byte(`{"notificationID": "c", "type": "Follow"}`)},
+			}, nil
+		},
+	}
+	g, ts := newEventsTestServer(t, node)
+	defer ts.Close()
+
+	res, reader := openEventStream(t, ts, "a")
+	defer res.Body.Close()
+
+	expected := []serverSentEvent{
+		{id: "b", data: `{"notification":{"notificationID":"b","type":"NewOrder"}}`},
+		{id: "c", data: `{"notification":{"notificationID":"c","type":"Follow"}}`},
+	}
+	for _, e := range expected {
+		if event := readServerSentEvent(t, reader); event != e {
+			t.Errorf("Expected replayed event %v, got %v", e, event)
+		}
+	}
+
+	type notificationWrapper struct {
+		Notification interface{} `json:"notification"`
+	}
+	type chatMessageWrapper struct {
+		ChatMessage interface{} `json:"chatMessage"`
+	}
+
+	// The replayed notification is not sent twice.
+	if err := g.NotifyWebsockets(notificationWrapper{testNotification{"c", "Follow"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.NotifyWebsockets(notificationWrapper{testNotification{"d", "Unfollow"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.NotifyWebsockets(chatMessageWrapper{map[string]string{"message": "hello"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected = []serverSentEvent{
+		{id: "d", data: `{"notification":{"notificationID":"d","type":"Unfollow"}}`},
+		{data: `{"chatMessage":{"message":"hello"}}`},
+	}
+	for _, e := range expected {
+		if event := readServerSentEvent(t, reader); event != e {
+			t.Errorf("Expected live event %v, got %v", e, event)
+		}
+	}
+
+	// An unknown event ID only streams new events.
+	res2, reader2 := openEventStream(t, ts, "z")
+	defer res2.Body.Close()
+
+	if err := g.NotifyWebsockets(notificationWrapper{testNotification{"e", "Follow"}}); err != nil {
+		t.Fatal(err)
+	}
+	e := serverSentEvent{id: "e", data: `{"notification":{"notificationID":"e","type":"Follow"}}`}
+	if event := readServerSentEvent(t, reader2); event != e {
+		t.Errorf("Expected live event %v, got %v", e, event)
+	}
+}
+
+func TestGateway_handleGETEventsAuthentication(t *testing.T) {
+	_, ts := newEventsTestServer(t, &mockNode{})
+	defer ts.Close()
+
+	req, err := http.NewRequest("GET", ts.URL+"/v1/ob/events", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth("alice", "hunter2")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected status code %d, got %d", http.StatusForbidden, res.StatusCode)
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/cpacia/openbazaar3.0/core/coreiface"
 	"github.com/gorilla/mux"
@@ -61,7 +62,7 @@ func NewGateway(node coreiface.CoreIface, config *GatewayConfig, options ...core
 
 	topMux.Handle("/v1/ob/", r)
 	topMux.Handle("/v1/wallet/", r)
-	topMux.Handle("/ws", g.AuthenticationMiddleware(newWebsocketHandler(g.hub)))
+	topMux.Handle("/ws", g.AuthenticationMiddleware(newWebsocketHandler(g.hub, node, config.PublicOnly)))
 
 	var (
 		err error
@@ -83,14 +84,41 @@ func (g *Gateway) Close() error {
 	return g.listener.Close()
 }
 
-// NotifyWebsockets marshals the message to JSON and broadcasts it
-// to all existing websocket connections.
+// NotifyWebsockets broadcasts the message to the websocket connections
+// subscribed to its topic and to the event streams. The message must marshal
+// to a JSON object with a single field. The name of the field is the topic
+// and its value is sent as the event data.
 func (g *Gateway) NotifyWebsockets(message interface{}) error {
-	out, err := json.MarshalIndent(message, "", "    ")
+	out, err := json.Marshal(message)
 	if err != nil {
 		return err
 	}
-	g.hub.Broadcast <- out
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(out, &fields); err != nil || len(fields) != 1 {
+		return fmt.Errorf("websocket message must be an object with a single field")
+	}
+	var params wsEventParams
+	for topic, data := range fields {
+		params = wsEventParams{Topic: topic, Data: data}
+	}
+	event, err := json.MarshalIndent(rpcNotification{
+		JSONRPC: rpcVersion,
+		Method:  "event",
+		Params:  params,
+	}, "", "    ")
+	if err != nil {
+		return err
+	}
+	var id string
+	if params.Topic == "notification" {
+		var notification struct {
+			ID string `json:"notificationID"`
+		}
+		if err := json.Unmarshal(params.Data, &notification); err == nil {
+			id = notification.ID
+		}
+	}
+	g.hub.Broadcast <- &wsEvent{topic: params.Topic, message: event, payload: out, id: id}
 	return nil
 }
 
@@ -134,6 +162,16 @@ func (g *Gateway) newV1Router() *mux.Router {
 		r.HandleFunc("/v1/ob/listing", g.handlePOSTListing).Methods("POST")
 		r.HandleFunc("/v1/ob/listing", g.handlePUTListing).Methods("PUT")
 		r.HandleFunc("/v1/ob/listing/{slug}", g.handleDELETEListing).Methods("DELETE")
+		r.HandleFunc("/v1/ob/listing/{slug}/history", g.handleGETListingHistory).Methods("GET")
+		r.HandleFunc("/v1/ob/listing/{slug}/diff", g.handleGETListingDiff).Methods("GET")
+		r.HandleFunc("/v1/ob/listing/{slug}/rollback/{cid}", g.handlePOSTListingRollback).Methods("POST")
+		r.HandleFunc("/v1/ob/listings/import", g.handlePOSTImportListings).Methods("POST")
+		r.HandleFunc("/v1/ob/listings/export", g.handleGETExportListings).Methods("GET")
+		r.HandleFunc("/v1/ob/inventory", g.handleGETInventory).Methods("GET")
+		r.HandleFunc("/v1/ob/inventory", g.handlePOSTInventory).Methods("POST")
+		r.HandleFunc("/v1/ob/search", g.handleGETSearch).Methods("GET")
+		r.HandleFunc("/v1/ob/directory", g.handleGETDirectory).Methods("GET")
+		r.HandleFunc("/v1/ob/directory/{peerID}/listings", g.handleGETDirectoryListings).Methods("GET")
 		r.HandleFunc("/v1/ob/avatar", g.handlePOSTAvatar).Methods("POST")
 		r.HandleFunc("/v1/ob/header", g.handlePOSTHeader).Methods("POST")
 		r.HandleFunc("/v1/ob/image", g.handlePOSTProductImage).Methods("POST")
@@ -141,6 +179,27 @@ func (g *Gateway) newV1Router() *mux.Router {
 		r.HandleFunc("/v1/wallet/currencies", g.handleGETCurrencies).Methods("GET")
 		r.HandleFunc("/v1/ob/preferences", g.handlePutUserPreferences).Methods("PUT")
 		r.HandleFunc("/v1/ob/preferences", g.handleGetUserPreferences).Methods("GET")
+		r.HandleFunc("/v1/ob/purchase", g.handlePOSTPurchase).Methods("POST")
+		r.HandleFunc("/v1/ob/estimatetotal", g.handlePOSTEstimateTotal).Methods("POST")
+		r.HandleFunc("/v1/ob/orderconfirmation", g.handlePOSTOrderConfirmation).Methods("POST")
+		r.HandleFunc("/v1/ob/orderfulfillment", g.handlePOSTOrderFulfillment).Methods("POST")
+		r.HandleFunc("/v1/ob/ordercompletion", g.handlePOSTOrderCompletion).Methods("POST")
+		r.HandleFunc("/v1/ob/ordercancel", g.handlePOSTOrderCancel).Methods("POST")
+		r.HandleFunc("/v1/ob/refund", g.handlePOSTRefund).Methods("POST")
+		r.HandleFunc("/v1/ob/refundoverpayment", g.handlePOSTRefundOverpayment).Methods("POST")
+		r.HandleFunc("/v1/ob/requestbalance", g.handlePOSTRequestBalance).Methods("POST")
+		r.HandleFunc("/v1/ob/order/{orderID}", g.handleGETOrder).Methods("GET")
+		r.HandleFunc("/v1/ob/order/{orderID}/messages", g.handleGETOrderMessages).Methods("GET")
+		r.HandleFunc("/v1/ob/order/{orderID}/reprocess", g.handlePOSTReprocessOrder).Methods("POST")
+		r.HandleFunc("/v1/ob/sales", g.handleGETSales).Methods("GET")
+		r.HandleFunc("/v1/ob/purchases", g.handleGETPurchases).Methods("GET")
+		r.HandleFunc("/v1/ob/events", g.handleGETEvents).Methods("GET")
+		r.HandleFunc("/v1/ob/notifications", g.handleGETNotifications).Methods("GET")
+		r.HandleFunc("/v1/ob/notifications/unreadcounts", g.handleGETUnreadNotificationCounts).Methods("GET")
+		r.HandleFunc("/v1/ob/marknotificationasread/{notificationID}", g.handlePOSTMarkNotificationAsRead).Methods("POST")
+		r.HandleFunc("/v1/ob/marknotificationsasread", g.handlePOSTMarkAllNotificationsAsRead).Methods("POST")
+		r.HandleFunc("/v1/ob/notification/{notificationID}", g.handleDELETENotification).Methods("DELETE")
+		r.HandleFunc("/v1/ob/webhooks/deliveries", g.handleGETWebhookDeliveries).Methods("GET")
 	}
 	r.HandleFunc("/v1/ob/image/{imageID}", g.handleGETImage).Methods("GET")
 	r.HandleFunc("/v1/ob/avatar/{peerID}/{size}", g.handleGETAvatar).Methods("GET")
@@ -163,3 +222,16 @@ func (g *Gateway) newV1Router() *mux.Router {
 func wrapError(err error) string {
 	return fmt.Sprintf(`{"error": "%s"}`, err.Error())
 }
+
+// writeCoreError writes the error returned by the core with the status code
+// matching the kind of error it wraps.
+func writeCoreError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, coreiface.ErrBadRequest):
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+	case errors.Is(err, coreiface.ErrNotFound):
+		http.Error(w, wrapError(err), http.StatusNotFound)
+	default:
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+	}
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/OpenBazaar/jsonpb"
@@ -12,6 +14,7 @@ import (
 	peer "github.com/libp2p/go-libp2p-peer"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 func (g *Gateway) handleGETListing(w http.ResponseWriter, r *http.Request) {
@@ -171,3 +174,157 @@ func (g *Gateway) handleDELETEListing(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 }
+
+func (g *Gateway) handleGETListingHistory(w http.ResponseWriter, r *http.Request) {
+	slug := mux.Vars(r)["slug"]
+
+	history, err := g.node.GetListingHistory(slug)
+	if errors.Is(err, coreiface.ErrNotFound) {
+		http.Error(w, wrapError(err), http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+
+	sanitizedJSONResponse(w, history)
+}
+
+func (g *Gateway) handleGETListingDiff(w http.ResponseWriter, r *http.Request) {
+	slug := mux.Vars(r)["slug"]
+
+	from, err := cid.Decode(r.URL.Query().Get("from"))
+	if err != nil {
+		http.Error(w, wrapError(fmt.Errorf("invalid from cid: %s", err)), http.StatusBadRequest)
+		return
+	}
+	to := cid.Undef
+	if s := r.URL.Query().Get("to"); s != "" {
+		to, err = cid.Decode(s)
+		if err != nil {
+			http.Error(w, wrapError(fmt.Errorf("invalid to cid: %s", err)), http.StatusBadRequest)
+			return
+		}
+	}
+
+	diff, err := g.node.DiffListingVersions(slug, from, to)
+	if errors.Is(err, coreiface.ErrNotFound) {
+		http.Error(w, wrapError(err), http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+
+	sanitizedJSONResponse(w, diff)
+}
+
+func (g *Gateway) handlePOSTListingRollback(w http.ResponseWriter, r *http.Request) {
+	slug := mux.Vars(r)["slug"]
+
+	version, err := cid.Decode(mux.Vars(r)["cid"])
+	if err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	err = g.node.RollbackListing(slug, version, nil)
+	if errors.Is(err, coreiface.ErrNotFound) {
+		http.Error(w, wrapError(err), http.StatusNotFound)
+		return
+	} else if errors.Is(err, coreiface.ErrBadRequest) {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (g *Gateway) handleGETInventory(w http.ResponseWriter, r *http.Request) {
+	inventory, err := g.node.GetInventory()
+	if err != nil {
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+	if inventory == nil {
+		inventory = []models.InventoryItem{}
+	}
+
+	sanitizedJSONResponse(w, inventory)
+}
+
+func (g *Gateway) handlePOSTInventory(w http.ResponseWriter, r *http.Request) {
+	var inventory []models.InventoryItem
+	if err := json.NewDecoder(r.Body).Decode(&inventory); err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	if err := g.node.SetInventory(inventory, nil); err != nil {
+		if errors.Is(err, coreiface.ErrBadRequest) {
+			http.Error(w, wrapError(err), http.StatusBadRequest)
+			return
+		} else if errors.Is(err, coreiface.ErrNotFound) {
+			http.Error(w, wrapError(err), http.StatusNotFound)
+			return
+		}
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (g *Gateway) handlePOSTImportListings(w http.ResponseWriter, r *http.Request) {
+	format, err := listingFileFormat(r)
+	if err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryrun"))
+
+	result, err := g.node.ImportListings(r.Body, format, dryRun, nil)
+	if errors.Is(err, coreiface.ErrBadRequest) {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+
+	sanitizedJSONResponse(w, result)
+}
+
+func (g *Gateway) handleGETExportListings(w http.ResponseWriter, r *http.Request) {
+	format, err := listingFileFormat(r)
+	if err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := g.node.ExportListings(&buf, format); err != nil {
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == models.ListingFileFormatJSON {
+		contentType = "application/json; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="listings.%s"`, format))
+	w.Write(buf.Bytes())
+}
+
+// listingFileFormat returns the listing file format from the format query
+// parameter. If it is not set we default to CSV.
+func listingFileFormat(r *http.Request) (models.ListingFileFormat, error) {
+	switch format := models.ListingFileFormat(strings.ToLower(r.URL.Query().Get("format"))); format {
+	case "", models.ListingFileFormatCSV:
+		return models.ListingFileFormatCSV, nil
+	case models.ListingFileFormatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown format %s", format)
+	}
+}
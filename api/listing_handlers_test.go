@@ -9,8 +9,10 @@ import (
 	"github.com/cpacia/openbazaar3.0/orders/pb"
 	"github.com/ipfs/go-cid"
 	peer "github.com/libp2p/go-libp2p-peer"
+	"io"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestListingHandlers(t *testing.T) {
@@ -610,5 +612,266 @@ func TestListingHandlers(t *testing.T) {
 				return []byte(fmt.Sprintf("%s\n", `{"error": "internal"}`)), nil
 			},
 		},
+		{
+			name:   "Get inventory",
+			path:   "/v1/ob/inventory",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getInventoryFunc = func() ([]models.InventoryItem, error) {
+					return []models.InventoryItem{
+						{Slug: "t-shirt", SKU: "size:large", Quantity: 10, Reserved: 2},
+					}, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON([]models.InventoryItem{
+					{Slug: "t-shirt", SKU: "size:large", Quantity: 10, Reserved: 2},
+				})
+			},
+		},
+		{
+			name:   "Post inventory",
+			path:   "/v1/ob/inventory",
+			method: http.MethodPost,
+			body:   []byte(`[{"slug": "t-shirt", "sku": "size:large", "quantity": 5}]`),
+			setNodeMethods: func(n *mockNode) {
+				n.setInventoryFunc = func(inventory []models.InventoryItem, done chan<- struct{}) error {
+					if len(inventory) != 1 || inventory[0].Slug != "t-shirt" || inventory[0].SKU != "size:large" || inventory[0].Quantity != 5 {
+						return errors.New("incorrect inventory")
+					}
+					return nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:   "Post inventory listing not found",
+			path:   "/v1/ob/inventory",
+			method: http.MethodPost,
+			body:   []byte(`[{"slug": "t-shirt", "sku": "", "quantity": 5}]`),
+			setNodeMethods: func(n *mockNode) {
+				n.setInventoryFunc = func(inventory []models.InventoryItem, done chan<- struct{}) error {
+					return fmt.Errorf("%w: listing t-shirt not found", coreiface.ErrNotFound)
+				}
+			},
+			statusCode: http.StatusNotFound,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "not found: listing t-shirt not found"}`)), nil
+			},
+		},
+		{
+			name:   "Post inventory invalid json",
+			path:   "/v1/ob/inventory",
+			method: http.MethodPost,
+			body:   []byte(`{`),
+			setNodeMethods: func(n *mockNode) {
+				n.setInventoryFunc = func(inventory []models.InventoryItem, done chan<- struct{}) error {
+					return nil
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "unexpected EOF"}`)), nil
+			},
+		},
+		{
+			name:   "Post import listings",
+			path:   "/v1/ob/listings/import?format=json&dryrun=true",
+			method: http.MethodPost,
+			body:   []byte(`[]`),
+			setNodeMethods: func(n *mockNode) {
+				n.importListingsFunc = func(r io.Reader, format models.ListingFileFormat, dryRun bool, done chan<- struct{}) (*models.ListingImportResult, error) {
+					if format != models.ListingFileFormatJSON || !dryRun {
+						return nil, errors.New("incorrect options")
+					}
+					return &models.ListingImportResult{
+						DryRun:   true,
+						Imported: []string{"t-shirt"},
+						Errors:   []models.ListingImportError{{Row: 2, Slug: "hat", Error: "price must be set"}},
+					}, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(&models.ListingImportResult{
+					DryRun:   true,
+					Imported: []string{"t-shirt"},
+					Errors:   []models.ListingImportError{{Row: 2, Slug: "hat", Error: "price must be set"}},
+				})
+			},
+		},
+		{
+			name:   "Post import listings bad file",
+			path:   "/v1/ob/listings/import",
+			method: http.MethodPost,
+			body:   []byte(`slug`),
+			setNodeMethods: func(n *mockNode) {
+				n.importListingsFunc = func(r io.Reader, format models.ListingFileFormat, dryRun bool, done chan<- struct{}) (*models.ListingImportResult, error) {
+					return nil, fmt.Errorf("%w: missing title column", coreiface.ErrBadRequest)
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "bad request: missing title column"}`)), nil
+			},
+		},
+		{
+			name:   "Post import listings unknown format",
+			path:   "/v1/ob/listings/import?format=xml",
+			method: http.MethodPost,
+			setNodeMethods: func(n *mockNode) {
+				n.importListingsFunc = func(r io.Reader, format models.ListingFileFormat, dryRun bool, done chan<- struct{}) (*models.ListingImportResult, error) {
+					return &models.ListingImportResult{}, nil
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "unknown format xml"}`)), nil
+			},
+		},
+		{
+			name:   "Get export listings",
+			path:   "/v1/ob/listings/export?format=csv",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.exportListingsFunc = func(w io.Writer, format models.ListingFileFormat) error {
+					if format != models.ListingFileFormatCSV {
+						return errors.New("incorrect format")
+					}
+					_, err := w.Write([]byte("slug,title\nt-shirt,T-Shirt\n"))
+					return err
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return []byte("slug,title\nt-shirt,T-Shirt\n"), nil
+			},
+		},
+		{
+			name:   "Get export listings error",
+			path:   "/v1/ob/listings/export?format=json",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.exportListingsFunc = func(w io.Writer, format models.ListingFileFormat) error {
+					return errors.New("database error")
+				}
+			},
+			statusCode: http.StatusInternalServerError,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "database error"}`)), nil
+			},
+		},
+		{
+			name:   "Get listing history",
+			path:   "/v1/ob/listing/t-shirt/history",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getListingHistoryFunc = func(slug string) ([]models.ListingVersion, error) {
+					if slug != "t-shirt" {
+						return nil, errors.New("incorrect slug")
+					}
+					return []models.ListingVersion{
+						{Slug: "t-shirt", CID: "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub", Timestamp: time.Unix(1000, 0), Summary: "created"},
+					}, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON([]models.ListingVersion{
+					{Slug: "t-shirt", CID: "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub", Timestamp: time.Unix(1000, 0), Summary: "created"},
+				})
+			},
+		},
+		{
+			name:   "Get listing history not found",
+			path:   "/v1/ob/listing/t-shirt/history",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getListingHistoryFunc = func(slug string) ([]models.ListingVersion, error) {
+					return nil, fmt.Errorf("%w: no history for listing t-shirt", coreiface.ErrNotFound)
+				}
+			},
+			statusCode: http.StatusNotFound,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "not found: no history for listing t-shirt"}`)), nil
+			},
+		},
+		{
+			name:   "Get listing diff",
+			path:   "/v1/ob/listing/t-shirt/diff?from=QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.diffListingVersionsFunc = func(slug string, from, to cid.Cid) (*models.ListingDiff, error) {
+					if from.String() != "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub" || to.Defined() {
+						return nil, errors.New("incorrect versions")
+					}
+					return &models.ListingDiff{
+						Slug:    slug,
+						From:    from.String(),
+						To:      "QmTbpxKFfuGHQiXWaYJpzUbGbm6M2aZ2mEDb24YdiHpbEJ",
+						Changes: []models.ListingChange{{Path: "item.price", Old: "100", New: "200"}},
+					}, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(&models.ListingDiff{
+					Slug:    "t-shirt",
+					From:    "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub",
+					To:      "QmTbpxKFfuGHQiXWaYJpzUbGbm6M2aZ2mEDb24YdiHpbEJ",
+					Changes: []models.ListingChange{{Path: "item.price", Old: "100", New: "200"}},
+				})
+			},
+		},
+		{
+			name:   "Get listing diff invalid cid",
+			path:   "/v1/ob/listing/t-shirt/diff?from=abc",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.diffListingVersionsFunc = func(slug string, from, to cid.Cid) (*models.ListingDiff, error) {
+					return &models.ListingDiff{}, nil
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				_, err := cid.Decode("abc")
+				return []byte(fmt.Sprintf("%s\n", fmt.Sprintf(`{"error": "invalid from cid: %s"}`, err))), nil
+			},
+		},
+		{
+			name:   "Post listing rollback",
+			path:   "/v1/ob/listing/t-shirt/rollback/QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub",
+			method: http.MethodPost,
+			setNodeMethods: func(n *mockNode) {
+				n.rollbackListingFunc = func(slug string, version cid.Cid, done chan<- struct{}) error {
+					if slug != "t-shirt" || version.String() != "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub" {
+						return errors.New("incorrect version")
+					}
+					return nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:   "Post listing rollback not found",
+			path:   "/v1/ob/listing/t-shirt/rollback/QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub",
+			method: http.MethodPost,
+			setNodeMethods: func(n *mockNode) {
+				n.rollbackListingFunc = func(slug string, version cid.Cid, done chan<- struct{}) error {
+					return fmt.Errorf("%w: version %s of listing %s not found", coreiface.ErrNotFound, version, slug)
+				}
+			},
+			statusCode: http.StatusNotFound,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "not found: version QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub of listing t-shirt not found"}`)), nil
+			},
+		},
 	})
 }
@@ -15,65 +15,97 @@ import (
 )
 
 type mockNode struct {
-	requestAddressFunc           func(ctx context.Context, to peer.ID, coinType iwallet.CoinType) (iwallet.Address, error)
-	sendChatMessageFunc          func(to peer.ID, message string, orderID models.OrderID, done chan<- struct{}) error
-	sendTypingMessageFunc        func(to peer.ID, orderID models.OrderID) error
-	markChatMessagesAsReadFunc   func(peer peer.ID, orderID models.OrderID) error
-	getChatConversationsFunc     func() ([]models.ChatConversation, error)
-	getChatMessagesByPeerFunc    func(peer peer.ID, limit int, offsetID string) ([]models.ChatMessage, error)
-	getChatMessagesByOrderIDFunc func(orderID models.OrderID, limit int, offsetID string) ([]models.ChatMessage, error)
-	deleteChatMessageFunc        func(messageID string) error
-	deleteChatConversationFunc   func(peerID peer.ID) error
-	deleteGroupChatMessagesFunc  func(orderID models.OrderID) error
-	confirmOrderFunc             func(orderID models.OrderID, done chan struct{}) error
-	fulfillOrderFunc             func(orderID models.OrderID, fulfillments []models.Fulfillment, done chan struct{}) error
-	cancelOrderFunc              func(orderID models.OrderID, done chan struct{}) error
-	followNodeFunc               func(peerID peer.ID, done chan<- struct{}) error
-	unfollowNodeFunc             func(peerID peer.ID, done chan<- struct{}) error
-	getMyFollowersFunc           func() (models.Followers, error)
-	getMyFollowingFunc           func() (models.Following, error)
-	getFollowersFunc             func(ctx context.Context, peerID peer.ID, useCache bool) (models.Followers, error)
-	getFollowingFunc             func(ctx context.Context, peerID peer.ID, useCache bool) (models.Following, error)
-	saveListingFunc              func(listing *pb.Listing, done chan<- struct{}) error
-	updateAllListingsFunc        func(updateFunc func(l *pb.Listing) (bool, error), done chan<- struct{}) error
-	deleteListingFunc            func(slug string, done chan<- struct{}) error
-	getMyListingsFunc            func() (models.ListingIndex, error)
-	getListingsFunc              func(ctx context.Context, peerID peer.ID, useCache bool) (models.ListingIndex, error)
-	getMyListingBySlugFunc       func(slug string) (*pb.SignedListing, error)
-	getMyListingByCIDFunc        func(cid cid.Cid) (*pb.SignedListing, error)
-	getListingBySlugFunc         func(ctx context.Context, peerID peer.ID, slug string, useCache bool) (*pb.SignedListing, error)
-	getListingByCIDFunc          func(ctx context.Context, cid cid.Cid) (*pb.SignedListing, error)
-	getImageFunc                 func(ctx context.Context, cid cid.Cid) (io.ReadSeeker, error)
-	getAvatarFunc                func(ctx context.Context, peerID peer.ID, size models.ImageSize, useCache bool) (io.ReadSeeker, error)
-	getHeaderFunc                func(ctx context.Context, peerID peer.ID, size models.ImageSize, useCache bool) (io.ReadSeeker, error)
-	setAvatarImageFunc           func(base64ImageData string, done chan struct{}) (models.ImageHashes, error)
-	setHeaderImageFunc           func(base64ImageData string, done chan struct{}) (models.ImageHashes, error)
-	setProductImageFunc          func(base64ImageData string, filename string) (models.ImageHashes, error)
-	setSelfAsModeratorFunc       func(ctx context.Context, modInfo *models.ModeratorInfo, done chan struct{}) error
-	setModeratorsOnListingsFunc  func(mods []peer.ID, done chan struct{}) error
-	removeSelfAsModeratorFunc    func(ctx context.Context, done chan<- struct{}) error
-	getModeratorsFunc            func(ctx context.Context) []peer.ID
-	getModeratorsAsyncFunc       func(ctx context.Context) <-chan peer.ID
-	publishFunc                  func(done chan<- struct{})
-	usingTestnetFunc             func() bool
-	usingTorFunc                 func() bool
-	ipfsNodeFunc                 func() *core.IpfsNode
-	multiwalletFunc              func() multiwallet.Multiwallet
-	identityFunc                 func() peer.ID
-	subscribeEventFunc           func(event interface{}) (events.Subscription, error)
-	setProfileFunc               func(profile *models.Profile, done chan<- struct{}) error
-	getMyProfileFunc             func() (*models.Profile, error)
-	getProfileFunc               func(ctx context.Context, peerID peer.ID, useCache bool) (*models.Profile, error)
-	purchaseFunc                 func(ctx context.Context, purchase *models.Purchase) (orderID models.OrderID, paymentAddress iwallet.Address, paymentAmount models.CurrencyValue, err error)
-	estimateOrderSubtotalFunc    func(ctx context.Context, purchase *models.Purchase) (*models.CurrencyValue, error)
-	rejectOrderFunc              func(orderID models.OrderID, reason string, done chan struct{}) error
-	refundOrderFunc              func(orderID models.OrderID, done chan struct{}) error
-	pingNodeFunc                 func(ctx context.Context, peer peer.ID) error
-	getUserPreferencesFunc       func() (*models.UserPreferences, error)
-	saveUserPreferencesFunc      func(prefs *models.UserPreferences, done chan struct{}) error
-	saveTransactionMetadataFunc  func(metadata *models.TransactionMetadata) error
-	getTransactionMetadataFunc   func(txid iwallet.TransactionID) (models.TransactionMetadata, error)
-	getExchangeRatesFunc         func() *wallet.ExchangeRateProvider
+	requestAddressFunc              func(ctx context.Context, to peer.ID, coinType iwallet.CoinType) (iwallet.Address, error)
+	sendChatMessageFunc             func(to peer.ID, message string, orderID models.OrderID, done chan<- struct{}) error
+	sendTypingMessageFunc           func(to peer.ID, orderID models.OrderID) error
+	markChatMessagesAsReadFunc      func(peer peer.ID, orderID models.OrderID) error
+	getChatConversationsFunc        func() ([]models.ChatConversation, error)
+	getChatMessagesByPeerFunc       func(peer peer.ID, limit int, offsetID string) ([]models.ChatMessage, error)
+	getChatMessagesByOrderIDFunc    func(orderID models.OrderID, limit int, offsetID string) ([]models.ChatMessage, error)
+	deleteChatMessageFunc           func(messageID string) error
+	deleteChatConversationFunc      func(peerID peer.ID) error
+	deleteGroupChatMessagesFunc     func(orderID models.OrderID) error
+	confirmOrderFunc                func(orderID models.OrderID, done chan struct{}) error
+	fulfillOrderFunc                func(orderID models.OrderID, fulfillments []models.Fulfillment, done chan struct{}) error
+	cancelOrderFunc                 func(orderID models.OrderID, done chan struct{}) error
+	openDisputeFunc                 func(orderID models.OrderID, reason string, evidence []string, done chan struct{}) error
+	closeDisputeFunc                func(orderID models.OrderID, buyerPercentage, vendorPercentage float32, resolution string, done chan struct{}) error
+	releaseFundsFunc                func(orderID models.OrderID) error
+	releaseFundsAfterTimeoutFunc    func(orderID models.OrderID) error
+	finalizePaymentFunc             func(orderID models.OrderID, done chan struct{}) error
+	completeOrderFunc               func(orderID models.OrderID, ratings []models.Rating, includeIDInRating bool, done chan struct{}) error
+	getOrderFunc                    func(orderID models.OrderID) (*models.Order, error)
+	getOrderMessagesFunc            func(orderID models.OrderID) (*models.OrderMessages, error)
+	reprocessOrderMessagesFunc      func(orderID models.OrderID) error
+	getSalesFunc                    func(query *models.OrderQuery) (*models.OrderList, error)
+	getPurchasesFunc                func(query *models.OrderQuery) (*models.OrderList, error)
+	followNodeFunc                  func(peerID peer.ID, done chan<- struct{}) error
+	unfollowNodeFunc                func(peerID peer.ID, done chan<- struct{}) error
+	getMyFollowersFunc              func() (models.Followers, error)
+	getMyFollowingFunc              func() (models.Following, error)
+	getFollowersFunc                func(ctx context.Context, peerID peer.ID, useCache bool) (models.Followers, error)
+	getFollowingFunc                func(ctx context.Context, peerID peer.ID, useCache bool) (models.Following, error)
+	saveListingFunc                 func(listing *pb.Listing, done chan<- struct{}) error
+	updateAllListingsFunc           func(updateFunc func(l *pb.Listing) (bool, error), done chan<- struct{}) error
+	deleteListingFunc               func(slug string, done chan<- struct{}) error
+	getMyListingsFunc               func() (models.ListingIndex, error)
+	getListingsFunc                 func(ctx context.Context, peerID peer.ID, useCache bool) (models.ListingIndex, error)
+	getMyListingBySlugFunc          func(slug string) (*pb.SignedListing, error)
+	getMyListingByCIDFunc           func(cid cid.Cid) (*pb.SignedListing, error)
+	importListingsFunc              func(r io.Reader, format models.ListingFileFormat, dryRun bool, done chan<- struct{}) (*models.ListingImportResult, error)
+	exportListingsFunc              func(w io.Writer, format models.ListingFileFormat) error
+	getListingHistoryFunc           func(slug string) ([]models.ListingVersion, error)
+	diffListingVersionsFunc         func(slug string, from, to cid.Cid) (*models.ListingDiff, error)
+	rollbackListingFunc             func(slug string, version cid.Cid, done chan<- struct{}) error
+	getInventoryFunc                func() ([]models.InventoryItem, error)
+	searchFunc                      func(query *models.SearchQuery) (*models.SearchResults, error)
+	setInventoryFunc                func(inventory []models.InventoryItem, done chan<- struct{}) error
+	getListingBySlugFunc            func(ctx context.Context, peerID peer.ID, slug string, useCache bool) (*pb.SignedListing, error)
+	getListingByCIDFunc             func(ctx context.Context, cid cid.Cid) (*pb.SignedListing, error)
+	getImageFunc                    func(ctx context.Context, cid cid.Cid) (io.ReadSeeker, error)
+	getAvatarFunc                   func(ctx context.Context, peerID peer.ID, size models.ImageSize, useCache bool) (io.ReadSeeker, error)
+	getHeaderFunc                   func(ctx context.Context, peerID peer.ID, size models.ImageSize, useCache bool) (io.ReadSeeker, error)
+	setAvatarImageFunc              func(base64ImageData string, done chan struct{}) (models.ImageHashes, error)
+	setHeaderImageFunc              func(base64ImageData string, done chan struct{}) (models.ImageHashes, error)
+	setProductImageFunc             func(base64ImageData string, filename string) (models.ImageHashes, error)
+	setSelfAsModeratorFunc          func(ctx context.Context, modInfo *models.ModeratorInfo, done chan struct{}) error
+	setModeratorsOnListingsFunc     func(mods []peer.ID, done chan struct{}) error
+	removeSelfAsModeratorFunc       func(ctx context.Context, done chan<- struct{}) error
+	getModeratorsFunc               func(ctx context.Context) []peer.ID
+	getModeratorsAsyncFunc          func(ctx context.Context) <-chan peer.ID
+	getVendorsFunc                  func(ctx context.Context) []peer.ID
+	getVendorsAsyncFunc             func(ctx context.Context) <-chan peer.ID
+	getDirectoryFunc                func(vendorsOnly bool) ([]models.DirectoryEntry, error)
+	getCrawledListingsFunc          func(peerID peer.ID) (models.ListingIndex, error)
+	getNotificationsFunc            func(query *models.NotificationQuery) (*models.NotificationList, error)
+	getNotificationsAfterFunc       func(notificationID string) ([]models.NotificationRecord, error)
+	getUnreadNotificationCountsFunc func() (map[string]int, error)
+	markNotificationAsReadFunc      func(notificationID string) error
+	markAllNotificationsAsReadFunc  func(types ...string) error
+	deleteNotificationFunc          func(notificationID string) error
+	getWebhookDeliveriesFunc        func(query *models.WebhookDeliveryQuery) (*models.WebhookDeliveryList, error)
+	publishFunc                     func(done chan<- struct{})
+	usingTestnetFunc                func() bool
+	usingTorFunc                    func() bool
+	ipfsNodeFunc                    func() *core.IpfsNode
+	multiwalletFunc                 func() multiwallet.Multiwallet
+	identityFunc                    func() peer.ID
+	subscribeEventFunc              func(event interface{}) (events.Subscription, error)
+	setProfileFunc                  func(profile *models.Profile, done chan<- struct{}) error
+	getMyProfileFunc                func() (*models.Profile, error)
+	getProfileFunc                  func(ctx context.Context, peerID peer.ID, useCache bool) (*models.Profile, error)
+	purchaseFunc                    func(ctx context.Context, purchase *models.Purchase) (orderID models.OrderID, paymentAddress iwallet.Address, paymentAmount models.CurrencyValue, err error)
+	estimateOrderSubtotalFunc       func(ctx context.Context, purchase *models.Purchase) (*models.CurrencyValue, error)
+	rejectOrderFunc                 func(orderID models.OrderID, reason string, done chan struct{}) error
+	refundOrderFunc                 func(orderID models.OrderID, done chan struct{}) error
+	refundOverpaymentFunc           func(orderID models.OrderID, done chan struct{}) error
+	requestOrderBalanceFunc         func(orderID models.OrderID, done chan<- struct{}) error
+	pingNodeFunc                    func(ctx context.Context, peer peer.ID) error
+	getUserPreferencesFunc          func() (*models.UserPreferences, error)
+	saveUserPreferencesFunc         func(prefs *models.UserPreferences, done chan struct{}) error
+	saveTransactionMetadataFunc     func(metadata *models.TransactionMetadata) error
+	getTransactionMetadataFunc      func(txid iwallet.TransactionID) (models.TransactionMetadata, error)
+	getExchangeRatesFunc            func() *wallet.ExchangeRateProvider
 }
 
 func (m *mockNode) RequestAddress(ctx context.Context, to peer.ID, coinType iwallet.CoinType) (iwallet.Address, error) {
@@ -115,6 +147,39 @@ func (m *mockNode) FulfillOrder(orderID models.OrderID, fulfillments []models.Fu
 func (m *mockNode) CancelOrder(orderID models.OrderID, done chan struct{}) error {
 	return m.cancelOrderFunc(orderID, done)
 }
+func (m *mockNode) OpenDispute(orderID models.OrderID, reason string, evidence []string, done chan struct{}) error {
+	return m.openDisputeFunc(orderID, reason, evidence, done)
+}
+func (m *mockNode) CloseDispute(orderID models.OrderID, buyerPercentage, vendorPercentage float32, resolution string, done chan struct{}) error {
+	return m.closeDisputeFunc(orderID, buyerPercentage, vendorPercentage, resolution, done)
+}
+func (m *mockNode) ReleaseFunds(orderID models.OrderID) error {
+	return m.releaseFundsFunc(orderID)
+}
+func (m *mockNode) ReleaseFundsAfterTimeout(orderID models.OrderID) error {
+	return m.releaseFundsAfterTimeoutFunc(orderID)
+}
+func (m *mockNode) FinalizePayment(orderID models.OrderID, done chan struct{}) error {
+	return m.finalizePaymentFunc(orderID, done)
+}
+func (m *mockNode) CompleteOrder(orderID models.OrderID, ratings []models.Rating, includeIDInRating bool, done chan struct{}) error {
+	return m.completeOrderFunc(orderID, ratings, includeIDInRating, done)
+}
+func (m *mockNode) GetOrder(orderID models.OrderID) (*models.Order, error) {
+	return m.getOrderFunc(orderID)
+}
+func (m *mockNode) GetOrderMessages(orderID models.OrderID) (*models.OrderMessages, error) {
+	return m.getOrderMessagesFunc(orderID)
+}
+func (m *mockNode) ReprocessOrderMessages(orderID models.OrderID) error {
+	return m.reprocessOrderMessagesFunc(orderID)
+}
+func (m *mockNode) GetSales(query *models.OrderQuery) (*models.OrderList, error) {
+	return m.getSalesFunc(query)
+}
+func (m *mockNode) GetPurchases(query *models.OrderQuery) (*models.OrderList, error) {
+	return m.getPurchasesFunc(query)
+}
 func (m *mockNode) FollowNode(peerID peer.ID, done chan<- struct{}) error {
 	return m.followNodeFunc(peerID, done)
 }
@@ -154,6 +219,30 @@ func (m *mockNode) GetMyListingBySlug(slug string) (*pb.SignedListing, error) {
 func (m *mockNode) GetMyListingByCID(cid cid.Cid) (*pb.SignedListing, error) {
 	return m.getMyListingByCIDFunc(cid)
 }
+func (m *mockNode) ImportListings(r io.Reader, format models.ListingFileFormat, dryRun bool, done chan<- struct{}) (*models.ListingImportResult, error) {
+	return m.importListingsFunc(r, format, dryRun, done)
+}
+func (m *mockNode) ExportListings(w io.Writer, format models.ListingFileFormat) error {
+	return m.exportListingsFunc(w, format)
+}
+func (m *mockNode) GetListingHistory(slug string) ([]models.ListingVersion, error) {
+	return m.getListingHistoryFunc(slug)
+}
+func (m *mockNode) DiffListingVersions(slug string, from, to cid.Cid) (*models.ListingDiff, error) {
+	return m.diffListingVersionsFunc(slug, from, to)
+}
+func (m *mockNode) RollbackListing(slug string, version cid.Cid, done chan<- struct{}) error {
+	return m.rollbackListingFunc(slug, version, done)
+}
+func (m *mockNode) GetInventory() ([]models.InventoryItem, error) {
+	return m.getInventoryFunc()
+}
+func (m *mockNode) Search(query *models.SearchQuery) (*models.SearchResults, error) {
+	return m.searchFunc(query)
+}
+func (m *mockNode) SetInventory(inventory []models.InventoryItem, done chan<- struct{}) error {
+	return m.setInventoryFunc(inventory, done)
+}
 func (m *mockNode) GetListingBySlug(ctx context.Context, peerID peer.ID, slug string, useCache bool) (*pb.SignedListing, error) {
 	return m.getListingBySlugFunc(ctx, peerID, slug, useCache)
 }
@@ -193,6 +282,39 @@ func (m *mockNode) GetModeratorsAsync(ctx context.Context) <-chan peer.ID {
 func (m *mockNode) SetModeratorsOnListings(mods []peer.ID, done chan struct{}) error {
 	return m.setModeratorsOnListingsFunc(mods, done)
 }
+func (m *mockNode) GetVendors(ctx context.Context) []peer.ID {
+	return m.getVendorsFunc(ctx)
+}
+func (m *mockNode) GetVendorsAsync(ctx context.Context) <-chan peer.ID {
+	return m.getVendorsAsyncFunc(ctx)
+}
+func (m *mockNode) GetDirectory(vendorsOnly bool) ([]models.DirectoryEntry, error) {
+	return m.getDirectoryFunc(vendorsOnly)
+}
+func (m *mockNode) GetCrawledListings(peerID peer.ID) (models.ListingIndex, error) {
+	return m.getCrawledListingsFunc(peerID)
+}
+func (m *mockNode) GetNotifications(query *models.NotificationQuery) (*models.NotificationList, error) {
+	return m.getNotificationsFunc(query)
+}
+func (m *mockNode) GetNotificationsAfter(notificationID string) ([]models.NotificationRecord, error) {
+	return m.getNotificationsAfterFunc(notificationID)
+}
+func (m *mockNode) GetUnreadNotificationCounts() (map[string]int, error) {
+	return m.getUnreadNotificationCountsFunc()
+}
+func (m *mockNode) MarkNotificationAsRead(notificationID string) error {
+	return m.markNotificationAsReadFunc(notificationID)
+}
+func (m *mockNode) MarkAllNotificationsAsRead(types ...string) error {
+	return m.markAllNotificationsAsReadFunc(types...)
+}
+func (m *mockNode) DeleteNotification(notificationID string) error {
+	return m.deleteNotificationFunc(notificationID)
+}
+func (m *mockNode) GetWebhookDeliveries(query *models.WebhookDeliveryQuery) (*models.WebhookDeliveryList, error) {
+	return m.getWebhookDeliveriesFunc(query)
+}
 func (m *mockNode) Publish(done chan<- struct{}) {
 	m.publishFunc(done)
 }
@@ -235,6 +357,12 @@ func (m *mockNode) RejectOrder(orderID models.OrderID, reason string, done chan
 func (m *mockNode) RefundOrder(orderID models.OrderID, done chan struct{}) error {
 	return m.refundOrderFunc(orderID, done)
 }
+func (m *mockNode) RefundOverpayment(orderID models.OrderID, done chan struct{}) error {
+	return m.refundOverpaymentFunc(orderID, done)
+}
+func (m *mockNode) RequestOrderBalance(orderID models.OrderID, done chan<- struct{}) error {
+	return m.requestOrderBalanceFunc(orderID, done)
+}
 func (m *mockNode) PingNode(ctx context.Context, peer peer.ID) error {
 	return m.pingNodeFunc(ctx, peer)
 }
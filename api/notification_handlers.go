@@ -0,0 +1,108 @@
+package api
+
+import (
+	"errors"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func (g *Gateway) handleGETNotifications(w http.ResponseWriter, r *http.Request) {
+	query, err := parseNotificationQuery(r)
+	if err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	notifications, err := g.node.GetNotifications(query)
+	if errors.Is(err, coreiface.ErrBadRequest) {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+	sanitizedJSONResponse(w, notifications)
+}
+
+func (g *Gateway) handleGETUnreadNotificationCounts(w http.ResponseWriter, r *http.Request) {
+	counts, err := g.node.GetUnreadNotificationCounts()
+	if err != nil {
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+	sanitizedJSONResponse(w, counts)
+}
+
+func (g *Gateway) handlePOSTMarkNotificationAsRead(w http.ResponseWriter, r *http.Request) {
+	err := g.node.MarkNotificationAsRead(mux.Vars(r)["notificationID"])
+	if errors.Is(err, coreiface.ErrNotFound) {
+		http.Error(w, wrapError(err), http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (g *Gateway) handlePOSTMarkAllNotificationsAsRead(w http.ResponseWriter, r *http.Request) {
+	if err := g.node.MarkAllNotificationsAsRead(splitQueryValues(r, "type")...); err != nil {
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (g *Gateway) handleDELETENotification(w http.ResponseWriter, r *http.Request) {
+	err := g.node.DeleteNotification(mux.Vars(r)["notificationID"])
+	if errors.Is(err, coreiface.ErrNotFound) {
+		http.Error(w, wrapError(err), http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+}
+
+// parseNotificationQuery builds a NotificationQuery from the URL query
+// parameters. Types may be provided as a comma separated list.
+func parseNotificationQuery(r *http.Request) (*models.NotificationQuery, error) {
+	var (
+		values = r.URL.Query()
+		query  = &models.NotificationQuery{
+			Types:    splitQueryValues(r, "type"),
+			OffsetID: values.Get("offsetID"),
+			Limit:    -1,
+		}
+		err error
+	)
+	if unread := values.Get("unread"); unread != "" {
+		query.UnreadOnly, err = strconv.ParseBool(unread)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if limit := values.Get("limit"); limit != "" {
+		query.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return query, nil
+}
+
+// splitQueryValues returns all the values of the URL query parameter
+// splitting any comma separated lists.
+func splitQueryValues(r *http.Request, key string) []string {
+	var values []string
+	for _, value := range r.URL.Query()[key] {
+		for _, v := range strings.Split(value, ",") {
+			if v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
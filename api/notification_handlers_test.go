@@ -0,0 +1,195 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/models"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNotificationHandlers(t *testing.T) {
+	list := &models.NotificationList{
+		Notifications: []models.NotificationRecord{
+			{
+				ID:           "abc",
+				Type:         "NewOrder",
+				Timestamp:    time.Now(),
+				Notification: []byte(`{"notificationID":"abc","type":"NewOrder"}`),
+			},
+		},
+		Total:        2,
+		NextOffsetID: "abc",
+	}
+	counts := map[string]int{
+		"NewOrder": 1,
+		"Follow":   3,
+	}
+
+	runAPITests(t, apiTests{
+		{
+			name:   "Get notifications",
+			path:   "/v1/ob/notifications",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getNotificationsFunc = func(query *models.NotificationQuery) (*models.NotificationList, error) {
+					if !reflect.DeepEqual(query, &models.NotificationQuery{Limit: -1}) {
+						return nil, errors.New("incorrect query")
+					}
+					return list, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(list)
+			},
+		},
+		{
+			name:   "Get notifications with query",
+			path:   "/v1/ob/notifications?type=NewOrder,Follow&type=Refund&unread=true&limit=1&offsetID=xyz",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getNotificationsFunc = func(query *models.NotificationQuery) (*models.NotificationList, error) {
+					expected := &models.NotificationQuery{
+						Types:      []string{"NewOrder", "Follow", "Refund"},
+						UnreadOnly: true,
+						Limit:      1,
+						OffsetID:   "xyz",
+					}
+					if !reflect.DeepEqual(query, expected) {
+						return nil, errors.New("incorrect query")
+					}
+					return list, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(list)
+			},
+		},
+		{
+			name:   "Get notifications invalid limit",
+			path:   "/v1/ob/notifications?limit=abc",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getNotificationsFunc = func(query *models.NotificationQuery) (*models.NotificationList, error) {
+					return list, nil
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "strconv.Atoi: parsing "abc": invalid syntax"}`)), nil
+			},
+		},
+		{
+			name:   "Get notifications bad offset",
+			path:   "/v1/ob/notifications?offsetID=xyz",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getNotificationsFunc = func(query *models.NotificationQuery) (*models.NotificationList, error) {
+					return nil, fmt.Errorf("%w: offset notification not found", coreiface.ErrBadRequest)
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "bad request: offset notification not found"}`)), nil
+			},
+		},
+		{
+			name:   "Get unread notification counts",
+			path:   "/v1/ob/notifications/unreadcounts",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getUnreadNotificationCountsFunc = func() (map[string]int, error) {
+					return counts, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(counts)
+			},
+		},
+		{
+			name:   "Mark notification as read",
+			path:   "/v1/ob/marknotificationasread/abc",
+			method: http.MethodPost,
+			setNodeMethods: func(n *mockNode) {
+				n.markNotificationAsReadFunc = func(notificationID string) error {
+					if notificationID != "abc" {
+						return errors.New("incorrect notification ID")
+					}
+					return nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:   "Mark notification as read not found",
+			path:   "/v1/ob/marknotificationasread/abc",
+			method: http.MethodPost,
+			setNodeMethods: func(n *mockNode) {
+				n.markNotificationAsReadFunc = func(notificationID string) error {
+					return fmt.Errorf("%w: notification not found", coreiface.ErrNotFound)
+				}
+			},
+			statusCode: http.StatusNotFound,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "not found: notification not found"}`)), nil
+			},
+		},
+		{
+			name:   "Mark all notifications as read",
+			path:   "/v1/ob/marknotificationsasread?type=NewOrder",
+			method: http.MethodPost,
+			setNodeMethods: func(n *mockNode) {
+				n.markAllNotificationsAsReadFunc = func(types ...string) error {
+					if !reflect.DeepEqual(types, []string{"NewOrder"}) {
+						return errors.New("incorrect types")
+					}
+					return nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:   "Delete notification",
+			path:   "/v1/ob/notification/abc",
+			method: http.MethodDelete,
+			setNodeMethods: func(n *mockNode) {
+				n.deleteNotificationFunc = func(notificationID string) error {
+					if notificationID != "abc" {
+						return errors.New("incorrect notification ID")
+					}
+					return nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:   "Delete notification not found",
+			path:   "/v1/ob/notification/abc",
+			method: http.MethodDelete,
+			setNodeMethods: func(n *mockNode) {
+				n.deleteNotificationFunc = func(notificationID string) error {
+					return fmt.Errorf("%w: notification not found", coreiface.ErrNotFound)
+				}
+			},
+			statusCode: http.StatusNotFound,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "not found: notification not found"}`)), nil
+			},
+		},
+	})
+}
@@ -0,0 +1,289 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (g *Gateway) handlePOSTPurchase(w http.ResponseWriter, r *http.Request) {
+	var purchase models.Purchase
+	if err := json.NewDecoder(r.Body).Decode(&purchase); err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	orderID, paymentAddress, paymentAmount, err := g.node.PurchaseListing(r.Context(), &purchase)
+	if err != nil {
+		writeCoreError(w, err)
+		return
+	}
+
+	type response struct {
+		OrderID        string                `json:"orderID"`
+		PaymentAddress string                `json:"paymentAddress"`
+		Amount         *models.CurrencyValue `json:"amount"`
+	}
+	sanitizedJSONResponse(w, &response{
+		OrderID:        orderID.String(),
+		PaymentAddress: paymentAddress.String(),
+		Amount:         &paymentAmount,
+	})
+}
+
+func (g *Gateway) handlePOSTEstimateTotal(w http.ResponseWriter, r *http.Request) {
+	var purchase models.Purchase
+	if err := json.NewDecoder(r.Body).Decode(&purchase); err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	total, err := g.node.EstimateOrderSubtotal(r.Context(), &purchase)
+	if err != nil {
+		writeCoreError(w, err)
+		return
+	}
+
+	sanitizedJSONResponse(w, total)
+}
+
+func (g *Gateway) handlePOSTOrderConfirmation(w http.ResponseWriter, r *http.Request) {
+	type confirmation struct {
+		OrderID string `json:"orderID"`
+		Reject  bool   `json:"reject"`
+		Reason  string `json:"reason"`
+	}
+	var c confirmation
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	if c.Reject {
+		err = g.node.RejectOrder(models.OrderID(c.OrderID), c.Reason, nil)
+	} else {
+		err = g.node.ConfirmOrder(models.OrderID(c.OrderID), nil)
+	}
+	if err != nil {
+		writeCoreError(w, err)
+		return
+	}
+}
+
+func (g *Gateway) handlePOSTOrderFulfillment(w http.ResponseWriter, r *http.Request) {
+	type fulfillment struct {
+		OrderID      string               `json:"orderID"`
+		Fulfillments []models.Fulfillment `json:"fulfillments"`
+	}
+	var f fulfillment
+	if err := json.NewDecoder(r.Body).Decode(&f); err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	err := g.node.FulfillOrder(models.OrderID(f.OrderID), f.Fulfillments, nil)
+	if err != nil {
+		writeCoreError(w, err)
+		return
+	}
+}
+
+func (g *Gateway) handlePOSTOrderCompletion(w http.ResponseWriter, r *http.Request) {
+	type completion struct {
+		OrderID   string          `json:"orderID"`
+		Ratings   []models.Rating `json:"ratings"`
+		Anonymous bool            `json:"anonymous"`
+	}
+	var c completion
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	err := g.node.CompleteOrder(models.OrderID(c.OrderID), c.Ratings, !c.Anonymous, nil)
+	if err != nil {
+		writeCoreError(w, err)
+		return
+	}
+}
+
+func (g *Gateway) handlePOSTOrderCancel(w http.ResponseWriter, r *http.Request) {
+	type cancel struct {
+		OrderID string `json:"orderID"`
+	}
+	var c cancel
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	err := g.node.CancelOrder(models.OrderID(c.OrderID), nil)
+	if err != nil {
+		writeCoreError(w, err)
+		return
+	}
+}
+
+func (g *Gateway) handlePOSTRefund(w http.ResponseWriter, r *http.Request) {
+	type refund struct {
+		OrderID string `json:"orderID"`
+	}
+	var rf refund
+	if err := json.NewDecoder(r.Body).Decode(&rf); err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	err := g.node.RefundOrder(models.OrderID(rf.OrderID), nil)
+	if err != nil {
+		writeCoreError(w, err)
+		return
+	}
+}
+
+func (g *Gateway) handlePOSTRefundOverpayment(w http.ResponseWriter, r *http.Request) {
+	type refund struct {
+		OrderID string `json:"orderID"`
+	}
+	var rf refund
+	if err := json.NewDecoder(r.Body).Decode(&rf); err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	err := g.node.RefundOverpayment(models.OrderID(rf.OrderID), nil)
+	if err != nil {
+		writeCoreError(w, err)
+		return
+	}
+}
+
+func (g *Gateway) handlePOSTRequestBalance(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrderID string `json:"orderID"`
+	}
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	err := g.node.RequestOrderBalance(models.OrderID(req.OrderID), nil)
+	if err != nil {
+		writeCoreError(w, err)
+		return
+	}
+}
+
+func (g *Gateway) handleGETOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := mux.Vars(r)["orderID"]
+
+	order, err := g.node.GetOrder(models.OrderID(orderID))
+	if err != nil {
+		writeCoreError(w, err)
+		return
+	}
+
+	sanitizedJSONResponse(w, order)
+}
+
+func (g *Gateway) handleGETOrderMessages(w http.ResponseWriter, r *http.Request) {
+	orderID := mux.Vars(r)["orderID"]
+
+	messages, err := g.node.GetOrderMessages(models.OrderID(orderID))
+	if err != nil {
+		writeCoreError(w, err)
+		return
+	}
+
+	sanitizedJSONResponse(w, messages)
+}
+
+func (g *Gateway) handlePOSTReprocessOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := mux.Vars(r)["orderID"]
+
+	err := g.node.ReprocessOrderMessages(models.OrderID(orderID))
+	if err != nil {
+		writeCoreError(w, err)
+		return
+	}
+}
+
+func (g *Gateway) handleGETSales(w http.ResponseWriter, r *http.Request) {
+	query, err := parseOrderQuery(r)
+	if err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	sales, err := g.node.GetSales(query)
+	if err != nil {
+		writeCoreError(w, err)
+		return
+	}
+	sanitizedJSONResponse(w, sales)
+}
+
+func (g *Gateway) handleGETPurchases(w http.ResponseWriter, r *http.Request) {
+	query, err := parseOrderQuery(r)
+	if err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	purchases, err := g.node.GetPurchases(query)
+	if err != nil {
+		writeCoreError(w, err)
+		return
+	}
+	sanitizedJSONResponse(w, purchases)
+}
+
+// parseOrderQuery builds an OrderQuery from the URL query parameters. States
+// may be provided as a comma separated list and the dates in RFC3339 format.
+func parseOrderQuery(r *http.Request) (*models.OrderQuery, error) {
+	var (
+		values = r.URL.Query()
+		query  = &models.OrderQuery{
+			Coin:          values.Get("coin"),
+			PeerID:        values.Get("peerID"),
+			Search:        values.Get("search"),
+			OffsetID:      values.Get("offsetID"),
+			SortAscending: values.Get("sort") == "asc",
+			Limit:         -1,
+		}
+		err error
+	)
+	for _, state := range values["state"] {
+		for _, s := range strings.Split(state, ",") {
+			if s != "" {
+				query.States = append(query.States, models.OrderState(strings.ToUpper(s)))
+			}
+		}
+	}
+	if from := values.Get("from"); from != "" {
+		query.From, err = time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from date: %s", err)
+		}
+	}
+	if to := values.Get("to"); to != "" {
+		query.To, err = time.Parse(time.RFC3339, to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to date: %s", err)
+		}
+	}
+	if limit := values.Get("limit"); limit != "" {
+		query.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return query, nil
+}
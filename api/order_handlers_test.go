@@ -0,0 +1,513 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/models"
+	iwallet "github.com/cpacia/wallet-interface"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestOrderHandlers(t *testing.T) {
+	currency := &models.Currency{
+		Name:         "Mock",
+		Code:         "MCK",
+		CurrencyType: models.CurrencyTypeCrypto,
+		Divisibility: 8,
+	}
+
+	runAPITests(t, apiTests{
+		{
+			name:   "Post purchase",
+			path:   "/v1/ob/purchase",
+			method: http.MethodPost,
+			body:   []byte(`{"paymentCoin": "MCK"}`),
+			setNodeMethods: func(n *mockNode) {
+				n.purchaseFunc = func(ctx context.Context, purchase *models.Purchase) (models.OrderID, iwallet.Address, models.CurrencyValue, error) {
+					return models.OrderID("1234"), iwallet.NewAddress("abc", iwallet.CtMock), *models.NewCurrencyValue("1000", currency), nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				type response struct {
+					OrderID        string                `json:"orderID"`
+					PaymentAddress string                `json:"paymentAddress"`
+					Amount         *models.CurrencyValue `json:"amount"`
+				}
+				return marshalAndSanitizeJSON(&response{
+					OrderID:        "1234",
+					PaymentAddress: "abc",
+					Amount:         models.NewCurrencyValue("1000", currency),
+				})
+			},
+		},
+		{
+			name:   "Post purchase invalid JSON",
+			path:   "/v1/ob/purchase",
+			method: http.MethodPost,
+			body:   []byte(`{`),
+			setNodeMethods: func(n *mockNode) {
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "unexpected EOF"}`)), nil
+			},
+		},
+		{
+			name:   "Post purchase bad request",
+			path:   "/v1/ob/purchase",
+			method: http.MethodPost,
+			body:   []byte(`{}`),
+			setNodeMethods: func(n *mockNode) {
+				n.purchaseFunc = func(ctx context.Context, purchase *models.Purchase) (models.OrderID, iwallet.Address, models.CurrencyValue, error) {
+					return "", iwallet.Address{}, models.CurrencyValue{}, fmt.Errorf("%w: error", coreiface.ErrBadRequest)
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "bad request: error"}`)), nil
+			},
+		},
+		{
+			name:   "Post estimate total",
+			path:   "/v1/ob/estimatetotal",
+			method: http.MethodPost,
+			body:   []byte(`{}`),
+			setNodeMethods: func(n *mockNode) {
+				n.estimateOrderSubtotalFunc = func(ctx context.Context, purchase *models.Purchase) (*models.CurrencyValue, error) {
+					return models.NewCurrencyValue("1000", currency), nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(models.NewCurrencyValue("1000", currency))
+			},
+		},
+		{
+			name:   "Post estimate total internal error",
+			path:   "/v1/ob/estimatetotal",
+			method: http.MethodPost,
+			body:   []byte(`{}`),
+			setNodeMethods: func(n *mockNode) {
+				n.estimateOrderSubtotalFunc = func(ctx context.Context, purchase *models.Purchase) (*models.CurrencyValue, error) {
+					return nil, errors.New("internal")
+				}
+			},
+			statusCode: http.StatusInternalServerError,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "internal"}`)), nil
+			},
+		},
+		{
+			name:   "Post order confirmation",
+			path:   "/v1/ob/orderconfirmation",
+			method: http.MethodPost,
+			body:   []byte(`{"orderID": "1234"}`),
+			setNodeMethods: func(n *mockNode) {
+				n.confirmOrderFunc = func(orderID models.OrderID, done chan struct{}) error {
+					if orderID != "1234" {
+						return errors.New("incorrect order ID")
+					}
+					return nil
+				}
+				n.rejectOrderFunc = func(orderID models.OrderID, reason string, done chan struct{}) error {
+					return errors.New("reject called")
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:   "Post order confirmation reject",
+			path:   "/v1/ob/orderconfirmation",
+			method: http.MethodPost,
+			body:   []byte(`{"orderID": "1234", "reject": true, "reason": "out of stock"}`),
+			setNodeMethods: func(n *mockNode) {
+				n.confirmOrderFunc = func(orderID models.OrderID, done chan struct{}) error {
+					return errors.New("confirm called")
+				}
+				n.rejectOrderFunc = func(orderID models.OrderID, reason string, done chan struct{}) error {
+					if reason != "out of stock" {
+						return errors.New("incorrect reason")
+					}
+					return nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:   "Post order confirmation not found",
+			path:   "/v1/ob/orderconfirmation",
+			method: http.MethodPost,
+			body:   []byte(`{"orderID": "1234"}`),
+			setNodeMethods: func(n *mockNode) {
+				n.confirmOrderFunc = func(orderID models.OrderID, done chan struct{}) error {
+					return fmt.Errorf("%w: order not found", coreiface.ErrNotFound)
+				}
+			},
+			statusCode: http.StatusNotFound,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "not found: order not found"}`)), nil
+			},
+		},
+		{
+			name:   "Post order fulfillment",
+			path:   "/v1/ob/orderfulfillment",
+			method: http.MethodPost,
+			body:   []byte(`{"orderID": "1234", "fulfillments": [{"itemIndex": 0, "note": "thanks"}]}`),
+			setNodeMethods: func(n *mockNode) {
+				n.fulfillOrderFunc = func(orderID models.OrderID, fulfillments []models.Fulfillment, done chan struct{}) error {
+					if len(fulfillments) != 1 || fulfillments[0].Note != "thanks" {
+						return errors.New("incorrect fulfillments")
+					}
+					return nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:   "Post order fulfillment bad request",
+			path:   "/v1/ob/orderfulfillment",
+			method: http.MethodPost,
+			body:   []byte(`{"orderID": "1234"}`),
+			setNodeMethods: func(n *mockNode) {
+				n.fulfillOrderFunc = func(orderID models.OrderID, fulfillments []models.Fulfillment, done chan struct{}) error {
+					return fmt.Errorf("%w: order is not in a state where it can be fulfilled", coreiface.ErrBadRequest)
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "bad request: order is not in a state where it can be fulfilled"}`)), nil
+			},
+		},
+		{
+			name:   "Post order completion",
+			path:   "/v1/ob/ordercompletion",
+			method: http.MethodPost,
+			body:   []byte(`{"orderID": "1234", "anonymous": true, "ratings": [{"overall": 5}]}`),
+			setNodeMethods: func(n *mockNode) {
+				n.completeOrderFunc = func(orderID models.OrderID, ratings []models.Rating, includeIDInRating bool, done chan struct{}) error {
+					if includeIDInRating {
+						return errors.New("expected anonymous rating")
+					}
+					if len(ratings) != 1 || ratings[0].Overall != 5 {
+						return errors.New("incorrect ratings")
+					}
+					return nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:   "Post order cancel",
+			path:   "/v1/ob/ordercancel",
+			method: http.MethodPost,
+			body:   []byte(`{"orderID": "1234"}`),
+			setNodeMethods: func(n *mockNode) {
+				n.cancelOrderFunc = func(orderID models.OrderID, done chan struct{}) error {
+					return nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:   "Post order cancel bad request",
+			path:   "/v1/ob/ordercancel",
+			method: http.MethodPost,
+			body:   []byte(`{"orderID": "1234"}`),
+			setNodeMethods: func(n *mockNode) {
+				n.cancelOrderFunc = func(orderID models.OrderID, done chan struct{}) error {
+					return fmt.Errorf("%w: order is not in a state where it can be canceled", coreiface.ErrBadRequest)
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "bad request: order is not in a state where it can be canceled"}`)), nil
+			},
+		},
+		{
+			name:   "Post refund",
+			path:   "/v1/ob/refund",
+			method: http.MethodPost,
+			body:   []byte(`{"orderID": "1234"}`),
+			setNodeMethods: func(n *mockNode) {
+				n.refundOrderFunc = func(orderID models.OrderID, done chan struct{}) error {
+					return nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:   "Post refund not found",
+			path:   "/v1/ob/refund",
+			method: http.MethodPost,
+			body:   []byte(`{"orderID": "1234"}`),
+			setNodeMethods: func(n *mockNode) {
+				n.refundOrderFunc = func(orderID models.OrderID, done chan struct{}) error {
+					return fmt.Errorf("%w: order not found", coreiface.ErrNotFound)
+				}
+			},
+			statusCode: http.StatusNotFound,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "not found: order not found"}`)), nil
+			},
+		},
+		{
+			name:   "Post refund overpayment",
+			path:   "/v1/ob/refundoverpayment",
+			method: http.MethodPost,
+			body:   []byte(`{"orderID": "1234"}`),
+			setNodeMethods: func(n *mockNode) {
+				n.refundOverpaymentFunc = func(orderID models.OrderID, done chan struct{}) error {
+					return nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:   "Post refund overpayment bad request",
+			path:   "/v1/ob/refundoverpayment",
+			method: http.MethodPost,
+			body:   []byte(`{"orderID": "1234"}`),
+			setNodeMethods: func(n *mockNode) {
+				n.refundOverpaymentFunc = func(orderID models.OrderID, done chan struct{}) error {
+					return fmt.Errorf("%w: order has not been overpaid", coreiface.ErrBadRequest)
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "bad request: order has not been overpaid"}`)), nil
+			},
+		},
+		{
+			name:   "Post request balance",
+			path:   "/v1/ob/requestbalance",
+			method: http.MethodPost,
+			body:   []byte(`{"orderID": "1234"}`),
+			setNodeMethods: func(n *mockNode) {
+				n.requestOrderBalanceFunc = func(orderID models.OrderID, done chan<- struct{}) error {
+					return nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:   "Post request balance not found",
+			path:   "/v1/ob/requestbalance",
+			method: http.MethodPost,
+			body:   []byte(`{"orderID": "1234"}`),
+			setNodeMethods: func(n *mockNode) {
+				n.requestOrderBalanceFunc = func(orderID models.OrderID, done chan<- struct{}) error {
+					return fmt.Errorf("%w: order not found", coreiface.ErrNotFound)
+				}
+			},
+			statusCode: http.StatusNotFound,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "not found: order not found"}`)), nil
+			},
+		},
+		{
+			name:   "Get order",
+			path:   "/v1/ob/order/1234",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getOrderFunc = func(orderID models.OrderID) (*models.Order, error) {
+					return &models.Order{ID: orderID}, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(&models.Order{ID: "1234"})
+			},
+		},
+		{
+			name:   "Get order messages",
+			path:   "/v1/ob/order/1234/messages",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getOrderMessagesFunc = func(orderID models.OrderID) (*models.OrderMessages, error) {
+					return &models.OrderMessages{
+						OrderID: orderID,
+						Errored: []models.OrderMessageRecord{
+							{MessageType: "ORDER_CONFIRMATION", Message: []byte(`{}`), Error: "invalid signature"},
+						},
+					}, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(&models.OrderMessages{
+					OrderID: "1234",
+					Errored: []models.OrderMessageRecord{
+						{MessageType: "ORDER_CONFIRMATION", Message: []byte(`{}`), Error: "invalid signature"},
+					},
+				})
+			},
+		},
+		{
+			name:   "Get order messages not found",
+			path:   "/v1/ob/order/1234/messages",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getOrderMessagesFunc = func(orderID models.OrderID) (*models.OrderMessages, error) {
+					return nil, fmt.Errorf("%w: order not found", coreiface.ErrNotFound)
+				}
+			},
+			statusCode: http.StatusNotFound,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "not found: order not found"}`)), nil
+			},
+		},
+		{
+			name:   "Post reprocess order",
+			path:   "/v1/ob/order/1234/reprocess",
+			method: http.MethodPost,
+			setNodeMethods: func(n *mockNode) {
+				n.reprocessOrderMessagesFunc = func(orderID models.OrderID) error {
+					if orderID != "1234" {
+						return errors.New("incorrect order ID")
+					}
+					return nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:   "Post reprocess order not found",
+			path:   "/v1/ob/order/1234/reprocess",
+			method: http.MethodPost,
+			setNodeMethods: func(n *mockNode) {
+				n.reprocessOrderMessagesFunc = func(orderID models.OrderID) error {
+					return fmt.Errorf("%w: order not found", coreiface.ErrNotFound)
+				}
+			},
+			statusCode: http.StatusNotFound,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "not found: order not found"}`)), nil
+			},
+		},
+		{
+			name:   "Get sales",
+			path:   "/v1/ob/sales?state=pending,confirmed&coin=BTC&search=shirt&limit=10&offsetID=abc&sort=asc&from=2020-01-01T00:00:00Z",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getSalesFunc = func(query *models.OrderQuery) (*models.OrderList, error) {
+					if len(query.States) != 2 || query.States[0] != models.OrderStatePending || query.States[1] != models.OrderStateConfirmed {
+						return nil, errors.New("incorrect states")
+					}
+					if query.Coin != "BTC" || query.Search != "shirt" || query.Limit != 10 || query.OffsetID != "abc" || !query.SortAscending {
+						return nil, errors.New("incorrect query")
+					}
+					if !query.From.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)) || !query.To.IsZero() {
+						return nil, errors.New("incorrect dates")
+					}
+					return &models.OrderList{
+						Orders: []models.OrderSummary{{OrderID: "1234", State: models.OrderStatePending}},
+						Total:  1,
+						Counts: map[models.OrderState]int{models.OrderStatePending: 1},
+					}, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(&models.OrderList{
+					Orders: []models.OrderSummary{{OrderID: "1234", State: models.OrderStatePending}},
+					Total:  1,
+					Counts: map[models.OrderState]int{models.OrderStatePending: 1},
+				})
+			},
+		},
+		{
+			name:   "Get sales invalid date",
+			path:   "/v1/ob/sales?from=yesterday",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "invalid from date: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006""}`)), nil
+			},
+		},
+		{
+			name:   "Get purchases",
+			path:   "/v1/ob/purchases?peerID=QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getPurchasesFunc = func(query *models.OrderQuery) (*models.OrderList, error) {
+					if query.PeerID != "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub" {
+						return nil, errors.New("incorrect peer ID")
+					}
+					return &models.OrderList{
+						Orders: []models.OrderSummary{},
+						Counts: map[models.OrderState]int{},
+					}, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(&models.OrderList{
+					Orders: []models.OrderSummary{},
+					Counts: map[models.OrderState]int{},
+				})
+			},
+		},
+		{
+			name:   "Get purchases bad request",
+			path:   "/v1/ob/purchases?offsetID=abc",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getPurchasesFunc = func(query *models.OrderQuery) (*models.OrderList, error) {
+					return nil, fmt.Errorf("%w: offset order not found", coreiface.ErrBadRequest)
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "bad request: offset order not found"}`)), nil
+			},
+		},
+		{
+			name:   "Get order not found",
+			path:   "/v1/ob/order/1234",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getOrderFunc = func(orderID models.OrderID) (*models.Order, error) {
+					return nil, fmt.Errorf("%w: order not found", coreiface.ErrNotFound)
+				}
+			},
+			statusCode: http.StatusNotFound,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "not found: order not found"}`)), nil
+			},
+		},
+	})
+}
@@ -119,6 +119,10 @@ func (g *Gateway) handlePOSTFetchProfiles(w http.ResponseWriter, r *http.Request
 		Error  string `json:"error"`
 	}
 
+	type profileWrapper struct {
+		Profile interface{} `json:"profile"`
+	}
+
 	var (
 		profiles     = make([]models.Profile, 0, len(peerIDs))
 		responseChan = make(chan interface{}, 8)
@@ -181,10 +185,10 @@ func (g *Gateway) handlePOSTFetchProfiles(w http.ResponseWriter, r *http.Request
 				switch p := i.(type) {
 				case profileWithAsyncID:
 					p.ID = asyncID
-					g.NotifyWebsockets(p)
+					g.NotifyWebsockets(profileWrapper{p})
 				case profileError:
 					p.ID = asyncID
-					g.NotifyWebsockets(p)
+					g.NotifyWebsockets(profileWrapper{p})
 				}
 			}
 		}()
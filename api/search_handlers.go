@@ -0,0 +1,75 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/models"
+	"net/http"
+	"strconv"
+)
+
+func (g *Gateway) handleGETSearch(w http.ResponseWriter, r *http.Request) {
+	query, err := parseSearchQuery(r)
+	if err != nil {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	}
+
+	results, err := g.node.Search(query)
+	if errors.Is(err, coreiface.ErrBadRequest) {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+	sanitizedJSONResponse(w, results)
+}
+
+// parseSearchQuery builds a SearchQuery from the URL query parameters.
+func parseSearchQuery(r *http.Request) (*models.SearchQuery, error) {
+	var (
+		values = r.URL.Query()
+		query  = &models.SearchQuery{
+			Query:    values.Get("q"),
+			Category: values.Get("category"),
+			Currency: values.Get("currency"),
+			ShipsTo:  values.Get("shipsTo"),
+		}
+		err error
+	)
+	if nsfw := values.Get("nsfw"); nsfw != "" {
+		query.NSFW, err = strconv.ParseBool(nsfw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nsfw: %s", err)
+		}
+	}
+	if minPrice := values.Get("minPrice"); minPrice != "" {
+		price, err := strconv.ParseFloat(minPrice, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minPrice: %s", err)
+		}
+		query.MinPrice = &price
+	}
+	if maxPrice := values.Get("maxPrice"); maxPrice != "" {
+		price, err := strconv.ParseFloat(maxPrice, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maxPrice: %s", err)
+		}
+		query.MaxPrice = &price
+	}
+	if limit := values.Get("limit"); limit != "" {
+		query.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if offset := values.Get("offset"); offset != "" {
+		query.Offset, err = strconv.Atoi(offset)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return query, nil
+}
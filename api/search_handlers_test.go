@@ -0,0 +1,97 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/models"
+	"net/http"
+	"testing"
+)
+
+func TestSearchHandlers(t *testing.T) {
+	results := &models.SearchResults{
+		Total: 1,
+		Results: []models.SearchResult{
+			{
+				PeerID: "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub",
+				Score:  5,
+				Listing: models.ListingMetadata{
+					Slug:  "ron-swanson-shirt",
+					Title: "Ron Swanson Shirt",
+				},
+			},
+		},
+	}
+
+	runAPITests(t, apiTests{
+		{
+			name:   "Get search",
+			path:   "/v1/ob/search?q=shirt&category=clothing&minPrice=5&maxPrice=20.5&currency=USD&shipsTo=CANADA&nsfw=true&limit=10&offset=5",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.searchFunc = func(query *models.SearchQuery) (*models.SearchResults, error) {
+					if query.Query != "shirt" || query.Category != "clothing" || query.Currency != "USD" || query.ShipsTo != "CANADA" {
+						return nil, errors.New("incorrect query")
+					}
+					if query.MinPrice == nil || *query.MinPrice != 5 || query.MaxPrice == nil || *query.MaxPrice != 20.5 {
+						return nil, errors.New("incorrect price range")
+					}
+					if !query.NSFW || query.Limit != 10 || query.Offset != 5 {
+						return nil, errors.New("incorrect options")
+					}
+					return results, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(results)
+			},
+		},
+		{
+			name:   "Get search no filters",
+			path:   "/v1/ob/search",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.searchFunc = func(query *models.SearchQuery) (*models.SearchResults, error) {
+					if query.NSFW || query.MinPrice != nil || query.MaxPrice != nil {
+						return nil, errors.New("incorrect query")
+					}
+					return results, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(results)
+			},
+		},
+		{
+			name:   "Get search invalid price",
+			path:   "/v1/ob/search?minPrice=abc",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.searchFunc = func(query *models.SearchQuery) (*models.SearchResults, error) {
+					return results, nil
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "invalid minPrice: strconv.ParseFloat: parsing "abc": invalid syntax"}`)), nil
+			},
+		},
+		{
+			name:   "Get search bad price range",
+			path:   "/v1/ob/search?minPrice=10&maxPrice=5",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.searchFunc = func(query *models.SearchQuery) (*models.SearchResults, error) {
+					return nil, fmt.Errorf("%w: minPrice is greater than maxPrice", coreiface.ErrBadRequest)
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "bad request: minPrice is greater than maxPrice"}`)), nil
+			},
+		},
+	})
+}
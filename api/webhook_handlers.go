@@ -0,0 +1,38 @@
+package api
+
+import (
+	"errors"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/models"
+	"net/http"
+	"strconv"
+)
+
+func (g *Gateway) handleGETWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	var (
+		values = r.URL.Query()
+		query  = &models.WebhookDeliveryQuery{
+			Status:   values.Get("status"),
+			OffsetID: values.Get("offsetID"),
+			Limit:    -1,
+		}
+		err error
+	)
+	if limit := values.Get("limit"); limit != "" {
+		query.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			http.Error(w, wrapError(err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	deliveries, err := g.node.GetWebhookDeliveries(query)
+	if errors.Is(err, coreiface.ErrBadRequest) {
+		http.Error(w, wrapError(err), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		http.Error(w, wrapError(err), http.StatusInternalServerError)
+		return
+	}
+	sanitizedJSONResponse(w, deliveries)
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/models"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWebhookHandlers(t *testing.T) {
+	list := &models.WebhookDeliveryList{
+		Deliveries: []models.WebhookDelivery{
+			{
+				ID:           "abc",
+				URL:          "https://example.com/hook",
+				EventType:    "NewOrder",
+				Payload:      []byte(`{"type":"NewOrder"}`),
+				Status:       models.WebhookDeliveryDelivered,
+				Attempts:     1,
+				ResponseCode: http.StatusOK,
+				Timestamp:    time.Now(),
+			},
+		},
+		Total: 1,
+	}
+
+	runAPITests(t, apiTests{
+		{
+			name:   "Get webhook deliveries",
+			path:   "/v1/ob/webhooks/deliveries",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getWebhookDeliveriesFunc = func(query *models.WebhookDeliveryQuery) (*models.WebhookDeliveryList, error) {
+					if !reflect.DeepEqual(query, &models.WebhookDeliveryQuery{Limit: -1}) {
+						return nil, errors.New("incorrect query")
+					}
+					return list, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(list)
+			},
+		},
+		{
+			name:   "Get webhook deliveries with query",
+			path:   "/v1/ob/webhooks/deliveries?status=failed&limit=10&offsetID=xyz",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getWebhookDeliveriesFunc = func(query *models.WebhookDeliveryQuery) (*models.WebhookDeliveryList, error) {
+					expected := &models.WebhookDeliveryQuery{
+						Status:   models.WebhookDeliveryFailed,
+						Limit:    10,
+						OffsetID: "xyz",
+					}
+					if !reflect.DeepEqual(query, expected) {
+						return nil, errors.New("incorrect query")
+					}
+					return list, nil
+				}
+			},
+			statusCode: http.StatusOK,
+			expectedResponse: func() ([]byte, error) {
+				return marshalAndSanitizeJSON(list)
+			},
+		},
+		{
+			name:   "Get webhook deliveries invalid limit",
+			path:   "/v1/ob/webhooks/deliveries?limit=abc",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getWebhookDeliveriesFunc = func(query *models.WebhookDeliveryQuery) (*models.WebhookDeliveryList, error) {
+					return list, nil
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "strconv.Atoi: parsing "abc": invalid syntax"}`)), nil
+			},
+		},
+		{
+			name:   "Get webhook deliveries bad status",
+			path:   "/v1/ob/webhooks/deliveries?status=lost",
+			method: http.MethodGet,
+			setNodeMethods: func(n *mockNode) {
+				n.getWebhookDeliveriesFunc = func(query *models.WebhookDeliveryQuery) (*models.WebhookDeliveryList, error) {
+					return nil, fmt.Errorf("%w: unknown delivery status lost", coreiface.ErrBadRequest)
+				}
+			},
+			statusCode: http.StatusBadRequest,
+			expectedResponse: func() ([]byte, error) {
+				return []byte(fmt.Sprintf("%s\n", `{"error": "bad request: unknown delivery status lost"}`)), nil
+			},
+		},
+	})
+}
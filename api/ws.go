@@ -1,10 +1,130 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/models"
 	"github.com/gorilla/websocket"
+	peer "github.com/libp2p/go-libp2p-peer"
 	"net/http"
+	"sort"
+	"sync"
+	"time"
 )
 
+const (
+	// wsHeartbeatInterval is how often a heartbeat is sent to each
+	// websocket connection.
+	wsHeartbeatInterval = time.Second * 30
+
+	wsWriteTimeout = time.Second * 10
+
+	// wsMaxMessageSize is the largest request a client may send.
+	wsMaxMessageSize = 1 << 16
+
+	rpcVersion = "2.0"
+)
+
+// JSON-RPC error codes.
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcInternalError  = -32603
+	rpcNotFound       = -32001
+)
+
+// websocketTopics are the topics a websocket connection can subscribe to.
+// The topic of a message is the name of its single top level field.
+var websocketTopics = map[string]bool{
+	"notification":  true,
+	"chatMessage":   true,
+	"messageRead":   true,
+	"messageTyping": true,
+	"wallet":        true,
+	"status":        true,
+	"profile":       true,
+}
+
+// rpcRequest is a JSON-RPC request sent by a client. Requests without an ID
+// are notifications and are not responded to.
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+// rpcError is the error returned in a JSON-RPC response.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+type rpcResult struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result"`
+}
+
+type rpcErrorResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   *rpcError       `json:"error"`
+}
+
+// rpcNotification is a message pushed to the client. It is used for events
+// and heartbeats.
+type rpcNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type wsEventParams struct {
+	Topic string          `json:"topic"`
+	Data  json.RawMessage `json:"data"`
+}
+
+type wsHeartbeatParams struct {
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// wsEvent is a message broadcast to the connections subscribed to its topic.
+// The payload is the original message which is sent as is to the event
+// streams. The ID is set for notifications so that streams can be resumed.
+type wsEvent struct {
+	topic   string
+	message []byte
+	payload []byte
+	id      string
+}
+
+// rpcMethod handles a JSON-RPC method call. Private methods are not
+// available on a public gateway.
+type rpcMethod struct {
+	handler func(c *connection, params json.RawMessage) (interface{}, error)
+	private bool
+}
+
+var rpcMethods = map[string]rpcMethod{
+	"ping":              {handler: rpcPing},
+	"subscribe":         {handler: rpcSubscribe},
+	"unsubscribe":       {handler: rpcUnsubscribe},
+	"getProfile":        {handler: rpcGetProfile},
+	"sendChatMessage":   {handler: rpcSendChatMessage, private: true},
+	"sendTypingMessage": {handler: rpcSendTypingMessage, private: true},
+	"markChatAsRead":    {handler: rpcMarkChatAsRead, private: true},
+}
+
 type connection struct {
 	// The websocket connection
 	ws *websocket.Conn
@@ -14,31 +134,281 @@ type connection struct {
 
 	// The hub
 	h *hub
+
+	node       coreiface.CoreIface
+	publicOnly bool
+	heartbeat  time.Duration
+
+	// ctx is cancelled when the connection is closed.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mtx    sync.Mutex
+	topics map[string]bool
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// subscribed returns whether the connection is subscribed to the topic.
+func (c *connection) subscribed(topic string) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.topics[topic]
+}
+
+// queue queues the message to be written to the connection. If the
+// connection is not keeping up it is closed.
+func (c *connection) queue(message []byte) {
+	select {
+	case c.send <- message:
+	case <-c.done:
+	default:
+		log.Warning("Websocket connection is not keeping up, closing")
+		c.close()
+	}
+}
+
+// close closes the websocket connection. It is safe to call more than once.
+func (c *connection) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.cancel()
+		c.ws.Close()
+	})
 }
 
 func (c *connection) reader() {
+	defer c.close()
+	c.ws.SetReadLimit(wsMaxMessageSize)
 	for {
 		_, message, err := c.ws.ReadMessage()
 		if err != nil {
-			log.Errorf("Websocket read error: %s", err.Error())
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.Errorf("Websocket read error: %s", err.Error())
+			}
 			break
 		}
-
-		// Just echo for now until we set up the API
-		c.h.Broadcast <- message
+		c.handleRequest(message)
 	}
-	c.ws.Close()
 }
 
 func (c *connection) writer() {
-	for message := range c.send {
-		err := c.ws.WriteMessage(websocket.TextMessage, message)
-		if err != nil {
+	defer c.close()
+	ticker := time.NewTicker(c.heartbeat)
+	defer ticker.Stop()
+	for {
+		var message []byte
+		select {
+		case message = <-c.send:
+		case <-ticker.C:
+			out, err := json.Marshal(rpcNotification{
+				JSONRPC: rpcVersion,
+				Method:  "heartbeat",
+				Params:  wsHeartbeatParams{Timestamp: time.Now()},
+			})
+			if err != nil {
+				log.Errorf("Error marshalling websocket heartbeat: %s", err)
+				continue
+			}
+			message = out
+		case <-c.done:
+			return
+		}
+		c.ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+		if err := c.ws.WriteMessage(websocket.TextMessage, message); err != nil {
 			log.Errorf("Websocket write error: %s", err.Error())
-			break
+			return
+		}
+	}
+}
+
+// handleRequest parses the JSON-RPC request and calls the method. Methods
+// run in their own goroutine so a slow call does not hold up the rest.
+func (c *connection) handleRequest(message []byte) {
+	var req rpcRequest
+	if err := json.Unmarshal(message, &req); err != nil {
+		c.respond(json.RawMessage("null"), nil, &rpcError{Code: rpcParseError, Message: "parse error"})
+		return
+	}
+	if req.JSONRPC != rpcVersion || req.Method == "" {
+		c.respond(req.ID, nil, &rpcError{Code: rpcInvalidRequest, Message: "invalid request"})
+		return
+	}
+	method, ok := rpcMethods[req.Method]
+	if !ok || (method.private && c.publicOnly) {
+		c.respond(req.ID, nil, &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("method %s not found", req.Method)})
+		return
+	}
+	go func() {
+		result, err := method.handler(c, req.Params)
+		if req.ID == nil {
+			return
+		}
+		c.respond(req.ID, result, err)
+	}()
+}
+
+// respond sends the result, or the error if it is not nil, to the client.
+func (c *connection) respond(id json.RawMessage, result interface{}, err error) {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	var response interface{} = rpcResult{JSONRPC: rpcVersion, ID: id, Result: result}
+	if err != nil {
+		var rerr *rpcError
+		switch {
+		case errors.As(err, &rerr):
+		case errors.Is(err, coreiface.ErrNotFound):
+			rerr = &rpcError{Code: rpcNotFound, Message: err.Error()}
+		case errors.Is(err, coreiface.ErrBadRequest):
+			rerr = &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		default:
+			rerr = &rpcError{Code: rpcInternalError, Message: err.Error()}
+		}
+		response = rpcErrorResponse{JSONRPC: rpcVersion, ID: id, Error: rerr}
+	}
+	out, err := json.Marshal(response)
+	if err != nil {
+		log.Errorf("Error marshalling websocket response: %s", err)
+		return
+	}
+	c.queue(out)
+}
+
+// unmarshalParams unmarshals the request params into v.
+func unmarshalParams(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 {
+		return &rpcError{Code: rpcInvalidParams, Message: "missing params"}
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+// decodePeerID decodes the peer ID returning an invalid params error if
+// it is invalid.
+func decodePeerID(peerID string) (peer.ID, error) {
+	pid, err := peer.IDB58Decode(peerID)
+	if err != nil {
+		return "", &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf("invalid peer ID: %s", err)}
+	}
+	return pid, nil
+}
+
+func rpcPing(c *connection, params json.RawMessage) (interface{}, error) {
+	return "pong", nil
+}
+
+type topicsParams struct {
+	Topics []string `json:"topics"`
+}
+
+// updateTopics subscribes or unsubscribes from the topics and returns
+// the topics now subscribed to.
+func (c *connection) updateTopics(params json.RawMessage, subscribe bool) (interface{}, error) {
+	var p topicsParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	for _, topic := range p.Topics {
+		if !websocketTopics[topic] {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf("unknown topic %s", topic)}
+		}
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for _, topic := range p.Topics {
+		if subscribe {
+			c.topics[topic] = true
+		} else {
+			delete(c.topics, topic)
+		}
+	}
+	result := topicsParams{Topics: []string{}}
+	for topic := range websocketTopics {
+		if c.topics[topic] {
+			result.Topics = append(result.Topics, topic)
+		}
+	}
+	sort.Strings(result.Topics)
+	return result, nil
+}
+
+func rpcSubscribe(c *connection, params json.RawMessage) (interface{}, error) {
+	return c.updateTopics(params, true)
+}
+
+func rpcUnsubscribe(c *connection, params json.RawMessage) (interface{}, error) {
+	return c.updateTopics(params, false)
+}
+
+func rpcGetProfile(c *connection, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		PeerID   string `json:"peerID"`
+		UseCache bool   `json:"useCache"`
+	}
+	if len(params) > 0 {
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
 		}
 	}
-	c.ws.Close()
+	if p.PeerID == "" || p.PeerID == c.node.Identity().Pretty() {
+		return c.node.GetMyProfile()
+	}
+	pid, err := decodePeerID(p.PeerID)
+	if err != nil {
+		return nil, err
+	}
+	return c.node.GetProfile(c.ctx, pid, p.UseCache)
+}
+
+func rpcSendChatMessage(c *connection, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		PeerID  string `json:"peerID"`
+		Message string `json:"message"`
+		OrderID string `json:"orderID"`
+	}
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	pid, err := decodePeerID(p.PeerID)
+	if err != nil {
+		return nil, err
+	}
+	return nil, c.node.SendChatMessage(pid, p.Message, models.OrderID(p.OrderID), nil)
+}
+
+func rpcSendTypingMessage(c *connection, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		PeerID  string `json:"peerID"`
+		OrderID string `json:"orderID"`
+	}
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	pid, err := decodePeerID(p.PeerID)
+	if err != nil {
+		return nil, err
+	}
+	return nil, c.node.SendTypingMessage(pid, models.OrderID(p.OrderID))
+}
+
+func rpcMarkChatAsRead(c *connection, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		PeerID  string `json:"peerID"`
+		OrderID string `json:"orderID"`
+	}
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	pid, err := decodePeerID(p.PeerID)
+	if err != nil {
+		return nil, err
+	}
+	return nil, c.node.MarkChatMessagesAsRead(pid, models.OrderID(p.OrderID))
 }
 
 var upgrader = &websocket.Upgrader{
@@ -51,22 +421,35 @@ type hub struct {
 	// Registered connections
 	connections map[*connection]bool
 
-	// Outbound messages to the connections
-	Broadcast chan []byte
+	// Outbound events to the subscribed connections
+	Broadcast chan *wsEvent
 
 	// Register requests from the connections
 	register chan *connection
 
 	// Unregister requests from connections
 	unregister chan *connection
+
+	// Registered server-sent event streams
+	streams map[*eventStream]bool
+
+	// Register requests from the event streams
+	registerStream chan *eventStream
+
+	// Unregister requests from the event streams
+	unregisterStream chan *eventStream
 }
 
 func newHub() *hub {
 	return &hub{
-		Broadcast:   make(chan []byte),
+		Broadcast:   make(chan *wsEvent),
 		register:    make(chan *connection),
 		unregister:  make(chan *connection),
 		connections: make(map[*connection]bool),
+
+		streams:          make(map[*eventStream]bool),
+		registerStream:   make(chan *eventStream),
+		unregisterStream: make(chan *eventStream),
 	}
 }
 
@@ -77,18 +460,28 @@ func (h *hub) run() {
 			h.connections[c] = true
 			log.Debug("Registered new websocket connection")
 		case c := <-h.unregister:
-			if _, ok := h.connections[c]; ok {
-				delete(h.connections, c)
-				close(c.send)
-			}
+			delete(h.connections, c)
 			log.Debug("Unregistered websocket connection")
-		case m := <-h.Broadcast:
+		case s := <-h.registerStream:
+			h.streams[s] = true
+			log.Debug("Registered new event stream")
+		case s := <-h.unregisterStream:
+			delete(h.streams, s)
+			log.Debug("Unregistered event stream")
+		case e := <-h.Broadcast:
 			for c := range h.connections {
+				if c.subscribed(e.topic) {
+					c.queue(e.message)
+				}
+			}
+			for s := range h.streams {
 				select {
-				case c.send <- m:
+				case s.send <- e:
 				default:
-					delete(h.connections, c)
-					close(c.send)
+					// The client is not keeping up. Closing the stream
+					// lets it reconnect and replay what it missed.
+					close(s.send)
+					delete(h.streams, s)
 				}
 			}
 		}
@@ -96,12 +489,18 @@ func (h *hub) run() {
 }
 
 type websocketHandler struct {
-	hub *hub
+	hub        *hub
+	node       coreiface.CoreIface
+	publicOnly bool
+	heartbeat  time.Duration
 }
 
-func newWebsocketHandler(hub *hub) *websocketHandler {
+func newWebsocketHandler(hub *hub, node coreiface.CoreIface, publicOnly bool) *websocketHandler {
 	handler := websocketHandler{
-		hub: hub,
+		hub:        hub,
+		node:       node,
+		publicOnly: publicOnly,
+		heartbeat:  wsHeartbeatInterval,
 	}
 	return &handler
 }
@@ -109,10 +508,22 @@ func newWebsocketHandler(hub *hub) *websocketHandler {
 func (wsh websocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ws, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		log.Errorf("Error upgrading websocket:", err)
+		log.Errorf("Error upgrading websocket: %s", err)
 		return
 	}
-	c := &connection{send: make(chan []byte, 256), ws: ws, h: wsh.hub}
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &connection{
+		send:       make(chan []byte, 256),
+		ws:         ws,
+		h:          wsh.hub,
+		node:       wsh.node,
+		publicOnly: wsh.publicOnly,
+		heartbeat:  wsh.heartbeat,
+		ctx:        ctx,
+		cancel:     cancel,
+		topics:     make(map[string]bool),
+		done:       make(chan struct{}),
+	}
 	c.h.register <- c
 	defer func() { c.h.unregister <- c }()
 	go c.writer()
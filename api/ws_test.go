@@ -0,0 +1,320 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/gorilla/websocket"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/libp2p/go-testutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type wsMessage struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+	Params struct {
+		Topic string          `json:"topic"`
+		Data  json.RawMessage `json:"data"`
+	} `json:"params"`
+}
+
+type wsTestClient struct {
+	t    *testing.T
+	conn *websocket.Conn
+}
+
+func (c *wsTestClient) call(id int, method string, params interface{}) {
+	req := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"method":  method,
+	}
+	if params != nil {
+		req["params"] = params
+	}
+	if err := c.conn.WriteJSON(req); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// next returns the next message which is not a heartbeat.
+func (c *wsTestClient) next() wsMessage {
+	for {
+		c.conn.SetReadDeadline(time.Now().Add(time.Second * 10))
+		var msg wsMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			c.t.Fatal(err)
+		}
+		if msg.Method != "heartbeat" {
+			return msg
+		}
+	}
+}
+
+func newWSTestGateway(t *testing.T, publicOnly bool) (*Gateway, *mockNode, *httptest.Server) {
+	peerID, err := testutil.RandPeerID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := &mockNode{
+		identityFunc: func() peer.ID {
+			return peerID
+		},
+	}
+	g := &Gateway{
+		node:   node,
+		config: &GatewayConfig{PublicOnly: publicOnly},
+		hub:    newHub(),
+	}
+	go g.hub.run()
+	ts := httptest.NewServer(newWebsocketHandler(g.hub, node, publicOnly))
+	return g, node, ts
+}
+
+func dialWSTestClient(t *testing.T, ts *httptest.Server) *wsTestClient {
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &wsTestClient{t: t, conn: conn}
+}
+
+func TestWebsocketRPC(t *testing.T) {
+	_, node, ts := newWSTestGateway(t, false)
+	defer ts.Close()
+	c := dialWSTestClient(t, ts)
+	defer c.conn.Close()
+
+	remote, err := testutil.RandPeerID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	node.sendChatMessageFunc = func(to peer.ID, message string, orderID models.OrderID, done chan<- struct{}) error {
+		if to != remote || message != "hello" || orderID != "1234" {
+			return fmt.Errorf("incorrect chat message")
+		}
+		return nil
+	}
+	node.sendTypingMessageFunc = func(to peer.ID, orderID models.OrderID) error {
+		return nil
+	}
+	node.markChatMessagesAsReadFunc = func(p peer.ID, orderID models.OrderID) error {
+		return fmt.Errorf("%w: no messages", coreiface.ErrNotFound)
+	}
+	node.getMyProfileFunc = func() (*models.Profile, error) {
+		return &models.Profile{Name: "Ron Swanson"}, nil
+	}
+	node.getProfileFunc = func(ctx context.Context, peerID peer.ID, useCache bool) (*models.Profile, error) {
+		if peerID != remote || !useCache {
+			return nil, fmt.Errorf("incorrect profile request")
+		}
+		return &models.Profile{Name: "Tom Haverford"}, nil
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		params         interface{}
+		expectedResult string
+		expectedError  int
+	}{
+		{
+			name:           "Ping",
+			method:         "ping",
+			expectedResult: `"pong"`,
+		},
+		{
+			name:           "Send chat message",
+			method:         "sendChatMessage",
+			params:         map[string]string{"peerID": remote.Pretty(), "message": "hello", "orderID": "1234"},
+			expectedResult: `null`,
+		},
+		{
+			name:          "Send chat message invalid peer ID",
+			method:        "sendChatMessage",
+			params:        map[string]string{"peerID": "abc", "message": "hello"},
+			expectedError: rpcInvalidParams,
+		},
+		{
+			name:          "Send chat message missing params",
+			method:        "sendChatMessage",
+			expectedError: rpcInvalidParams,
+		},
+		{
+			name:           "Send typing message",
+			method:         "sendTypingMessage",
+			params:         map[string]string{"peerID": remote.Pretty()},
+			expectedResult: `null`,
+		},
+		{
+			name:          "Mark chat as read not found",
+			method:        "markChatAsRead",
+			params:        map[string]string{"peerID": remote.Pretty()},
+			expectedError: rpcNotFound,
+		},
+		{
+			name:           "Get my profile",
+			method:         "getProfile",
+			expectedResult: "Ron Swanson",
+		},
+		{
+			name:           "Get profile",
+			method:         "getProfile",
+			params:         map[string]interface{}{"peerID": remote.Pretty(), "useCache": true},
+			expectedResult: "Tom Haverford",
+		},
+		{
+			name:          "Unknown method",
+			method:        "deleteEverything",
+			expectedError: rpcMethodNotFound,
+		},
+		{
+			name:          "Unknown topic",
+			method:        "subscribe",
+			params:        map[string][]string{"topics": {"secrets"}},
+			expectedError: rpcInvalidParams,
+		},
+	}
+
+	for i, test := range tests {
+		c.call(i, test.method, test.params)
+		msg := c.next()
+		if string(msg.ID) != fmt.Sprintf("%d", i) {
+			t.Errorf("%s: expected id %d, got %s", test.name, i, string(msg.ID))
+			continue
+		}
+		if test.expectedError != 0 {
+			if msg.Error == nil || msg.Error.Code != test.expectedError {
+				t.Errorf("%s: expected error code %d, got %v", test.name, test.expectedError, msg.Error)
+			}
+			continue
+		}
+		if msg.Error != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, msg.Error.Message)
+			continue
+		}
+		if !strings.Contains(string(msg.Result), test.expectedResult) {
+			t.Errorf("%s: expected result %s, got %s", test.name, test.expectedResult, string(msg.Result))
+		}
+	}
+
+	// Invalid requests are rejected rather than rebroadcast.
+	if err := c.conn.WriteMessage(websocket.TextMessage, []byte(`{"notification": {}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if msg := c.next(); msg.Error == nil || msg.Error.Code != rpcInvalidRequest {
+		t.Errorf("Expected invalid request error, got %v", msg.Error)
+	}
+	if err := c.conn.WriteMessage(websocket.TextMessage, []byte(`not json`)); err != nil {
+		t.Fatal(err)
+	}
+	if msg := c.next(); msg.Error == nil || msg.Error.Code != rpcParseError || string(msg.ID) != "null" {
+		t.Errorf("Expected parse error, got %v", msg.Error)
+	}
+}
+
+func TestWebsocketSubscriptions(t *testing.T) {
+	g, _, ts := newWSTestGateway(t, false)
+	defer ts.Close()
+
+	subscriber := dialWSTestClient(t, ts)
+	defer subscriber.conn.Close()
+	other := dialWSTestClient(t, ts)
+	defer other.conn.Close()
+
+	subscriber.call(1, "subscribe", map[string][]string{"topics": {"chatMessage", "notification"}})
+	if msg := subscriber.next(); string(msg.Result) != `{"topics":["chatMessage","notification"]}` {
+		t.Fatalf("Incorrect subscribe result: %s", string(msg.Result))
+	}
+	subscriber.call(2, "unsubscribe", map[string][]string{"topics": {"notification"}})
+	if msg := subscriber.next(); string(msg.Result) != `{"topics":["chatMessage"]}` {
+		t.Fatalf("Incorrect unsubscribe result: %s", string(msg.Result))
+	}
+
+	type notificationWrapper struct {
+		Notification interface{} `json:"notification"`
+	}
+	type chatMessageWrapper struct {
+		ChatMessage interface{} `json:"chatMessage"`
+	}
+	if err := g.NotifyWebsockets(notificationWrapper{map[string]string{"type": "NewOrder"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.NotifyWebsockets(chatMessageWrapper{map[string]string{"message": "hello"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.NotifyWebsockets(struct{}{}); err == nil {
+		t.Error("Expected error for message without a topic")
+	}
+
+	// Only the chat message is delivered to the subscriber.
+	msg := subscriber.next()
+	if msg.Method != "event" || msg.Params.Topic != "chatMessage" {
+		t.Fatalf("Expected chatMessage event, got %s %s", msg.Method, msg.Params.Topic)
+	}
+	var data map[string]string
+	if err := json.Unmarshal(msg.Params.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	if data["message"] != "hello" {
+		t.Errorf("Incorrect event data: %s", string(msg.Params.Data))
+	}
+
+	// The other connection is not subscribed so the next message it
+	// receives is the response to its ping.
+	other.call(3, "ping", nil)
+	if msg := other.next(); msg.Method != "" || string(msg.Result) != `"pong"` {
+		t.Errorf("Expected pong, got %s %s", msg.Method, string(msg.Result))
+	}
+}
+
+func TestWebsocketHeartbeat(t *testing.T) {
+	handler := newWebsocketHandler(newHub(), &mockNode{}, false)
+	handler.heartbeat = time.Millisecond * 50
+	go handler.hub.run()
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	c := dialWSTestClient(t, ts)
+	defer c.conn.Close()
+
+	c.conn.SetReadDeadline(time.Now().Add(time.Second * 10))
+	var msg wsMessage
+	if err := c.conn.ReadJSON(&msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Method != "heartbeat" {
+		t.Errorf("Expected heartbeat, got %s", msg.Method)
+	}
+}
+
+func TestWebsocketPublicOnly(t *testing.T) {
+	_, node, ts := newWSTestGateway(t, true)
+	defer ts.Close()
+	node.sendChatMessageFunc = func(to peer.ID, message string, orderID models.OrderID, done chan<- struct{}) error {
+		return nil
+	}
+	c := dialWSTestClient(t, ts)
+	defer c.conn.Close()
+
+	remote, err := testutil.RandPeerID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.call(1, "sendChatMessage", map[string]string{"peerID": remote.Pretty(), "message": "hello"})
+	if msg := c.next(); msg.Error == nil || msg.Error.Code != rpcMethodNotFound {
+		t.Errorf("Expected method not found error, got %v", msg.Error)
+	}
+	c.call(2, "ping", nil)
+	if msg := c.next(); string(msg.Result) != `"pong"` {
+		t.Errorf("Expected pong, got %s", string(msg.Result))
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/api"
+	"github.com/cpacia/openbazaar3.0/models"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// apiClientOptions are the options used to connect to the API of
+// a running OpenBazaar node.
+type apiClientOptions struct {
+	APIAddr     string `short:"a" long:"apiaddr" description:"The address of the node's API" default:"http://127.0.0.1:4002"`
+	APIUsername string `short:"u" long:"apiusername" description:"The username to use with the API authentication"`
+	APIPassword string `short:"P" long:"apipassword" description:"The password to use with the API authentication"`
+	APICookie   string `long:"apicookie" description:"The cookie to use with the API authentication"`
+}
+
+// do makes a request to the node's API and returns the response body. An
+// error is returned if the API does not respond with a 200 status code.
+func (o *apiClientOptions) do(method, path string, query url.Values, body io.Reader) ([]byte, error) {
+	u := strings.TrimSuffix(o.APIAddr, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if o.APIUsername != "" || o.APIPassword != "" {
+		req.SetBasicAuth(o.APIUsername, o.APIPassword)
+	}
+	if o.APICookie != "" {
+		req.AddCookie(&http.Cookie{Name: api.AuthCookieName, Value: o.APICookie})
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	out, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("api returned %s: %s", resp.Status, strings.TrimSpace(string(out)))
+	}
+	return out, nil
+}
+
+// Listings is the parent of the listings import and export commands.
+type Listings struct{}
+
+// ImportListings imports listings from a CSV or JSON file into a running
+// OpenBazaar node.
+type ImportListings struct {
+	apiClientOptions
+	Format string `short:"f" long:"format" description:"The format of the file. If omitted it's taken from the file extension." choice:"csv" choice:"json"`
+	DryRun bool   `long:"dryrun" description:"Validate the listings without saving them"`
+}
+
+// Execute imports the listings in the file passed in as the first argument.
+func (x *ImportListings) Execute(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: openbazaar listings import [options] <file>")
+	}
+	format := x.Format
+	if format == "" {
+		format = listingFileFormatFromExtension(args[0])
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	query := url.Values{}
+	query.Set("format", format)
+	if x.DryRun {
+		query.Set("dryrun", "true")
+	}
+	out, err := x.do(http.MethodPost, "/v1/ob/listings/import", query, f)
+	if err != nil {
+		return err
+	}
+
+	var result models.ListingImportResult
+	if err := json.Unmarshal(out, &result); err != nil {
+		return err
+	}
+	for _, e := range result.Errors {
+		fmt.Printf("Row %d %s: %s\n", e.Row, e.Slug, e.Error)
+	}
+	if result.DryRun {
+		fmt.Printf("Dry run: %d listings valid, %d failed\n", len(result.Imported), len(result.Errors))
+	} else {
+		fmt.Printf("Imported %d listings, %d failed\n", len(result.Imported), len(result.Errors))
+	}
+	return nil
+}
+
+// ExportListings exports the listings from a running OpenBazaar node to
+// a CSV or JSON file.
+type ExportListings struct {
+	apiClientOptions
+	Format string `short:"f" long:"format" description:"The format of the file. If omitted it's taken from the file extension." choice:"csv" choice:"json"`
+}
+
+// Execute exports the listings to the file passed in as the first argument
+// or to stdout if no file is provided.
+func (x *ExportListings) Execute(args []string) error {
+	if len(args) > 1 {
+		return errors.New("usage: openbazaar listings export [options] [file]")
+	}
+	format := x.Format
+	if format == "" && len(args) == 1 {
+		format = listingFileFormatFromExtension(args[0])
+	}
+
+	query := url.Values{}
+	query.Set("format", format)
+	out, err := x.do(http.MethodGet, "/v1/ob/listings/export", query, nil)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+	return ioutil.WriteFile(args[0], out, os.ModePerm)
+}
+
+// listingFileFormatFromExtension returns the listing file format for the
+// file extension. Anything other than .json is treated as CSV.
+func listingFileFormatFromExtension(filename string) string {
+	if strings.ToLower(filepath.Ext(filename)) == ".json" {
+		return string(models.ListingFileFormatJSON)
+	}
+	return string(models.ListingFileFormatCSV)
+}
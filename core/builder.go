@@ -377,6 +377,14 @@ func NewNode(ctx context.Context, cfg *repo.Config) (*OpenBazaarNode, error) {
 		}
 	}
 
+	var crawlBudget int
+	if cfg.EnableCrawler {
+		if cfg.CrawlBudget <= 0 || cfg.CrawlInterval <= 0 {
+			return nil, errors.New("crawl budget and crawl interval must be greater than zero")
+		}
+		crawlBudget = cfg.CrawlBudget
+	}
+
 	// Construct our OpenBazaar node.repo object
 	obNode := &OpenBazaarNode{
 		ipfsNode:               ipfsNode,
@@ -395,6 +403,9 @@ func NewNode(ctx context.Context, cfg *repo.Config) (*OpenBazaarNode, error) {
 		torOnly:                cfg.Tor,
 		storeAndForwardServers: cfg.StoreAndForwardServers,
 		shutdownTorFunc:        shutdownTorFunc,
+		crawlBudget:            crawlBudget,
+		crawlInterval:          cfg.CrawlInterval,
+		crawlMaxAge:            cfg.CrawlMaxAge,
 		publishChan:            make(chan pubCloser),
 		initialBootstrapChan:   make(chan struct{}),
 		shutdown:               make(chan struct{}),
@@ -406,6 +417,43 @@ func NewNode(ctx context.Context, cfg *repo.Config) (*OpenBazaarNode, error) {
 	}
 
 	obNode.notifier = notifications.NewNotifier(bus, obRepo.DB(), obNode.gateway.NotifyWebsockets)
+	if len(cfg.Webhooks) > 0 {
+		webhooks := make([]notifications.Webhook, 0, len(cfg.Webhooks))
+		for _, s := range cfg.Webhooks {
+			webhook, err := notifications.ParseWebhook(s)
+			if err != nil {
+				return nil, err
+			}
+			webhooks = append(webhooks, webhook)
+		}
+		obNode.webhooks, err = notifications.NewWebhookDispatcher(&notifications.WebhookConfig{
+			DB:          obRepo.DB(),
+			Webhooks:    webhooks,
+			Secret:      cfg.WebhookSecret,
+			MaxAttempts: cfg.WebhookMaxAttempts,
+		})
+		if err != nil {
+			return nil, err
+		}
+		obNode.notifier.AddSink(obNode.webhooks)
+	}
+	if cfg.SMTPServer != "" {
+		obNode.emailNotifier, err = notifications.NewEmailNotifier(&notifications.EmailConfig{
+			DB:             obRepo.DB(),
+			Server:         cfg.SMTPServer,
+			Username:       cfg.SMTPUsername,
+			Password:       cfg.SMTPPassword,
+			From:           cfg.SMTPFrom,
+			Security:       cfg.SMTPSecurity,
+			DigestInterval: cfg.EmailDigestInterval,
+			ChatDelay:      cfg.EmailChatDelay,
+			TemplateDir:    path.Join(cfg.DataDir, "email-templates"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		obNode.notifier.AddSink(obNode.emailNotifier)
+	}
 	obNode.messenger, err = obnet.NewMessenger(&obnet.MessengerConfig{
 		Service:        service,
 		SNFServers:     snfServers,
@@ -552,6 +600,8 @@ func (n *OpenBazaarNode) registerHandlers() {
 	n.networkService.RegisterHandler(pb.Message_ORDER, n.handleOrderMessage)
 	n.networkService.RegisterHandler(pb.Message_ADDRESS_REQUEST, n.handleAddressRequest)
 	n.networkService.RegisterHandler(pb.Message_ADDRESS_RESPONSE, n.handleAddressResponse)
+	n.networkService.RegisterHandler(pb.Message_INVENTORY_REQUEST, n.handleInventoryRequest)
+	n.networkService.RegisterHandler(pb.Message_INVENTORY_RESPONSE, n.handleInventoryResponse)
 	n.networkService.RegisterHandler(pb.Message_PING, n.handlePingMessage)
 	n.networkService.RegisterHandler(pb.Message_PONG, n.handlePongMessage)
 }
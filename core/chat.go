@@ -30,14 +30,19 @@ func (n *OpenBazaarNode) SendChatMessage(to peer.ID, message string, orderID mod
 		return errors.New("message is too long")
 	}
 
-	chatMsg := pb.ChatMessage{
+	chatMsg := &pb.ChatMessage{
 		Message:   message,
 		OrderID:   orderID.String(),
 		Timestamp: ptypes.TimestampNow(),
 		Flag:      pb.ChatMessage_MESSAGE,
 	}
+	return n.sendChatMessage(to, chatMsg, done)
+}
 
-	payload, err := ptypes.MarshalAny(&chatMsg)
+// sendChatMessage saves the chat message to the database and sends
+// it reliably to the peer.
+func (n *OpenBazaarNode) sendChatMessage(to peer.ID, chatMsg *pb.ChatMessage, done chan<- struct{}) error {
+	payload, err := ptypes.MarshalAny(chatMsg)
 	if err != nil {
 		maybeCloseDone(done)
 		return err
@@ -4,13 +4,19 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
 	"github.com/cpacia/openbazaar3.0/database"
 	"github.com/cpacia/openbazaar3.0/models"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
 	"github.com/cpacia/openbazaar3.0/orders/pb"
 	"github.com/cpacia/openbazaar3.0/orders/utils"
+	iwallet "github.com/cpacia/wallet-interface"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/jinzhu/gorm"
 	crypto "github.com/libp2p/go-libp2p-crypto"
+	"os"
 )
 
 // CompleteOrder builds a OrderComplete message and sends it to the vendor. The ratings slice must
@@ -24,15 +30,21 @@ func (n *OpenBazaarNode) CompleteOrder(orderID models.OrderID, ratings []models.
 	)
 	err = n.repo.DB().View(func(tx database.Tx) error {
 		profile, err = tx.GetProfile()
-		if err != nil {
+		if err != nil && !os.IsNotExist(err) {
 			return err
 		}
-		return tx.Read().Where("id = ?", orderID.String()).Find(&order).Error
+		return tx.Read().Where("id = ?", orderID.String()).First(&order).Error
 	})
-	if err != nil {
+	if gorm.IsRecordNotFoundError(err) {
+		return fmt.Errorf("%w: order not found", coreiface.ErrNotFound)
+	} else if err != nil {
 		return err
 	}
 
+	if !order.CanComplete(n.Identity()) {
+		return fmt.Errorf("%w: order is not in a state where it can be completed", coreiface.ErrBadRequest)
+	}
+
 	orderOpen, err := order.OrderOpenMessage()
 	if err != nil {
 		return err
@@ -44,7 +56,7 @@ func (n *OpenBazaarNode) CompleteOrder(orderID models.OrderID, ratings []models.
 	}
 
 	if len(ratings) != len(orderOpen.Items) {
-		return errors.New("number of ratings does not equal number of items in the order")
+		return fmt.Errorf("%w: number of ratings does not equal number of items in the order", coreiface.ErrBadRequest)
 	}
 
 	if len(ratingSignatures.Sigs) != len(orderOpen.Items) {
@@ -90,14 +102,18 @@ func (n *OpenBazaarNode) CompleteOrder(orderID models.OrderID, ratings []models.
 				return err
 			}
 
-			ratingPB.BuyerName = profile.Name
+			var name, handle string
+			if profile != nil {
+				name, handle = profile.Name, profile.Handle
+			}
+			ratingPB.BuyerName = name
 			ratingPB.BuyerID = &pb.ID{
 				PeerID: n.Identity().Pretty(),
 				Pubkeys: &pb.ID_Pubkeys{
 					Identity: identityPubkey,
 					Escrow:   n.escrowMasterKey.PubKey().SerializeCompressed(),
 				},
-				Handle: profile.Handle,
+				Handle: handle,
 				Sig:    sig.Serialize(),
 			}
 
@@ -106,25 +122,96 @@ func (n *OpenBazaarNode) CompleteOrder(orderID models.OrderID, ratings []models.
 				return err
 			}
 			ratingPB.BuyerSig = buyerSig
+		}
 
-			ser, err := proto.Marshal(ratingPB)
-			if err != nil {
-				return err
-			}
+		ratingPB.SignatureVersion = utils.RatingSignatureVersion
+		ser, err := proto.Marshal(ratingPB)
+		if err != nil {
+			return err
+		}
 
-			ratingSig, err := ratingKeys[i].Sign(ser)
-			if err != nil {
-				return err
-			}
-			ratingPB.RatingSignature = ratingSig.Serialize()
+		hashed := sha256.Sum256(ser)
+		ratingSig, err := ratingKeys[i].Sign(hashed[:])
+		if err != nil {
+			return err
 		}
+		ratingPB.RatingSignature = ratingSig.Serialize()
 
 		completeMsg.Ratings = append(completeMsg.Ratings, ratingPB)
 	}
 
-	if orderOpen.Payment.Method == pb.OrderOpen_Payment_MODERATED {
-		// TODO: release funds
+	// If the funds are still sitting in escrow we include our signatures releasing
+	// them to the vendor. This is not needed if the payment was already finalized
+	// or if the moderator closed a dispute.
+	if orderOpen.Payment.Method == pb.OrderOpen_Payment_MODERATED && order.SerializedPaymentFinalized == nil &&
+		order.SerializedDisputeClosed == nil {
+
+		fulfillments, err := order.OrderFulfillmentMessages()
+		if err != nil {
+			return err
+		}
+		if len(fulfillments) == 0 || fulfillments[0].ReleaseInfo == nil {
+			return errors.New("fulfillment is missing vendor release info")
+		}
+
+		wallet, err := n.multiwallet.WalletForCurrencyCode(orderOpen.Payment.Coin)
+		if err != nil {
+			return err
+		}
+		escrowWallet, ok := wallet.(iwallet.Escrow)
+		if !ok {
+			return errors.New("wallet does not support escrow")
+		}
+		fee, err := escrowWallet.EstimateEscrowFee(2, iwallet.FlNormal)
+		if err != nil {
+			return err
+		}
+
+		to := iwallet.NewAddress(fulfillments[0].ReleaseInfo.ToAddress, iwallet.CoinType(orderOpen.Payment.Coin))
+		completeMsg.ReleaseInfo, err = n.buildEscrowRelease(&order, wallet, to, fee)
+		if err != nil {
+			return err
+		}
+	}
+
+	completeAny, err := ptypes.MarshalAny(completeMsg)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	resp := &npb.OrderMessage{
+		OrderID:     order.ID.String(),
+		MessageType: npb.OrderMessage_ORDER_COMPLETE,
+		Message:     completeAny,
+	}
+
+	if err := utils.SignOrderMessage(resp, n.ipfsNode.PrivateKey); err != nil {
+		return err
+	}
+
+	payload, err := ptypes.MarshalAny(resp)
+	if err != nil {
+		return err
+	}
+
+	message := newMessageWithID()
+	message.MessageType = npb.Message_ORDER
+	message.Payload = payload
+
+	buyer, err := order.Buyer()
+	if err != nil {
+		return err
+	}
+	vendor, err := order.Vendor()
+	if err != nil {
+		return err
+	}
+
+	return n.repo.DB().Update(func(tx database.Tx) error {
+		if _, err := n.orderProcessor.ProcessMessage(tx, buyer, resp); err != nil {
+			return err
+		}
+
+		return n.messenger.ReliablySendMessage(tx, vendor, message, done)
+	})
 }
@@ -0,0 +1,295 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/models/factory"
+	iwallet "github.com/cpacia/wallet-interface"
+	"testing"
+	"time"
+)
+
+func TestOpenBazaarNode_CompleteOrder(t *testing.T) {
+	network, err := NewMocknet(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer network.TearDown()
+
+	go network.StartWalletNetwork()
+
+	for _, node := range network.Nodes() {
+		go node.orderProcessor.Start()
+	}
+
+	listing := factory.NewPhysicalListing("tshirt")
+
+	done := make(chan struct{})
+	if err := network.Nodes()[0].SaveListing(listing, done); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	index, err := network.Nodes()[0].GetMyListings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done2 := make(chan struct{})
+	if err := network.Nodes()[2].SetProfile(&models.Profile{Name: "Ron Paul"}, done2); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done2:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	modInfo := &models.ModeratorInfo{
+		AcceptedCurrencies: []string{"MCK"},
+		Fee: models.ModeratorFee{
+			Percentage: 10,
+			FeeType:    models.PercentageFee,
+		},
+	}
+	done3 := make(chan struct{})
+	if err := network.Nodes()[2].SetSelfAsModerator(context.Background(), modInfo, done3); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done3:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	purchase := factory.NewPurchase()
+	purchase.Items[0].ListingHash = index[0].CID
+	purchase.Moderator = network.Nodes()[2].Identity().Pretty()
+
+	orderSub0, err := network.Nodes()[0].eventBus.Subscribe(&events.NewOrder{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orderID, paymentAddress, paymentAmount, err := network.Nodes()[1].PurchaseListing(context.Background(), purchase)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-orderSub0.Out():
+		orderSub0.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	wallet1, err := network.Nodes()[1].multiwallet.WalletForCurrencyCode(iwallet.CtMock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr1, err := wallet1.CurrentAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txSub1, err := network.Nodes()[1].eventBus.Subscribe(&events.TransactionReceived{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := network.WalletNetwork().GenerateToAddress(addr1, iwallet.NewAmount(100000000000)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-txSub1.Out():
+		txSub1.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	fundingSub0, err := network.Nodes()[0].eventBus.Subscribe(&events.OrderFunded{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fundingSub1, err := network.Nodes()[1].eventBus.Subscribe(&events.OrderPaymentReceived{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wTx, err := wallet1.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wallet1.Spend(wTx, paymentAddress, paymentAmount.Amount, iwallet.FlNormal); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := wTx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-fundingSub0.Out():
+		fundingSub0.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	select {
+	case <-fundingSub1.Out():
+		fundingSub1.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	// The buyer cannot complete before the order is fulfilled.
+	if err := network.Nodes()[1].CompleteOrder(orderID, nil, true, nil); !errors.Is(err, coreiface.ErrBadRequest) {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+
+	confirmSub1, err := network.Nodes()[1].eventBus.Subscribe(&events.OrderConfirmation{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done4 := make(chan struct{})
+	if err := network.Nodes()[0].ConfirmOrder(orderID, done4); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done4:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	select {
+	case <-confirmSub1.Out():
+		confirmSub1.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	fulfillmentSub1, err := network.Nodes()[1].eventBus.Subscribe(&events.OrderFulfillment{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fulfillments := []models.Fulfillment{
+		{
+			ItemIndex: 0,
+			PhysicalDelivery: &models.PhysicalDelivery{
+				TrackingNumber: "1234",
+				Shipper:        "UPS",
+			},
+		},
+	}
+	done5 := make(chan struct{})
+	if err := network.Nodes()[0].FulfillOrder(orderID, fulfillments, done5); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done5:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	select {
+	case <-fulfillmentSub1.Out():
+		fulfillmentSub1.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	ratings := []models.Rating{
+		{
+			Overall:         5,
+			Quality:         4,
+			Description:     3,
+			DeliverySpeed:   2,
+			CustomerService: 1,
+			Review:          "Great tshirt",
+		},
+	}
+
+	// The vendor cannot complete.
+	if err := network.Nodes()[0].CompleteOrder(orderID, ratings, true, nil); !errors.Is(err, coreiface.ErrBadRequest) {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+
+	completeSub0, err := network.Nodes()[0].eventBus.Subscribe(&events.OrderCompletion{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spendSub0, err := network.Nodes()[0].eventBus.Subscribe(&events.SpendFromPaymentAddress{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done6 := make(chan struct{})
+	if err := network.Nodes()[1].CompleteOrder(orderID, ratings, true, done6); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done6:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	select {
+	case <-completeSub0.Out():
+		completeSub0.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	// The vendor should have released the funds from escrow.
+	select {
+	case <-spendSub0.Out():
+		spendSub0.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	var vendorOrder models.Order
+	err = network.Nodes()[0].repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("id = ?", orderID.String()).First(&vendorOrder).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if vendorOrder.SerializedOrderComplete == nil {
+		t.Error("Node 0 failed to save order complete")
+	}
+
+	buyerOrder, err := network.Nodes()[1].GetOrder(orderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if buyerOrder.SerializedOrderComplete == nil {
+		t.Error("Node 1 failed to save order complete")
+	}
+
+	// Completing twice is not allowed.
+	if err := network.Nodes()[1].CompleteOrder(orderID, ratings, true, nil); !errors.Is(err, coreiface.ErrBadRequest) {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+
+	if _, err := network.Nodes()[1].GetOrder("abc"); !errors.Is(err, coreiface.ErrNotFound) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
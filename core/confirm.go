@@ -5,11 +5,8 @@ import (
 	"github.com/cpacia/openbazaar3.0/core/coreiface"
 	"github.com/cpacia/openbazaar3.0/database"
 	"github.com/cpacia/openbazaar3.0/models"
-	npb "github.com/cpacia/openbazaar3.0/net/pb"
-	"github.com/cpacia/openbazaar3.0/orders/pb"
-	"github.com/cpacia/openbazaar3.0/orders/utils"
 	iwallet "github.com/cpacia/wallet-interface"
-	"github.com/golang/protobuf/ptypes"
+	"github.com/jinzhu/gorm"
 )
 
 // ConfirmOrder sends a ORDER_CONFIRMATION message to the remote peer and updates the node's
@@ -26,7 +23,9 @@ func (n *OpenBazaarNode) ConfirmOrder(orderID models.OrderID, done chan struct{}
 	err := n.repo.DB().View(func(tx database.Tx) error {
 		return tx.Read().Where("id = ?", orderID.String()).First(&order).Error
 	})
-	if err != nil {
+	if gorm.IsRecordNotFoundError(err) {
+		return fmt.Errorf("%w: order not found", coreiface.ErrNotFound)
+	} else if err != nil {
 		return err
 	}
 
@@ -34,76 +33,21 @@ func (n *OpenBazaarNode) ConfirmOrder(orderID models.OrderID, done chan struct{}
 		return fmt.Errorf("%w: order is not in a state where it can be confirmed", coreiface.ErrBadRequest)
 	}
 
-	buyer, err := order.Buyer()
-	if err != nil {
+	// As with auto-confirm, the sweep is only committed once the confirmation
+	// is saved and the database is no longer held.
+	var wTx iwallet.Tx
+	err = n.repo.DB().Update(func(tx database.Tx) error {
+		wTx, err = n.orderProcessor.ConfirmOrder(tx, &order, done)
 		return err
-	}
-	vendor, err := order.Vendor()
+	})
 	if err != nil {
+		if wTx != nil {
+			wTx.Rollback()
+		}
 		return err
 	}
-
-	return n.repo.DB().Update(func(tx database.Tx) error {
-		orderOpen, err := order.OrderOpenMessage()
-		if err != nil {
-			return err
-		}
-
-		var (
-			wTx          iwallet.Tx
-			txid         iwallet.TransactionID
-			confirmation = &pb.OrderConfirmation{}
-		)
-		if orderOpen.Payment.Method == pb.OrderOpen_Payment_CANCELABLE {
-			wTx, txid, err = n.releaseFromCancelableAddress(&order)
-			if err != nil {
-				return err
-			}
-			confirmation.TransactionID = txid.String()
-		}
-
-		confirmAny, err := ptypes.MarshalAny(confirmation)
-		if err != nil {
-			return err
-		}
-
-		resp := &npb.OrderMessage{
-			OrderID:     order.ID.String(),
-			MessageType: npb.OrderMessage_ORDER_CONFIRMATION,
-			Message:     confirmAny,
-		}
-
-		if err := utils.SignOrderMessage(resp, n.ipfsNode.PrivateKey); err != nil {
-			return err
-		}
-
-		payload, err := ptypes.MarshalAny(resp)
-		if err != nil {
-			return err
-		}
-
-		message := newMessageWithID()
-		message.MessageType = npb.Message_ORDER
-		message.Payload = payload
-
-		_, err = n.orderProcessor.ProcessMessage(tx, vendor, resp)
-		if err != nil {
-			if orderOpen.Payment.Method == pb.OrderOpen_Payment_CANCELABLE {
-				wTx.Rollback()
-			}
-			return err
-		}
-
-		if err := n.messenger.ReliablySendMessage(tx, buyer, message, done); err != nil {
-			if orderOpen.Payment.Method == pb.OrderOpen_Payment_CANCELABLE {
-				wTx.Rollback()
-			}
-			return err
-		}
-
-		if orderOpen.Payment.Method == pb.OrderOpen_Payment_CANCELABLE {
-			return wTx.Commit()
-		}
-		return nil
-	})
+	if wTx != nil {
+		return wTx.Commit()
+	}
+	return nil
 }
@@ -316,3 +316,154 @@ func TestOpenBazaarNode_ConfirmOrder(t *testing.T) {
 		t.Errorf("Expected 2 transactions, got %d", len(txs))
 	}
 }
+
+func TestOpenBazaarNode_AutoConfirmOrder(t *testing.T) {
+	network, err := NewMocknet(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer network.TearDown()
+
+	go network.StartWalletNetwork()
+
+	for _, node := range network.Nodes() {
+		go node.orderProcessor.Start()
+	}
+
+	// The mocknet disables auto-confirm so enable it for the vendor.
+	err = network.Nodes()[0].repo.DB().Update(func(tx database.Tx) error {
+		var prefs models.UserPreferences
+		if err := tx.Read().First(&prefs).Error; err != nil {
+			return err
+		}
+		prefs.AutoConfirm = true
+		return tx.Save(&prefs)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orderSub0, err := network.Nodes()[0].eventBus.Subscribe(&events.NewOrder{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderAckSub0, err := network.Nodes()[1].eventBus.Subscribe(&events.MessageACK{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listing := factory.NewPhysicalListing("tshirt")
+
+	done := make(chan struct{})
+	if err := network.Nodes()[0].SaveListing(listing, done); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	index, err := network.Nodes()[0].GetMyListings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	purchase := factory.NewPurchase()
+	purchase.Items[0].ListingHash = index[0].CID
+
+	orderID, paymentAddress, paymentAmount, err := network.Nodes()[1].PurchaseListing(context.Background(), purchase)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-orderSub0.Out():
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	select {
+	case <-orderAckSub0.Out():
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	autoConfirmSub, err := network.Nodes()[0].eventBus.Subscribe(&events.OrderAutoConfirmed{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	confirmSub, err := network.Nodes()[1].eventBus.Subscribe(&events.OrderConfirmation{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txSub1, err := network.Nodes()[1].eventBus.Subscribe(&events.TransactionReceived{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wallet1, err := network.Nodes()[1].multiwallet.WalletForCurrencyCode(iwallet.CtMock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := wallet1.CurrentAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := network.wn.GenerateToAddress(addr, iwallet.NewAmount(10000000000000)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-txSub1.Out():
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	// Funding the order should cause the vendor to confirm it.
+	wTx, err := wallet1.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wallet1.Spend(wTx, paymentAddress, paymentAmount.Amount, iwallet.FlNormal); err != nil {
+		t.Fatal(err)
+	}
+	if err := wTx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-autoConfirmSub.Out():
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	select {
+	case <-confirmSub.Out():
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	var order models.Order
+	err = network.Nodes()[0].repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("id = ?", orderID.String()).Last(&order).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.SerializedOrderConfirmation == nil {
+		t.Error("Node 0 failed to save order confirmation")
+	}
+
+	var order2 models.Order
+	err = network.Nodes()[1].repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("id = ?", orderID.String()).Last(&order2).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order2.SerializedOrderConfirmation == nil {
+		t.Error("Node 1 failed to save order confirmation")
+	}
+}
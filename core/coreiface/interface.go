@@ -31,6 +31,17 @@ type CoreIface interface {
 	ConfirmOrder(orderID models.OrderID, done chan struct{}) error
 	FulfillOrder(orderID models.OrderID, fulfillments []models.Fulfillment, done chan struct{}) error
 	CancelOrder(orderID models.OrderID, done chan struct{}) error
+	OpenDispute(orderID models.OrderID, reason string, evidence []string, done chan struct{}) error
+	CloseDispute(orderID models.OrderID, buyerPercentage, vendorPercentage float32, resolution string, done chan struct{}) error
+	ReleaseFunds(orderID models.OrderID) error
+	ReleaseFundsAfterTimeout(orderID models.OrderID) error
+	FinalizePayment(orderID models.OrderID, done chan struct{}) error
+	CompleteOrder(orderID models.OrderID, ratings []models.Rating, includeIDInRating bool, done chan struct{}) error
+	GetOrder(orderID models.OrderID) (*models.Order, error)
+	GetOrderMessages(orderID models.OrderID) (*models.OrderMessages, error)
+	ReprocessOrderMessages(orderID models.OrderID) error
+	GetSales(query *models.OrderQuery) (*models.OrderList, error)
+	GetPurchases(query *models.OrderQuery) (*models.OrderList, error)
 	FollowNode(peerID peer.ID, done chan<- struct{}) error
 	UnfollowNode(peerID peer.ID, done chan<- struct{}) error
 	GetMyFollowers() (models.Followers, error)
@@ -44,6 +55,14 @@ type CoreIface interface {
 	GetListings(ctx context.Context, peerID peer.ID, useCache bool) (models.ListingIndex, error)
 	GetMyListingBySlug(slug string) (*pb.SignedListing, error)
 	GetMyListingByCID(cid cid.Cid) (*pb.SignedListing, error)
+	ImportListings(r io.Reader, format models.ListingFileFormat, dryRun bool, done chan<- struct{}) (*models.ListingImportResult, error)
+	ExportListings(w io.Writer, format models.ListingFileFormat) error
+	GetListingHistory(slug string) ([]models.ListingVersion, error)
+	DiffListingVersions(slug string, from, to cid.Cid) (*models.ListingDiff, error)
+	RollbackListing(slug string, version cid.Cid, done chan<- struct{}) error
+	GetInventory() ([]models.InventoryItem, error)
+	Search(query *models.SearchQuery) (*models.SearchResults, error)
+	SetInventory(inventory []models.InventoryItem, done chan<- struct{}) error
 	GetListingBySlug(ctx context.Context, peerID peer.ID, slug string, useCache bool) (*pb.SignedListing, error)
 	GetListingByCID(ctx context.Context, cid cid.Cid) (*pb.SignedListing, error)
 	GetImage(ctx context.Context, cid cid.Cid) (io.ReadSeeker, error)
@@ -57,6 +76,17 @@ type CoreIface interface {
 	GetModerators(ctx context.Context) []peer.ID
 	GetModeratorsAsync(ctx context.Context) <-chan peer.ID
 	SetModeratorsOnListings(mods []peer.ID, done chan struct{}) error
+	GetVendors(ctx context.Context) []peer.ID
+	GetVendorsAsync(ctx context.Context) <-chan peer.ID
+	GetDirectory(vendorsOnly bool) ([]models.DirectoryEntry, error)
+	GetCrawledListings(peerID peer.ID) (models.ListingIndex, error)
+	GetNotifications(query *models.NotificationQuery) (*models.NotificationList, error)
+	GetNotificationsAfter(notificationID string) ([]models.NotificationRecord, error)
+	GetUnreadNotificationCounts() (map[string]int, error)
+	MarkNotificationAsRead(notificationID string) error
+	MarkAllNotificationsAsRead(types ...string) error
+	DeleteNotification(notificationID string) error
+	GetWebhookDeliveries(query *models.WebhookDeliveryQuery) (*models.WebhookDeliveryList, error)
 	GetPreferences() (*models.UserPreferences, error)
 	SavePreferences(prefs *models.UserPreferences, done chan struct{}) error
 	Publish(done chan<- struct{})
@@ -73,6 +103,8 @@ type CoreIface interface {
 	EstimateOrderSubtotal(ctx context.Context, purchase *models.Purchase) (*models.CurrencyValue, error)
 	RejectOrder(orderID models.OrderID, reason string, done chan struct{}) error
 	RefundOrder(orderID models.OrderID, done chan struct{}) error
+	RefundOverpayment(orderID models.OrderID, done chan struct{}) error
+	RequestOrderBalance(orderID models.OrderID, done chan<- struct{}) error
 	PingNode(ctx context.Context, peer peer.ID) error
 	SaveTransactionMetadata(metadata *models.TransactionMetadata) error
 	GetTransactionMetadata(txid iwallet.TransactionID) (models.TransactionMetadata, error)
@@ -0,0 +1,368 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/database/ffsqlite"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-ipfs/core/coreapi"
+	"github.com/ipfs/interface-go-ipfs-core/path"
+	"github.com/jinzhu/gorm"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"strings"
+	"time"
+)
+
+const (
+	// vendorTopic is the DHT key at which vendor "providers" are stored.
+	vendorTopic = "openbazaar:vendors"
+
+	// vendorCid is the cid path of the provider block.
+	vendorCid = "QmNia9Htn1saehRPiUeM6oXdg9oer8ZdKUQaSKHtTmEbBQ"
+
+	// maxVendors is the maximum number of vendors to return in a single query.
+	maxVendors = 1000
+
+	// crawlMaxFailures is the number of consecutive failed fetches after
+	// which a store is dropped from the crawler's cache.
+	crawlMaxFailures = 5
+
+	// crawlDiscoveryTimeout is how long we spend querying the DHT for
+	// vendors at the start of each crawl.
+	crawlDiscoveryTimeout = time.Minute
+)
+
+// GetVendors returns a slice of vendors found on the network.
+func (n *OpenBazaarNode) GetVendors(ctx context.Context) []peer.ID {
+	var vendors []peer.ID
+	for vendor := range n.GetVendorsAsync(ctx) {
+		vendors = append(vendors, vendor)
+	}
+	return vendors
+}
+
+// GetVendorsAsync returns a chan over which new vendor IDs are pushed.
+func (n *OpenBazaarNode) GetVendorsAsync(ctx context.Context) <-chan peer.ID {
+	ch := make(chan peer.ID)
+
+	go func() {
+		c, err := cid.Decode(vendorCid)
+		if err != nil {
+			log.Errorf("Error decoding vendor cid: %s", err)
+			close(ch)
+			return
+		}
+		provCh := n.ipfsNode.Routing.FindProvidersAsync(ctx, c, maxVendors)
+
+		for prov := range provCh {
+			ch <- prov.ID
+		}
+		close(ch)
+	}()
+
+	return ch
+}
+
+// GetDirectory returns the stores found by the crawler which have been
+// fetched at least once, most recently crawled first. If vendorsOnly is
+// set only stores with the vendor flag set in their profile are returned.
+func (n *OpenBazaarNode) GetDirectory(vendorsOnly bool) ([]models.DirectoryEntry, error) {
+	var stores []models.CrawledStore
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		db := tx.Read().Where("root_c_id <> ?", "")
+		if vendorsOnly {
+			db = db.Where("vendor = ?", true)
+		}
+		return db.Order("last_crawled desc").Find(&stores).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]models.DirectoryEntry, 0, len(stores))
+	for _, store := range stores {
+		profile, err := store.Profile()
+		if err != nil {
+			log.Errorf("Error loading cached profile for %s: %s", store.PeerID, err)
+			continue
+		}
+		entries = append(entries, models.DirectoryEntry{
+			PeerID:       store.PeerID,
+			Vendor:       store.Vendor,
+			ListingCount: store.ListingCount,
+			LastCrawled:  store.LastCrawled,
+			Profile:      profile,
+		})
+	}
+	return entries, nil
+}
+
+// GetCrawledListings returns the listing index of a store as cached by
+// the crawler.
+func (n *OpenBazaarNode) GetCrawledListings(peerID peer.ID) (models.ListingIndex, error) {
+	var store models.CrawledStore
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("peer_id = ?", peerID.Pretty()).Where("root_c_id <> ?", "").First(&store).Error
+	})
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("%w: store not crawled", coreiface.ErrNotFound)
+	} else if err != nil {
+		return nil, err
+	}
+	return store.ListingIndex()
+}
+
+// setVendorProvider adds or removes this node as a provider of the vendor
+// key in the DHT so that crawlers can find us.
+func (n *OpenBazaarNode) setVendorProvider(ctx context.Context, vendor bool) error {
+	api, err := coreapi.NewCoreAPI(n.ipfsNode)
+	if err != nil {
+		return err
+	}
+	if vendor {
+		// This sets us as a "provider" in the DHT for the vendor key.
+		// Other peers can find us by doing a DHT GetProviders query for
+		// the same key.
+		_, err = api.Block().Put(ctx, strings.NewReader(vendorTopic))
+		return err
+	}
+	c, err := cid.Decode(vendorCid)
+	if err != nil {
+		return err
+	}
+	has, err := n.ipfsNode.Blockstore.Has(c)
+	if err != nil || !has {
+		return err
+	}
+	return api.Block().Rm(ctx, path.New(vendorCid))
+}
+
+// crawlerHandler runs the network crawler on every crawlInterval. It is
+// only started if the crawl budget is greater than zero.
+func (n *OpenBazaarNode) crawlerHandler() {
+	select {
+	case <-n.initialBootstrapChan:
+	case <-n.shutdown:
+		return
+	}
+
+	ticker := time.NewTicker(n.crawlInterval)
+	defer ticker.Stop()
+	for {
+		n.crawlNetwork()
+		select {
+		case <-ticker.C:
+		case <-n.shutdown:
+			return
+		}
+	}
+}
+
+// crawlNetwork runs a single crawl. New stores are discovered from the DHT
+// vendor key and from our own followers and following. Then up to
+// crawlBudget stores whose cache is older than crawlMaxAge are fetched,
+// oldest first. Each fetched store's followers and following are added to
+// the cache so that later crawls walk outwards through the follower graph.
+func (n *OpenBazaarNode) crawlNetwork() {
+	ctx, cancel := context.WithTimeout(context.Background(), crawlDiscoveryTimeout)
+	var discovered []peer.ID
+	for p := range n.GetVendorsAsync(ctx) {
+		discovered = append(discovered, p)
+	}
+	cancel()
+	if err := n.discoverStores(discovered, models.CrawlSourceDHT); err != nil {
+		log.Errorf("Crawler error saving discovered stores: %s", err)
+	}
+
+	following, err := n.GetMyFollowing()
+	if err == nil {
+		if err := n.discoverStores(peerIDs(following), models.CrawlSourceFollowing); err != nil {
+			log.Errorf("Crawler error saving discovered stores: %s", err)
+		}
+	}
+	followers, err := n.GetMyFollowers()
+	if err == nil {
+		if err := n.discoverStores(peerIDs(followers), models.CrawlSourceFollowers); err != nil {
+			log.Errorf("Crawler error saving discovered stores: %s", err)
+		}
+	}
+
+	var stale []models.CrawledStore
+	err = n.repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("last_crawled < ?", time.Now().Add(-n.crawlMaxAge)).
+			Order("last_crawled asc").Limit(n.crawlBudget).Find(&stale).Error
+	})
+	if err != nil {
+		log.Errorf("Crawler error loading stores: %s", err)
+		return
+	}
+
+	for _, store := range stale {
+		select {
+		case <-n.shutdown:
+			return
+		default:
+		}
+		p, err := peer.IDB58Decode(store.PeerID)
+		if err != nil {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), searchFetchTimeout)
+		err = n.crawlStore(ctx, p, store)
+		cancel()
+		if err != nil {
+			log.Debugf("Crawler error fetching %s: %s", p, err)
+		}
+	}
+}
+
+// crawlStore fetches the profile, listing index, followers and following of
+// the store and updates the cache. Nothing is fetched if the store's IPNS
+// record is unchanged since the last crawl. A vendor's listings are also
+// added to the search index and removed again if the store stops selling.
+func (n *OpenBazaarNode) crawlStore(ctx context.Context, p peer.ID, store models.CrawledStore) error {
+	pth, err := n.resolve(ctx, p, false)
+	if err != nil {
+		return n.recordCrawlFailure(store, err)
+	}
+	root := strings.TrimPrefix(pth.String(), "/ipfs/")
+	if root == store.RootCID {
+		store.LastCrawled = time.Now()
+		store.LastError = ""
+		store.Failures = 0
+		return n.repo.DB().Update(func(tx database.Tx) error {
+			return tx.Save(&store)
+		})
+	}
+
+	profileBytes, err := n.cat(ctx, path.Join(pth, ffsqlite.ProfileFile))
+	if err != nil {
+		return n.recordCrawlFailure(store, err)
+	}
+	profile := new(models.Profile)
+	if err := json.Unmarshal(profileBytes, profile); err != nil {
+		return n.recordCrawlFailure(store, err)
+	}
+	if err := validateProfile(profile); err != nil {
+		return n.recordCrawlFailure(store, err)
+	}
+
+	// Stores without listings or followers don't publish the files so
+	// any errors fetching them are ignored.
+	var index models.ListingIndex
+	if profile.Vendor {
+		if indexBytes, err := n.cat(ctx, path.Join(pth, ffsqlite.ListingIndexFile)); err == nil {
+			if err := json.Unmarshal(indexBytes, &index); err != nil {
+				return n.recordCrawlFailure(store, err)
+			}
+		}
+	}
+	var following models.Following
+	if b, err := n.cat(ctx, path.Join(pth, ffsqlite.FollowingFile)); err == nil {
+		json.Unmarshal(b, &following)
+	}
+	var followers models.Followers
+	if b, err := n.cat(ctx, path.Join(pth, ffsqlite.FollowersFile)); err == nil {
+		json.Unmarshal(b, &followers)
+	}
+
+	serializedIndex, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	store.RootCID = root
+	store.Vendor = profile.Vendor
+	store.ListingCount = len(index)
+	store.SerializedProfile = profileBytes
+	store.SerializedListingIndex = serializedIndex
+	store.LastCrawled = time.Now()
+	store.LastError = ""
+	store.Failures = 0
+
+	var stats *models.ProfileStats
+	if store.Vendor {
+		stats = n.validatedRatingStats(ctx, p, pth)
+	}
+	err = n.repo.DB().Update(func(tx database.Tx) error {
+		if store.Vendor {
+			if err := indexPeerListings(tx, p, root, index, stats); err != nil {
+				return err
+			}
+		} else if err := removePeerListings(tx, p); err != nil {
+			return err
+		}
+		return tx.Save(&store)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := n.discoverStores(peerIDs(following), models.CrawlSourceFollowing); err != nil {
+		return err
+	}
+	return n.discoverStores(peerIDs(followers), models.CrawlSourceFollowers)
+}
+
+// recordCrawlFailure saves the error on the store and drops the store from
+// the cache once it has failed crawlMaxFailures times in a row. The error
+// is returned.
+func (n *OpenBazaarNode) recordCrawlFailure(store models.CrawledStore, crawlErr error) error {
+	store.LastCrawled = time.Now()
+	store.LastError = crawlErr.Error()
+	store.Failures++
+	err := n.repo.DB().Update(func(tx database.Tx) error {
+		if store.Failures >= crawlMaxFailures {
+			return tx.Delete("peer_id", store.PeerID, nil, &models.CrawledStore{})
+		}
+		return tx.Save(&store)
+	})
+	if err != nil {
+		return err
+	}
+	return crawlErr
+}
+
+// discoverStores adds any of the peers not already in the crawler's cache.
+// They will be fetched on the next crawl which has budget to spare.
+func (n *OpenBazaarNode) discoverStores(peers []peer.ID, source models.CrawlSource) error {
+	return n.repo.DB().Update(func(tx database.Tx) error {
+		for _, p := range peers {
+			if p == n.Identity() {
+				continue
+			}
+			var store models.CrawledStore
+			err := tx.Read().Where("peer_id = ?", p.Pretty()).First(&store).Error
+			if err == nil {
+				continue
+			} else if !gorm.IsRecordNotFoundError(err) {
+				return err
+			}
+			err = tx.Save(&models.CrawledStore{
+				PeerID:       p.Pretty(),
+				Source:       source,
+				DiscoveredAt: time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// peerIDs decodes a list of base58 peer IDs skipping any which are invalid.
+func peerIDs(ids []string) []peer.ID {
+	peers := make([]peer.ID, 0, len(ids))
+	for _, id := range ids {
+		p, err := peer.IDB58Decode(id)
+		if err != nil {
+			continue
+		}
+		peers = append(peers, p)
+	}
+	return peers
+}
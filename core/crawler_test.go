@@ -0,0 +1,305 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/models/factory"
+	"github.com/jinzhu/gorm"
+	"testing"
+	"time"
+)
+
+func TestOpenBazaarNode_GetVendors(t *testing.T) {
+	network, err := NewMocknet(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer network.TearDown()
+
+	done := make(chan struct{})
+	if err := network.Nodes()[0].SetProfile(&models.Profile{Name: "Ron Swanson", Vendor: true}, done); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	vendors := network.Nodes()[1].GetVendors(context.Background())
+	if len(vendors) != 1 {
+		t.Fatalf("Returned incorrect number of vendors. Expected %d, got %d", 1, len(vendors))
+	}
+	if vendors[0] != network.Nodes()[0].Identity() {
+		t.Errorf("Returned incorrect peer ID. Expected %s, got %s", network.Nodes()[0].Identity().Pretty(), vendors[0].Pretty())
+	}
+}
+
+func TestOpenBazaarNode_Crawler(t *testing.T) {
+	network, err := NewMocknet(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer network.TearDown()
+
+	var (
+		vendor   = network.Nodes()[0]
+		follower = network.Nodes()[1]
+		crawler  = network.Nodes()[2]
+	)
+
+	done := make(chan struct{})
+	if err := vendor.SetProfile(&models.Profile{Name: "Ron Swanson", Vendor: true}, done); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	done = make(chan struct{})
+	if err := vendor.SaveListing(factory.NewPhysicalListing("ron-swanson-shirt"), done); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	done = make(chan struct{})
+	if err := follower.SetProfile(&models.Profile{Name: "Leslie Knope"}, done); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	done = make(chan struct{})
+	if err := crawler.FollowNode(follower.Identity(), done); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	if _, err := crawler.GetCrawledListings(vendor.Identity()); !errors.Is(err, coreiface.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound got %v", err)
+	}
+
+	crawler.crawlBudget = 1
+	crawler.crawlMaxAge = time.Hour
+
+	crawledStores := func() []models.CrawledStore {
+		var stores []models.CrawledStore
+		err := crawler.repo.DB().View(func(tx database.Tx) error {
+			return tx.Read().Find(&stores).Error
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return stores
+	}
+
+	// The first crawl discovers the vendor from the DHT and the follower
+	// from our following list but only has the budget to fetch one.
+	crawler.crawlNetwork()
+	stores := crawledStores()
+	if len(stores) != 2 {
+		t.Fatalf("Expected 2 discovered stores, got %d", len(stores))
+	}
+	fetched := 0
+	for _, store := range stores {
+		if store.RootCID != "" {
+			fetched++
+		}
+	}
+	if fetched != 1 {
+		t.Errorf("Expected 1 fetched store, got %d", fetched)
+	}
+
+	crawler.crawlNetwork()
+	for _, store := range crawledStores() {
+		if store.RootCID == "" || store.LastError != "" {
+			t.Errorf("Store %s was not fetched: %s", store.PeerID, store.LastError)
+		}
+	}
+
+	// Both stores are fresh so the next crawl fetches nothing.
+	before := crawledStores()
+	crawler.crawlNetwork()
+	after := crawledStores()
+	for i := range before {
+		if !before[i].LastCrawled.Equal(after[i].LastCrawled) {
+			t.Errorf("Fresh store %s was fetched again", before[i].PeerID)
+		}
+	}
+
+	directory, err := crawler.GetDirectory(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(directory) != 2 {
+		t.Errorf("Expected 2 stores in the directory, got %d", len(directory))
+	}
+
+	directory, err = crawler.GetDirectory(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(directory) != 1 {
+		t.Fatalf("Expected 1 vendor in the directory, got %d", len(directory))
+	}
+	if directory[0].PeerID != vendor.Identity().Pretty() || directory[0].ListingCount != 1 {
+		t.Errorf("Incorrect directory entry %v", directory[0])
+	}
+	if directory[0].Profile == nil || directory[0].Profile.Name != "Ron Swanson" {
+		t.Errorf("Incorrect cached profile %v", directory[0].Profile)
+	}
+
+	index, err := crawler.GetCrawledListings(vendor.Identity())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index) != 1 || index[0].Slug != "ron-swanson-shirt" {
+		t.Errorf("Incorrect cached listing index %v", index)
+	}
+
+	// The vendor's listings are added to the search index.
+	results, err := crawler.Search(&models.SearchQuery{Query: "swanson"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results.Total != 1 || results.Results[0].PeerID != vendor.Identity().Pretty() {
+		t.Errorf("Expected the crawled listing in the search index, got %v", results.Results)
+	}
+}
+
+func TestOpenBazaarNode_CrawlerRemovesNonVendorListings(t *testing.T) {
+	network, err := NewMocknet(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer network.TearDown()
+
+	var (
+		vendor  = network.Nodes()[0]
+		crawler = network.Nodes()[1]
+	)
+
+	done := make(chan struct{})
+	if err := vendor.SetProfile(&models.Profile{Name: "Ron Swanson", Vendor: true}, done); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	done = make(chan struct{})
+	if err := vendor.SaveListing(factory.NewPhysicalListing("ron-swanson-shirt"), done); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	crawl := func() {
+		var store models.CrawledStore
+		err := crawler.repo.DB().View(func(tx database.Tx) error {
+			return tx.Read().Where("peer_id = ?", vendor.Identity().Pretty()).First(&store).Error
+		})
+		if gorm.IsRecordNotFoundError(err) {
+			store.PeerID = vendor.Identity().Pretty()
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if err := crawler.crawlStore(context.Background(), vendor.Identity(), store); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	searchRows := func() (listings, terms, peers int) {
+		err := crawler.repo.DB().View(func(tx database.Tx) error {
+			if err := tx.Read().Model(&models.SearchListing{}).Where("peer_id = ?", vendor.Identity().Pretty()).Count(&listings).Error; err != nil {
+				return err
+			}
+			if err := tx.Read().Model(&models.SearchTerm{}).Where("peer_id = ?", vendor.Identity().Pretty()).Count(&terms).Error; err != nil {
+				return err
+			}
+			return tx.Read().Model(&models.SearchPeer{}).Where("peer_id = ?", vendor.Identity().Pretty()).Count(&peers).Error
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return listings, terms, peers
+	}
+
+	crawl()
+	listings, terms, peers := searchRows()
+	if listings != 1 || terms == 0 || peers != 1 {
+		t.Fatalf("Expected the vendor's listing in the search index, got %d listings, %d terms and %d peers", listings, terms, peers)
+	}
+
+	done = make(chan struct{})
+	if err := vendor.SetProfile(&models.Profile{Name: "Ron Swanson"}, done); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	crawl()
+	listings, terms, peers = searchRows()
+	if listings != 0 || terms != 0 || peers != 0 {
+		t.Errorf("Expected the store to be removed from the search index, got %d listings, %d terms and %d peers", listings, terms, peers)
+	}
+
+	results, err := crawler.Search(&models.SearchQuery{Query: "swanson"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results.Total != 0 {
+		t.Errorf("Expected no search results, got %d", results.Total)
+	}
+}
+
+func TestOpenBazaarNode_CrawlerDropsFailingStores(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	store := models.CrawledStore{PeerID: "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub"}
+	for i := 0; i < crawlMaxFailures; i++ {
+		if err := node.recordCrawlFailure(store, errors.New("not found")); err == nil {
+			t.Fatal("Expected the crawl error to be returned")
+		}
+		store.Failures++
+	}
+
+	var count int
+	err = node.repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Model(&models.CrawledStore{}).Count(&count).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Errorf("Expected the failing store to be dropped, got %d stores", count)
+	}
+}
@@ -0,0 +1,519 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	iwallet "github.com/cpacia/wallet-interface"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"math"
+)
+
+// OpenDispute is called by either the buyer or the vendor on a moderated order
+// to start a dispute. A DISPUTE_OPEN message containing our copy of the order
+// is sent to both the moderator and the other party. Upon receiving it the other
+// party will respond by sending their copy of the order to the moderator in a
+// DISPUTE_UPDATE message.
+//
+// The done chan will be closed after the message has been sent to both peers.
+func (n *OpenBazaarNode) OpenDispute(orderID models.OrderID, reason string, evidence []string, done chan struct{}) error {
+	var order models.Order
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("id = ?", orderID.String()).First(&order).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if !order.CanDispute(n.Identity()) {
+		return fmt.Errorf("%w: order is not in a state where it can be disputed", coreiface.ErrBadRequest)
+	}
+
+	orderOpen, err := order.OrderOpenMessage()
+	if err != nil {
+		return err
+	}
+
+	moderator, err := order.Moderator()
+	if err != nil {
+		return err
+	}
+
+	var (
+		openedBy      = pb.DisputeOpen_BUYER
+		payoutAddress = orderOpen.RefundAddress
+		otherParty    peer.ID
+	)
+	if order.Role() == models.RoleVendor {
+		openedBy = pb.DisputeOpen_VENDOR
+		otherParty, err = order.Buyer()
+		if err != nil {
+			return err
+		}
+		wallet, err := n.multiwallet.WalletForCurrencyCode(orderOpen.Payment.Coin)
+		if err != nil {
+			return err
+		}
+		addr, err := wallet.CurrentAddress()
+		if err != nil {
+			return err
+		}
+		payoutAddress = addr.String()
+	} else {
+		otherParty, err = order.Vendor()
+		if err != nil {
+			return err
+		}
+	}
+
+	contract, err := order.Contract()
+	if err != nil {
+		return err
+	}
+	ser, err := proto.Marshal(contract)
+	if err != nil {
+		return err
+	}
+
+	disputeOpen := &pb.DisputeOpen{
+		Timestamp:     ptypes.TimestampNow(),
+		OpenedBy:      openedBy,
+		Reason:        reason,
+		Contract:      ser,
+		Evidence:      evidence,
+		PayoutAddress: payoutAddress,
+	}
+
+	disputeAny, err := ptypes.MarshalAny(disputeOpen)
+	if err != nil {
+		return err
+	}
+
+	resp := &npb.OrderMessage{
+		OrderID:     order.ID.String(),
+		MessageType: npb.OrderMessage_DISPUTE_OPEN,
+		Message:     disputeAny,
+	}
+
+	if err := utils.SignOrderMessage(resp, n.ipfsNode.PrivateKey); err != nil {
+		return err
+	}
+
+	return n.sendDisputeMessage(resp, done, moderator, otherParty)
+}
+
+// CloseDispute is called by the moderator to resolve a dispute. The funds in escrow
+// are split between the buyer and vendor according to the provided percentages after
+// the moderator fee is deducted. The moderator signs the payout transaction and sends
+// it to both parties in a DISPUTE_CLOSE message. Either party who was awarded funds
+// can then co-sign and broadcast the transaction by calling ReleaseFunds.
+//
+// The done chan will be closed after the message has been sent to both peers.
+func (n *OpenBazaarNode) CloseDispute(orderID models.OrderID, buyerPercentage, vendorPercentage float32, resolution string, done chan struct{}) error {
+	var order models.Order
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("id = ?", orderID.String()).First(&order).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if !order.CanCloseDispute(n.Identity()) {
+		return fmt.Errorf("%w: order is not in a state where the dispute can be closed", coreiface.ErrBadRequest)
+	}
+
+	if buyerPercentage < 0 || vendorPercentage < 0 || math.Abs(float64(buyerPercentage+vendorPercentage)-100) > 0.0001 {
+		return fmt.Errorf("%w: buyer and vendor percentages must add up to 100", coreiface.ErrBadRequest)
+	}
+
+	buyer, err := order.Buyer()
+	if err != nil {
+		return err
+	}
+	vendor, err := order.Vendor()
+	if err != nil {
+		return err
+	}
+
+	payout, err := n.buildDisputePayout(&order, buyerPercentage, vendorPercentage)
+	if err != nil {
+		return err
+	}
+
+	disputeClose := &pb.DisputeClose{
+		Timestamp:        ptypes.TimestampNow(),
+		Resolution:       resolution,
+		BuyerPercentage:  buyerPercentage,
+		VendorPercentage: vendorPercentage,
+		ReleaseInfo:      payout,
+	}
+
+	disputeAny, err := ptypes.MarshalAny(disputeClose)
+	if err != nil {
+		return err
+	}
+
+	resp := &npb.OrderMessage{
+		OrderID:     order.ID.String(),
+		MessageType: npb.OrderMessage_DISPUTE_CLOSE,
+		Message:     disputeAny,
+	}
+
+	if err := utils.SignOrderMessage(resp, n.ipfsNode.PrivateKey); err != nil {
+		return err
+	}
+
+	return n.sendDisputeMessage(resp, done, buyer, vendor)
+}
+
+// ReleaseFunds is called by the buyer or vendor after the moderator has closed the
+// dispute. It adds our signatures to the moderator's payout transaction and broadcasts
+// it, releasing the funds from escrow.
+func (n *OpenBazaarNode) ReleaseFunds(orderID models.OrderID) error {
+	var order models.Order
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("id = ?", orderID.String()).First(&order).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if !order.CanReleaseFunds(n.Identity()) {
+		return fmt.Errorf("%w: order is not in a state where the funds can be released", coreiface.ErrBadRequest)
+	}
+
+	orderOpen, err := order.OrderOpenMessage()
+	if err != nil {
+		return err
+	}
+
+	disputeClose, err := order.DisputeClosedMessage()
+	if err != nil {
+		return err
+	}
+
+	// Never sign a payout that doesn't match the moderator's decision.
+	if err := orders.ValidateDisputePayout(&order, disputeClose); err != nil {
+		return fmt.Errorf("%w: %s", coreiface.ErrBadRequest, err)
+	}
+
+	wallet, err := n.multiwallet.WalletForCurrencyCode(orderOpen.Payment.Coin)
+	if err != nil {
+		return err
+	}
+
+	escrowWallet, ok := wallet.(iwallet.Escrow)
+	if !ok {
+		return errors.New("wallet does not support escrow")
+	}
+
+	var txn iwallet.Transaction
+	for _, id := range disputeClose.ReleaseInfo.FromIDs {
+		txn.From = append(txn.From, iwallet.SpendInfo{ID: id})
+	}
+	for _, out := range disputeClose.ReleaseInfo.Outputs {
+		txn.To = append(txn.To, iwallet.SpendInfo{
+			Address: iwallet.NewAddress(out.Address, iwallet.CoinType(orderOpen.Payment.Coin)),
+			Amount:  iwallet.NewAmount(out.Amount),
+		})
+	}
+
+	var moderatorSigs []iwallet.EscrowSignature
+	for _, sig := range disputeClose.ReleaseInfo.EscrowSignatures {
+		moderatorSigs = append(moderatorSigs, iwallet.EscrowSignature{
+			Index:     int(sig.Index),
+			Signature: sig.Signature,
+		})
+	}
+
+	script, err := hex.DecodeString(orderOpen.Payment.Script)
+	if err != nil {
+		return err
+	}
+
+	chainCode, err := hex.DecodeString(orderOpen.Payment.Chaincode)
+	if err != nil {
+		return err
+	}
+
+	escrowKey, err := utils.GenerateEscrowPrivateKey(n.escrowMasterKey, chainCode)
+	if err != nil {
+		return err
+	}
+
+	ourSigs, err := escrowWallet.SignMultisigTransaction(txn, *escrowKey, script)
+	if err != nil {
+		return err
+	}
+
+	wTx, err := wallet.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := escrowWallet.BuildAndSend(wTx, txn, [][]iwallet.EscrowSignature{ourSigs, moderatorSigs}, script); err != nil {
+		wTx.Rollback()
+		return err
+	}
+	return wTx.Commit()
+}
+
+// ReleaseFundsAfterTimeout is used by the vendor to release the escrowed funds
+// to themselves after the escrow timeout has passed. This uses the timelocked
+// branch of the escrow script and does not require signatures from the buyer
+// or moderator.
+func (n *OpenBazaarNode) ReleaseFundsAfterTimeout(orderID models.OrderID) error {
+	var order models.Order
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("id = ?", orderID.String()).First(&order).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if !order.CanReleaseFundsAfterTimeout(n.Identity()) {
+		return fmt.Errorf("%w: order is not in a state where the funds can be released", coreiface.ErrBadRequest)
+	}
+
+	orderOpen, err := order.OrderOpenMessage()
+	if err != nil {
+		return err
+	}
+
+	wallet, err := n.multiwallet.WalletForCurrencyCode(orderOpen.Payment.Coin)
+	if err != nil {
+		return err
+	}
+
+	escrowWallet, ok := wallet.(iwallet.EscrowWithTimeout)
+	if !ok {
+		return errors.New("wallet does not support escrow timeouts")
+	}
+
+	txs, err := order.GetTransactions()
+	if err != nil {
+		return err
+	}
+
+	inputs, totalIn := unspentEscrowOutputs(txs, orderOpen.Payment.Address)
+	if len(inputs) == 0 {
+		return errors.New("no funds in escrow to release")
+	}
+	txn := iwallet.Transaction{From: inputs}
+
+	amount := totalIn.Sub(iwallet.NewAmount(orderOpen.Payment.EscrowReleaseFee))
+	if amount.Cmp(iwallet.NewAmount(0)) <= 0 {
+		return errors.New("escrowed funds do not cover the transaction fee")
+	}
+
+	address, err := wallet.CurrentAddress()
+	if err != nil {
+		return err
+	}
+	txn.To = append(txn.To, iwallet.SpendInfo{
+		Address: address,
+		Amount:  amount,
+	})
+
+	script, err := hex.DecodeString(orderOpen.Payment.Script)
+	if err != nil {
+		return err
+	}
+
+	chainCode, err := hex.DecodeString(orderOpen.Payment.Chaincode)
+	if err != nil {
+		return err
+	}
+
+	escrowKey, err := utils.GenerateEscrowPrivateKey(n.escrowMasterKey, chainCode)
+	if err != nil {
+		return err
+	}
+
+	wTx, err := wallet.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := escrowWallet.ReleaseFundsAfterTimeout(wTx, txn, *escrowKey, script); err != nil {
+		wTx.Rollback()
+		return err
+	}
+	return wTx.Commit()
+}
+
+// sendDisputeMessage processes the dispute message locally then sends it to
+// the two other parties to the order.
+func (n *OpenBazaarNode) sendDisputeMessage(orderMessage *npb.OrderMessage, done chan struct{}, to1, to2 peer.ID) error {
+	payload, err := ptypes.MarshalAny(orderMessage)
+	if err != nil {
+		return err
+	}
+
+	message1 := newMessageWithID()
+	message1.MessageType = npb.Message_ORDER
+	message1.Payload = payload
+
+	message2 := newMessageWithID()
+	message2.MessageType = npb.Message_ORDER
+	message2.Payload = payload
+
+	var (
+		done1 = make(chan struct{})
+		done2 = make(chan struct{})
+	)
+	err = n.repo.DB().Update(func(tx database.Tx) error {
+		if _, err := n.orderProcessor.ProcessMessage(tx, n.Identity(), orderMessage); err != nil {
+			return err
+		}
+
+		if err := n.messenger.ReliablySendMessage(tx, to1, message1, done1); err != nil {
+			return err
+		}
+
+		return n.messenger.ReliablySendMessage(tx, to2, message2, done2)
+	})
+	if err != nil {
+		return err
+	}
+
+	closeDoneAfter(done, done1, done2)
+	return nil
+}
+
+// buildDisputePayout builds and signs the transaction paying out the escrowed funds.
+// The moderator's fee is taken off the top and the remainder is split between the
+// buyer and vendor.
+func (n *OpenBazaarNode) buildDisputePayout(order *models.Order, buyerPercentage, vendorPercentage float32) (*pb.EscrowPayout, error) {
+	orderOpen, err := order.OrderOpenMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := n.multiwallet.WalletForCurrencyCode(orderOpen.Payment.Coin)
+	if err != nil {
+		return nil, err
+	}
+
+	escrowWallet, ok := wallet.(iwallet.Escrow)
+	if !ok {
+		return nil, errors.New("wallet does not support escrow")
+	}
+
+	txs, err := order.GetTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, totalIn := unspentEscrowOutputs(txs, orderOpen.Payment.Address)
+	if len(inputs) == 0 {
+		return nil, errors.New("no funds in escrow to pay out")
+	}
+	txn := iwallet.Transaction{From: inputs}
+
+	buyerAmount, vendorAmount, moderatorFee, err := orders.DisputePayoutAmounts(orderOpen, totalIn, buyerPercentage)
+	if err != nil {
+		return nil, err
+	}
+
+	if buyerAmount.Cmp(iwallet.NewAmount(0)) > 0 {
+		buyerAddress, err := orders.DisputePayoutAddress(order, pb.DisputeOpen_BUYER)
+		if err != nil {
+			return nil, err
+		}
+		txn.To = append(txn.To, iwallet.SpendInfo{
+			Address: iwallet.NewAddress(buyerAddress, iwallet.CoinType(orderOpen.Payment.Coin)),
+			Amount:  buyerAmount,
+		})
+	}
+	if vendorAmount.Cmp(iwallet.NewAmount(0)) > 0 {
+		vendorAddress, err := orders.DisputePayoutAddress(order, pb.DisputeOpen_VENDOR)
+		if err != nil {
+			return nil, err
+		}
+		txn.To = append(txn.To, iwallet.SpendInfo{
+			Address: iwallet.NewAddress(vendorAddress, iwallet.CoinType(orderOpen.Payment.Coin)),
+			Amount:  vendorAmount,
+		})
+	}
+	if moderatorFee.Cmp(iwallet.NewAmount(0)) > 0 {
+		moderatorAddress, err := wallet.CurrentAddress()
+		if err != nil {
+			return nil, err
+		}
+		txn.To = append(txn.To, iwallet.SpendInfo{
+			Address: moderatorAddress,
+			Amount:  moderatorFee,
+		})
+	}
+
+	script, err := hex.DecodeString(orderOpen.Payment.Script)
+	if err != nil {
+		return nil, err
+	}
+
+	chainCode, err := hex.DecodeString(orderOpen.Payment.Chaincode)
+	if err != nil {
+		return nil, err
+	}
+
+	moderatorKey, err := utils.GenerateEscrowPrivateKey(n.escrowMasterKey, chainCode)
+	if err != nil {
+		return nil, err
+	}
+
+	sigs, err := escrowWallet.SignMultisigTransaction(txn, *moderatorKey, script)
+	if err != nil {
+		return nil, err
+	}
+
+	payout := new(pb.EscrowPayout)
+	for _, from := range txn.From {
+		payout.FromIDs = append(payout.FromIDs, from.ID)
+	}
+	for _, to := range txn.To {
+		payout.Outputs = append(payout.Outputs, &pb.EscrowPayout_Output{
+			Address: to.Address.String(),
+			Amount:  to.Amount.String(),
+		})
+	}
+	for _, sig := range sigs {
+		payout.EscrowSignatures = append(payout.EscrowSignatures, &pb.Signature{
+			Signature: sig.Signature,
+			Index:     uint32(sig.Index),
+		})
+	}
+	return payout, nil
+}
+
+// unspentEscrowOutputs returns the outputs paying the escrow address which have
+// not been spent by any of the order's transactions along with their total.
+func unspentEscrowOutputs(txs []iwallet.Transaction, escrowAddress string) ([]iwallet.SpendInfo, iwallet.Amount) {
+	spent := make(map[string]bool)
+	for _, tx := range txs {
+		for _, from := range tx.From {
+			spent[hex.EncodeToString(from.ID)] = true
+		}
+	}
+
+	var (
+		outputs []iwallet.SpendInfo
+		total   = iwallet.NewAmount(0)
+	)
+	for _, tx := range txs {
+		for _, to := range tx.To {
+			if !spent[hex.EncodeToString(to.ID)] && to.Address.String() == escrowAddress {
+				outputs = append(outputs, to)
+				total = total.Add(to.Amount)
+			}
+		}
+	}
+	return outputs, total
+}
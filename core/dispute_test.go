@@ -0,0 +1,455 @@
+package core
+
+import (
+	"context"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/models/factory"
+	"github.com/cpacia/openbazaar3.0/orders"
+	iwallet "github.com/cpacia/wallet-interface"
+	"testing"
+	"time"
+)
+
+func TestOpenBazaarNode_Dispute(t *testing.T) {
+	network, err := NewMocknet(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer network.TearDown()
+
+	go network.StartWalletNetwork()
+
+	for _, node := range network.Nodes() {
+		go node.orderProcessor.Start()
+	}
+
+	listing := factory.NewPhysicalListing("tshirt")
+
+	done := make(chan struct{})
+	if err := network.Nodes()[0].SaveListing(listing, done); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	index, err := network.Nodes()[0].GetMyListings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done2 := make(chan struct{})
+	if err := network.Nodes()[2].SetProfile(&models.Profile{Name: "Ron Paul"}, done2); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done2:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	modInfo := &models.ModeratorInfo{
+		AcceptedCurrencies: []string{"MCK"},
+		Fee: models.ModeratorFee{
+			Percentage: 10,
+			FeeType:    models.PercentageFee,
+		},
+	}
+	done3 := make(chan struct{})
+	if err := network.Nodes()[2].SetSelfAsModerator(context.Background(), modInfo, done3); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done3:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	purchase := factory.NewPurchase()
+	purchase.Items[0].ListingHash = index[0].CID
+	purchase.Moderator = network.Nodes()[2].Identity().Pretty()
+
+	orderSub0, err := network.Nodes()[0].eventBus.Subscribe(&events.NewOrder{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orderID, paymentAddress, paymentAmount, err := network.Nodes()[1].PurchaseListing(context.Background(), purchase)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-orderSub0.Out():
+		orderSub0.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	wallet1, err := network.Nodes()[1].multiwallet.WalletForCurrencyCode(iwallet.CtMock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr1, err := wallet1.CurrentAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txSub1, err := network.Nodes()[1].eventBus.Subscribe(&events.TransactionReceived{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := network.WalletNetwork().GenerateToAddress(addr1, iwallet.NewAmount(100000000000)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-txSub1.Out():
+		txSub1.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	fundingSub0, err := network.Nodes()[0].eventBus.Subscribe(&events.OrderFunded{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fundingSub1, err := network.Nodes()[1].eventBus.Subscribe(&events.OrderPaymentReceived{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wTx, err := wallet1.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wallet1.Spend(wTx, paymentAddress, paymentAmount.Amount, iwallet.FlNormal); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := wTx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-fundingSub0.Out():
+		fundingSub0.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	select {
+	case <-fundingSub1.Out():
+		fundingSub1.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	// The vendor cannot dispute before fulfilling.
+	if err := network.Nodes()[0].OpenDispute(orderID, "buyer is unresponsive", nil, nil); err == nil {
+		t.Error("Expected vendor dispute to fail")
+	}
+
+	disputeOpenSub0, err := network.Nodes()[0].eventBus.Subscribe(&events.DisputeOpen{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	disputeOpenSub2, err := network.Nodes()[2].eventBus.Subscribe(&events.DisputeOpen{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	disputeUpdateSub2, err := network.Nodes()[2].eventBus.Subscribe(&events.DisputeUpdate{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done4 := make(chan struct{})
+	if err := network.Nodes()[1].OpenDispute(orderID, "item never arrived", nil, done4); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done4:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	select {
+	case <-disputeOpenSub0.Out():
+		disputeOpenSub0.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	select {
+	case event := <-disputeOpenSub2.Out():
+		disputeOpenSub2.Close()
+		disputeEvent := event.(*events.DisputeOpen)
+		if disputeEvent.DisputerID != network.Nodes()[1].Identity().Pretty() {
+			t.Errorf("Incorrect disputer ID. Expected %s, got %s", network.Nodes()[1].Identity().Pretty(), disputeEvent.DisputerID)
+		}
+		if disputeEvent.DisputeeID != network.Nodes()[0].Identity().Pretty() {
+			t.Errorf("Incorrect disputee ID. Expected %s, got %s", network.Nodes()[0].Identity().Pretty(), disputeEvent.DisputeeID)
+		}
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	select {
+	case <-disputeUpdateSub2.Out():
+		disputeUpdateSub2.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	var order models.Order
+	err = network.Nodes()[2].repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("id = ?", orderID.String()).First(&order).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if order.Role() != models.RoleModerator {
+		t.Errorf("Expected moderator role, got %s", order.Role())
+	}
+	if order.SerializedDisputeOpen == nil {
+		t.Error("Moderator failed to save dispute open")
+	}
+	if order.SerializedDisputeUpdate == nil {
+		t.Error("Moderator failed to save dispute update")
+	}
+	funded, err := order.IsFunded()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !funded {
+		t.Error("Moderator failed to load funding transaction")
+	}
+	if !order.CanCloseDispute(network.Nodes()[2].Identity()) {
+		t.Error("Moderator should be able to close dispute")
+	}
+
+	var order1 models.Order
+	err = network.Nodes()[1].repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("id = ?", orderID.String()).First(&order1).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order1.CanDispute(network.Nodes()[1].Identity()) {
+		t.Error("Buyer should not be able to dispute twice")
+	}
+
+	// Only the moderator can close the dispute.
+	if err := network.Nodes()[1].CloseDispute(orderID, 100, 0, "", nil); err == nil {
+		t.Error("Expected buyer close dispute to fail")
+	}
+
+	if err := network.Nodes()[2].CloseDispute(orderID, 50, 60, "", nil); err == nil {
+		t.Error("Expected close dispute with invalid percentages to fail")
+	}
+
+	disputeCloseSub0, err := network.Nodes()[0].eventBus.Subscribe(&events.DisputeClose{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	disputeCloseSub1, err := network.Nodes()[1].eventBus.Subscribe(&events.DisputeClose{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done5 := make(chan struct{})
+	if err := network.Nodes()[2].CloseDispute(orderID, 100, 0, "vendor never shipped", done5); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done5:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	select {
+	case <-disputeCloseSub0.Out():
+		disputeCloseSub0.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	select {
+	case <-disputeCloseSub1.Out():
+		disputeCloseSub1.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	err = network.Nodes()[1].repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("id = ?", orderID.String()).First(&order1).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orderOpen, err := order1.OrderOpenMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	disputeClose, err := order1.DisputeClosedMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The moderator takes a 10% fee from the escrowed funds less the transaction fee.
+	var (
+		available       = paymentAmount.Amount.Sub(iwallet.NewAmount(orderOpen.Payment.EscrowReleaseFee))
+		expectedModFee  = orders.PercentageOf(available, 10)
+		expectedPayment = available.Sub(expectedModFee)
+	)
+	if len(disputeClose.ReleaseInfo.Outputs) != 2 {
+		t.Fatalf("Expected 2 payout outputs, got %d", len(disputeClose.ReleaseInfo.Outputs))
+	}
+	if disputeClose.ReleaseInfo.Outputs[0].Address != orderOpen.RefundAddress {
+		t.Errorf("Incorrect buyer payout address. Expected %s, got %s", orderOpen.RefundAddress, disputeClose.ReleaseInfo.Outputs[0].Address)
+	}
+	if disputeClose.ReleaseInfo.Outputs[0].Amount != expectedPayment.String() {
+		t.Errorf("Incorrect buyer payout amount. Expected %s, got %s", expectedPayment, disputeClose.ReleaseInfo.Outputs[0].Amount)
+	}
+	if disputeClose.ReleaseInfo.Outputs[1].Amount != expectedModFee.String() {
+		t.Errorf("Incorrect moderator fee. Expected %s, got %s", expectedModFee, disputeClose.ReleaseInfo.Outputs[1].Amount)
+	}
+
+	// The vendor was awarded nothing so cannot release the funds.
+	if err := network.Nodes()[0].ReleaseFunds(orderID); err == nil {
+		t.Error("Expected vendor release funds to fail")
+	}
+
+	spendSub1, err := network.Nodes()[1].eventBus.Subscribe(&events.SpendFromPaymentAddress{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := network.Nodes()[1].ReleaseFunds(orderID); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-spendSub1.Out():
+		spendSub1.Close()
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	err = network.Nodes()[1].repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("id = ?", orderID.String()).First(&order1).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order1.CanReleaseFunds(network.Nodes()[1].Identity()) {
+		t.Error("Buyer should not be able to release funds twice")
+	}
+}
+
+func Test_unspentEscrowOutputs(t *testing.T) {
+	escrowAddress := iwallet.NewAddress("escrow", iwallet.CtMock)
+	funding := iwallet.Transaction{
+		ID: "funding",
+		To: []iwallet.SpendInfo{
+			{
+				ID:      []byte{0x01},
+				Address: escrowAddress,
+				Amount:  iwallet.NewAmount(10000),
+			},
+			{
+				ID:      []byte{0x02},
+				Address: iwallet.NewAddress("change", iwallet.CtMock),
+				Amount:  iwallet.NewAmount(500),
+			},
+		},
+	}
+	secondFunding := iwallet.Transaction{
+		ID: "secondFunding",
+		To: []iwallet.SpendInfo{
+			{
+				ID:      []byte{0x03},
+				Address: escrowAddress,
+				Amount:  iwallet.NewAmount(2000),
+			},
+		},
+	}
+	refund := iwallet.Transaction{
+		ID: "refund",
+		From: []iwallet.SpendInfo{
+			{
+				ID:      []byte{0x03},
+				Address: escrowAddress,
+				Amount:  iwallet.NewAmount(2000),
+			},
+		},
+		To: []iwallet.SpendInfo{
+			{
+				ID:      []byte{0x04},
+				Address: iwallet.NewAddress("buyer", iwallet.CtMock),
+				Amount:  iwallet.NewAmount(1900),
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		txs             []iwallet.Transaction
+		expectedOutputs int
+		expectedTotal   iwallet.Amount
+	}{
+		{
+			name:            "no transactions",
+			expectedOutputs: 0,
+			expectedTotal:   iwallet.NewAmount(0),
+		},
+		{
+			name:            "single funding",
+			txs:             []iwallet.Transaction{funding},
+			expectedOutputs: 1,
+			expectedTotal:   iwallet.NewAmount(10000),
+		},
+		{
+			name:            "multiple fundings",
+			txs:             []iwallet.Transaction{funding, secondFunding},
+			expectedOutputs: 2,
+			expectedTotal:   iwallet.NewAmount(12000),
+		},
+		{
+			name:            "refunded output",
+			txs:             []iwallet.Transaction{funding, secondFunding, refund},
+			expectedOutputs: 1,
+			expectedTotal:   iwallet.NewAmount(10000),
+		},
+		{
+			name:            "refund recorded before funding",
+			txs:             []iwallet.Transaction{refund, secondFunding},
+			expectedOutputs: 0,
+			expectedTotal:   iwallet.NewAmount(0),
+		},
+	}
+
+	for _, test := range tests {
+		outputs, total := unspentEscrowOutputs(test.txs, escrowAddress.String())
+		if len(outputs) != test.expectedOutputs {
+			t.Errorf("%s: expected %d outputs, got %d", test.name, test.expectedOutputs, len(outputs))
+		}
+		if total.Cmp(test.expectedTotal) != 0 {
+			t.Errorf("%s: expected total %s, got %s", test.name, test.expectedTotal, total)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	iwallet "github.com/cpacia/wallet-interface"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// FinalizePayment sends a PAYMENT_FINALIZED message to the vendor releasing the
+// payment before the order is completed. Only a buyer can call this method.
+//
+// If the payment method is MODERATED the message will contain the buyer's signatures
+// releasing the escrowed funds to the address the vendor provided in their fulfillment.
+// If the payment method is CANCELABLE the buyer is giving up their ability to cancel
+// and the vendor will move the funds into their wallet when the message is received.
+func (n *OpenBazaarNode) FinalizePayment(orderID models.OrderID, done chan struct{}) error {
+	var order models.Order
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("id = ?", orderID.String()).First(&order).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if !order.CanFinalizePayment(n.Identity()) {
+		return fmt.Errorf("%w: order is not in a state where the payment can be finalized", coreiface.ErrBadRequest)
+	}
+
+	orderOpen, err := order.OrderOpenMessage()
+	if err != nil {
+		return err
+	}
+
+	buyer, err := order.Buyer()
+	if err != nil {
+		return err
+	}
+	vendor, err := order.Vendor()
+	if err != nil {
+		return err
+	}
+
+	finalized := new(pb.PaymentFinalized)
+	if orderOpen.Payment.Method == pb.OrderOpen_Payment_MODERATED {
+		fulfillments, err := order.OrderFulfillmentMessages()
+		if err != nil {
+			return err
+		}
+
+		wallet, err := n.multiwallet.WalletForCurrencyCode(orderOpen.Payment.Coin)
+		if err != nil {
+			return err
+		}
+		escrowWallet, ok := wallet.(iwallet.Escrow)
+		if !ok {
+			return errors.New("wallet does not support escrow")
+		}
+		fee, err := escrowWallet.EstimateEscrowFee(2, iwallet.FlNormal)
+		if err != nil {
+			return err
+		}
+
+		to := iwallet.NewAddress(fulfillments[0].ReleaseInfo.ToAddress, iwallet.CoinType(orderOpen.Payment.Coin))
+		finalized.ReleaseInfo, err = n.buildEscrowRelease(&order, wallet, to, fee)
+		if err != nil {
+			return err
+		}
+	}
+
+	finalizedAny, err := ptypes.MarshalAny(finalized)
+	if err != nil {
+		return err
+	}
+
+	resp := &npb.OrderMessage{
+		OrderID:     order.ID.String(),
+		MessageType: npb.OrderMessage_PAYMENT_FINALIZED,
+		Message:     finalizedAny,
+	}
+
+	if err := utils.SignOrderMessage(resp, n.ipfsNode.PrivateKey); err != nil {
+		return err
+	}
+
+	payload, err := ptypes.MarshalAny(resp)
+	if err != nil {
+		return err
+	}
+
+	message := newMessageWithID()
+	message.MessageType = npb.Message_ORDER
+	message.Payload = payload
+
+	return n.repo.DB().Update(func(tx database.Tx) error {
+		if _, err := n.orderProcessor.ProcessMessage(tx, buyer, resp); err != nil {
+			return err
+		}
+
+		return n.messenger.ReliablySendMessage(tx, vendor, message, done)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/cpacia/openbazaar3.0/orders/utils"
 	iwallet "github.com/cpacia/wallet-interface"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/jinzhu/gorm"
 )
 
 // FulfillOrder sends an order fulfillment to the remote peer and updates the order state.
@@ -19,7 +20,9 @@ func (n *OpenBazaarNode) FulfillOrder(orderID models.OrderID, fulfillments []mod
 	err := n.repo.DB().View(func(tx database.Tx) error {
 		return tx.Read().Where("id = ?", orderID.String()).Find(&order).Error
 	})
-	if err != nil {
+	if gorm.IsRecordNotFoundError(err) {
+		return fmt.Errorf("%w: order not found", coreiface.ErrNotFound)
+	} else if err != nil {
 		return err
 	}
 
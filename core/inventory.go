@@ -0,0 +1,222 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/orders"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"strconv"
+)
+
+// GetInventory returns the stock levels for each SKU of our listings.
+func (n *OpenBazaarNode) GetInventory() ([]models.InventoryItem, error) {
+	var inventory []models.InventoryItem
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Order("slug, sku").Find(&inventory).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inventory, nil
+}
+
+// SetInventory sets the stock level for each of the provided listing SKUs.
+// The quantity in the listing is updated to match and the listings are
+// republished. A negative quantity means the stock is not tracked.
+func (n *OpenBazaarNode) SetInventory(inventory []models.InventoryItem, done chan<- struct{}) error {
+	err := n.repo.DB().Update(func(tx database.Tx) error {
+		updated := make(map[string]*pb.Listing)
+		for _, inv := range inventory {
+			listing, ok := updated[inv.Slug]
+			if !ok {
+				sl, err := tx.GetListing(inv.Slug)
+				if err != nil {
+					return fmt.Errorf("%w: listing %s not found", coreiface.ErrNotFound, inv.Slug)
+				}
+				listing = sl.Listing
+				updated[inv.Slug] = listing
+			}
+			if listing.Metadata.ContractType == pb.Listing_Metadata_CRYPTOCURRENCY {
+				return fmt.Errorf("%w: inventory is not tracked for cryptocurrency listings", coreiface.ErrBadRequest)
+			}
+
+			var sku *pb.Listing_Item_Sku
+			for _, s := range listing.Item.Skus {
+				if models.SKUKey(s) == inv.SKU {
+					sku = s
+					break
+				}
+			}
+			if sku == nil {
+				if inv.SKU != "" || len(listing.Item.Options) > 0 {
+					return fmt.Errorf("%w: sku %s not found in listing %s", coreiface.ErrBadRequest, inv.SKU, inv.Slug)
+				}
+				sku = &pb.Listing_Item_Sku{Surcharge: "0"}
+				listing.Item.Skus = append(listing.Item.Skus, sku)
+			}
+			sku.Quantity = strconv.FormatInt(inv.Quantity, 10)
+
+			item := models.InventoryItem{Slug: inv.Slug, SKU: inv.SKU}
+			if err := tx.Read().Where("slug = ? AND sku = ?", inv.Slug, inv.SKU).FirstOrInit(&item).Error; err != nil {
+				return err
+			}
+			item.Quantity = inv.Quantity
+			item.ListingQuantity = inv.Quantity
+			if err := tx.Save(&item); err != nil {
+				return err
+			}
+		}
+
+		for _, listing := range updated {
+			if err := n.saveAndIndexListing(tx, listing); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		maybeCloseDone(done)
+		return err
+	}
+	n.Publish(done)
+	return nil
+}
+
+// syncInventory updates our inventory with the SKU quantities in the listing.
+// The stock for a SKU is only overwritten if the quantity in the listing has
+// changed since it was last synced so that saving the listing again does not
+// undo the sales made since then.
+func syncInventory(dbtx database.Tx, listing *pb.Listing) error {
+	var existing []models.InventoryItem
+	if err := dbtx.Read().Where("slug = ?", listing.Slug).Find(&existing).Error; err != nil {
+		return err
+	}
+	current := make(map[string]models.InventoryItem)
+	for _, item := range existing {
+		current[item.SKU] = item
+	}
+
+	inListing := make(map[string]bool)
+	if listing.Metadata.ContractType != pb.Listing_Metadata_CRYPTOCURRENCY {
+		for _, sku := range listing.Item.Skus {
+			key := models.SKUKey(sku)
+			quantity := models.SKUQuantity(sku)
+			inListing[key] = true
+
+			item, ok := current[key]
+			if ok && item.ListingQuantity == quantity {
+				continue
+			}
+			if !ok {
+				item = models.InventoryItem{Slug: listing.Slug, SKU: key}
+			}
+			item.Quantity = quantity
+			item.ListingQuantity = quantity
+			if err := dbtx.Save(&item); err != nil {
+				return err
+			}
+		}
+	}
+
+	for key := range current {
+		if !inListing[key] {
+			if err := dbtx.Delete("slug", listing.Slug, map[string]interface{}{"sku = ?": key}, &models.InventoryItem{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// keepInventory sets the SKU quantities in the listing to our current stock
+// so that restoring an older version of the listing does not reset the stock
+// to the quantities it had at the time.
+func keepInventory(dbtx database.Tx, listing *pb.Listing) error {
+	if listing.Metadata.ContractType == pb.Listing_Metadata_CRYPTOCURRENCY {
+		return nil
+	}
+	var existing []models.InventoryItem
+	if err := dbtx.Read().Where("slug = ?", listing.Slug).Find(&existing).Error; err != nil {
+		return err
+	}
+	current := make(map[string]models.InventoryItem)
+	for _, item := range existing {
+		current[item.SKU] = item
+	}
+	for _, sku := range listing.Item.Skus {
+		if item, ok := current[models.SKUKey(sku)]; ok {
+			sku.Quantity = strconv.FormatInt(item.Quantity, 10)
+		}
+	}
+	return nil
+}
+
+// checkListingInventory returns an error if the order purchases more of an
+// item than the vendor has in stock. The published quantities may be out of
+// date so the vendor is asked for their current stock, falling back to the
+// published quantities if they do not respond. The vendor also checks the
+// order against their own inventory when it arrives.
+func (n *OpenBazaarNode) checkListingInventory(ctx context.Context, orderOpen *pb.OrderOpen) error {
+	needed, err := orders.OrderInventory(orderOpen)
+	if err != nil {
+		return fmt.Errorf("%w: %s", coreiface.ErrBadRequest, err)
+	}
+	live := make(map[string]map[string]int64)
+	for _, item := range needed {
+		for _, sl := range orderOpen.Listings {
+			if sl.Listing.Slug != item.Slug {
+				continue
+			}
+			for _, sku := range sl.Listing.Item.Skus {
+				if models.SKUKey(sku) != item.SKU {
+					continue
+				}
+				quantity := models.SKUQuantity(sku)
+				if quantity < 0 {
+					continue
+				}
+
+				available, ok := live[item.Slug]
+				if !ok {
+					available, err = n.requestListingInventory(ctx, sl.Listing)
+					if err != nil {
+						return err
+					}
+					live[item.Slug] = available
+				}
+				if available != nil {
+					quantity, ok = available[item.SKU]
+					if !ok {
+						continue
+					}
+				}
+
+				if item.Quantity > quantity {
+					return fmt.Errorf("%w: insufficient inventory for item %s: %d available", coreiface.ErrBadRequest, item.Slug, quantity)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// requestListingInventory requests the current stock for the listing from its
+// vendor. Nil is returned if the vendor does not respond.
+func (n *OpenBazaarNode) requestListingInventory(ctx context.Context, listing *pb.Listing) (map[string]int64, error) {
+	vendor, err := peer.IDB58Decode(listing.VendorID.PeerID)
+	if err != nil {
+		return nil, err
+	}
+	available, err := n.RequestInventory(ctx, vendor, listing.Slug)
+	if err == ErrNoResponse {
+		log.Debugf("No inventory response from %s, using published quantities for %s", vendor, listing.Slug)
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return available, nil
+}
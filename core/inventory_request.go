@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/golang/protobuf/ptypes"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"time"
+)
+
+const (
+	// inventoryRequestTimeout is the amount of time to wait for a response
+	// to a INVENTORY_REQUEST message.
+	inventoryRequestTimeout = time.Second * 3
+)
+
+// RequestInventory requests the stock currently available for each SKU of the
+// remote peer's listing. Only tracked SKUs are returned. This is sent as an online
+// message and will not retry if no response is returned.
+func (n *OpenBazaarNode) RequestInventory(ctx context.Context, to peer.ID, slug string) (map[string]int64, error) {
+	invReq := pb.InventoryRequestMessage{
+		Slug: slug,
+	}
+
+	payload, err := ptypes.MarshalAny(&invReq)
+	if err != nil {
+		return nil, err
+	}
+
+	message := newMessageWithID()
+	message.MessageType = pb.Message_INVENTORY_REQUEST
+	message.Payload = payload
+
+	sub, err := n.eventBus.Subscribe(&events.InventoryRequestResponse{})
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, inventoryRequestTimeout)
+	defer cancel()
+
+	go n.networkService.SendMessage(ctx, to, message)
+
+	for {
+		select {
+		case resp := <-sub.Out():
+			invResp := resp.(*events.InventoryRequestResponse)
+
+			// We only care about responses from our peer and for our listing.
+			if invResp.PeerID != to.Pretty() || invResp.Slug != slug {
+				continue
+			}
+
+			return invResp.Available, nil
+		case <-time.After(inventoryRequestTimeout):
+			return nil, ErrNoResponse
+		case <-ctx.Done():
+			return nil, ErrNoResponse
+		}
+	}
+}
+
+// handleInventoryRequest is the handler for the INVENTORY_REQUEST message. It responds
+// with the stock available for each tracked SKU of the listing using an online message.
+func (n *OpenBazaarNode) handleInventoryRequest(from peer.ID, message *pb.Message) error {
+	if message.MessageType != pb.Message_INVENTORY_REQUEST {
+		return errors.New("message is not type INVENTORY_REQUEST")
+	}
+
+	req := new(pb.InventoryRequestMessage)
+	if err := ptypes.UnmarshalAny(message.Payload, req); err != nil {
+		return err
+	}
+
+	var inventory []models.InventoryItem
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("slug = ?", req.Slug).Find(&inventory).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	invResp := pb.InventoryResponseMessage{
+		Slug: req.Slug,
+	}
+	for _, item := range inventory {
+		if !item.Tracked() {
+			continue
+		}
+		invResp.Skus = append(invResp.Skus, &pb.InventoryResponseMessage_Sku{
+			Sku:       item.SKU,
+			Available: item.Available(),
+		})
+	}
+
+	payload, err := ptypes.MarshalAny(&invResp)
+	if err != nil {
+		return err
+	}
+
+	resp := newMessageWithID()
+	resp.MessageType = pb.Message_INVENTORY_RESPONSE
+	resp.Payload = payload
+
+	return n.networkService.SendMessage(context.Background(), from, resp)
+}
+
+// handleInventoryResponse is the handler for the INVENTORY_RESPONSE message. It pushes
+// the response to the event bus for any listening subscribers.
+func (n *OpenBazaarNode) handleInventoryResponse(from peer.ID, message *pb.Message) error {
+	if message.MessageType != pb.Message_INVENTORY_RESPONSE {
+		return errors.New("message is not type INVENTORY_RESPONSE")
+	}
+
+	resp := new(pb.InventoryResponseMessage)
+	if err := ptypes.UnmarshalAny(message.Payload, resp); err != nil {
+		return err
+	}
+
+	available := make(map[string]int64)
+	for _, sku := range resp.Skus {
+		available[sku.Sku] = sku.Available
+	}
+
+	n.eventBus.Emit(&events.InventoryRequestResponse{
+		PeerID:    from.Pretty(),
+		Slug:      resp.Slug,
+		Available: available,
+	})
+	return nil
+}
@@ -0,0 +1,184 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/models/factory"
+	"testing"
+	"time"
+)
+
+func TestOpenBazaarNode_Inventory(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	const (
+		slug = "ron-swanson-shirt"
+		sku  = "size:large/color:red"
+	)
+
+	waitForDone := func(done chan struct{}) {
+		select {
+		case <-done:
+		case <-time.After(time.Second * 10):
+			t.Fatal("Timeout waiting on channel")
+		}
+	}
+
+	getItem := func() *models.InventoryItem {
+		inventory, err := node.GetInventory()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, item := range inventory {
+			if item.Slug == slug && item.SKU == sku {
+				return &item
+			}
+		}
+		return nil
+	}
+
+	listing := factory.NewPhysicalListing(slug)
+	done := make(chan struct{})
+	if err := node.SaveListing(listing, done); err != nil {
+		t.Fatal(err)
+	}
+	waitForDone(done)
+
+	inventory, err := node.GetInventory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inventory) != len(listing.Item.Skus) {
+		t.Errorf("Expected %d inventory items, got %d", len(listing.Item.Skus), len(inventory))
+	}
+	item := getItem()
+	if item == nil {
+		t.Fatal("Inventory item not found")
+	}
+	if item.Quantity != 12 {
+		t.Errorf("Expected quantity 12, got %d", item.Quantity)
+	}
+
+	// Simulate sales and make sure saving the listing again does not
+	// overwrite the stock.
+	err = node.repo.DB().Update(func(tx database.Tx) error {
+		item.Quantity = 5
+		return tx.Save(item)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done = make(chan struct{})
+	if err := node.SaveListing(listing, done); err != nil {
+		t.Fatal(err)
+	}
+	waitForDone(done)
+
+	if item := getItem(); item.Quantity != 5 {
+		t.Errorf("Expected quantity 5, got %d", item.Quantity)
+	}
+
+	done = make(chan struct{})
+	if err := node.SetInventory([]models.InventoryItem{{Slug: slug, SKU: sku, Quantity: 3}}, done); err != nil {
+		t.Fatal(err)
+	}
+	waitForDone(done)
+
+	if item := getItem(); item.Quantity != 3 {
+		t.Errorf("Expected quantity 3, got %d", item.Quantity)
+	}
+
+	sl, err := node.GetMyListingBySlug(slug)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range sl.Listing.Item.Skus {
+		if models.SKUKey(s) == sku && s.Quantity != "3" {
+			t.Errorf("Expected listing quantity 3, got %s", s.Quantity)
+		}
+	}
+
+	err = node.SetInventory([]models.InventoryItem{{Slug: slug, SKU: "size:huge", Quantity: 3}}, nil)
+	if !errors.Is(err, coreiface.ErrBadRequest) {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+
+	err = node.SetInventory([]models.InventoryItem{{Slug: "asdf", SKU: sku, Quantity: 3}}, nil)
+	if !errors.Is(err, coreiface.ErrNotFound) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+
+	done = make(chan struct{})
+	if err := node.DeleteListing(slug, done); err != nil {
+		t.Fatal(err)
+	}
+	waitForDone(done)
+
+	inventory, err = node.GetInventory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inventory) != 0 {
+		t.Errorf("Expected inventory to be deleted, got %d items", len(inventory))
+	}
+}
+
+func TestOpenBazaarNode_RequestInventory(t *testing.T) {
+	network, err := NewMocknet(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer network.TearDown()
+
+	const sku = "size:large/color:red"
+
+	listing := factory.NewPhysicalListing("tshirt")
+	done := make(chan struct{})
+	if err := network.Nodes()[0].SaveListing(listing, done); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	// Sell all but one without republishing the listing.
+	err = network.Nodes()[0].repo.DB().Update(func(tx database.Tx) error {
+		return tx.Update("quantity", 1, map[string]interface{}{"slug = ?": "tshirt", "sku = ?": sku}, &models.InventoryItem{})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	available, err := network.Nodes()[1].RequestInventory(context.Background(), network.Nodes()[0].Identity(), "tshirt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if available[sku] != 1 {
+		t.Errorf("Expected 1 available, got %d", available[sku])
+	}
+
+	index, err := network.Nodes()[0].GetMyListings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The published listing still has stock but the vendor does not.
+	purchase := factory.NewPurchase()
+	purchase.Items[0].ListingHash = index[0].CID
+	purchase.Items[0].Quantity = "2"
+
+	_, _, _, err = network.Nodes()[1].PurchaseListing(context.Background(), purchase)
+	if !errors.Is(err, coreiface.ErrBadRequest) {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jinzhu/gorm"
+	"os"
+	"time"
+)
+
+const (
+	// listingExpiryInterval is how often we check our listings for expiry.
+	listingExpiryInterval = time.Hour
+
+	// listingExpiryWarning is how long before a listing expires that we
+	// notify the vendor and, if enabled, renew the listing.
+	listingExpiryWarning = time.Hour * 24 * 7
+
+	// listingRenewalPeriod is how far the expiry of a listing is extended
+	// when it is automatically renewed.
+	listingRenewalPeriod = time.Hour * 24 * 30
+)
+
+// listingExpiryHandler checks our listings for expiry when the node starts
+// and then on each listingExpiryInterval until shutdown.
+func (n *OpenBazaarNode) listingExpiryHandler() {
+	ticker := time.NewTicker(listingExpiryInterval)
+	defer ticker.Stop()
+	for {
+		if err := n.checkListingExpiry(); err != nil {
+			log.Errorf("Error checking listing expiry: %s", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-n.shutdown:
+			return
+		}
+	}
+}
+
+// checkListingExpiry looks for listings which are close to expiring. If the
+// AutoRenewListings preference is set they are renewed, otherwise the vendor
+// is notified once per expiry and the listing is removed from the index when
+// it expires. If any listings changed we publish once for the whole batch.
+func (n *OpenBazaarNode) checkListingExpiry() error {
+	var (
+		changed bool
+		now     = time.Now()
+	)
+	err := n.repo.DB().Update(func(tx database.Tx) error {
+		index, err := tx.GetListingIndex()
+		if os.IsNotExist(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		var prefs models.UserPreferences
+		if err := tx.Read().First(&prefs).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+			return err
+		}
+
+		for _, lmd := range append(models.ListingIndex{}, index...) {
+			sl, err := tx.GetListing(lmd.Slug)
+			if err != nil {
+				log.Errorf("Error loading listing %s: %s", lmd.Slug, err)
+				continue
+			}
+			listing := sl.Listing
+			expiry, err := ptypes.Timestamp(listing.Metadata.Expiry)
+			if err != nil || expiry.Sub(now) > listingExpiryWarning {
+				continue
+			}
+
+			if prefs.AutoRenewListings {
+				if expiry.Before(now) {
+					expiry = now
+				}
+				listing.Metadata.Expiry, err = ptypes.TimestampProto(expiry.Add(listingRenewalPeriod))
+				if err != nil {
+					return err
+				}
+				cid, err := n.saveListingToDB(tx, listing)
+				if err != nil {
+					return err
+				}
+				renewed, err := models.NewListingMetadataFromListing(listing, cid)
+				if err != nil {
+					return err
+				}
+				index.UpdateListing(*renewed)
+
+				ev := &events.ListingRenewed{
+					Slug:   listing.Slug,
+					Title:  listing.Item.Title,
+					Expiry: expiry.Add(listingRenewalPeriod),
+				}
+				tx.RegisterCommitHook(func() {
+					n.eventBus.Emit(ev)
+				})
+				changed = true
+				log.Infof("Renewed listing %s", listing.Slug)
+				continue
+			}
+
+			if !expiry.After(now) {
+				index.DeleteListing(listing.Slug)
+
+				ev := &events.ListingExpired{
+					Slug:  listing.Slug,
+					Title: listing.Item.Title,
+				}
+				tx.RegisterCommitHook(func() {
+					n.eventBus.Emit(ev)
+				})
+				changed = true
+				log.Infof("Listing %s expired", listing.Slug)
+				continue
+			}
+
+			// Only notify once for each expiry. If the vendor updates the
+			// expiry and it gets close again they will be notified again.
+			var notified models.Event
+			name := "listing_expiring_" + listing.Slug
+			err = tx.Read().Where("name = ?", name).First(&notified).Error
+			if err != nil && !gorm.IsRecordNotFoundError(err) {
+				return err
+			}
+			if err == nil && notified.Time.Unix() == expiry.Unix() {
+				continue
+			}
+			if err := tx.Save(&models.Event{Name: name, Time: expiry}); err != nil {
+				return err
+			}
+
+			ev := &events.ListingExpiring{
+				Slug:   listing.Slug,
+				Title:  listing.Item.Title,
+				Expiry: expiry,
+			}
+			tx.RegisterCommitHook(func() {
+				n.eventBus.Emit(ev)
+			})
+		}
+
+		if !changed {
+			return nil
+		}
+		if err := tx.SetListingIndex(index); err != nil {
+			return err
+		}
+		return n.updateAndSaveProfile(tx)
+	})
+	if err != nil {
+		return err
+	}
+	if changed {
+		n.Publish(nil)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package core
+
+import (
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/models/factory"
+	"github.com/golang/protobuf/ptypes"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestOpenBazaarNode_checkListingExpiry(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	sub, err := node.eventBus.Subscribe([]interface{}{
+		&events.ListingExpiring{},
+		&events.ListingRenewed{},
+		&events.ListingExpired{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	// saveWithExpiry saves the listing directly to the database so that
+	// we can save listings which have already expired.
+	saveWithExpiry := func(slug string, expiry time.Time) {
+		listing := factory.NewPhysicalListing(slug)
+		listing.Metadata.Expiry, err = ptypes.TimestampProto(expiry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sl, err := node.signListing(listing)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = node.repo.DB().Update(func(tx database.Tx) error {
+			if err := tx.SetListing(sl); err != nil {
+				return err
+			}
+			index, err := tx.GetListingIndex()
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			index.UpdateListing(models.ListingMetadata{Slug: slug})
+			return tx.SetListingIndex(index)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectEvent := func(expected interface{}) {
+		t.Helper()
+		select {
+		case ev := <-sub.Out():
+			switch expected.(type) {
+			case *events.ListingExpiring:
+				if _, ok := ev.(*events.ListingExpiring); !ok {
+					t.Errorf("Expected ListingExpiring event got %T", ev)
+				}
+			case *events.ListingRenewed:
+				if _, ok := ev.(*events.ListingRenewed); !ok {
+					t.Errorf("Expected ListingRenewed event got %T", ev)
+				}
+			case *events.ListingExpired:
+				if _, ok := ev.(*events.ListingExpired); !ok {
+					t.Errorf("Expected ListingExpired event got %T", ev)
+				}
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("Timed out waiting on %T event", expected)
+		}
+	}
+
+	expectNoEvent := func() {
+		t.Helper()
+		select {
+		case ev := <-sub.Out():
+			t.Errorf("Unexpected %T event", ev)
+		case <-time.After(time.Millisecond * 500):
+		}
+	}
+
+	// Listing expiring soon.
+	saveWithExpiry("expiring", time.Now().Add(time.Hour*24))
+	if err := node.checkListingExpiry(); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(&events.ListingExpiring{})
+
+	// We should only be notified once.
+	if err := node.checkListingExpiry(); err != nil {
+		t.Fatal(err)
+	}
+	expectNoEvent()
+
+	// Listing already expired.
+	saveWithExpiry("expired", time.Now().Add(-time.Hour))
+	if err := node.checkListingExpiry(); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(&events.ListingExpired{})
+
+	index, err := node.GetMyListings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := index.GetListingCID("expired"); err == nil {
+		t.Error("Expected expired listing to be removed from the index")
+	}
+	if len(index) != 1 {
+		t.Errorf("Expected 1 listing in the index, got %d", len(index))
+	}
+
+	// Auto-renew the expiring listing.
+	prefs, err := node.GetPreferences()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefs.AutoRenewListings = true
+	if err := node.SavePreferences(prefs, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := node.checkListingExpiry(); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(&events.ListingRenewed{})
+
+	sl, err := node.GetMyListingBySlug("expiring")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiry, err := ptypes.Timestamp(sl.Listing.Metadata.Expiry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Until(expiry) < listingRenewalPeriod {
+		t.Errorf("Expected listing expiry to be extended, got %s", expiry)
+	}
+
+	// The renewed listing is no longer close to expiring.
+	if err := node.checkListingExpiry(); err != nil {
+		t.Fatal(err)
+	}
+	expectNoEvent()
+}
@@ -0,0 +1,230 @@
+package core
+
+import (
+	"fmt"
+	"github.com/OpenBazaar/jsonpb"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/ipfs/go-cid"
+	"github.com/jinzhu/gorm"
+	"os"
+	"time"
+)
+
+// maxListingVersions is the number of versions of each listing we keep
+// in the listing history. Older versions are pruned.
+const maxListingVersions = 50
+
+// GetListingHistory returns the saved versions of the listing with the
+// given slug, newest first. The history is kept after a listing is deleted
+// so that it can be restored with RollbackListing.
+func (n *OpenBazaarNode) GetListingHistory(slug string) ([]models.ListingVersion, error) {
+	var versions []models.ListingVersion
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("slug = ?", slug).Order("id desc").Find(&versions).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w: no history for listing %s", coreiface.ErrNotFound, slug)
+	}
+	return versions, nil
+}
+
+// DiffListingVersions returns the structured difference between two versions
+// of the listing with the given slug. If to is cid.Undef the from version is
+// compared against the latest version.
+func (n *OpenBazaarNode) DiffListingVersions(slug string, from, to cid.Cid) (*models.ListingDiff, error) {
+	var (
+		fromVersion, toVersion *models.ListingVersion
+		err                    error
+	)
+	err = n.repo.DB().View(func(tx database.Tx) error {
+		fromVersion, err = getListingVersion(tx, slug, from)
+		if err != nil {
+			return err
+		}
+		toVersion, err = getListingVersion(tx, slug, to)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fromListing, err := fromVersion.SignedListing()
+	if err != nil {
+		return nil, err
+	}
+	toListing, err := toVersion.SignedListing()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := models.DiffListings(fromListing.Listing, toListing.Listing)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ListingDiff{
+		Slug:    slug,
+		From:    fromVersion.CID,
+		To:      toVersion.CID,
+		Changes: changes,
+	}, nil
+}
+
+// RollbackListing restores the listing with the given slug to a previous
+// version from the listing history. The listing is re-signed and saved as
+// the newest version and we publish once it's saved. Only the listing content
+// is restored, our current inventory is kept.
+func (n *OpenBazaarNode) RollbackListing(slug string, version cid.Cid, done chan<- struct{}) error {
+	var v *models.ListingVersion
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		var err error
+		v, err = getListingVersion(tx, slug, version)
+		return err
+	})
+	if err != nil {
+		maybeCloseDone(done)
+		return err
+	}
+	sl, err := v.SignedListing()
+	if err != nil {
+		maybeCloseDone(done)
+		return err
+	}
+
+	err = n.repo.DB().Update(func(tx database.Tx) error {
+		if err := keepInventory(tx, sl.Listing); err != nil {
+			return err
+		}
+		return n.saveAndIndexListing(tx, sl.Listing)
+	})
+	if err != nil {
+		maybeCloseDone(done)
+		return err
+	}
+	log.Infof("Rolled back listing %s to version %s", slug, v.CID)
+	n.Publish(done)
+	return nil
+}
+
+// recordListingVersion adds the signed listing to the listing history unless
+// it's the same as the latest version. Listings saved before the history
+// existed have their current version recorded first so it isn't lost.
+func (n *OpenBazaarNode) recordListingVersion(dbtx database.Tx, sl *pb.SignedListing, id cid.Cid) error {
+	slug := sl.Listing.Slug
+
+	var (
+		latest models.ListingVersion
+		prev   *pb.Listing
+	)
+	err := dbtx.Read().Where("slug = ?", slug).Order("id desc").First(&latest).Error
+	switch {
+	case err == nil:
+		if latest.CID == id.String() {
+			return nil
+		}
+		prevSL, err := latest.SignedListing()
+		if err != nil {
+			return err
+		}
+		prev = prevSL.Listing
+	case gorm.IsRecordNotFoundError(err):
+		current, err := dbtx.GetListing(slug)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if current != nil {
+			currentID, err := n.listingCID(current)
+			if err != nil {
+				return err
+			}
+			if currentID == id {
+				return nil
+			}
+			if err := saveListingVersion(dbtx, current, currentID, "imported from existing listing"); err != nil {
+				return err
+			}
+			prev = current.Listing
+		}
+	default:
+		return err
+	}
+
+	summary := "created"
+	if prev != nil {
+		changes, err := models.DiffListings(prev, sl.Listing)
+		if err != nil {
+			return err
+		}
+		summary = models.SummarizeListingChanges(changes)
+	}
+	if err := saveListingVersion(dbtx, sl, id, summary); err != nil {
+		return err
+	}
+
+	// Prune anything beyond the max number of versions.
+	var versions []models.ListingVersion
+	err = dbtx.Read().Select("id").Where("slug = ?", slug).Order("id desc").Offset(maxListingVersions).Limit(1).Find(&versions).Error
+	if err != nil {
+		return err
+	}
+	if len(versions) > 0 {
+		return dbtx.Delete("slug", slug, map[string]interface{}{"id <= ?": versions[0].ID}, &models.ListingVersion{})
+	}
+	return nil
+}
+
+// listingCID returns the CID of the signed listing as it's saved in
+// the public data directory.
+func (n *OpenBazaarNode) listingCID(sl *pb.SignedListing) (cid.Cid, error) {
+	m := jsonpb.Marshaler{
+		Indent:       "    ",
+		EmitDefaults: false,
+	}
+	ser, err := m.MarshalToString(sl)
+	if err != nil {
+		return cid.Cid{}, err
+	}
+	return n.cid([]byte(ser))
+}
+
+// saveListingVersion saves the signed listing to the listing history.
+func saveListingVersion(dbtx database.Tx, sl *pb.SignedListing, id cid.Cid, summary string) error {
+	ser, err := proto.Marshal(sl)
+	if err != nil {
+		return err
+	}
+	return dbtx.Save(&models.ListingVersion{
+		Slug:              sl.Listing.Slug,
+		CID:               id.String(),
+		Timestamp:         time.Now(),
+		Summary:           summary,
+		SerializedListing: ser,
+	})
+}
+
+// getListingVersion loads the version of the listing with the given CID from
+// the listing history. If the CID is cid.Undef the latest version is returned.
+// If a version appears more than once, as happens after a rollback, the most
+// recent is returned.
+func getListingVersion(dbtx database.Tx, slug string, id cid.Cid) (*models.ListingVersion, error) {
+	db := dbtx.Read().Where("slug = ?", slug)
+	if id.Defined() {
+		db = db.Where("c_id = ?", id.String())
+	}
+	var version models.ListingVersion
+	err := db.Order("id desc").First(&version).Error
+	if gorm.IsRecordNotFoundError(err) {
+		if id.Defined() {
+			return nil, fmt.Errorf("%w: version %s of listing %s not found", coreiface.ErrNotFound, id, slug)
+		}
+		return nil, fmt.Errorf("%w: no history for listing %s", coreiface.ErrNotFound, slug)
+	} else if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
@@ -0,0 +1,210 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/models/factory"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/ipfs/go-cid"
+	"testing"
+)
+
+func TestOpenBazaarNode_ListingHistory(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	if _, err := node.GetListingHistory("ron-swanson-shirt"); !errors.Is(err, coreiface.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound got %v", err)
+	}
+
+	listing := factory.NewPhysicalListing("ron-swanson-shirt")
+	if err := node.SaveListing(listing, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Saving the same listing again should not add a version.
+	if err := node.SaveListing(listing, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	err = node.UpdateAllListings(func(l *pb.Listing) (bool, error) {
+		l.Item.Price = "200"
+		return true, nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := node.GetListingHistory("ron-swanson-shirt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("Expected 2 versions, got %d", len(history))
+	}
+	if history[1].Summary != "created" {
+		t.Errorf("Expected summary created, got %s", history[1].Summary)
+	}
+	if history[0].Summary != "changed item.price" {
+		t.Errorf("Expected summary changed item.price, got %s", history[0].Summary)
+	}
+
+	index, err := node.GetMyListings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index[0].CID != history[0].CID {
+		t.Errorf("Expected latest version CID %s, got %s", index[0].CID, history[0].CID)
+	}
+
+	first, err := cid.Decode(history[1].CID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff, err := node.DiffListingVersions("ron-swanson-shirt", first, cid.Undef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.From != history[1].CID || diff.To != history[0].CID {
+		t.Errorf("Incorrect diff versions %s and %s", diff.From, diff.To)
+	}
+	if len(diff.Changes) != 1 || diff.Changes[0].Path != "item.price" || diff.Changes[0].Old != "100" || diff.Changes[0].New != "200" {
+		t.Errorf("Incorrect diff changes %v", diff.Changes)
+	}
+
+	// Roll back after the listing is deleted.
+	if err := node.DeleteListing("ron-swanson-shirt", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := node.RollbackListing("ron-swanson-shirt", first, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	sl, err := node.GetMyListingBySlug("ron-swanson-shirt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sl.Listing.Item.Price != "100" {
+		t.Errorf("Expected price 100 after rollback, got %s", sl.Listing.Item.Price)
+	}
+
+	history, err = node.GetListingHistory("ron-swanson-shirt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("Expected 3 versions, got %d", len(history))
+	}
+
+	missing, err := cid.Decode("QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := node.RollbackListing("ron-swanson-shirt", missing, nil); !errors.Is(err, coreiface.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound got %v", err)
+	}
+	if _, err := node.DiffListingVersions("ron-swanson-shirt", missing, cid.Undef); !errors.Is(err, coreiface.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound got %v", err)
+	}
+}
+
+func TestOpenBazaarNode_ListingHistoryPrune(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	listing := factory.NewPhysicalListing("ron-swanson-shirt")
+	for i := 0; i < maxListingVersions+5; i++ {
+		listing.Item.Description = fmt.Sprintf("Example item %d", i)
+		if err := node.SaveListing(listing, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	history, err := node.GetListingHistory("ron-swanson-shirt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != maxListingVersions {
+		t.Errorf("Expected %d versions, got %d", maxListingVersions, len(history))
+	}
+}
+
+func TestOpenBazaarNode_RollbackListingInventory(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	const (
+		slug = "ron-swanson-shirt"
+		sku  = "size:large/color:red"
+	)
+
+	listing := factory.NewPhysicalListing(slug)
+	if err := node.SaveListing(listing, nil); err != nil {
+		t.Fatal(err)
+	}
+	err = node.UpdateAllListings(func(l *pb.Listing) (bool, error) {
+		l.Item.Price = "200"
+		for _, s := range l.Item.Skus {
+			s.Quantity = "20"
+		}
+		return true, nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a sale.
+	err = node.repo.DB().Update(func(tx database.Tx) error {
+		return tx.Save(&models.InventoryItem{Slug: slug, SKU: sku, Quantity: 15, ListingQuantity: 20})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := node.GetListingHistory(slug)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := cid.Decode(history[len(history)-1].CID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := node.RollbackListing(slug, first, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	sl, err := node.GetMyListingBySlug(slug)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sl.Listing.Item.Price != "100" {
+		t.Errorf("Expected price 100 after rollback, got %s", sl.Listing.Item.Price)
+	}
+	for _, s := range sl.Listing.Item.Skus {
+		if models.SKUKey(s) == sku && s.Quantity != "15" {
+			t.Errorf("Expected listing quantity 15, got %s", s.Quantity)
+		}
+	}
+
+	inventory, err := node.GetInventory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range inventory {
+		if item.SKU == sku && item.Quantity != 15 {
+			t.Errorf("Expected quantity 15 after rollback, got %d", item.Quantity)
+		}
+	}
+}
@@ -37,31 +37,7 @@ import (
 // index and update the listing count in the profile.
 func (n *OpenBazaarNode) SaveListing(listing *pb.Listing, done chan<- struct{}) error {
 	err := n.repo.DB().Update(func(tx database.Tx) error {
-		cid, err := n.saveListingToDB(tx, listing)
-		if err != nil {
-			return err
-		}
-
-		lmd, err := models.NewListingMetadataFromListing(listing, cid)
-		if err != nil {
-			return err
-		}
-
-		index, err := tx.GetListingIndex()
-		if err != nil && !os.IsNotExist(err) {
-			return err
-		}
-		index.UpdateListing(*lmd)
-
-		if err := tx.SetListingIndex(index); err != nil {
-			return err
-		}
-
-		// Update profile counts
-		if err := n.updateAndSaveProfile(tx); err != nil {
-			return err
-		}
-		return nil
+		return n.saveAndIndexListing(tx, listing)
 	})
 	if err != nil {
 		maybeCloseDone(done)
@@ -103,6 +79,9 @@ func (n *OpenBazaarNode) DeleteListing(slug string, done chan<- struct{}) error
 		if err := tx.Delete("slug", slug, nil, &models.Coupon{}); err != nil {
 			return err
 		}
+		if err := tx.Delete("slug", slug, nil, &models.InventoryItem{}); err != nil {
+			return err
+		}
 
 		index, err := tx.GetListingIndex()
 		if err != nil {
@@ -149,6 +128,7 @@ func (n *OpenBazaarNode) GetMyListings() (models.ListingIndex, error) {
 // GetListings returns the listing index for node with the given peer ID.
 // If useCache is set it will return the index from the local cache
 // (if it has one) if listing index file is not found on the network.
+// The listings are added to the local search index in the background.
 func (n *OpenBazaarNode) GetListings(ctx context.Context, peerID peer.ID, useCache bool) (models.ListingIndex, error) {
 	pth, err := n.resolve(ctx, peerID, useCache)
 	if err != nil {
@@ -162,6 +142,15 @@ func (n *OpenBazaarNode) GetListings(ctx context.Context, peerID peer.ID, useCac
 	if err := json.Unmarshal(indexBytes, &index); err != nil {
 		return nil, err
 	}
+	if peerID != n.Identity() {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), searchFetchTimeout)
+			defer cancel()
+			if err := n.updateSearchIndex(ctx, peerID, pth); err != nil {
+				log.Debugf("Error updating search index for %s: %s", peerID, err)
+			}
+		}()
+	}
 	return index, nil
 }
 
@@ -353,8 +342,35 @@ func (n *OpenBazaarNode) updateAllListings(tx database.Tx, updateFunc func(l *pb
 	return true, n.updateAndSaveProfile(tx)
 }
 
+// saveAndIndexListing saves the listing, adds it to the listing index and
+// updates the listing count in the profile.
+func (n *OpenBazaarNode) saveAndIndexListing(dbtx database.Tx, listing *pb.Listing) error {
+	cid, err := n.saveListingToDB(dbtx, listing)
+	if err != nil {
+		return err
+	}
+
+	lmd, err := models.NewListingMetadataFromListing(listing, cid)
+	if err != nil {
+		return err
+	}
+
+	index, err := dbtx.GetListingIndex()
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	index.UpdateListing(*lmd)
+
+	if err := dbtx.SetListingIndex(index); err != nil {
+		return err
+	}
+
+	// Update profile counts
+	return n.updateAndSaveProfile(dbtx)
+}
+
 // saveListingToDB updates any needed fields in the listing and saves or updates the
-// listing on disk and the coupon database table.
+// listing on disk, the coupon database table and the inventory.
 func (n *OpenBazaarNode) saveListingToDB(dbtx database.Tx, listing *pb.Listing) (cid.Cid, error) {
 	// Set the escrow timeout.
 	if n.UsingTestnet() {
@@ -482,22 +498,29 @@ func (n *OpenBazaarNode) saveListingToDB(dbtx database.Tx, listing *pb.Listing)
 		}
 	}
 
+	id, err := n.listingCID(sl)
+	if err != nil {
+		return cid.Cid{}, err
+	}
+
+	// Record the new version in the listing history. This must be
+	// done before saving so the existing listing can be recorded
+	// if it predates the history.
+	if err := n.recordListingVersion(dbtx, sl, id); err != nil {
+		return cid.Cid{}, err
+	}
+
 	// Save listing
 	if err := dbtx.SetListing(sl); err != nil {
 		return cid.Cid{}, err
 	}
 
-	m := jsonpb.Marshaler{
-		Indent:       "    ",
-		EmitDefaults: false,
-	}
-	ser, err := m.MarshalToString(sl)
-	if err != nil {
+	// Update the inventory with any changed SKU quantities
+	if err := syncInventory(dbtx, listing); err != nil {
 		return cid.Cid{}, err
 	}
 
-	// Update listing index
-	return n.cid([]byte(ser))
+	return id, nil
 }
 
 // signListing signs a protobuf serialization of the listing with the inventory
@@ -814,6 +837,7 @@ func (n *OpenBazaarNode) validateListing(sl *pb.SignedListing) (err error) {
 	if len(sl.Listing.Taxes) > MaxListItems {
 		return coreiface.ErrTooManyItems{"taxes", strconv.Itoa(MaxListItems)}
 	}
+	taxRegions := make(map[pb.CountryCode]bool)
 	for _, tax := range sl.Listing.Taxes {
 		if tax.TaxType == "" {
 			return coreiface.ErrMissingField("taxes.taxtype")
@@ -830,6 +854,20 @@ func (n *OpenBazaarNode) validateListing(sl *pb.SignedListing) (err error) {
 		if tax.Percentage == 0 || tax.Percentage > 100 {
 			return errors.New("tax percentage must be between 0 and 100")
 		}
+		// Each country may only be covered by one tax rule so that the
+		// tax charged on an order is never ambiguous.
+		for _, region := range tax.TaxRegions {
+			if region == pb.CountryCode_NA {
+				return coreiface.ErrMissingField("taxes.taxregions")
+			}
+			if taxRegions[region] {
+				return fmt.Errorf("tax region %s is covered by more than one tax rule", region)
+			}
+			taxRegions[region] = true
+		}
+	}
+	if taxRegions[pb.CountryCode_ALL] && len(taxRegions) > 1 {
+		return errors.New("a tax rule covering all regions cannot be combined with other tax regions")
 	}
 
 	// Coupons
@@ -867,6 +905,33 @@ func (n *OpenBazaarNode) validateListing(sl *pb.SignedListing) (err error) {
 		if coupon.GetPercentDiscount() == 0 && flag {
 			return errors.New("coupons must have at least one positive discount value")
 		}
+		if coupon.MaxRedemptions > 0 && coupon.MaxRedemptionsPerBuyer > coupon.MaxRedemptions {
+			return errors.New("coupon max redemptions per buyer cannot be greater than max redemptions")
+		}
+		if coupon.MinimumOrderValue != "" {
+			if len(coupon.MinimumOrderValue) > SentenceMaxCharacters {
+				return coreiface.ErrTooManyCharacters{"coupons.minimumordervalue", strconv.Itoa(SentenceMaxCharacters)}
+			}
+			minimum, ok := new(big.Int).SetString(coupon.MinimumOrderValue, 10)
+			if !ok || minimum.Cmp(big.NewInt(0)) < 0 {
+				return errors.New("invalid coupon minimum order value")
+			}
+		}
+		if len(coupon.Skus) > MaxListItems {
+			return coreiface.ErrTooManyItems{"coupons.skus", strconv.Itoa(MaxListItems)}
+		}
+		for _, productID := range coupon.Skus {
+			found := false
+			for _, sku := range sl.Listing.Item.Skus {
+				if productID != "" && sku.ProductID == productID {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("coupon sku %s not found in listing", productID)
+			}
+		}
 	}
 
 	// Moderators
@@ -989,6 +1054,16 @@ func validatePhysicalListing(listing *pb.Listing) error {
 		return fmt.Errorf("number of options is greater than the max of %d", MaxListItems)
 	}
 
+	// PriceTiers
+	if err := validatePriceTiers("item.pricetiers", listing.Item.PriceTiers); err != nil {
+		return err
+	}
+	for _, sku := range listing.Item.Skus {
+		if err := validatePriceTiers("item.skus.pricetiers", sku.PriceTiers); err != nil {
+			return err
+		}
+	}
+
 	// ShippingOptions
 	if len(listing.ShippingOptions) == 0 {
 		return coreiface.ErrMissingField("shippingoptions")
@@ -1051,9 +1126,89 @@ func validatePhysicalListing(listing *pb.Listing) error {
 			if len(option.Price) > WordMaxCharacters {
 				return coreiface.ErrTooManyCharacters{"shippingoptions.services.price", strconv.Itoa(WordMaxCharacters)}
 			}
+			if option.FreeShippingThreshold != "" {
+				if len(option.FreeShippingThreshold) > WordMaxCharacters {
+					return coreiface.ErrTooManyCharacters{"shippingoptions.services.freeshippingthreshold", strconv.Itoa(WordMaxCharacters)}
+				}
+				threshold, ok := new(big.Int).SetString(option.FreeShippingThreshold, 10)
+				if !ok || threshold.Cmp(big.NewInt(0)) < 0 {
+					return errors.New("invalid shipping service free shipping threshold")
+				}
+			}
+			if err := validateWeightBands(option.WeightBands); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateWeightBands validates the weight based rates of a shipping service.
+// Bands must be sorted by strictly increasing maximum weight as the first
+// band which fits the weight of the parcel is used.
+func validateWeightBands(bands []*pb.Listing_ShippingOption_Service_WeightBand) error {
+	if len(bands) > MaxListItems {
+		return coreiface.ErrTooManyItems{"shippingoptions.services.weightbands", strconv.Itoa(MaxListItems)}
+	}
+	var lastGrams uint64
+	for _, band := range bands {
+		if band.MaxGrams == 0 {
+			return coreiface.ErrMissingField("shippingoptions.services.weightbands.maxgrams")
+		}
+		if band.MaxGrams <= lastGrams {
+			return errors.New("shipping service weight bands must be in increasing order of weight")
+		}
+		lastGrams = band.MaxGrams
+
+		if band.Price == "" {
+			return coreiface.ErrMissingField("shippingoptions.services.weightbands.price")
+		}
+		if len(band.Price) > WordMaxCharacters {
+			return coreiface.ErrTooManyCharacters{"shippingoptions.services.weightbands.price", strconv.Itoa(WordMaxCharacters)}
 		}
+		price, ok := new(big.Int).SetString(band.Price, 10)
+		if !ok || price.Cmp(big.NewInt(0)) < 0 {
+			return errors.New("invalid shipping service weight band price")
+		}
+	}
+	return nil
+}
+
+// validatePriceTiers validates the quantity price tiers of a listing or sku.
+// Tiers must be sorted by strictly increasing minimum quantity so that the
+// tier used for a given quantity is never ambiguous.
+func validatePriceTiers(field string, tiers []*pb.Listing_Item_PriceTier) error {
+	if len(tiers) > MaxListItems {
+		return coreiface.ErrTooManyItems{field, strconv.Itoa(MaxListItems)}
 	}
+	var lastQuantity uint64
+	for _, tier := range tiers {
+		if tier.MinQuantity < 2 {
+			return fmt.Errorf("%s minimum quantity must be greater than one", field)
+		}
+		if tier.MinQuantity <= lastQuantity {
+			return fmt.Errorf("%s must be in increasing order of minimum quantity", field)
+		}
+		lastQuantity = tier.MinQuantity
 
+		switch discount := tier.Discount.(type) {
+		case *pb.Listing_Item_PriceTier_PercentDiscount:
+			if discount.PercentDiscount <= 0 || discount.PercentDiscount > 100 {
+				return fmt.Errorf("%s percent discount must be greater than zero and at most 100 percent", field)
+			}
+		case *pb.Listing_Item_PriceTier_Price:
+			if len(discount.Price) > SentenceMaxCharacters {
+				return coreiface.ErrTooManyCharacters{field + ".price", strconv.Itoa(SentenceMaxCharacters)}
+			}
+			price, ok := new(big.Int).SetString(discount.Price, 10)
+			if !ok || price.Cmp(big.NewInt(0)) < 0 {
+				return fmt.Errorf("invalid %s price", field)
+			}
+		default:
+			return coreiface.ErrMissingField(field + ".discount")
+		}
+	}
 	return nil
 }
 
@@ -1069,6 +1224,8 @@ func (n *OpenBazaarNode) validateCryptocurrencyListing(listing *pb.Listing) erro
 		return coreiface.ErrCryptocurrencyListingIllegalField("shippingOptions")
 	case len(listing.Item.Condition) > 0:
 		return coreiface.ErrCryptocurrencyListingIllegalField("item.condition")
+	case len(listing.Item.PriceTiers) > 0:
+		return coreiface.ErrCryptocurrencyListingIllegalField("item.priceTiers")
 	}
 
 	return nil
@@ -0,0 +1,366 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/OpenBazaar/jsonpb"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/proxyclient"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// maxImportImageSize is the largest image we will load when
+	// importing listings.
+	maxImportImageSize = 20 << 20
+
+	// importImageTimeout is how long we wait to fetch each image
+	// when importing listings.
+	importImageTimeout = time.Second * 30
+
+	// importDirName is the directory inside the data directory that
+	// local images may be loaded from when importing listings.
+	importDirName = "listing-import"
+)
+
+// errDryRun is used to roll back the database transaction for each
+// listing during a dry run import.
+var errDryRun = errors.New("dry run")
+
+// importRow is a single listing parsed from an import file.
+type importRow struct {
+	row     int
+	listing *pb.Listing
+	images  []string
+	err     error
+}
+
+// importImage is an image loaded from the reference in an import file.
+type importImage struct {
+	base64Data string
+	filename   string
+}
+
+// ImportListings saves each listing in the import file. A listing which fails
+// to parse or validate is skipped and the reason is returned in the result
+// alongside the row it came from. If dryRun is set each listing is validated
+// but nothing is saved. Otherwise we publish once after all the listings have
+// been saved.
+func (n *OpenBazaarNode) ImportListings(r io.Reader, format models.ListingFileFormat, dryRun bool, done chan<- struct{}) (*models.ListingImportResult, error) {
+	rows, err := readListingImport(r, format)
+	if err != nil {
+		maybeCloseDone(done)
+		return nil, fmt.Errorf("%w: %s", coreiface.ErrBadRequest, err)
+	}
+
+	result := &models.ListingImportResult{
+		DryRun:   dryRun,
+		Imported: []string{},
+		Errors:   []models.ListingImportError{},
+	}
+	addError := func(row importRow, err error) {
+		slug := ""
+		if row.listing != nil {
+			slug = row.listing.Slug
+		}
+		result.Errors = append(result.Errors, models.ListingImportError{
+			Row:   row.row,
+			Slug:  slug,
+			Error: err.Error(),
+		})
+	}
+
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		if row.err != nil {
+			addError(row, row.err)
+			continue
+		}
+		listing := row.listing
+		if listing.Item == nil || listing.Metadata == nil {
+			addError(row, errors.New("listing item and metadata must be set"))
+			continue
+		}
+
+		// The slug is generated here rather than in saveListingToDB as
+		// generating it requires its own database transaction.
+		if listing.Slug == "" {
+			listing.Slug, err = n.generateListingSlug(listing.Item.Title)
+			if err != nil {
+				addError(row, err)
+				continue
+			}
+			base := listing.Slug
+			for i := 1; seen[listing.Slug]; i++ {
+				listing.Slug = fmt.Sprintf("%s-%d", base, i)
+			}
+		} else if seen[listing.Slug] {
+			addError(row, errors.New("duplicate slug in import file"))
+			continue
+		}
+		seen[listing.Slug] = true
+
+		var (
+			images   = make([]importImage, 0, len(row.images))
+			imageErr error
+		)
+		for _, ref := range row.images {
+			img, err := n.loadImportImage(ref)
+			if err != nil {
+				imageErr = fmt.Errorf("error loading image %s: %s", ref, err)
+				break
+			}
+			images = append(images, *img)
+		}
+		if imageErr != nil {
+			addError(row, imageErr)
+			continue
+		}
+
+		err = n.repo.DB().Update(func(tx database.Tx) error {
+			for _, img := range images {
+				var hashes models.ImageHashes
+				if dryRun {
+					hashes, err = dryRunImageHashes(img)
+				} else {
+					hashes, err = n.resizeAndAddImage(tx, img.base64Data, img.filename, 120, 120)
+				}
+				if err != nil {
+					return err
+				}
+				listing.Item.Images = append(listing.Item.Images, &pb.Listing_Item_Image{
+					Filename: hashes.Filename,
+					Original: hashes.Original,
+					Large:    hashes.Large,
+					Medium:   hashes.Medium,
+					Small:    hashes.Small,
+					Tiny:     hashes.Tiny,
+				})
+			}
+			if err := n.saveAndIndexListing(tx, listing); err != nil {
+				return err
+			}
+			if dryRun {
+				return errDryRun
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errDryRun) {
+			addError(row, err)
+			continue
+		}
+		result.Imported = append(result.Imported, listing.Slug)
+	}
+
+	if dryRun || len(result.Imported) == 0 {
+		maybeCloseDone(done)
+		return result, nil
+	}
+	n.Publish(done)
+	return result, nil
+}
+
+// ExportListings writes all of our listings to the writer in the
+// provided format.
+func (n *OpenBazaarNode) ExportListings(w io.Writer, format models.ListingFileFormat) error {
+	var listings []*pb.Listing
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		index, err := tx.GetListingIndex()
+		if os.IsNotExist(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		for _, lmd := range index {
+			sl, err := tx.GetListing(lmd.Slug)
+			if err != nil {
+				return err
+			}
+			listings = append(listings, sl.Listing)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case models.ListingFileFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(models.ListingCSVHeader); err != nil {
+			return err
+		}
+		for _, listing := range listings {
+			if err := writer.Write(models.ListingToCSVRecord(listing)); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	case models.ListingFileFormatJSON:
+		m := jsonpb.Marshaler{Indent: "    "}
+		serialized := make([]json.RawMessage, 0, len(listings))
+		for _, listing := range listings {
+			ser, err := m.MarshalToString(listing)
+			if err != nil {
+				return err
+			}
+			serialized = append(serialized, json.RawMessage(ser))
+		}
+		out, err := json.MarshalIndent(serialized, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	default:
+		return fmt.Errorf("%w: unknown format %s", coreiface.ErrBadRequest, format)
+	}
+}
+
+// readListingImport parses each of the listings in the import file.
+// An error is only returned if the file itself cannot be read. Errors
+// parsing an individual listing are set on its row.
+func readListingImport(r io.Reader, format models.ListingFileFormat) ([]importRow, error) {
+	var rows []importRow
+	switch format {
+	case models.ListingFileFormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+
+		headerRow, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("error reading header: %s", err)
+		}
+		header, err := models.ParseListingCSVHeader(headerRow)
+		if err != nil {
+			return nil, err
+		}
+		for i := 2; ; i++ {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			listing, images, err := models.ListingFromCSVRecord(header, record)
+			rows = append(rows, importRow{row: i, listing: listing, images: images, err: err})
+		}
+	case models.ListingFileFormatJSON:
+		var serialized []json.RawMessage
+		if err := json.NewDecoder(r).Decode(&serialized); err != nil {
+			return nil, err
+		}
+		for i, ser := range serialized {
+			listing := new(pb.Listing)
+			err := jsonpb.Unmarshal(bytes.NewReader(ser), listing)
+			rows = append(rows, importRow{row: i + 1, listing: listing, err: err})
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %s", format)
+	}
+	return rows, nil
+}
+
+// loadImportImage loads the image data for an image reference from an
+// import file. The reference may be an HTTP(S) URL, an ipfs://<cid>/<filename>
+// reference to an image we already have, or the path to a local file relative
+// to the listing-import directory inside the data directory.
+func (n *OpenBazaarNode) loadImportImage(ref string) (*importImage, error) {
+	var (
+		r        io.Reader
+		filename string
+	)
+	switch {
+	case strings.HasPrefix(ref, "ipfs://"):
+		parts := strings.SplitN(strings.TrimPrefix(ref, "ipfs://"), "/", 2)
+		id, err := cid.Decode(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		filename = id.String()
+		if len(parts) == 2 && parts[1] != "" {
+			filename = parts[1]
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), importImageTimeout)
+		defer cancel()
+		f, err := n.GetImage(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		r = f
+	case strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://"):
+		u, err := url.Parse(ref)
+		if err != nil {
+			return nil, err
+		}
+		filename = path.Base(u.Path)
+		client := proxyclient.NewHttpClient()
+		client.Timeout = importImageTimeout
+		resp, err := client.Get(ref)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+		r = resp.Body
+	default:
+		// Cleaning the path as if it were rooted keeps it from
+		// escaping the import directory.
+		importDir := filepath.Join(n.repo.DataDir(), importDirName)
+		f, err := os.Open(filepath.Join(importDir, filepath.FromSlash(path.Clean("/"+ref))))
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		filename = path.Base(ref)
+		r = f
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxImportImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxImportImageSize {
+		return nil, errors.New("image is too large")
+	}
+	return &importImage{
+		base64Data: base64.StdEncoding.EncodeToString(data),
+		filename:   filename,
+	}, nil
+}
+
+// dryRunImageHashes checks that the image decodes and returns placeholder
+// hashes derived from its data so the listing can be validated without
+// adding the image to IPFS.
+func dryRunImageHashes(img importImage) (models.ImageHashes, error) {
+	if _, err := decodeImageData(img.base64Data); err != nil {
+		return models.ImageHashes{}, fmt.Errorf("%w: invalid image: %s", coreiface.ErrBadRequest, err.Error())
+	}
+	mh, err := multihash.Sum([]byte(img.base64Data), multihash.SHA2_256, -1)
+	if err != nil {
+		return models.ImageHashes{}, err
+	}
+	id := cid.NewCidV0(mh).String()
+	return models.ImageHashes{Tiny: id, Small: id, Medium: id, Large: id, Original: id, Filename: img.filename}, nil
+}
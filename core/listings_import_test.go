@@ -0,0 +1,215 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/models/factory"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/ipfs/go-cid"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOpenBazaarNode_ImportExportListings(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	hashes, err := node.SetProductImage(jpgImageB64, "shirt.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listing := factory.NewPhysicalListing("ron-swanson-shirt")
+	listing.Item.Images = []*pb.Listing_Item_Image{
+		{
+			Filename: hashes.Filename,
+			Original: hashes.Original,
+			Large:    hashes.Large,
+			Medium:   hashes.Medium,
+			Small:    hashes.Small,
+			Tiny:     hashes.Tiny,
+		},
+	}
+	if err := node.SaveListing(listing, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []models.ListingFileFormat{models.ListingFileFormatCSV, models.ListingFileFormatJSON} {
+		var buf bytes.Buffer
+		if err := node.ExportListings(&buf, format); err != nil {
+			t.Fatalf("%s: %s", format, err)
+		}
+		exported := buf.Bytes()
+
+		if err := node.DeleteListing("ron-swanson-shirt", nil); err != nil {
+			t.Fatalf("%s: %s", format, err)
+		}
+
+		// A dry run should not save the listing.
+		result, err := node.ImportListings(bytes.NewReader(exported), format, true, nil)
+		if err != nil {
+			t.Fatalf("%s: %s", format, err)
+		}
+		if !result.DryRun || len(result.Imported) != 1 || len(result.Errors) != 0 {
+			t.Errorf("%s: incorrect dry run result %v", format, result)
+		}
+		if _, err := node.GetMyListingBySlug("ron-swanson-shirt"); err == nil {
+			t.Errorf("%s: expected dry run to not save the listing", format)
+		}
+
+		done := make(chan struct{})
+		result, err = node.ImportListings(bytes.NewReader(exported), format, false, done)
+		if err != nil {
+			t.Fatalf("%s: %s", format, err)
+		}
+		select {
+		case <-done:
+		case <-time.After(time.Second * 10):
+			t.Fatalf("%s: timeout waiting on channel", format)
+		}
+		if result.DryRun || len(result.Imported) != 1 || result.Imported[0] != "ron-swanson-shirt" {
+			t.Errorf("%s: incorrect import result %v", format, result)
+		}
+
+		sl, err := node.GetMyListingBySlug("ron-swanson-shirt")
+		if err != nil {
+			t.Fatalf("%s: %s", format, err)
+		}
+		if sl.Listing.Item.Title != listing.Item.Title {
+			t.Errorf("%s: expected title %s, got %s", format, listing.Item.Title, sl.Listing.Item.Title)
+		}
+		if len(sl.Listing.Item.Skus) != len(listing.Item.Skus) {
+			t.Errorf("%s: expected %d skus, got %d", format, len(listing.Item.Skus), len(sl.Listing.Item.Skus))
+		}
+		if len(sl.Listing.Item.Images) != 1 || sl.Listing.Item.Images[0].Filename != hashes.Filename || sl.Listing.Item.Images[0].Small == "" {
+			t.Errorf("%s: incorrect images %v", format, sl.Listing.Item.Images)
+		}
+
+		index, err := node.GetMyListings()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(index) != 1 {
+			t.Errorf("%s: expected 1 listing in the index, got %d", format, len(index))
+		}
+	}
+}
+
+func TestOpenBazaarNode_ImportListingsErrors(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	img, err := base64.StdEncoding.DecodeString(jpgImageB64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	importDir := filepath.Join(node.repo.DataDir(), importDirName)
+	if err := os.MkdirAll(importDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(importDir, "shirt.jpg"), img, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	// Local images outside of the import directory can't be loaded.
+	dir, err := ioutil.TempDir("", "import")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	outsidePath := filepath.Join(dir, "shirt.jpg")
+	if err := ioutil.WriteFile(outsidePath, img, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	records := [][]string{
+		{"title", "price", "pricingCurrency", "acceptedCurrencies", "contractType", "format", "condition", "expiry", "images", "shippingOptions"},
+		{"Ron Swanson Shirt", "100", "USD", "MCK", "PHYSICAL_GOOD", "FIXED_PRICE", "new", "2037-01-01T00:00:00Z", "shirt.jpg", "Domestic|FIXED_PRICE|UNITED_STATES|Standard:100:0:5 days"},
+		{"Ron Swanson Shirt", "100", "USD", "MCK", "PHYSICAL_GOOD", "FIXED_PRICE", "new", "2037-01-01T00:00:00Z", "shirt.jpg", "Domestic|FIXED_PRICE|UNITED_STATES|Standard:100:0:5 days"},
+		{"Bad Contract Type", "100", "USD", "MCK", "NOT_A_TYPE", "FIXED_PRICE", "new", "2037-01-01T00:00:00Z", "shirt.jpg", ""},
+		{"No Shipping", "100", "USD", "MCK", "PHYSICAL_GOOD", "FIXED_PRICE", "new", "2037-01-01T00:00:00Z", "shirt.jpg", ""},
+		{"Outside Image", "100", "USD", "MCK", "PHYSICAL_GOOD", "FIXED_PRICE", "new", "2037-01-01T00:00:00Z", outsidePath, "Domestic|FIXED_PRICE|UNITED_STATES|Standard:100:0:5 days"},
+	}
+	if err := w.WriteAll(records); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := node.ImportListings(bytes.NewReader(buf.Bytes()), models.ListingFileFormatCSV, true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Imported) != 2 || len(result.Errors) != 3 {
+		t.Errorf("Incorrect dry run result %v", result)
+	}
+	keys, err := node.ipfsNode.Blockstore.AllKeysChan(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dryRunBlocks := make(map[string]bool)
+	for key := range keys {
+		dryRunBlocks[key.Hash().B58String()] = true
+	}
+
+	result, err = node.ImportListings(bytes.NewReader(buf.Bytes()), models.ListingFileFormatCSV, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Generated slugs which collide within the file get a suffix.
+	if len(result.Imported) != 2 || result.Imported[0] == result.Imported[1] {
+		t.Errorf("Incorrect imported listings %v", result.Imported)
+	}
+	if len(result.Errors) != 3 {
+		t.Fatalf("Expected 3 errors, got %v", result.Errors)
+	}
+	if result.Errors[0].Row != 4 || result.Errors[1].Row != 5 || result.Errors[2].Row != 6 {
+		t.Errorf("Incorrect error rows %d, %d and %d", result.Errors[0].Row, result.Errors[1].Row, result.Errors[2].Row)
+	}
+
+	index, err := node.GetMyListings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index) != 2 {
+		t.Errorf("Expected 2 listings in the index, got %d", len(index))
+	}
+
+	// The dry run should not have added the images to IPFS.
+	sl, err := node.GetMyListingBySlug(result.Imported[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, img := range sl.Listing.Item.Images {
+		id, err := cid.Decode(img.Original)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dryRunBlocks[id.Hash().B58String()] {
+			t.Errorf("Expected dry run to not add image %s", img.Filename)
+		}
+	}
+
+	// A file which can't be read at all is an error.
+	_, err = node.ImportListings(bytes.NewReader([]byte("slug,price\n")), models.ListingFileFormatCSV, false, nil)
+	if err == nil {
+		t.Error("Expected error for missing title column")
+	}
+	_, err = node.ImportListings(bytes.NewReader([]byte("{")), models.ListingFileFormatJSON, false, nil)
+	if err == nil {
+		t.Error("Expected error for invalid json")
+	}
+}
@@ -2025,3 +2025,298 @@ func Test_validateListing(t *testing.T) {
 		}
 	}
 }
+
+func TestOpenBazaarNode_SaveListingCouponLimits(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	tests := []struct {
+		name      string
+		transform func(coupon *pb.Listing_Coupon)
+		valid     bool
+	}{
+		{
+			name: "Valid limits",
+			transform: func(coupon *pb.Listing_Coupon) {
+				coupon.Expiry = ptypes.TimestampNow()
+				coupon.MaxRedemptions = 10
+				coupon.MaxRedemptionsPerBuyer = 1
+				coupon.MinimumOrderValue = "500"
+				coupon.Skus = []string{"1"}
+			},
+			valid: true,
+		},
+		{
+			name: "Max redemptions per buyer greater than max redemptions",
+			transform: func(coupon *pb.Listing_Coupon) {
+				coupon.MaxRedemptions = 1
+				coupon.MaxRedemptionsPerBuyer = 2
+			},
+			valid: false,
+		},
+		{
+			name: "Invalid minimum order value",
+			transform: func(coupon *pb.Listing_Coupon) {
+				coupon.MinimumOrderValue = "abc"
+			},
+			valid: false,
+		},
+		{
+			name: "Sku not in listing",
+			transform: func(coupon *pb.Listing_Coupon) {
+				coupon.Skus = []string{"3"}
+			},
+			valid: false,
+		},
+	}
+
+	for _, test := range tests {
+		listing := factory.NewPhysicalListing("ron-swanson-shirt")
+		test.transform(listing.Coupons[0])
+		err := node.SaveListing(listing, nil)
+		if test.valid && err != nil {
+			t.Errorf("%s: failed when it should not have: %s", test.name, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: did not fail when it should have", test.name)
+		}
+	}
+}
+
+func TestOpenBazaarNode_SaveListingPriceTiers(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	percentTier := func(minQuantity uint64, percent float32) *pb.Listing_Item_PriceTier {
+		return &pb.Listing_Item_PriceTier{
+			MinQuantity: minQuantity,
+			Discount:    &pb.Listing_Item_PriceTier_PercentDiscount{PercentDiscount: percent},
+		}
+	}
+	priceTier := func(minQuantity uint64, price string) *pb.Listing_Item_PriceTier {
+		return &pb.Listing_Item_PriceTier{
+			MinQuantity: minQuantity,
+			Discount:    &pb.Listing_Item_PriceTier_Price{Price: price},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		transform func(listing *pb.Listing)
+		valid     bool
+	}{
+		{
+			name: "Valid tiers",
+			transform: func(listing *pb.Listing) {
+				listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{
+					percentTier(10, 5),
+					priceTier(100, "80"),
+				}
+				listing.Item.Skus[0].PriceTiers = []*pb.Listing_Item_PriceTier{
+					priceTier(5, "90"),
+				}
+			},
+			valid: true,
+		},
+		{
+			name: "Minimum quantity of one",
+			transform: func(listing *pb.Listing) {
+				listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{percentTier(1, 5)}
+			},
+			valid: false,
+		},
+		{
+			name: "Tiers out of order",
+			transform: func(listing *pb.Listing) {
+				listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{
+					percentTier(100, 10),
+					percentTier(10, 5),
+				}
+			},
+			valid: false,
+		},
+		{
+			name: "Duplicate minimum quantity",
+			transform: func(listing *pb.Listing) {
+				listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{
+					percentTier(10, 5),
+					priceTier(10, "80"),
+				}
+			},
+			valid: false,
+		},
+		{
+			name: "Percent discount over 100",
+			transform: func(listing *pb.Listing) {
+				listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{percentTier(10, 101)}
+			},
+			valid: false,
+		},
+		{
+			name: "Invalid price",
+			transform: func(listing *pb.Listing) {
+				listing.Item.Skus[0].PriceTiers = []*pb.Listing_Item_PriceTier{priceTier(10, "abc")}
+			},
+			valid: false,
+		},
+		{
+			name: "Missing discount",
+			transform: func(listing *pb.Listing) {
+				listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{{MinQuantity: 10}}
+			},
+			valid: false,
+		},
+	}
+
+	for _, test := range tests {
+		listing := factory.NewPhysicalListing("ron-swanson-shirt")
+		test.transform(listing)
+		err := node.SaveListing(listing, nil)
+		if test.valid && err != nil {
+			t.Errorf("%s: failed when it should not have: %s", test.name, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: did not fail when it should have", test.name)
+		}
+	}
+}
+
+func TestOpenBazaarNode_SaveListingShippingRates(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	tests := []struct {
+		name      string
+		transform func(service *pb.Listing_ShippingOption_Service)
+		valid     bool
+	}{
+		{
+			name: "Valid rates",
+			transform: func(service *pb.Listing_ShippingOption_Service) {
+				service.FreeShippingThreshold = "500"
+				service.WeightBands = []*pb.Listing_ShippingOption_Service_WeightBand{
+					{MaxGrams: 500, Price: "5"},
+					{MaxGrams: 2000, Price: "10"},
+				}
+			},
+			valid: true,
+		},
+		{
+			name: "Invalid free shipping threshold",
+			transform: func(service *pb.Listing_ShippingOption_Service) {
+				service.FreeShippingThreshold = "-1"
+			},
+			valid: false,
+		},
+		{
+			name: "Weight bands out of order",
+			transform: func(service *pb.Listing_ShippingOption_Service) {
+				service.WeightBands = []*pb.Listing_ShippingOption_Service_WeightBand{
+					{MaxGrams: 2000, Price: "10"},
+					{MaxGrams: 500, Price: "5"},
+				}
+			},
+			valid: false,
+		},
+		{
+			name: "Weight band missing max weight",
+			transform: func(service *pb.Listing_ShippingOption_Service) {
+				service.WeightBands = []*pb.Listing_ShippingOption_Service_WeightBand{
+					{Price: "5"},
+				}
+			},
+			valid: false,
+		},
+		{
+			name: "Invalid weight band price",
+			transform: func(service *pb.Listing_ShippingOption_Service) {
+				service.WeightBands = []*pb.Listing_ShippingOption_Service_WeightBand{
+					{MaxGrams: 500, Price: "abc"},
+				}
+			},
+			valid: false,
+		},
+	}
+
+	for _, test := range tests {
+		listing := factory.NewPhysicalListing("ron-swanson-shirt")
+		test.transform(listing.ShippingOptions[0].Services[0])
+		err := node.SaveListing(listing, nil)
+		if test.valid && err != nil {
+			t.Errorf("%s: failed when it should not have: %s", test.name, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: did not fail when it should have", test.name)
+		}
+	}
+}
+
+func TestOpenBazaarNode_SaveListingTaxRegions(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	tests := []struct {
+		name  string
+		taxes []*pb.Listing_Tax
+		valid bool
+	}{
+		{
+			name: "Separate regions",
+			taxes: []*pb.Listing_Tax{
+				{TaxType: "Sales tax", Percentage: 7, TaxRegions: []pb.CountryCode{pb.CountryCode_UNITED_STATES}},
+				{TaxType: "GST", Percentage: 5, TaxRegions: []pb.CountryCode{pb.CountryCode_CANADA}},
+			},
+			valid: true,
+		},
+		{
+			name: "All regions",
+			taxes: []*pb.Listing_Tax{
+				{TaxType: "VAT", Percentage: 20, TaxRegions: []pb.CountryCode{pb.CountryCode_ALL}},
+			},
+			valid: true,
+		},
+		{
+			name: "Overlapping regions",
+			taxes: []*pb.Listing_Tax{
+				{TaxType: "Sales tax", Percentage: 7, TaxRegions: []pb.CountryCode{pb.CountryCode_UNITED_STATES}},
+				{TaxType: "Import tax", Percentage: 5, TaxRegions: []pb.CountryCode{pb.CountryCode_CANADA, pb.CountryCode_UNITED_STATES}},
+			},
+			valid: false,
+		},
+		{
+			name: "All regions with another region",
+			taxes: []*pb.Listing_Tax{
+				{TaxType: "VAT", Percentage: 20, TaxRegions: []pb.CountryCode{pb.CountryCode_ALL}},
+				{TaxType: "GST", Percentage: 5, TaxRegions: []pb.CountryCode{pb.CountryCode_CANADA}},
+			},
+			valid: false,
+		},
+		{
+			name: "Missing region",
+			taxes: []*pb.Listing_Tax{
+				{TaxType: "VAT", Percentage: 20, TaxRegions: []pb.CountryCode{pb.CountryCode_NA}},
+			},
+			valid: false,
+		},
+	}
+
+	for _, test := range tests {
+		listing := factory.NewPhysicalListing("ron-swanson-shirt")
+		listing.Taxes = test.taxes
+		err := node.SaveListing(listing, nil)
+		if test.valid && err != nil {
+			t.Errorf("%s: failed when it should not have: %s", test.name, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: did not fail when it should have", test.name)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	iwallet "github.com/cpacia/wallet-interface"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jinzhu/gorm"
+)
+
+// RequestOrderBalance sends the buyer a chat message for the order requesting
+// payment of the amount still owed. Only a vendor can call this method and only
+// if the order has not been paid in full.
+func (n *OpenBazaarNode) RequestOrderBalance(orderID models.OrderID, done chan<- struct{}) error {
+	var order models.Order
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("id = ?", orderID.String()).First(&order).Error
+	})
+	if gorm.IsRecordNotFoundError(err) {
+		return fmt.Errorf("%w: order not found", coreiface.ErrNotFound)
+	} else if err != nil {
+		return err
+	}
+
+	if order.Role() != models.RoleVendor {
+		return fmt.Errorf("%w: only the vendor can request the order balance", coreiface.ErrBadRequest)
+	}
+
+	switch order.State() {
+	case models.OrderStateDeclined, models.OrderStateCanceled, models.OrderStateRefunded, models.OrderStateCompleted:
+		return fmt.Errorf("%w: order is closed", coreiface.ErrBadRequest)
+	}
+
+	owed, err := order.AmountOwed()
+	if err != nil {
+		return err
+	}
+	if owed.Cmp(iwallet.NewAmount(0)) <= 0 {
+		return fmt.Errorf("%w: order is not underpaid", coreiface.ErrBadRequest)
+	}
+
+	orderOpen, err := order.OrderOpenMessage()
+	if err != nil {
+		return err
+	}
+	buyer, err := order.Buyer()
+	if err != nil {
+		return err
+	}
+
+	chatMsg := &npb.ChatMessage{
+		Message:   fmt.Sprintf("Payment request: %s %s remaining to complete payment for this order.", owed.String(), orderOpen.Payment.Coin),
+		OrderID:   order.ID.String(),
+		Timestamp: ptypes.TimestampNow(),
+		Flag:      npb.ChatMessage_MESSAGE,
+		PaymentRequest: &npb.ChatMessage_PaymentRequest{
+			Coin:    orderOpen.Payment.Coin,
+			Address: orderOpen.Payment.Address,
+			Amount:  owed.String(),
+		},
+	}
+	return n.sendChatMessage(buyer, chatMsg, done)
+}
+
+// RefundOverpayment sends a REFUND message to the buyer refunding only the amount
+// paid in excess of the order total. Unlike RefundOrder this does not end the
+// order. Only a vendor can call this method and only for direct payments as the
+// funds for other payment methods are not controlled by the vendor alone.
+// Moderated and cancelable orders return ErrBadRequest.
+func (n *OpenBazaarNode) RefundOverpayment(orderID models.OrderID, done chan struct{}) error {
+	var order models.Order
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("id = ?", orderID.String()).First(&order).Error
+	})
+	if gorm.IsRecordNotFoundError(err) {
+		return fmt.Errorf("%w: order not found", coreiface.ErrNotFound)
+	} else if err != nil {
+		return err
+	}
+
+	if order.Role() != models.RoleVendor {
+		return fmt.Errorf("%w: only the vendor can refund an overpayment", coreiface.ErrBadRequest)
+	}
+
+	orderOpen, err := order.OrderOpenMessage()
+	if err != nil {
+		return err
+	}
+	switch orderOpen.Payment.Method {
+	case pb.OrderOpen_Payment_MODERATED:
+		return fmt.Errorf("%w: overpayments to moderated orders are held in escrow and can only be returned by refunding the order", coreiface.ErrBadRequest)
+	case pb.OrderOpen_Payment_CANCELABLE:
+		return fmt.Errorf("%w: overpayment refunds are only supported for direct payments", coreiface.ErrBadRequest)
+	}
+
+	overpayment, err := order.Overpayment()
+	if err != nil {
+		return err
+	}
+	if overpayment.Cmp(iwallet.NewAmount(0)) <= 0 {
+		return fmt.Errorf("%w: order has not been overpaid", coreiface.ErrBadRequest)
+	}
+
+	buyer, err := order.Buyer()
+	if err != nil {
+		return err
+	}
+	vendor, err := order.Vendor()
+	if err != nil {
+		return err
+	}
+
+	return n.repo.DB().Update(func(tx database.Tx) error {
+		wallet, err := n.multiwallet.WalletForCurrencyCode(orderOpen.Payment.Coin)
+		if err != nil {
+			return err
+		}
+
+		wTx, err := wallet.Begin()
+		if err != nil {
+			return err
+		}
+
+		refundAddress := iwallet.NewAddress(orderOpen.RefundAddress, iwallet.CoinType(orderOpen.Payment.Coin))
+		txid, err := wallet.Spend(wTx, refundAddress, overpayment, iwallet.FlNormal)
+		if err != nil {
+			wTx.Rollback()
+			return err
+		}
+
+		refundAny, err := ptypes.MarshalAny(&pb.Refund{
+			RefundInfo:  &pb.Refund_TransactionID{TransactionID: txid.String()},
+			Amount:      overpayment.String(),
+			Overpayment: true,
+		})
+		if err != nil {
+			wTx.Rollback()
+			return err
+		}
+
+		refundMsg := &npb.OrderMessage{
+			OrderID:     order.ID.String(),
+			MessageType: npb.OrderMessage_REFUND,
+			Message:     refundAny,
+		}
+
+		if err := utils.SignOrderMessage(refundMsg, n.ipfsNode.PrivateKey); err != nil {
+			wTx.Rollback()
+			return err
+		}
+
+		payload, err := ptypes.MarshalAny(refundMsg)
+		if err != nil {
+			wTx.Rollback()
+			return err
+		}
+
+		message := newMessageWithID()
+		message.MessageType = npb.Message_ORDER
+		message.Payload = payload
+
+		if _, err := n.orderProcessor.ProcessMessage(tx, vendor, refundMsg); err != nil {
+			wTx.Rollback()
+			return err
+		}
+		if err := n.messenger.ReliablySendMessage(tx, buyer, message, done); err != nil {
+			wTx.Rollback()
+			return err
+		}
+
+		return wTx.Commit()
+	})
+}
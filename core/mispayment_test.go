@@ -0,0 +1,91 @@
+package core
+
+import (
+	"errors"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/models/factory"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	iwallet "github.com/cpacia/wallet-interface"
+	"testing"
+)
+
+func TestOpenBazaarNode_RefundOverpayment(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	tests := []struct {
+		name   string
+		role   models.OrderRole
+		method pb.OrderOpen_Payment_Method
+		paid   int
+	}{
+		{
+			name:   "Buyer",
+			role:   models.RoleBuyer,
+			method: pb.OrderOpen_Payment_DIRECT,
+			paid:   2000,
+		},
+		{
+			name:   "Moderated",
+			role:   models.RoleVendor,
+			method: pb.OrderOpen_Payment_MODERATED,
+			paid:   2000,
+		},
+		{
+			name:   "Cancelable",
+			role:   models.RoleVendor,
+			method: pb.OrderOpen_Payment_CANCELABLE,
+			paid:   2000,
+		},
+		{
+			name:   "Not overpaid",
+			role:   models.RoleVendor,
+			method: pb.OrderOpen_Payment_DIRECT,
+			paid:   1000,
+		},
+	}
+
+	for _, test := range tests {
+		orderOpen, err := factory.NewOrder()
+		if err != nil {
+			t.Fatal(err)
+		}
+		orderOpen.Payment.Method = test.method
+		orderOpen.Payment.Amount = "1000"
+
+		order := &models.Order{ID: models.OrderID(test.name)}
+		order.SetRole(test.role)
+		if err := order.PutMessage(utils.MustWrapOrderMessage(orderOpen)); err != nil {
+			t.Fatal(err)
+		}
+		err = order.PutTransaction(iwallet.Transaction{
+			ID: iwallet.TransactionID(test.name),
+			To: []iwallet.SpendInfo{
+				{
+					Address: iwallet.NewAddress(orderOpen.Payment.Address, iwallet.CtMock),
+					Amount:  iwallet.NewAmount(test.paid),
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = node.repo.DB().Update(func(tx database.Tx) error {
+			return tx.Save(order)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = node.RefundOverpayment(order.ID, nil)
+		if !errors.Is(err, coreiface.ErrBadRequest) {
+			t.Errorf("%s: expected ErrBadRequest, got %v", test.name, err)
+		}
+	}
+}
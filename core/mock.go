@@ -228,6 +228,20 @@ func NewMocknet(numNodes int) (*Mocknet, error) {
 		escrowKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), dbEscrowKey.Value)
 		ratingKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), dbRatingKey.Value)
 
+		// The tests step through the order flow by hand so the vendors
+		// should not confirm orders on their own.
+		err = r.DB().Update(func(tx database.Tx) error {
+			var prefs models.UserPreferences
+			if err := tx.Read().First(&prefs).Error; err != nil {
+				return err
+			}
+			prefs.AutoConfirm = false
+			return tx.Save(&prefs)
+		})
+		if err != nil {
+			return nil, err
+		}
+
 		bus := events.NewBus()
 		tracker := NewFollowerTracker(r, bus, ipfsNode.PeerHost)
 
@@ -41,6 +41,12 @@ const (
 // This cannot be called with the database lock held.
 func (n *OpenBazaarNode) Publish(done chan<- struct{}) {
 	go func() {
+		// Everything which changes our listings publishes so this
+		// is where we keep our own listings in the search index. They
+		// are only re-indexed if they have changed.
+		if err := n.indexMyListings(); err != nil {
+			log.Errorf("Error indexing our listings: %s", err)
+		}
 		<-n.initialBootstrapChan
 		n.publishChan <- pubCloser{done}
 	}()
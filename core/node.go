@@ -80,6 +80,14 @@ type OpenBazaarNode struct {
 	// and sends them off to the websocket.
 	notifier *notifications.Notifier
 
+	// webhooks delivers the events from the notifier to the configured
+	// webhooks. It is nil if there are no webhooks.
+	webhooks *notifications.WebhookDispatcher
+
+	// emailNotifier emails the events from the notifier to the user. It
+	// is nil if no SMTP server is configured.
+	emailNotifier *notifications.EmailNotifier
+
 	// gateway is the openbazaar API.
 	gateway *api.Gateway
 
@@ -110,6 +118,17 @@ type OpenBazaarNode struct {
 	// shutdownTorFunc is used to shutdown the embedded Tor client.
 	shutdownTorFunc func() error
 
+	// crawlBudget is the maximum number of stores the network crawler
+	// fetches in a single crawl. The crawler is disabled if it is zero.
+	crawlBudget int
+
+	// crawlInterval is how often the network crawler runs.
+	crawlInterval time.Duration
+
+	// crawlMaxAge is how long a store fetched by the crawler is considered
+	// fresh. Fresh stores are not fetched again.
+	crawlMaxAge time.Duration
+
 	// initialBootstrapChan is closed after the initial IPFS bootstrap completes.
 	initialBootstrapChan chan struct{}
 
@@ -130,6 +149,17 @@ func (n *OpenBazaarNode) Start() {
 		go n.multiwallet.Start()
 		go n.gateway.Serve()
 		go n.notifier.Start()
+		if n.webhooks != nil {
+			go n.webhooks.Start()
+		}
+		if n.emailNotifier != nil {
+			go n.emailNotifier.Start()
+		}
+		go n.listingExpiryHandler()
+		go n.searchIndexHandler()
+		if n.crawlBudget > 0 {
+			go n.crawlerHandler()
+		}
 		if err := n.removeDisabledCoinsFromListings(); err != nil && !os.IsNotExist(err) {
 			log.Errorf("Error removing disabled coins from listings: %s", err)
 		}
@@ -158,6 +188,12 @@ func (n *OpenBazaarNode) Stop(force bool) error {
 		if n.notifier != nil {
 			n.notifier.Stop()
 		}
+		if n.webhooks != nil {
+			n.webhooks.Stop()
+		}
+		if n.emailNotifier != nil {
+			n.emailNotifier.Stop()
+		}
 	}
 	if n.shutdownTorFunc != nil {
 		n.shutdownTorFunc()
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/jinzhu/gorm"
+)
+
+// GetNotifications returns a page of the saved notifications matching the
+// query, newest first.
+func (n *OpenBazaarNode) GetNotifications(query *models.NotificationQuery) (*models.NotificationList, error) {
+	if query == nil {
+		query = &models.NotificationQuery{}
+	}
+	filter := func(db *gorm.DB) *gorm.DB {
+		db = db.Model(&models.NotificationRecord{})
+		if len(query.Types) > 0 {
+			db = db.Where("type IN (?)", query.Types)
+		}
+		if query.UnreadOnly {
+			db = db.Where("read = ?", false)
+		}
+		return db
+	}
+
+	var (
+		list = &models.NotificationList{
+			Notifications: []models.NotificationRecord{},
+		}
+		records []models.NotificationRecord
+	)
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		if err := filter(tx.Read()).Count(&list.Total).Error; err != nil {
+			return err
+		}
+
+		db, err := newestFirstPage(tx, filter(tx.Read()), &models.NotificationRecord{}, "notification", query.OffsetID, query.Limit)
+		if err != nil {
+			return err
+		}
+		return db.Find(&records).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Limit > 0 && len(records) > query.Limit {
+		records = records[:query.Limit]
+		list.NextOffsetID = records[len(records)-1].ID
+	}
+	list.Notifications = append(list.Notifications, records...)
+	return list, nil
+}
+
+// GetNotificationsAfter returns the notifications saved after the
+// notification with the given ID, oldest first.
+func (n *OpenBazaarNode) GetNotificationsAfter(notificationID string) ([]models.NotificationRecord, error) {
+	records := []models.NotificationRecord{}
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		var after models.NotificationRecord
+		err := tx.Read().Where("id = ?", notificationID).First(&after).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return fmt.Errorf("%w: notification not found", coreiface.ErrNotFound)
+		} else if err != nil {
+			return err
+		}
+		return tx.Read().Where("timestamp > ? OR (timestamp = ? AND id > ?)", after.Timestamp, after.Timestamp, after.ID).
+			Order("timestamp asc, id asc").Find(&records).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// GetUnreadNotificationCounts returns the number of unread notifications
+// of each type.
+func (n *OpenBazaarNode) GetUnreadNotificationCounts() (map[string]int, error) {
+	counts := make(map[string]int)
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		rows, err := tx.Read().Model(&models.NotificationRecord{}).Where("read = ?", false).
+			Select("type, count(*)").Group("type").Rows()
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var (
+				typ   string
+				count int
+			)
+			if err := rows.Scan(&typ, &count); err != nil {
+				return err
+			}
+			counts[typ] = count
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// MarkNotificationAsRead marks the notification with the given ID as read.
+func (n *OpenBazaarNode) MarkNotificationAsRead(notificationID string) error {
+	return n.repo.DB().Update(func(tx database.Tx) error {
+		if err := notificationExists(tx, notificationID); err != nil {
+			return err
+		}
+		return tx.Update("read", true, map[string]interface{}{"id = ?": notificationID}, &models.NotificationRecord{})
+	})
+}
+
+// MarkAllNotificationsAsRead marks all notifications as read. If any types
+// are provided only notifications of those types are marked.
+func (n *OpenBazaarNode) MarkAllNotificationsAsRead(types ...string) error {
+	where := map[string]interface{}{"read = ?": false}
+	if len(types) > 0 {
+		where["type IN (?)"] = types
+	}
+	return n.repo.DB().Update(func(tx database.Tx) error {
+		return tx.Update("read", true, where, &models.NotificationRecord{})
+	})
+}
+
+// DeleteNotification deletes the notification with the given ID.
+func (n *OpenBazaarNode) DeleteNotification(notificationID string) error {
+	return n.repo.DB().Update(func(tx database.Tx) error {
+		if err := notificationExists(tx, notificationID); err != nil {
+			return err
+		}
+		return tx.Delete("id", notificationID, nil, &models.NotificationRecord{})
+	})
+}
+
+// notificationExists returns ErrNotFound if there is no notification with
+// the given ID.
+func notificationExists(tx database.Tx, notificationID string) error {
+	var count int
+	if err := tx.Read().Model(&models.NotificationRecord{}).Where("id = ?", notificationID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: notification not found", coreiface.ErrNotFound)
+	}
+	return nil
+}
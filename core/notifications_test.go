@@ -0,0 +1,188 @@
+package core
+
+import (
+	"errors"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"testing"
+	"time"
+)
+
+func TestOpenBazaarNode_Notifications(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	var (
+		now     = time.Now()
+		records = []models.NotificationRecord{
+			{ID: "a", Type: "NewOrder", Timestamp: now.Add(-time.Minute * 4)},
+			{ID: "b", Type: "Follow", Timestamp: now.Add(-time.Minute * 3)},
+			{ID: "c", Type: "NewOrder", Timestamp: now.Add(-time.Minute * 2), Read: true},
+			{ID: "d", Type: "Follow", Timestamp: now.Add(-time.Minute)},
+			{ID: "e", Type: "Refund", Timestamp: now},
+		}
+	)
+	err = node.repo.DB().Update(func(tx database.Tx) error {
+		for _, record := range records {
+			record.Notification = []byte(`{}`)
+			if err := tx.Save(&record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := func(list *models.NotificationList) []string {
+		var ids []string
+		for _, n := range list.Notifications {
+			ids = append(ids, n.ID)
+		}
+		return ids
+	}
+	equal := func(a, b []string) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	tests := []struct {
+		name         string
+		query        *models.NotificationQuery
+		expected     []string
+		total        int
+		nextOffsetID string
+	}{
+		{
+			name:     "All notifications",
+			query:    &models.NotificationQuery{},
+			expected: []string{"e", "d", "c", "b", "a"},
+			total:    5,
+		},
+		{
+			name:     "By type",
+			query:    &models.NotificationQuery{Types: []string{"NewOrder", "Refund"}},
+			expected: []string{"e", "c", "a"},
+			total:    3,
+		},
+		{
+			name:     "Unread only",
+			query:    &models.NotificationQuery{Types: []string{"NewOrder"}, UnreadOnly: true},
+			expected: []string{"a"},
+			total:    1,
+		},
+		{
+			name:         "First page",
+			query:        &models.NotificationQuery{Limit: 2},
+			expected:     []string{"e", "d"},
+			total:        5,
+			nextOffsetID: "d",
+		},
+		{
+			name:         "Second page",
+			query:        &models.NotificationQuery{Limit: 2, OffsetID: "d"},
+			expected:     []string{"c", "b"},
+			total:        5,
+			nextOffsetID: "b",
+		},
+		{
+			name:     "Last page",
+			query:    &models.NotificationQuery{Limit: 2, OffsetID: "b"},
+			expected: []string{"a"},
+			total:    5,
+		},
+	}
+	for _, test := range tests {
+		list, err := node.GetNotifications(test.query)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if !equal(ids(list), test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, ids(list))
+		}
+		if list.Total != test.total {
+			t.Errorf("%s: expected total %d, got %d", test.name, test.total, list.Total)
+		}
+		if list.NextOffsetID != test.nextOffsetID {
+			t.Errorf("%s: expected next offset %s, got %s", test.name, test.nextOffsetID, list.NextOffsetID)
+		}
+	}
+
+	if _, err := node.GetNotifications(&models.NotificationQuery{OffsetID: "z"}); !errors.Is(err, coreiface.ErrBadRequest) {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+
+	after, err := node.GetNotificationsAfter("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	afterIDs := ids(&models.NotificationList{Notifications: after})
+	if !equal(afterIDs, []string{"c", "d", "e"}) {
+		t.Errorf("Expected notifications after b to be [c d e], got %v", afterIDs)
+	}
+	if _, err := node.GetNotificationsAfter("z"); !errors.Is(err, coreiface.ErrNotFound) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+
+	counts, err := node.GetUnreadNotificationCounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts["NewOrder"] != 1 || counts["Follow"] != 2 || counts["Refund"] != 1 {
+		t.Errorf("Incorrect unread counts: %v", counts)
+	}
+
+	if err := node.MarkNotificationAsRead("a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := node.MarkNotificationAsRead("z"); !errors.Is(err, coreiface.ErrNotFound) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if err := node.MarkAllNotificationsAsRead("Follow"); err != nil {
+		t.Fatal(err)
+	}
+	counts, err = node.GetUnreadNotificationCounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 1 || counts["Refund"] != 1 {
+		t.Errorf("Incorrect unread counts after marking read: %v", counts)
+	}
+	if err := node.MarkAllNotificationsAsRead(); err != nil {
+		t.Fatal(err)
+	}
+	counts, err = node.GetUnreadNotificationCounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 0 {
+		t.Errorf("Expected no unread notifications, got %v", counts)
+	}
+
+	if err := node.DeleteNotification("a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := node.DeleteNotification("a"); !errors.Is(err, coreiface.ErrNotFound) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	list, err := node.GetNotifications(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Total != 4 {
+		t.Errorf("Expected 4 notifications after delete, got %d", list.Total)
+	}
+}
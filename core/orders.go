@@ -0,0 +1,157 @@
+package core
+
+import (
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/jinzhu/gorm"
+	"strings"
+)
+
+// GetOrder loads and returns the order with the given ID from the database.
+func (n *OpenBazaarNode) GetOrder(orderID models.OrderID) (*models.Order, error) {
+	var order models.Order
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("id = ?", orderID.String()).First(&order).Error
+	})
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("%w: order not found", coreiface.ErrNotFound)
+	} else if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// GetOrderMessages returns the processed, parked and errored messages for the
+// order with the given ID.
+func (n *OpenBazaarNode) GetOrderMessages(orderID models.OrderID) (*models.OrderMessages, error) {
+	order, err := n.GetOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	return order.Messages()
+}
+
+// ReprocessOrderMessages runs the parked and errored messages for the order
+// back through the order processor.
+func (n *OpenBazaarNode) ReprocessOrderMessages(orderID models.OrderID) error {
+	err := n.repo.DB().Update(func(tx database.Tx) error {
+		return n.orderProcessor.ReprocessMessages(tx, orderID)
+	})
+	if gorm.IsRecordNotFoundError(err) {
+		return fmt.Errorf("%w: order not found", coreiface.ErrNotFound)
+	}
+	return err
+}
+
+// GetSales returns the orders where we are the vendor that match the query.
+// The counterparty in the query is the buyer.
+func (n *OpenBazaarNode) GetSales(query *models.OrderQuery) (*models.OrderList, error) {
+	return n.queryOrders(models.RoleVendor, "buyer_id", query)
+}
+
+// GetPurchases returns the orders where we are the buyer that match the query.
+// The counterparty in the query is the vendor.
+func (n *OpenBazaarNode) GetPurchases(query *models.OrderQuery) (*models.OrderList, error) {
+	return n.queryOrders(models.RoleBuyer, "vendor_id", query)
+}
+
+// queryOrders loads a page of orders for the given role from the database. The
+// filtering is done against the derived order columns so only the orders in the
+// returned page need to be deserialized.
+func (n *OpenBazaarNode) queryOrders(role models.OrderRole, counterpartyColumn string, query *models.OrderQuery) (*models.OrderList, error) {
+	if query == nil {
+		query = &models.OrderQuery{}
+	}
+	filter := func(db *gorm.DB) *gorm.DB {
+		db = db.Model(&models.Order{}).Where("my_role = ?", string(role))
+		if !query.From.IsZero() {
+			db = db.Where("opened_at >= ?", query.From.UTC())
+		}
+		if !query.To.IsZero() {
+			db = db.Where("opened_at <= ?", query.To.UTC())
+		}
+		if query.Coin != "" {
+			db = db.Where("payment_coin = ?", query.Coin)
+		}
+		if query.PeerID != "" {
+			db = db.Where(counterpartyColumn+" = ?", query.PeerID)
+		}
+		if query.Search != "" {
+			db = db.Where("search_terms LIKE ?", "%"+strings.ToLower(query.Search)+"%")
+		}
+		return db
+	}
+
+	var (
+		list = &models.OrderList{
+			Orders: []models.OrderSummary{},
+			Counts: make(map[models.OrderState]int),
+		}
+		orders []models.Order
+	)
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		rows, err := filter(tx.Read()).Select("current_state, count(*)").Group("current_state").Rows()
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var (
+				state string
+				count int
+			)
+			if err := rows.Scan(&state, &count); err != nil {
+				return err
+			}
+			list.Counts[models.OrderState(state)] = count
+		}
+
+		db := filter(tx.Read())
+		if len(query.States) > 0 {
+			db = db.Where("current_state IN (?)", query.States)
+		}
+		if err := db.Count(&list.Total).Error; err != nil {
+			return err
+		}
+
+		sort, cmp := "opened_at desc, id desc", "<"
+		if query.SortAscending {
+			sort, cmp = "opened_at asc, id asc", ">"
+		}
+		if query.OffsetID != "" {
+			var offset models.Order
+			err := tx.Read().Where("id = ?", query.OffsetID).First(&offset).Error
+			if gorm.IsRecordNotFoundError(err) {
+				return fmt.Errorf("%w: offset order not found", coreiface.ErrBadRequest)
+			} else if err != nil {
+				return err
+			}
+			db = db.Where(fmt.Sprintf("opened_at %s ? OR (opened_at = ? AND id %s ?)", cmp, cmp), offset.OpenedAt.UTC(), offset.OpenedAt.UTC(), offset.ID.String())
+		}
+		db = db.Order(sort)
+		if query.Limit > 0 {
+			// Fetch one extra so we know if there is another page.
+			db = db.Limit(query.Limit + 1)
+		}
+		return db.Find(&orders).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Limit > 0 && len(orders) > query.Limit {
+		orders = orders[:query.Limit]
+		list.NextOffsetID = orders[len(orders)-1].ID.String()
+	}
+
+	for _, order := range orders {
+		summary, err := order.Summary(n.Identity())
+		if err != nil {
+			return nil, err
+		}
+		list.Orders = append(list.Orders, *summary)
+	}
+	return list, nil
+}
@@ -0,0 +1,276 @@
+package core
+
+import (
+	"errors"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	"github.com/golang/protobuf/ptypes"
+	"testing"
+	"time"
+)
+
+func TestOpenBazaarNode_GetSalesAndPurchases(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.repo.DestroyRepo()
+
+	var (
+		start   = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		buyerA  = "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub"
+		buyerB  = "QmT5NvUtoM5nWFfrQdVrFtvGfKFmG7AHE8P34isapyhCxX"
+		vendorA = "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4"
+	)
+
+	newOrder := func(id string, role models.OrderRole, day int, buyer, vendor, coin, title, shipTo string, rejected bool) models.Order {
+		ts, err := ptypes.TimestampProto(start.Add(time.Hour * 24 * time.Duration(day)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		order := models.Order{ID: models.OrderID(id)}
+		order.SetRole(role)
+		err = order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+			Listings: []*pb.SignedListing{
+				{
+					Listing: &pb.Listing{
+						Slug:     title,
+						VendorID: &pb.ID{PeerID: vendor},
+						Item:     &pb.Listing_Item{Title: title},
+					},
+				},
+			},
+			BuyerID:   &pb.ID{PeerID: buyer},
+			Timestamp: ts,
+			Shipping:  &pb.OrderOpen_Shipping{ShipTo: shipTo},
+			Payment: &pb.OrderOpen_Payment{
+				Coin:    coin,
+				Amount:  "1000",
+				Address: "abc",
+			},
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rejected {
+			if err := order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderReject{})); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return order
+	}
+
+	orders := []models.Order{
+		newOrder("s1", models.RoleVendor, 0, buyerA, node.Identity().Pretty(), "BTC", "Red Shirt", "Alice Smith", false),
+		newOrder("s2", models.RoleVendor, 1, buyerB, node.Identity().Pretty(), "BTC", "Blue Shirt", "Bob Jones", true),
+		newOrder("s3", models.RoleVendor, 2, buyerA, node.Identity().Pretty(), "BCH", "Green Hat", "Alice Smith", false),
+		newOrder("s4", models.RoleVendor, 3, buyerB, node.Identity().Pretty(), "BTC", "Red Hat", "Bob Jones", false),
+		newOrder("s5", models.RoleVendor, 4, buyerA, node.Identity().Pretty(), "BTC", "Socks", "Carol White", false),
+		newOrder("p1", models.RoleBuyer, 0, node.Identity().Pretty(), vendorA, "BTC", "Book", "Me", false),
+		newOrder("m1", models.RoleModerator, 0, buyerA, vendorA, "BTC", "Lamp", "Dan", false),
+	}
+	err = node.repo.DB().Update(func(tx database.Tx) error {
+		for i := range orders {
+			if err := tx.Save(&orders[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := func(list *models.OrderList) []string {
+		var ret []string
+		for _, o := range list.Orders {
+			ret = append(ret, o.OrderID.String())
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name         string
+		purchases    bool
+		query        *models.OrderQuery
+		expectedIDs  []string
+		expectedNext string
+		total        int
+	}{
+		{
+			name:        "All sales",
+			query:       &models.OrderQuery{},
+			expectedIDs: []string{"s5", "s4", "s3", "s2", "s1"},
+			total:       5,
+		},
+		{
+			name:        "Nil query",
+			query:       nil,
+			expectedIDs: []string{"s5", "s4", "s3", "s2", "s1"},
+			total:       5,
+		},
+		{
+			name:        "Sort ascending",
+			query:       &models.OrderQuery{SortAscending: true},
+			expectedIDs: []string{"s1", "s2", "s3", "s4", "s5"},
+			total:       5,
+		},
+		{
+			name:        "Filter state",
+			query:       &models.OrderQuery{States: []models.OrderState{models.OrderStateDeclined}},
+			expectedIDs: []string{"s2"},
+			total:       1,
+		},
+		{
+			name:        "Filter coin",
+			query:       &models.OrderQuery{Coin: "BCH"},
+			expectedIDs: []string{"s3"},
+			total:       1,
+		},
+		{
+			name:        "Filter counterparty",
+			query:       &models.OrderQuery{PeerID: buyerB},
+			expectedIDs: []string{"s4", "s2"},
+			total:       2,
+		},
+		{
+			name:        "Search title",
+			query:       &models.OrderQuery{Search: "red"},
+			expectedIDs: []string{"s4", "s1"},
+			total:       2,
+		},
+		{
+			name:        "Search ship to",
+			query:       &models.OrderQuery{Search: "ALICE"},
+			expectedIDs: []string{"s3", "s1"},
+			total:       2,
+		},
+		{
+			name:        "Date range",
+			query:       &models.OrderQuery{From: start.Add(time.Hour * 24), To: start.Add(time.Hour * 24 * 3)},
+			expectedIDs: []string{"s4", "s3", "s2"},
+			total:       3,
+		},
+		{
+			name:         "First page",
+			query:        &models.OrderQuery{Limit: 2},
+			expectedIDs:  []string{"s5", "s4"},
+			expectedNext: "s4",
+			total:        5,
+		},
+		{
+			name:         "Second page",
+			query:        &models.OrderQuery{Limit: 2, OffsetID: "s4"},
+			expectedIDs:  []string{"s3", "s2"},
+			expectedNext: "s2",
+			total:        5,
+		},
+		{
+			name:        "Last page",
+			query:       &models.OrderQuery{Limit: 2, OffsetID: "s2"},
+			expectedIDs: []string{"s1"},
+			total:       5,
+		},
+		{
+			name:         "Ascending page",
+			query:        &models.OrderQuery{Limit: 2, OffsetID: "s2", SortAscending: true},
+			expectedIDs:  []string{"s3", "s4"},
+			expectedNext: "s4",
+			total:        5,
+		},
+		{
+			name:        "Purchases",
+			purchases:   true,
+			query:       &models.OrderQuery{PeerID: vendorA},
+			expectedIDs: []string{"p1"},
+			total:       1,
+		},
+	}
+
+	for _, test := range tests {
+		var list *models.OrderList
+		if test.purchases {
+			list, err = node.GetPurchases(test.query)
+		} else {
+			list, err = node.GetSales(test.query)
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+			continue
+		}
+		got := ids(list)
+		if len(got) != len(test.expectedIDs) {
+			t.Errorf("%s: expected orders %v, got %v", test.name, test.expectedIDs, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.expectedIDs[i] {
+				t.Errorf("%s: expected orders %v, got %v", test.name, test.expectedIDs, got)
+				break
+			}
+		}
+		if list.NextOffsetID != test.expectedNext {
+			t.Errorf("%s: expected next offset %s, got %s", test.name, test.expectedNext, list.NextOffsetID)
+		}
+		if list.Total != test.total {
+			t.Errorf("%s: expected total %d, got %d", test.name, test.total, list.Total)
+		}
+	}
+
+	// Counts ignore the state filter but respect the others.
+	list, err := node.GetSales(&models.OrderQuery{States: []models.OrderState{models.OrderStateDeclined}, Coin: "BTC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Counts[models.OrderStateDeclined] != 1 {
+		t.Errorf("Expected 1 declined order, got %d", list.Counts[models.OrderStateDeclined])
+	}
+	if list.Counts[models.OrderStateAwaitingPayment] != 3 {
+		t.Errorf("Expected 3 awaiting payment orders, got %d", list.Counts[models.OrderStateAwaitingPayment])
+	}
+
+	if list.Orders[0].Title != "Blue Shirt" || list.Orders[0].ShipTo != "Bob Jones" || list.Orders[0].State != models.OrderStateDeclined {
+		t.Errorf("Incorrect order summary returned: %v", list.Orders[0])
+	}
+
+	if _, err := node.GetSales(&models.OrderQuery{OffsetID: "abc"}); !errors.Is(err, coreiface.ErrBadRequest) {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+}
+
+func TestOpenBazaarNode_GetOrderMessages(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.repo.DestroyRepo()
+
+	if _, err := node.GetOrderMessages("1234"); !errors.Is(err, coreiface.ErrNotFound) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if err := node.ReprocessOrderMessages("1234"); !errors.Is(err, coreiface.ErrNotFound) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+
+	order := models.Order{ID: "1234"}
+	if err := order.PutErrorMessage(utils.MustWrapOrderMessage(&pb.OrderConfirmation{}), errors.New("invalid signature")); err != nil {
+		t.Fatal(err)
+	}
+	err = node.repo.DB().Update(func(tx database.Tx) error {
+		return tx.Save(&order)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages, err := node.GetOrderMessages("1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages.Errored) != 1 || messages.Errored[0].Error != "invalid signature" {
+		t.Errorf("Incorrect errored messages returned: %v", messages.Errored)
+	}
+}
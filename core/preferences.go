@@ -5,9 +5,11 @@ import (
 	"github.com/cpacia/openbazaar3.0/core/coreiface"
 	"github.com/cpacia/openbazaar3.0/database"
 	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/notifications"
 	"github.com/cpacia/openbazaar3.0/orders/pb"
 	"github.com/jinzhu/gorm"
 	peer "github.com/libp2p/go-libp2p-peer"
+	"net/mail"
 	"os"
 )
 
@@ -78,6 +80,24 @@ func (n *OpenBazaarNode) SavePreferences(prefs *models.UserPreferences, done cha
 				return fmt.Errorf("%w: no wallet for currency %s", coreiface.ErrBadRequest, cur)
 			}
 		}
+		if prefs.EmailNotifications != "" {
+			if _, err := mail.ParseAddress(prefs.EmailNotifications); err != nil {
+				return fmt.Errorf("%w: invalid email address", coreiface.ErrBadRequest)
+			}
+		}
+		emailTypes, err := prefs.EmailNotificationTypes()
+		if err != nil {
+			return fmt.Errorf("%w: invalid email notification types", coreiface.ErrBadRequest)
+		}
+		for _, typ := range emailTypes {
+			if !isEmailNotificationType(typ) {
+				return fmt.Errorf("%w: unknown email notification type %s", coreiface.ErrBadRequest, typ)
+			}
+		}
+		if _, err := prefs.AutoConfirmRules(); err != nil {
+			return fmt.Errorf("%w: invalid auto-confirm rules: %s", coreiface.ErrBadRequest, err)
+		}
+
 		prefs.ID = 1
 		if err := tx.Save(prefs); err != nil {
 			return err
@@ -107,3 +127,14 @@ func (n *OpenBazaarNode) SavePreferences(prefs *models.UserPreferences, done cha
 	}
 	return nil
 }
+
+// isEmailNotificationType returns whether the type is one which can be
+// sent as an email.
+func isEmailNotificationType(typ string) bool {
+	for _, t := range notifications.EmailNotificationTypes {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
@@ -63,6 +63,32 @@ func TestOpenBazaarNode_SavePreferences(t *testing.T) {
 		t.Errorf("Expected error got nil")
 	}
 
+	prefs = models.UserPreferences{
+		EmailNotifications: "not an email",
+	}
+
+	if err := node.SavePreferences(&prefs, nil); err == nil {
+		t.Errorf("Expected error got nil")
+	}
+
+	prefs = models.UserPreferences{
+		EmailNotifications: "store@example.com",
+		EmailTypes:         []byte(`["NewOrder","Follow"]`),
+	}
+
+	if err := node.SavePreferences(&prefs, nil); err == nil {
+		t.Errorf("Expected error got nil")
+	}
+
+	prefs = models.UserPreferences{
+		EmailNotifications: "store@example.com",
+		EmailTypes:         []byte(`["NewOrder","ChatMessage"]`),
+	}
+
+	if err := node.SavePreferences(&prefs, nil); err != nil {
+		t.Fatal(err)
+	}
+
 	mods := []string{"12D3KooWLbTBv97L6jvaLkdSRpqhCX3w7PyPDWU7kwJsKJyztAUN"}
 	out, err := json.Marshal(mods)
 	if err != nil {
@@ -57,7 +57,7 @@ func (n *OpenBazaarNode) SetProfile(profile *models.Profile, done chan<- struct{
 		if err := tx.SetProfile(profile); err != nil {
 			return err
 		}
-		return nil
+		return n.setVendorProvider(context.Background(), profile.Vendor)
 	})
 	if err != nil {
 		maybeCloseDone(done)
@@ -173,6 +173,10 @@ func (n *OpenBazaarNode) PurchaseListing(ctx context.Context, purchase *models.P
 // EstimateOrderSubtotal estimates the total for the order given the provided
 // purchase details. This is only an estimate because it may be based on the
 // current exchange rates which may change by the time the order is placed.
+// Any quantity price tiers are applied to the unit price before coupons.
+// Coupons are only discounted if they apply to the selected SKU, have not
+// expired and the minimum order value is met. The vendor may still decline
+// the order if a coupon has reached its redemption limit.
 func (n *OpenBazaarNode) EstimateOrderSubtotal(ctx context.Context, purchase *models.Purchase) (*models.CurrencyValue, error) {
 	orderOpen, err := n.createOrder(ctx, purchase)
 	if err != nil {
@@ -337,6 +341,10 @@ func (n *OpenBazaarNode) createOrder(ctx context.Context, purchase *models.Purch
 		Payment:       &pb.OrderOpen_Payment{},
 	}
 
+	if err := n.checkListingInventory(ctx, order); err != nil {
+		return nil, err
+	}
+
 	chaincode := make([]byte, 32)
 	if _, err := rand.Read(chaincode); err != nil {
 		return nil, err
@@ -373,6 +381,10 @@ func (n *OpenBazaarNode) createOrder(ctx context.Context, purchase *models.Purch
 		if err != nil {
 			return nil, err
 		}
+		order.Payment.ModeratorFee, err = n.orderModeratorFee(moderatorProfile, normalizeCurrencyCode(purchase.PaymentCoin))
+		if err != nil {
+			return nil, err
+		}
 		moderatorPubkeyBytes, err := hex.DecodeString(moderatorProfile.EscrowPublicKey)
 		if err != nil {
 			return nil, err
@@ -425,11 +437,18 @@ func (n *OpenBazaarNode) createOrder(ctx context.Context, purchase *models.Purch
 	order.Payment.Chaincode = hex.EncodeToString(chaincode)
 	order.Payment.Coin = normalizeCurrencyCode(purchase.PaymentCoin)
 
-	total, err := orders.CalculateOrderTotal(order, n.exchangeRates)
+	total, taxes, err := orders.CalculateOrderTotalWithTaxes(order, n.exchangeRates)
 	if err != nil {
 		return nil, err
 	}
 	order.Payment.Amount = total.String()
+	order.Taxes = taxes
+
+	prefs, err := n.GetPreferences()
+	if err != nil {
+		return nil, err
+	}
+	order.Payment.MispaymentBuffer = prefs.MisPaymentBuffer
 
 	ratingKeys, err := utils.GenerateRatingPublicKeys(n.ratingMasterKey.PubKey(), len(order.Items), chaincode)
 	if err != nil {
@@ -438,3 +457,32 @@ func (n *OpenBazaarNode) createOrder(ctx context.Context, purchase *models.Purch
 	order.RatingKeys = ratingKeys
 	return order, nil
 }
+
+// orderModeratorFee returns the moderator's current fee to record in the order.
+// The fixed portion is converted into the payment coin so that the buyer, vendor
+// and moderator all calculate the same fee if there is a dispute.
+func (n *OpenBazaarNode) orderModeratorFee(moderatorProfile *models.Profile, paymentCoin string) (*pb.OrderOpen_Payment_ModeratorFee, error) {
+	fee := &pb.OrderOpen_Payment_ModeratorFee{Fixed: "0"}
+	if moderatorProfile.ModeratorInfo == nil {
+		return fee, nil
+	}
+	feeInfo := moderatorProfile.ModeratorInfo.Fee
+	if feeInfo.FeeType == models.FixedFee || feeInfo.FeeType == models.FixedPlusPercentageFee {
+		if feeInfo.FixedFee == nil {
+			return nil, errors.New("moderator fixed fee is not set")
+		}
+		paymentCurrency, err := models.CurrencyDefinitions.Lookup(paymentCoin)
+		if err != nil {
+			return nil, err
+		}
+		fixed, err := orders.ConvertCurrencyAmount(feeInfo.FixedFee, paymentCurrency, n.exchangeRates)
+		if err != nil {
+			return nil, err
+		}
+		fee.Fixed = fixed.String()
+	}
+	if feeInfo.FeeType == models.PercentageFee || feeInfo.FeeType == models.FixedPlusPercentageFee {
+		fee.Percentage = float32(feeInfo.Percentage)
+	}
+	return fee, nil
+}
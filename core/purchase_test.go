@@ -443,6 +443,76 @@ func TestOpenBazaarNode_EstimateOrderSubtotal(t *testing.T) {
 	if val.Amount.Cmp(iwallet.NewAmount(expectedAmount)) != 0 {
 		t.Errorf("Returned incorrect amount: Expected %d, got %s", expectedAmount, val.Amount)
 	}
+
+	purchase.Items[0].Coupons = []string{"insider"}
+	val, err = network.Nodes()[1].EstimateOrderSubtotal(context.Background(), purchase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedAmount = 4784212
+	if val.Amount.Cmp(iwallet.NewAmount(expectedAmount)) != 0 {
+		t.Errorf("Returned incorrect amount with coupon: Expected %d, got %s", expectedAmount, val.Amount)
+	}
+
+	// A coupon restricted to another sku is not applied.
+	listing.Coupons[0].Skus = []string{"2"}
+	done2 := make(chan struct{})
+	if err := network.Nodes()[0].SaveListing(listing, done2); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done2:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+	index, err = network.Nodes()[0].GetMyListings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	purchase.Items[0].ListingHash = index[0].CID
+
+	val, err = network.Nodes()[1].EstimateOrderSubtotal(context.Background(), purchase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedAmount = 4992221
+	if val.Amount.Cmp(iwallet.NewAmount(expectedAmount)) != 0 {
+		t.Errorf("Returned incorrect amount with restricted coupon: Expected %d, got %s", expectedAmount, val.Amount)
+	}
+
+	// Price tiers are applied when the quantity is met.
+	listing.Coupons[0].Skus = nil
+	listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{
+		{
+			MinQuantity: 2,
+			Discount:    &pb.Listing_Item_PriceTier_Price{Price: "90"},
+		},
+	}
+	done3 := make(chan struct{})
+	if err := network.Nodes()[0].SaveListing(listing, done3); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done3:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+	index, err = network.Nodes()[0].GetMyListings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	purchase.Items[0].ListingHash = index[0].CID
+	purchase.Items[0].Coupons = nil
+	purchase.Items[0].Quantity = "2"
+
+	val, err = network.Nodes()[1].EstimateOrderSubtotal(context.Background(), purchase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedAmount = 8320370
+	if val.Amount.Cmp(iwallet.NewAmount(expectedAmount)) != 0 {
+		t.Errorf("Returned incorrect amount with price tier: Expected %d, got %s", expectedAmount, val.Amount)
+	}
 }
 
 func TestOpenBazaarNode_createOrder(t *testing.T) {
@@ -664,6 +734,12 @@ func TestOpenBazaarNode_createOrder(t *testing.T) {
 				if order.Payment.Amount != "4992221" {
 					return errors.New("incorrect payment amount")
 				}
+				if len(order.Taxes) != 1 {
+					return errors.New("incorrect number of taxes")
+				}
+				if order.Taxes[0].ListingHash != order.Items[0].ListingHash || order.Taxes[0].TaxType != "Sales tax" {
+					return errors.New("incorrect tax in order")
+				}
 
 				return nil
 			},
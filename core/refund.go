@@ -3,6 +3,8 @@ package core
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
 	"github.com/cpacia/openbazaar3.0/database"
 	"github.com/cpacia/openbazaar3.0/models"
 	npb "github.com/cpacia/openbazaar3.0/net/pb"
@@ -11,6 +13,7 @@ import (
 	iwallet "github.com/cpacia/wallet-interface"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
+	"github.com/jinzhu/gorm"
 )
 
 // RefundOrder sends a REFUND message to the remote peer and updates the node's
@@ -26,7 +29,9 @@ func (n *OpenBazaarNode) RefundOrder(orderID models.OrderID, done chan struct{})
 	err := n.repo.DB().View(func(tx database.Tx) error {
 		return tx.Read().Where("id = ?", orderID.String()).First(&order).Error
 	})
-	if err != nil {
+	if gorm.IsRecordNotFoundError(err) {
+		return fmt.Errorf("%w: order not found", coreiface.ErrNotFound)
+	} else if err != nil {
 		return err
 	}
 
@@ -45,7 +50,7 @@ func (n *OpenBazaarNode) RefundOrder(orderID models.OrderID, done chan struct{})
 	}
 
 	if !order.CanRefund(n.Identity()) {
-		return errors.New("order is not in a state where it can be refunded ")
+		return fmt.Errorf("%w: order is not in a state where it can be refunded", coreiface.ErrBadRequest)
 	}
 
 	return n.repo.DB().Update(func(tx database.Tx) error {
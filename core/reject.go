@@ -9,6 +9,7 @@ import (
 	"github.com/cpacia/openbazaar3.0/orders/pb"
 	"github.com/cpacia/openbazaar3.0/orders/utils"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/jinzhu/gorm"
 )
 
 // RejectOrder sends a ORDER_REJECT message to the remote peer and updates the node's
@@ -19,7 +20,9 @@ func (n *OpenBazaarNode) RejectOrder(orderID models.OrderID, reason string, done
 	err := n.repo.DB().View(func(tx database.Tx) error {
 		return tx.Read().Where("id = ?", orderID.String()).First(&order).Error
 	})
-	if err != nil {
+	if gorm.IsRecordNotFoundError(err) {
+		return fmt.Errorf("%w: order not found", coreiface.ErrNotFound)
+	} else if err != nil {
 		return err
 	}
 
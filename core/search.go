@@ -0,0 +1,527 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/OpenBazaar/jsonpb"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/database/ffsqlite"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	"github.com/golang/protobuf/proto"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-ipfs/core/coreapi"
+	"github.com/ipfs/go-ipns"
+	ipnspb "github.com/ipfs/go-ipns/pb"
+	"github.com/ipfs/interface-go-ipfs-core/path"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// searchRefreshInterval is how often we check the stores in the search
+	// index, and the stores we follow, for new IPNS records.
+	searchRefreshInterval = time.Hour
+
+	// searchFetchTimeout is how long we wait to fetch a store's listing
+	// index and ratings when updating the search index.
+	searchFetchTimeout = time.Minute
+
+	// searchBatchSize is the maximum number of listings loaded from the
+	// search index in a single query.
+	searchBatchSize = 500
+
+	// maxSearchRatings is the maximum number of a store's ratings we fetch
+	// and validate to calculate its vendor rating. This is the number of
+	// ratings at which the rating boost in the search ranking is maximized.
+	maxSearchRatings = 50
+)
+
+// Search searches the local search index. The index holds the listings from
+// our own store, the stores we follow and any other store we have fetched
+// listings from. Results are ranked by text relevance and vendor rating.
+func (n *OpenBazaarNode) Search(query *models.SearchQuery) (*models.SearchResults, error) {
+	if (query.MinPrice != nil || query.MaxPrice != nil) && query.Currency == "" {
+		return nil, fmt.Errorf("%w: currency is required with minPrice and maxPrice", coreiface.ErrBadRequest)
+	}
+	if query.MinPrice != nil && query.MaxPrice != nil && *query.MinPrice > *query.MaxPrice {
+		return nil, fmt.Errorf("%w: minPrice is greater than maxPrice", coreiface.ErrBadRequest)
+	}
+
+	var (
+		terms     = query.Terms()
+		relevance map[searchKey]float64
+		rows      []models.SearchListing
+	)
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		db := tx.Read()
+		if !query.NSFW {
+			db = db.Where("nsfw = ?", false)
+		}
+		if query.Currency != "" {
+			db = db.Where("price_currency = ?", strings.ToUpper(query.Currency))
+		}
+		if query.MinPrice != nil {
+			db = db.Where("price >= ?", *query.MinPrice)
+		}
+		if query.MaxPrice != nil {
+			db = db.Where("price <= ?", *query.MaxPrice)
+		}
+		if len(terms) == 0 {
+			return db.Find(&rows).Error
+		}
+
+		var err error
+		relevance, err = matchSearchTerms(tx, terms)
+		if err != nil {
+			return err
+		}
+		slugs := make(map[string][]string)
+		for key := range relevance {
+			slugs[key.peerID] = append(slugs[key.peerID], key.slug)
+		}
+		for peerID, peerSlugs := range slugs {
+			for len(peerSlugs) > 0 {
+				batch := peerSlugs
+				if len(batch) > searchBatchSize {
+					batch = batch[:searchBatchSize]
+				}
+				peerSlugs = peerSlugs[len(batch):]
+
+				var batchRows []models.SearchListing
+				if err := db.Where("peer_id = ? AND slug IN (?)", peerID, batch).Find(&batchRows).Error; err != nil {
+					return err
+				}
+				rows = append(rows, batchRows...)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := []models.SearchResult{}
+	for _, row := range rows {
+		if !row.Matches(query) {
+			continue
+		}
+		score := 1.0
+		if len(terms) > 0 {
+			score = relevance[searchKey{row.PeerID, row.Slug}]
+		}
+		lmd, err := row.ListingMetadata()
+		if err != nil {
+			log.Errorf("Error loading search index entry %s/%s: %s", row.PeerID, row.Slug, err)
+			continue
+		}
+		results = append(results, models.SearchResult{
+			PeerID:  row.PeerID,
+			Score:   row.Score(score),
+			Listing: *lmd,
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Listing.Title < results[j].Listing.Title
+	})
+
+	total := len(results)
+	if query.Offset > 0 {
+		if query.Offset > len(results) {
+			query.Offset = len(results)
+		}
+		results = results[query.Offset:]
+	}
+	if query.Limit > 0 && len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return &models.SearchResults{
+		Total:   total,
+		Results: results,
+	}, nil
+}
+
+// searchKey identifies a listing in the search index.
+type searchKey struct {
+	peerID string
+	slug   string
+}
+
+// matchSearchTerms returns the text relevance of each listing in the search
+// index which matches every term. A term matches a listing if one of the
+// words in the listing starts with it.
+func matchSearchTerms(tx database.Tx, terms []string) (map[searchKey]float64, error) {
+	var relevance map[searchKey]float64
+	for i, term := range terms {
+		var matches []models.SearchTerm
+		err := tx.Read().Where("term >= ? AND term < ?", term, term+string(utf8.MaxRune)).Find(&matches).Error
+		if err != nil {
+			return nil, err
+		}
+
+		// A term may match several words in the same listing so the
+		// fields they were found in are combined before weighting.
+		fields := make(map[searchKey]int)
+		for _, m := range matches {
+			key := searchKey{m.PeerID, m.Slug}
+			if _, ok := relevance[key]; i > 0 && !ok {
+				continue
+			}
+			fields[key] |= m.Fields
+		}
+		next := make(map[searchKey]float64, len(fields))
+		for key, f := range fields {
+			st := models.SearchTerm{Fields: f}
+			next[key] = relevance[key] + st.Weight()
+		}
+		relevance = next
+		if len(relevance) == 0 {
+			break
+		}
+	}
+	return relevance, nil
+}
+
+// searchIndexHandler keeps the search index fresh. It updates the index
+// when new IPNS records are received over pubsub and checks each indexed
+// store, and each store we follow, for changes on every searchRefreshInterval.
+func (n *OpenBazaarNode) searchIndexHandler() {
+	go n.listenForIPNSRecords()
+
+	ticker := time.NewTicker(searchRefreshInterval)
+	defer ticker.Stop()
+	for {
+		n.refreshSearchIndex()
+		select {
+		case <-ticker.C:
+		case <-n.shutdown:
+			return
+		}
+	}
+}
+
+// refreshSearchIndex resolves each store in the search index, and each store
+// we follow, and re-indexes any which have published a new IPNS record.
+func (n *OpenBazaarNode) refreshSearchIndex() {
+	if err := n.indexMyListings(); err != nil {
+		log.Errorf("Error indexing our listings: %s", err)
+	}
+
+	peers, err := n.searchIndexPeers()
+	if err != nil {
+		log.Errorf("Error loading search index peers: %s", err)
+		return
+	}
+	for p := range peers {
+		select {
+		case <-n.shutdown:
+			return
+		default:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), searchFetchTimeout)
+		pth, err := n.resolve(ctx, p, false)
+		if err == nil {
+			err = n.updateSearchIndex(ctx, p, pth)
+		}
+		cancel()
+		if err != nil {
+			log.Debugf("Error updating search index for %s: %s", p, err)
+		}
+	}
+}
+
+// listenForIPNSRecords subscribes to the IPNS pubsub topic and re-indexes a
+// store when it publishes a new record, provided the store is already in the
+// search index or is one we follow.
+func (n *OpenBazaarNode) listenForIPNSRecords() {
+	api, err := coreapi.NewCoreAPI(n.ipfsNode)
+	if err != nil {
+		log.Errorf("Error subscribing to IPNS records: %s", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-n.shutdown
+		cancel()
+	}()
+
+	sub, err := api.PubSub().Subscribe(ctx, ipnsPubsubTopic)
+	if err != nil {
+		log.Errorf("Error subscribing to IPNS records: %s", err)
+		return
+	}
+	defer sub.Close()
+
+	for {
+		msg, err := sub.Next(ctx)
+		if err != nil {
+			return
+		}
+		p := peer.ID(msg.From())
+		if p == n.Identity() {
+			continue
+		}
+		pth, err := validatedIPNSRecordPath(p, msg.Data())
+		if err != nil {
+			log.Debugf("Received invalid IPNS record from %s: %s", p, err)
+			continue
+		}
+		peers, err := n.searchIndexPeers()
+		if err != nil {
+			log.Errorf("Error loading search index peers: %s", err)
+			continue
+		}
+		if !peers[p] {
+			continue
+		}
+		go func() {
+			fetchCtx, cancel := context.WithTimeout(ctx, searchFetchTimeout)
+			defer cancel()
+			if err := n.updateSearchIndex(fetchCtx, p, pth); err != nil {
+				log.Debugf("Error updating search index for %s: %s", p, err)
+			}
+		}()
+	}
+}
+
+// validatedIPNSRecordPath returns the path in the serialized IPNS record
+// after checking it was signed by the peer.
+func validatedIPNSRecordPath(p peer.ID, data []byte) (path.Path, error) {
+	entry := new(ipnspb.IpnsEntry)
+	if err := proto.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+	pk, err := ipns.ExtractPublicKey(p, entry)
+	if err != nil {
+		return nil, err
+	}
+	if err := ipns.Validate(pk, entry); err != nil {
+		return nil, err
+	}
+	return path.New(string(entry.Value)), nil
+}
+
+// searchIndexPeers returns the stores in the search index along with the
+// stores we follow.
+func (n *OpenBazaarNode) searchIndexPeers() (map[peer.ID]bool, error) {
+	peers := make(map[peer.ID]bool)
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		var searchPeers []models.SearchPeer
+		if err := tx.Read().Find(&searchPeers).Error; err != nil {
+			return err
+		}
+		for _, sp := range searchPeers {
+			p, err := peer.IDB58Decode(sp.PeerID)
+			if err != nil {
+				continue
+			}
+			peers[p] = true
+		}
+		following, err := tx.GetFollowing()
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		for _, f := range following {
+			p, err := peer.IDB58Decode(f)
+			if err != nil {
+				continue
+			}
+			peers[p] = true
+		}
+		return nil
+	})
+	delete(peers, n.Identity())
+	return peers, err
+}
+
+// updateSearchIndex fetches the listing index and ratings at the path and
+// replaces the peer's listings in the search index. Nothing is fetched if
+// the peer was already indexed from the same path.
+func (n *OpenBazaarNode) updateSearchIndex(ctx context.Context, p peer.ID, pth path.Path) error {
+	root := strings.TrimPrefix(pth.String(), "/ipfs/")
+
+	var current models.SearchPeer
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("peer_id = ?", p.Pretty()).First(&current).Error
+	})
+	if err == nil && current.RootCID == root {
+		return nil
+	}
+
+	indexBytes, err := n.cat(ctx, path.Join(pth, ffsqlite.ListingIndexFile))
+	if err != nil {
+		return err
+	}
+	var index models.ListingIndex
+	if err := json.Unmarshal(indexBytes, &index); err != nil {
+		return err
+	}
+
+	stats := n.validatedRatingStats(ctx, p, pth)
+
+	return n.repo.DB().Update(func(tx database.Tx) error {
+		return indexPeerListings(tx, p, root, index, stats)
+	})
+}
+
+// validatedRatingStats fetches the ratings in the rating index at the path
+// and returns the average and count of the ratings which are valid ratings of
+// the peer. The stats in the peer's profile and rating index are self-reported
+// so they are not used. It returns nil if there are no valid ratings.
+func (n *OpenBazaarNode) validatedRatingStats(ctx context.Context, p peer.ID, pth path.Path) *models.ProfileStats {
+	indexBytes, err := n.cat(ctx, path.Join(pth, ffsqlite.RatingIndexFile))
+	if err != nil {
+		return nil
+	}
+	var index models.RatingIndex
+	if err := json.Unmarshal(indexBytes, &index); err != nil {
+		return nil
+	}
+
+	var (
+		seen  = make(map[string]bool)
+		total float64
+		count uint32
+	)
+	for _, info := range index {
+		for _, id := range info.Ratings {
+			if count >= maxSearchRatings {
+				break
+			}
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			ratingID, err := cid.Decode(id)
+			if err != nil {
+				continue
+			}
+			ratingBytes, err := n.cat(ctx, path.IpfsPath(ratingID))
+			if err != nil {
+				continue
+			}
+			rating := new(pb.Rating)
+			if err := jsonpb.UnmarshalString(string(ratingBytes), rating); err != nil {
+				continue
+			}
+			if utils.ValidateRating(rating) != nil || rating.VendorID.PeerID != p.Pretty() {
+				continue
+			}
+			total += float64(rating.Overall)
+			count++
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+	return &models.ProfileStats{
+		AverageRating: float32(total / float64(count)),
+		RatingCount:   count,
+	}
+}
+
+// indexMyListings replaces our own listings in the search index. Nothing
+// is done if our listings and ratings have not changed since they were last
+// indexed.
+func (n *OpenBazaarNode) indexMyListings() error {
+	return n.repo.DB().Update(func(tx database.Tx) error {
+		index, err := tx.GetListingIndex()
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		ratings, err := tx.GetRatingIndex()
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		// Our listing index holds the CID of each listing so it changes
+		// whenever a listing does. The hash of the index and our ratings
+		// is saved as the root of our entry in the search index.
+		ser, err := json.Marshal(struct {
+			Listings models.ListingIndex
+			Ratings  models.RatingIndex
+		}{index, ratings})
+		if err != nil {
+			return err
+		}
+		mh, err := utils.MultihashSha256(ser)
+		if err != nil {
+			return err
+		}
+		root := mh.B58String()
+
+		var current models.SearchPeer
+		err = tx.Read().Where("peer_id = ?", n.Identity().Pretty()).First(&current).Error
+		if err == nil && current.RootCID == root {
+			return nil
+		}
+
+		// The ratings in our own rating index were validated when
+		// we received them.
+		var (
+			stats *models.ProfileStats
+			total float64
+		)
+		for _, info := range ratings {
+			if stats == nil {
+				stats = new(models.ProfileStats)
+			}
+			stats.RatingCount += uint32(info.Count)
+			total += info.Average * float64(info.Count)
+		}
+		if stats != nil && stats.RatingCount > 0 {
+			stats.AverageRating = float32(total / float64(stats.RatingCount))
+		}
+		return indexPeerListings(tx, n.Identity(), root, index, stats)
+	})
+}
+
+// indexPeerListings replaces the listings of the peer in the search index
+// with the listings in the index.
+func indexPeerListings(tx database.Tx, p peer.ID, root string, index models.ListingIndex, stats *models.ProfileStats) error {
+	if err := removePeerListings(tx, p); err != nil {
+		return err
+	}
+	for i := range index {
+		sl, err := models.NewSearchListing(p.Pretty(), &index[i], stats)
+		if err != nil {
+			return err
+		}
+		if err := tx.Save(sl); err != nil {
+			return err
+		}
+		for _, term := range sl.SearchTerms() {
+			if err := tx.Save(&term); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Save(&models.SearchPeer{
+		PeerID:      p.Pretty(),
+		RootCID:     root,
+		LastIndexed: time.Now(),
+	})
+}
+
+// removePeerListings removes the peer and its listings from the search index.
+func removePeerListings(tx database.Tx, p peer.ID) error {
+	if err := tx.Delete("peer_id", p.Pretty(), nil, &models.SearchListing{}); err != nil {
+		return err
+	}
+	if err := tx.Delete("peer_id", p.Pretty(), nil, &models.SearchTerm{}); err != nil {
+		return err
+	}
+	return tx.Delete("peer_id", p.Pretty(), nil, &models.SearchPeer{})
+}
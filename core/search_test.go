@@ -0,0 +1,281 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/models/factory"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"testing"
+	"time"
+)
+
+func TestOpenBazaarNode_Search(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	vendor1, err := peer.IDB58Decode("QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vendor2, err := peer.IDB58Decode("QmTbpxKFfuGHQiXWaYJpzUbGbm6M2aZ2mEDb24YdiHpbEJ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = node.repo.DB().Update(func(tx database.Tx) error {
+		err := indexPeerListings(tx, vendor1, "QmRoot1", models.ListingIndex{
+			{
+				Slug:         "ron-swanson-shirt",
+				Title:        "Ron Swanson Shirt",
+				Description:  "A great shirt",
+				Tags:         []string{"tshirts"},
+				Categories:   []string{"Clothing"},
+				ContractType: "PHYSICAL_GOOD",
+				Price:        *models.NewCurrencyValue("2000", models.CurrencyDefinitions["USD"]),
+				ShipsTo:      []string{"UNITED_STATES"},
+			},
+			{
+				Slug:         "coffee-mug",
+				Title:        "Coffee Mug",
+				Description:  "Goes well with a shirt",
+				Categories:   []string{"Kitchen"},
+				ContractType: "PHYSICAL_GOOD",
+				Price:        *models.NewCurrencyValue("800", models.CurrencyDefinitions["USD"]),
+				ShipsTo:      []string{"ALL"},
+			},
+		}, nil)
+		if err != nil {
+			return err
+		}
+		return indexPeerListings(tx, vendor2, "QmRoot2", models.ListingIndex{
+			{
+				Slug:         "leslie-knope-shirt",
+				Title:        "Leslie Knope Shirt",
+				Categories:   []string{"Clothing"},
+				ContractType: "PHYSICAL_GOOD",
+				Price:        *models.NewCurrencyValue("1000", models.CurrencyDefinitions["USD"]),
+				ShipsTo:      []string{"CANADA"},
+			},
+			{
+				Slug:         "adult-shirt",
+				Title:        "Adult Shirt",
+				Categories:   []string{"Clothing"},
+				ContractType: "PHYSICAL_GOOD",
+				NSFW:         true,
+				Price:        *models.NewCurrencyValue("1000", models.CurrencyDefinitions["USD"]),
+			},
+		}, &models.ProfileStats{AverageRating: 5, RatingCount: 100})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	float := func(f float64) *float64 { return &f }
+
+	tests := []struct {
+		name     string
+		query    *models.SearchQuery
+		expected []string
+	}{
+		{
+			name:     "Text relevance with rating boost",
+			query:    &models.SearchQuery{Query: "shirt"},
+			expected: []string{"leslie-knope-shirt", "ron-swanson-shirt", "coffee-mug"},
+		},
+		{
+			name:     "Prefix match",
+			query:    &models.SearchQuery{Query: "swan"},
+			expected: []string{"ron-swanson-shirt"},
+		},
+		{
+			name:     "All terms must match",
+			query:    &models.SearchQuery{Query: "swanson shirt"},
+			expected: []string{"ron-swanson-shirt"},
+		},
+		{
+			name:     "Include NSFW",
+			query:    &models.SearchQuery{Query: "adult", NSFW: true},
+			expected: []string{"adult-shirt"},
+		},
+		{
+			name:     "Exclude NSFW",
+			query:    &models.SearchQuery{Query: "adult"},
+			expected: []string{},
+		},
+		{
+			name:     "Category",
+			query:    &models.SearchQuery{Category: "kitchen"},
+			expected: []string{"coffee-mug"},
+		},
+		{
+			name:     "Price range",
+			query:    &models.SearchQuery{Currency: "usd", MinPrice: float(9), MaxPrice: float(15)},
+			expected: []string{"leslie-knope-shirt"},
+		},
+		{
+			name:     "Ships to",
+			query:    &models.SearchQuery{Query: "shirt", ShipsTo: "UNITED_STATES"},
+			expected: []string{"ron-swanson-shirt", "coffee-mug"},
+		},
+		{
+			name:     "Limit and offset",
+			query:    &models.SearchQuery{Query: "shirt", Limit: 1, Offset: 1},
+			expected: []string{"ron-swanson-shirt"},
+		},
+	}
+
+	for _, test := range tests {
+		results, err := node.Search(test.query)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		slugs := []string{}
+		for _, result := range results.Results {
+			slugs = append(slugs, result.Listing.Slug)
+		}
+		if len(slugs) != len(test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, slugs)
+			continue
+		}
+		for i := range slugs {
+			if slugs[i] != test.expected[i] {
+				t.Errorf("%s: expected %v, got %v", test.name, test.expected, slugs)
+				break
+			}
+		}
+	}
+
+	_, err = node.Search(&models.SearchQuery{Currency: "USD", MinPrice: float(10), MaxPrice: float(5)})
+	if !errors.Is(err, coreiface.ErrBadRequest) {
+		t.Errorf("Expected ErrBadRequest got %v", err)
+	}
+
+	// Prices in different currencies can't be compared.
+	_, err = node.Search(&models.SearchQuery{MinPrice: float(10)})
+	if !errors.Is(err, coreiface.ErrBadRequest) {
+		t.Errorf("Expected ErrBadRequest got %v", err)
+	}
+
+	// Re-indexing a peer replaces its listings.
+	err = node.repo.DB().Update(func(tx database.Tx) error {
+		return indexPeerListings(tx, vendor1, "QmRoot3", models.ListingIndex{}, nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := node.Search(&models.SearchQuery{Query: "swanson"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results.Total != 0 {
+		t.Errorf("Expected no results after re-indexing, got %d", results.Total)
+	}
+}
+
+func TestOpenBazaarNode_SearchIndexing(t *testing.T) {
+	network, err := NewMocknet(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer network.TearDown()
+
+	listing := factory.NewPhysicalListing("ron-swanson-shirt")
+	done := make(chan struct{})
+	if err := network.Nodes()[0].SaveListing(listing, done); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	// Publish a rating index claiming a perfect rating. The rating it
+	// lists is not a valid rating.
+	index, err := network.Nodes()[0].GetMyListings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = network.Nodes()[0].repo.DB().Update(func(tx database.Tx) error {
+		return tx.SetRatingIndex(models.RatingIndex{
+			{
+				Slug:    "ron-swanson-shirt",
+				Count:   100,
+				Average: 5,
+				Ratings: []string{index[0].CID},
+			},
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	done = make(chan struct{})
+	network.Nodes()[0].Publish(done)
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on channel")
+	}
+
+	// Our own listings are indexed when we publish.
+	results, err := network.Nodes()[0].Search(&models.SearchQuery{Query: "swanson"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results.Total != 1 || results.Results[0].PeerID != network.Nodes()[0].Identity().Pretty() {
+		t.Fatalf("Expected our listing in the search index, got %v", results.Results)
+	}
+	ourScore := results.Results[0].Score
+
+	// They are not re-indexed if they haven't changed.
+	getSearchPeer := func() models.SearchPeer {
+		var sp models.SearchPeer
+		err := network.Nodes()[0].repo.DB().View(func(tx database.Tx) error {
+			return tx.Read().Where("peer_id = ?", network.Nodes()[0].Identity().Pretty()).First(&sp).Error
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sp
+	}
+	indexed := getSearchPeer()
+	if err := network.Nodes()[0].indexMyListings(); err != nil {
+		t.Fatal(err)
+	}
+	if sp := getSearchPeer(); !sp.LastIndexed.Equal(indexed.LastIndexed) {
+		t.Error("Expected unchanged listings to not be re-indexed")
+	}
+
+	// Listings we fetch from other stores are indexed in the background.
+	if _, err := network.Nodes()[1].GetListings(context.Background(), network.Nodes()[0].Identity(), false); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		results, err := network.Nodes()[1].Search(&models.SearchQuery{Query: "swanson"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if results.Total == 1 {
+			if results.Results[0].PeerID != network.Nodes()[0].Identity().Pretty() {
+				t.Errorf("Incorrect peer ID %s", results.Results[0].PeerID)
+			}
+			// The other store's rating can't be validated so
+			// its listing is not boosted.
+			if results.Results[0].Score != ourScore/2 {
+				t.Errorf("Expected score %f, got %f", ourScore/2, results.Results[0].Score)
+			}
+			break
+		}
+		if i == 50 {
+			t.Fatal("Timed out waiting for listing to be indexed")
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+}
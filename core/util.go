@@ -1,7 +1,12 @@
 package core
 
 import (
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
 	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/jinzhu/gorm"
+	"time"
 )
 
 // NormalizeCurrencyCode standardizes the format for the given currency code.
@@ -20,3 +25,42 @@ func maybeCloseDone(done chan<- struct{}) {
 		close(done)
 	}
 }
+
+// closeDoneAfter closes the done chan, if it's not nil, after all of the
+// provided chans have been closed. This is used when one call sends messages
+// to more than one peer.
+func closeDoneAfter(done chan<- struct{}, chans ...chan struct{}) {
+	go func() {
+		for _, ch := range chans {
+			<-ch
+		}
+		maybeCloseDone(done)
+	}()
+}
+
+// newestFirstPage orders the query, which selects from the table of a model
+// with timestamp and id columns, newest first and limits it to the page
+// after the row with the offset ID. The name is used in the error returned
+// if the offset row is not found. One more row than the limit is selected
+// so the caller can tell if there is another page. A limit of zero or less
+// selects all the remaining rows.
+func newestFirstPage(tx database.Tx, db *gorm.DB, model interface{}, name, offsetID string, limit int) (*gorm.DB, error) {
+	if offsetID != "" {
+		var offset struct {
+			Timestamp time.Time
+			ID        string
+		}
+		err := tx.Read().Model(model).Select("timestamp, id").Where("id = ?", offsetID).Scan(&offset).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, fmt.Errorf("%w: offset %s not found", coreiface.ErrBadRequest, name)
+		} else if err != nil {
+			return nil, err
+		}
+		db = db.Where("timestamp < ? OR (timestamp = ? AND id < ?)", offset.Timestamp, offset.Timestamp, offset.ID)
+	}
+	db = db.Order("timestamp desc, id desc")
+	if limit > 0 {
+		db = db.Limit(limit + 1)
+	}
+	return db, nil
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/jinzhu/gorm"
+)
+
+// GetWebhookDeliveries returns a page of the webhook delivery log matching
+// the query, newest first.
+func (n *OpenBazaarNode) GetWebhookDeliveries(query *models.WebhookDeliveryQuery) (*models.WebhookDeliveryList, error) {
+	if query == nil {
+		query = &models.WebhookDeliveryQuery{}
+	}
+	switch query.Status {
+	case "", models.WebhookDeliveryPending, models.WebhookDeliveryDelivered, models.WebhookDeliveryFailed:
+	default:
+		return nil, fmt.Errorf("%w: unknown delivery status %s", coreiface.ErrBadRequest, query.Status)
+	}
+	filter := func(db *gorm.DB) *gorm.DB {
+		db = db.Model(&models.WebhookDelivery{})
+		if query.Status != "" {
+			db = db.Where("status = ?", query.Status)
+		}
+		return db
+	}
+
+	var (
+		list = &models.WebhookDeliveryList{
+			Deliveries: []models.WebhookDelivery{},
+		}
+		deliveries []models.WebhookDelivery
+	)
+	err := n.repo.DB().View(func(tx database.Tx) error {
+		if err := filter(tx.Read()).Count(&list.Total).Error; err != nil {
+			return err
+		}
+
+		db, err := newestFirstPage(tx, filter(tx.Read()), &models.WebhookDelivery{}, "delivery", query.OffsetID, query.Limit)
+		if err != nil {
+			return err
+		}
+		return db.Find(&deliveries).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Limit > 0 && len(deliveries) > query.Limit {
+		deliveries = deliveries[:query.Limit]
+		list.NextOffsetID = deliveries[len(deliveries)-1].ID
+	}
+	list.Deliveries = append(list.Deliveries, deliveries...)
+	return list, nil
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"errors"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"testing"
+	"time"
+)
+
+func TestOpenBazaarNode_GetWebhookDeliveries(t *testing.T) {
+	node, err := MockNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.DestroyNode()
+
+	var (
+		now        = time.Now()
+		deliveries = []models.WebhookDelivery{
+			{ID: "a", Status: models.WebhookDeliveryDelivered, Timestamp: now.Add(-time.Minute * 3)},
+			{ID: "b", Status: models.WebhookDeliveryFailed, Timestamp: now.Add(-time.Minute * 2)},
+			{ID: "c", Status: models.WebhookDeliveryPending, Timestamp: now.Add(-time.Minute)},
+			{ID: "d", Status: models.WebhookDeliveryDelivered, Timestamp: now},
+		}
+	)
+	err = node.repo.DB().Update(func(tx database.Tx) error {
+		for _, delivery := range deliveries {
+			delivery.URL = "https://example.com/hook"
+			delivery.EventType = "NewOrder"
+			delivery.Payload = []byte(`{}`)
+			if err := tx.Save(&delivery); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		query        *models.WebhookDeliveryQuery
+		expected     []string
+		total        int
+		nextOffsetID string
+	}{
+		{
+			name:     "All deliveries",
+			query:    &models.WebhookDeliveryQuery{},
+			expected: []string{"d", "c", "b", "a"},
+			total:    4,
+		},
+		{
+			name:     "By status",
+			query:    &models.WebhookDeliveryQuery{Status: models.WebhookDeliveryDelivered},
+			expected: []string{"d", "a"},
+			total:    2,
+		},
+		{
+			name:         "First page",
+			query:        &models.WebhookDeliveryQuery{Limit: 3},
+			expected:     []string{"d", "c", "b"},
+			total:        4,
+			nextOffsetID: "b",
+		},
+		{
+			name:     "Last page",
+			query:    &models.WebhookDeliveryQuery{Limit: 3, OffsetID: "b"},
+			expected: []string{"a"},
+			total:    4,
+		},
+	}
+	for _, test := range tests {
+		list, err := node.GetWebhookDeliveries(test.query)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		var ids []string
+		for _, delivery := range list.Deliveries {
+			ids = append(ids, delivery.ID)
+		}
+		if len(ids) != len(test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, ids)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != test.expected[i] {
+				t.Errorf("%s: expected %v, got %v", test.name, test.expected, ids)
+				break
+			}
+		}
+		if list.Total != test.total {
+			t.Errorf("%s: expected total %d, got %d", test.name, test.total, list.Total)
+		}
+		if list.NextOffsetID != test.nextOffsetID {
+			t.Errorf("%s: expected next offset %s, got %s", test.name, test.nextOffsetID, list.NextOffsetID)
+		}
+	}
+
+	if _, err := node.GetWebhookDeliveries(&models.WebhookDeliveryQuery{Status: "lost"}); !errors.Is(err, coreiface.ErrBadRequest) {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+	if _, err := node.GetWebhookDeliveries(&models.WebhookDeliveryQuery{OffsetID: "z"}); !errors.Is(err, coreiface.ErrBadRequest) {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+}
@@ -1,6 +1,9 @@
 package events
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Follow struct {
 	Notification
@@ -30,6 +33,8 @@ type ChatMessage struct {
 	Read      bool      `json:"read"`
 	Outgoing  bool      `json:"outgoing"`
 	Message   string    `json:"message"`
+
+	PaymentRequest json.RawMessage `json:"paymentRequest,omitempty"`
 }
 
 type ChatRead struct {
@@ -63,3 +68,11 @@ type AddressRequestResponse struct {
 	Address string `json:"address"`
 	Coin    string `json:"coin"`
 }
+
+type InventoryRequestResponse struct {
+	PeerID string `json:"peerID"`
+	Slug   string `json:"slug"`
+	// Available is the stock available keyed by SKU. Only
+	// tracked SKUs are included.
+	Available map[string]int64 `json:"available"`
+}
@@ -0,0 +1,29 @@
+package events
+
+import "time"
+
+// ListingExpiring is an event that gets pushed to the bus when
+// one of our listings is close to expiring.
+type ListingExpiring struct {
+	Notification
+	Slug   string    `json:"slug"`
+	Title  string    `json:"title"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// ListingRenewed is an event that gets pushed to the bus when
+// one of our listings is automatically renewed.
+type ListingRenewed struct {
+	Notification
+	Slug   string    `json:"slug"`
+	Title  string    `json:"title"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// ListingExpired is an event that gets pushed to the bus when
+// one of our listings expires and is removed from the index.
+type ListingExpired struct {
+	Notification
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+}
@@ -40,6 +40,15 @@ type OrderFunded struct {
 	Title       string       `json:"title"`
 }
 
+type OrderAutoConfirmed struct {
+	Notification
+	BuyerHandle string    `json:"buyerHandle"`
+	BuyerID     string    `json:"buyerID"`
+	OrderID     string    `json:"orderID"`
+	Thumbnail   Thumbnail `json:"thumbnail"`
+	Title       string    `json:"title"`
+}
+
 type OrderPaymentReceived struct {
 	Notification
 	OrderID      string `json:"orderID"`
@@ -47,6 +56,22 @@ type OrderPaymentReceived struct {
 	CoinType     string `json:"coinType"`
 }
 
+type OrderUnderpaid struct {
+	Notification
+	OrderID      string `json:"orderID"`
+	FundingTotal string `json:"fundingTotal"`
+	AmountOwed   string `json:"amountOwed"`
+	CoinType     string `json:"coinType"`
+}
+
+type OrderOverpaid struct {
+	Notification
+	OrderID      string `json:"orderID"`
+	FundingTotal string `json:"fundingTotal"`
+	Overpayment  string `json:"overpayment"`
+	CoinType     string `json:"coinType"`
+}
+
 type PaymentSentReceived struct {
 	OrderID string `json:"orderID"`
 	Txid    string `json:"transactionID"`
@@ -88,6 +113,15 @@ type Refund struct {
 	VendorID     string    `json:"vendorID"`
 }
 
+type OverpaymentRefund struct {
+	Notification
+	OrderID      string    `json:"orderID"`
+	Amount       string    `json:"amount"`
+	Thumbnail    Thumbnail `json:"thumbnail"`
+	VendorHandle string    `json:"vendorHandle"`
+	VendorID     string    `json:"vendorID"`
+}
+
 type OrderFulfillment struct {
 	Notification
 	OrderID      string    `json:"orderID"`
@@ -145,18 +179,21 @@ type DisputeAccepted struct {
 }
 
 type VendorDisputeTimeout struct {
+	Notification
 	OrderID   string    `json:"purchaseOrderID"`
 	ExpiresIn uint      `json:"expiresIn"`
 	Thumbnail Thumbnail `json:"thumbnail"`
 }
 
 type BuyerDisputeTimeout struct {
+	Notification
 	OrderID   string    `json:"orderID"`
-	ExpiresIn uint      `json:"expiresID"`
+	ExpiresIn uint      `json:"expiresIn"`
 	Thumbnail Thumbnail `json:"thumbnail"`
 }
 
 type BuyerDisputeExpiry struct {
+	Notification
 	OrderID   string    `json:"orderID"`
 	ExpiresIn uint      `json:"expiresIn"`
 	Thumbnail Thumbnail `json:"thumbnail"`
@@ -168,6 +205,7 @@ type VendorFinalizedPayment struct {
 }
 
 type ModeratorDisputeExpiry struct {
+	Notification
 	CaseID    string    `json:"disputeCaseID"`
 	ExpiresIn uint      `json:"expiresIn"`
 	Thumbnail Thumbnail `json:"thumbnail"`
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"errors"
 	"github.com/cpacia/openbazaar3.0/events"
 	"github.com/cpacia/openbazaar3.0/net/pb"
@@ -18,6 +19,18 @@ type ChatMessage struct {
 	Outgoing  bool      `json:"outgoing"`
 	Message   string    `json:"message"`
 	Sequence  int
+
+	// PaymentRequest is a serialized ChatPaymentRequest if the
+	// message requests a payment into an order.
+	PaymentRequest json.RawMessage `json:"paymentRequest,omitempty"`
+}
+
+// ChatPaymentRequest is a request, sent in a chat message, for the
+// remote peer to make a payment into an order.
+type ChatPaymentRequest struct {
+	Coin    string `json:"coin"`
+	Address string `json:"address"`
+	Amount  string `json:"amount"`
 }
 
 func NewChatMessageFromProto(peerID peer.ID, msg *pb.Message) (*ChatMessage, error) {
@@ -30,14 +43,26 @@ func NewChatMessageFromProto(peerID peer.ID, msg *pb.Message) (*ChatMessage, err
 		return nil, err
 	}
 
-	return &ChatMessage{
+	chatMessage := &ChatMessage{
 		MessageID: msg.MessageID,
 		PeerID:    peerID.Pretty(),
 		Message:   chtMsg.Message,
 		OrderID:   chtMsg.OrderID,
 		Timestamp: time.Unix(chtMsg.Timestamp.Seconds, int64(chtMsg.Timestamp.Nanos)),
 		Sequence:  int(msg.Sequence),
-	}, nil
+	}
+	if chtMsg.PaymentRequest != nil {
+		ser, err := json.Marshal(&ChatPaymentRequest{
+			Coin:    chtMsg.PaymentRequest.Coin,
+			Address: chtMsg.PaymentRequest.Address,
+			Amount:  chtMsg.PaymentRequest.Amount,
+		})
+		if err != nil {
+			return nil, err
+		}
+		chatMessage.PaymentRequest = ser
+	}
+	return chatMessage, nil
 }
 
 func (cm *ChatMessage) GetPeerID() (peer.ID, error) {
@@ -53,6 +78,8 @@ func (cm *ChatMessage) ToChatEvent() *events.ChatMessage {
 		Outgoing:  cm.Outgoing,
 		Read:      cm.Read,
 		Message:   cm.Message,
+
+		PaymentRequest: cm.PaymentRequest,
 	}
 }
 
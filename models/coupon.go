@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // Coupon is coupon for a listing with the given slug.
 // The hash is a multihash of the code. You can think
 // of the code as a password needed to use the coupon.
@@ -8,3 +10,14 @@ type Coupon struct {
 	Code string
 	Hash string
 }
+
+// CouponRedemption records a coupon used in an order we received as the
+// vendor. It is used to enforce the coupon's redemption limits and is
+// removed if the order is canceled or declined.
+type CouponRedemption struct {
+	OrderID    string `gorm:"primary_key"`
+	Slug       string `gorm:"primary_key"`
+	CouponHash string `gorm:"primary_key"`
+	BuyerID    string `gorm:"index"`
+	Timestamp  time.Time
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CrawlSource is how the crawler discovered a store.
+type CrawlSource string
+
+const (
+	// CrawlSourceDHT is used for stores found under the vendor key in the DHT.
+	CrawlSourceDHT CrawlSource = "dht"
+
+	// CrawlSourceFollowing is used for stores found in a following list.
+	CrawlSourceFollowing CrawlSource = "following"
+
+	// CrawlSourceFollowers is used for stores found in a followers list.
+	CrawlSourceFollowers CrawlSource = "followers"
+)
+
+// CrawledStore is a store discovered by the network crawler. The profile and
+// listing index are cached from the last crawl so that the node can serve a
+// directory of stores without going out to the network.
+//
+// A store is recorded with a zero LastCrawled as soon as it is discovered and
+// is fetched when the crawl budget allows. RootCID is empty until the first
+// successful fetch.
+type CrawledStore struct {
+	PeerID string      `gorm:"primary_key" json:"peerID"`
+	Source CrawlSource `json:"source"`
+
+	// RootCID is the IPNS value the cached data was fetched from. If it is
+	// unchanged on the next crawl nothing is re-fetched.
+	RootCID      string `json:"rootCID"`
+	Vendor       bool   `gorm:"index" json:"vendor"`
+	ListingCount int    `json:"listingCount"`
+
+	// SerializedProfile and SerializedListingIndex are the JSON encoded
+	// profile and listing index of the store.
+	SerializedProfile      []byte `json:"-"`
+	SerializedListingIndex []byte `json:"-"`
+
+	DiscoveredAt time.Time `json:"discoveredAt"`
+	LastCrawled  time.Time `gorm:"index" json:"lastCrawled"`
+
+	// LastError and Failures record unsuccessful fetches. Failures is
+	// reset by a successful fetch.
+	LastError string `json:"lastError,omitempty"`
+	Failures  int    `json:"failures"`
+}
+
+// IsFresh returns whether the store was crawled within maxAge.
+func (s *CrawledStore) IsFresh(maxAge time.Duration) bool {
+	return !s.LastCrawled.IsZero() && time.Since(s.LastCrawled) < maxAge
+}
+
+// Profile returns the cached profile or nil if the store has not yet been
+// fetched.
+func (s *CrawledStore) Profile() (*Profile, error) {
+	if len(s.SerializedProfile) == 0 {
+		return nil, nil
+	}
+	profile := new(Profile)
+	if err := json.Unmarshal(s.SerializedProfile, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// ListingIndex returns the cached listing index.
+func (s *CrawledStore) ListingIndex() (ListingIndex, error) {
+	var index ListingIndex
+	if len(s.SerializedListingIndex) == 0 {
+		return index, nil
+	}
+	if err := json.Unmarshal(s.SerializedListingIndex, &index); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// DirectoryEntry is a store in the directory built by the crawler.
+type DirectoryEntry struct {
+	PeerID       string    `json:"peerID"`
+	Vendor       bool      `json:"vendor"`
+	ListingCount int       `json:"listingCount"`
+	LastCrawled  time.Time `json:"lastCrawled"`
+	Profile      *Profile  `json:"profile"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCrawledStore(t *testing.T) {
+	store := &CrawledStore{PeerID: "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub"}
+	if store.IsFresh(time.Hour) {
+		t.Error("Store which has never been crawled should not be fresh")
+	}
+	profile, err := store.Profile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile != nil {
+		t.Error("Expected nil profile before the store is fetched")
+	}
+	index, err := store.ListingIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index) != 0 {
+		t.Errorf("Expected empty listing index, got %d", len(index))
+	}
+
+	store.LastCrawled = time.Now().Add(-time.Minute)
+	if !store.IsFresh(time.Hour) {
+		t.Error("Store should be fresh")
+	}
+	if store.IsFresh(time.Second) {
+		t.Error("Store should be stale")
+	}
+
+	store.SerializedProfile, err = json.Marshal(&Profile{Name: "Ron Swanson", Vendor: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.SerializedListingIndex, err = json.Marshal(ListingIndex{{Slug: "ron-swanson-shirt"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile, err = store.Profile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.Name != "Ron Swanson" || !profile.Vendor {
+		t.Errorf("Incorrect profile %v", profile)
+	}
+	index, err = store.ListingIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index) != 1 || index[0].Slug != "ron-swanson-shirt" {
+		t.Errorf("Incorrect listing index %v", index)
+	}
+}
@@ -144,6 +144,15 @@ func NewOrder() (*pb.OrderOpen, error) {
 			Script:           hex.EncodeToString(script),
 			Chaincode:        hex.EncodeToString(chaincode),
 		},
+		Taxes: []*pb.OrderOpen_Tax{
+			{
+				ListingHash:    listingHash.B58String(),
+				TaxType:        "Sales tax",
+				Percentage:     7,
+				Amount:         "272161",
+				ShippingAmount: "54432",
+			},
+		},
 		RatingKeys:           [][]byte{ratingKey},
 		AlternateContactInfo: "peter@familyguy.net",
 	}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"encoding/json"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"strconv"
+	"strings"
+)
+
+// InventoryItem is the stock level for a single SKU of one of our
+// listings. A negative quantity means the stock is not tracked and
+// the item can be purchased in any amount.
+type InventoryItem struct {
+	Slug string `gorm:"primary_key" json:"slug"`
+	SKU  string `gorm:"primary_key" json:"sku"`
+
+	// Quantity is the number of units in stock including
+	// those reserved by funded orders.
+	Quantity int64 `json:"quantity"`
+
+	// Reserved is the number of units held for funded orders
+	// which have not yet been confirmed.
+	Reserved int64 `json:"reserved"`
+
+	// ListingQuantity is the quantity set in the listing the last
+	// time the inventory was synced with it. The inventory is only
+	// overwritten from the listing if the listing quantity changes.
+	ListingQuantity int64 `json:"-"`
+}
+
+// Tracked returns whether the stock for this item is limited.
+func (i *InventoryItem) Tracked() bool {
+	return i.Quantity >= 0
+}
+
+// Available returns the number of units that can be purchased. It
+// returns -1 if the stock is not tracked.
+func (i *InventoryItem) Available() int64 {
+	if !i.Tracked() {
+		return -1
+	}
+	if i.Reserved >= i.Quantity {
+		return 0
+	}
+	return i.Quantity - i.Reserved
+}
+
+// MarshalJSON adds the available quantity to the JSON serialization.
+func (i InventoryItem) MarshalJSON() ([]byte, error) {
+	type inventoryItem InventoryItem
+	return json.Marshal(struct {
+		inventoryItem
+		Available int64 `json:"available"`
+	}{
+		inventoryItem: inventoryItem(i),
+		Available:     i.Available(),
+	})
+}
+
+// InventoryReservation records the stock held by an order so that it
+// can be returned if the order does not go through. Committed is set
+// once the quantity has been taken out of the stock on confirmation.
+type InventoryReservation struct {
+	OrderID   string `gorm:"primary_key"`
+	Slug      string `gorm:"primary_key"`
+	SKU       string `gorm:"primary_key"`
+	Quantity  int64
+	Committed bool
+}
+
+// SKUKey returns the inventory key for the SKU. It is made up of the
+// lowercase option and variant names of the SKU's selections, for
+// example "size:large/color:red". A listing without options has a
+// single SKU with an empty key.
+func SKUKey(sku *pb.Listing_Item_Sku) string {
+	selections := make([]string, 0, len(sku.Selections))
+	for _, sel := range sku.Selections {
+		selections = append(selections, strings.ToLower(sel.Option)+":"+strings.ToLower(sel.Variant))
+	}
+	return strings.Join(selections, "/")
+}
+
+// SKUQuantity parses the quantity of the SKU. A quantity which is
+// not set or does not parse is treated as not tracked.
+func SKUQuantity(sku *pb.Listing_Item_Sku) int64 {
+	quantity, err := strconv.ParseInt(sku.Quantity, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return quantity
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"testing"
+)
+
+func TestSKUKey(t *testing.T) {
+	tests := []struct {
+		sku      *pb.Listing_Item_Sku
+		expected string
+	}{
+		{
+			sku:      &pb.Listing_Item_Sku{},
+			expected: "",
+		},
+		{
+			sku: &pb.Listing_Item_Sku{
+				Selections: []*pb.Listing_Item_Sku_Selection{
+					{Option: "Size", Variant: "Large"},
+				},
+			},
+			expected: "size:large",
+		},
+		{
+			sku: &pb.Listing_Item_Sku{
+				Selections: []*pb.Listing_Item_Sku_Selection{
+					{Option: "Size", Variant: "Large"},
+					{Option: "Color", Variant: "Red"},
+				},
+			},
+			expected: "size:large/color:red",
+		},
+	}
+
+	for i, test := range tests {
+		if key := SKUKey(test.sku); key != test.expected {
+			t.Errorf("Test %d: expected key %s, got %s", i, test.expected, key)
+		}
+	}
+}
+
+func TestSKUQuantity(t *testing.T) {
+	tests := []struct {
+		quantity string
+		expected int64
+	}{
+		{"12", 12},
+		{"0", 0},
+		{"-1", -1},
+		{"", -1},
+		{"abc", -1},
+	}
+
+	for i, test := range tests {
+		if q := SKUQuantity(&pb.Listing_Item_Sku{Quantity: test.quantity}); q != test.expected {
+			t.Errorf("Test %d: expected quantity %d, got %d", i, test.expected, q)
+		}
+	}
+}
+
+func TestInventoryItem_Available(t *testing.T) {
+	tests := []struct {
+		item      InventoryItem
+		tracked   bool
+		available int64
+	}{
+		{
+			item:      InventoryItem{Quantity: -1},
+			tracked:   false,
+			available: -1,
+		},
+		{
+			item:      InventoryItem{Quantity: 10, Reserved: 3},
+			tracked:   true,
+			available: 7,
+		},
+		{
+			item:      InventoryItem{Quantity: 2, Reserved: 3},
+			tracked:   true,
+			available: 0,
+		},
+	}
+
+	for i, test := range tests {
+		if test.item.Tracked() != test.tracked {
+			t.Errorf("Test %d: expected tracked %t", i, test.tracked)
+		}
+		if a := test.item.Available(); a != test.available {
+			t.Errorf("Test %d: expected available %d, got %d", i, test.available, a)
+		}
+	}
+}
@@ -0,0 +1,387 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/golang/protobuf/ptypes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ListingFileFormat is a file format used to import and export listings.
+type ListingFileFormat string
+
+const (
+	// ListingFileFormatCSV is a flat CSV file with one listing per row.
+	ListingFileFormatCSV ListingFileFormat = "csv"
+
+	// ListingFileFormatJSON is a JSON array of listings in the jsonpb format.
+	ListingFileFormatJSON ListingFileFormat = "json"
+)
+
+// ListingImportResult is returned from a listing import. It contains the
+// slugs of the listings which were imported and the reason each of the
+// other rows failed. If DryRun is set nothing was actually saved.
+type ListingImportResult struct {
+	DryRun   bool                 `json:"dryRun"`
+	Imported []string             `json:"imported"`
+	Errors   []ListingImportError `json:"errors"`
+}
+
+// ListingImportError is the reason a row in an import file was not
+// imported. For CSV files the row is the record number in the file with
+// the header as row 1. For JSON files it's the position of the listing in
+// the array starting at 1.
+type ListingImportError struct {
+	Row   int    `json:"row"`
+	Slug  string `json:"slug,omitempty"`
+	Error string `json:"error"`
+}
+
+// ListingCSVHeader is the header row of the listing CSV format.
+//
+// Columns which hold a list separate their entries with a semicolon and
+// the fields within each entry with a pipe:
+//
+//	options:         Size=Small|Large;Color=Red|Green
+//	skus:            selections|quantity|surcharge|productID where the selections
+//	                 are Option:Variant pairs separated by a slash, for example
+//	                 Size:Large/Color:Red|12|0|SHIRT-LR
+//	shippingOptions: name|type|regions|services where the regions are comma
+//	                 separated country codes and the services are comma separated
+//	                 name:price:additionalItemPrice:estimatedDelivery entries
+//	images:          a URL, ipfs://<cid>/<filename> or the path to a local file
+//	                 relative to the listing-import directory in the data directory
+var ListingCSVHeader = []string{
+	"slug",
+	"title",
+	"description",
+	"contractType",
+	"format",
+	"condition",
+	"price",
+	"pricingCurrency",
+	"acceptedCurrencies",
+	"processingTime",
+	"grams",
+	"nsfw",
+	"tags",
+	"categories",
+	"options",
+	"skus",
+	"shippingOptions",
+	"images",
+	"termsAndConditions",
+	"refundPolicy",
+	"expiry",
+}
+
+const (
+	csvListSeparator  = ";"
+	csvFieldSeparator = "|"
+)
+
+// ParseListingCSVHeader returns a map of column name to index for the
+// header row of a listing CSV file. The columns may be in any order but
+// each must be one of the ListingCSVHeader columns.
+func ParseListingCSVHeader(row []string) (map[string]int, error) {
+	known := make(map[string]bool)
+	for _, col := range ListingCSVHeader {
+		known[col] = true
+	}
+	header := make(map[string]int)
+	for i, col := range row {
+		col = strings.TrimSpace(col)
+		if !known[col] {
+			return nil, fmt.Errorf("unknown column %s", col)
+		}
+		if _, ok := header[col]; ok {
+			return nil, fmt.Errorf("duplicate column %s", col)
+		}
+		header[col] = i
+	}
+	if _, ok := header["title"]; !ok {
+		return nil, errors.New("missing title column")
+	}
+	return header, nil
+}
+
+// ListingToCSVRecord flattens the listing into a row of the listing CSV
+// format. The columns are in the order of ListingCSVHeader.
+func ListingToCSVRecord(listing *pb.Listing) []string {
+	var (
+		metadata = listing.Metadata
+		item     = listing.Item
+	)
+	if metadata == nil {
+		metadata = &pb.Listing_Metadata{}
+	}
+	if item == nil {
+		item = &pb.Listing_Item{}
+	}
+
+	pricingCurrency := ""
+	if metadata.PricingCurrency != nil {
+		pricingCurrency = metadata.PricingCurrency.Code
+	}
+
+	expiry := ""
+	if metadata.Expiry != nil {
+		if t, err := ptypes.Timestamp(metadata.Expiry); err == nil {
+			expiry = t.UTC().Format(time.RFC3339)
+		}
+	}
+
+	options := make([]string, 0, len(item.Options))
+	for _, opt := range item.Options {
+		variants := make([]string, 0, len(opt.Variants))
+		for _, v := range opt.Variants {
+			variants = append(variants, v.Name)
+		}
+		options = append(options, opt.Name+"="+strings.Join(variants, csvFieldSeparator))
+	}
+
+	skus := make([]string, 0, len(item.Skus))
+	for _, sku := range item.Skus {
+		selections := make([]string, 0, len(sku.Selections))
+		for _, sel := range sku.Selections {
+			selections = append(selections, sel.Option+":"+sel.Variant)
+		}
+		skus = append(skus, strings.Join([]string{
+			strings.Join(selections, "/"),
+			sku.Quantity,
+			sku.Surcharge,
+			sku.ProductID,
+		}, csvFieldSeparator))
+	}
+
+	shippingOptions := make([]string, 0, len(listing.ShippingOptions))
+	for _, so := range listing.ShippingOptions {
+		regions := make([]string, 0, len(so.Regions))
+		for _, r := range so.Regions {
+			regions = append(regions, r.String())
+		}
+		services := make([]string, 0, len(so.Services))
+		for _, s := range so.Services {
+			services = append(services, strings.Join([]string{s.Name, s.Price, s.AdditionalItemPrice, s.EstimatedDelivery}, ":"))
+		}
+		shippingOptions = append(shippingOptions, strings.Join([]string{
+			so.Name,
+			so.Type.String(),
+			strings.Join(regions, ","),
+			strings.Join(services, ","),
+		}, csvFieldSeparator))
+	}
+
+	images := make([]string, 0, len(item.Images))
+	for _, img := range item.Images {
+		images = append(images, "ipfs://"+img.Original+"/"+img.Filename)
+	}
+
+	return []string{
+		listing.Slug,
+		item.Title,
+		item.Description,
+		metadata.ContractType.String(),
+		metadata.Format.String(),
+		item.Condition,
+		item.Price,
+		pricingCurrency,
+		strings.Join(metadata.AcceptedCurrencies, csvListSeparator),
+		item.ProcessingTime,
+		strconv.FormatFloat(float64(item.Grams), 'f', -1, 32),
+		strconv.FormatBool(item.Nsfw),
+		strings.Join(item.Tags, csvListSeparator),
+		strings.Join(item.Categories, csvListSeparator),
+		strings.Join(options, csvListSeparator),
+		strings.Join(skus, csvListSeparator),
+		strings.Join(shippingOptions, csvListSeparator),
+		strings.Join(images, csvListSeparator),
+		listing.TermsAndConditions,
+		listing.RefundPolicy,
+		expiry,
+	}
+}
+
+// ListingFromCSVRecord builds a listing from a row of the listing CSV
+// format. The header maps each column name to its index in the record.
+// The images are not set on the listing. Instead the image references
+// from the images column are returned so that the caller can fetch them.
+func ListingFromCSVRecord(header map[string]int, record []string) (*pb.Listing, []string, error) {
+	get := func(col string) string {
+		i, ok := header[col]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+	split := func(col string) []string {
+		var ret []string
+		for _, s := range strings.Split(get(col), csvListSeparator) {
+			if s = strings.TrimSpace(s); s != "" {
+				ret = append(ret, s)
+			}
+		}
+		return ret
+	}
+
+	listing := &pb.Listing{
+		Slug: strings.TrimSpace(get("slug")),
+		Metadata: &pb.Listing_Metadata{
+			AcceptedCurrencies: split("acceptedCurrencies"),
+		},
+		Item: &pb.Listing_Item{
+			Title:          get("title"),
+			Description:    get("description"),
+			Condition:      get("condition"),
+			Price:          strings.TrimSpace(get("price")),
+			ProcessingTime: get("processingTime"),
+			Tags:           split("tags"),
+			Categories:     split("categories"),
+		},
+		TermsAndConditions: get("termsAndConditions"),
+		RefundPolicy:       get("refundPolicy"),
+	}
+
+	if s := strings.TrimSpace(get("contractType")); s != "" {
+		ct, ok := pb.Listing_Metadata_ContractType_value[strings.ToUpper(s)]
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid contract type %s", s)
+		}
+		listing.Metadata.ContractType = pb.Listing_Metadata_ContractType(ct)
+	}
+
+	if s := strings.TrimSpace(get("format")); s != "" {
+		format, ok := pb.Listing_Metadata_Format_value[strings.ToUpper(s)]
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid format %s", s)
+		}
+		listing.Metadata.Format = pb.Listing_Metadata_Format(format)
+	}
+
+	if s := strings.TrimSpace(get("pricingCurrency")); s != "" {
+		def, err := CurrencyDefinitions.Lookup(s)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid pricing currency %s", s)
+		}
+		listing.Metadata.PricingCurrency = &pb.Currency{
+			Code:         def.Code.String(),
+			Divisibility: uint32(def.Divisibility),
+		}
+	}
+
+	if s := strings.TrimSpace(get("grams")); s != "" {
+		grams, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid grams %s", s)
+		}
+		listing.Item.Grams = float32(grams)
+	}
+
+	if s := strings.TrimSpace(get("nsfw")); s != "" {
+		nsfw, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid nsfw %s", s)
+		}
+		listing.Item.Nsfw = nsfw
+	}
+
+	if s := strings.TrimSpace(get("expiry")); s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid expiry %s", s)
+		}
+		listing.Metadata.Expiry, err = ptypes.TimestampProto(t)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, s := range split("options") {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 {
+			return nil, nil, fmt.Errorf("invalid option %s", s)
+		}
+		opt := &pb.Listing_Item_Option{Name: strings.TrimSpace(parts[0])}
+		for _, v := range strings.Split(parts[1], csvFieldSeparator) {
+			opt.Variants = append(opt.Variants, &pb.Listing_Item_Option_Variant{Name: strings.TrimSpace(v)})
+		}
+		listing.Item.Options = append(listing.Item.Options, opt)
+	}
+
+	for _, s := range split("skus") {
+		fields := strings.Split(s, csvFieldSeparator)
+		if len(fields) > 4 {
+			return nil, nil, fmt.Errorf("invalid sku %s", s)
+		}
+		for len(fields) < 4 {
+			fields = append(fields, "")
+		}
+		sku := &pb.Listing_Item_Sku{
+			Quantity:  strings.TrimSpace(fields[1]),
+			Surcharge: strings.TrimSpace(fields[2]),
+			ProductID: strings.TrimSpace(fields[3]),
+		}
+		if sku.Surcharge == "" {
+			sku.Surcharge = "0"
+		}
+		if sel := strings.TrimSpace(fields[0]); sel != "" {
+			for _, pair := range strings.Split(sel, "/") {
+				parts := strings.SplitN(pair, ":", 2)
+				if len(parts) != 2 {
+					return nil, nil, fmt.Errorf("invalid sku selection %s", pair)
+				}
+				sku.Selections = append(sku.Selections, &pb.Listing_Item_Sku_Selection{
+					Option:  strings.TrimSpace(parts[0]),
+					Variant: strings.TrimSpace(parts[1]),
+				})
+			}
+		}
+		listing.Item.Skus = append(listing.Item.Skus, sku)
+	}
+
+	for _, s := range split("shippingOptions") {
+		fields := strings.Split(s, csvFieldSeparator)
+		if len(fields) != 4 {
+			return nil, nil, fmt.Errorf("invalid shipping option %s", s)
+		}
+		typ, ok := pb.Listing_ShippingOption_ShippingType_value[strings.ToUpper(strings.TrimSpace(fields[1]))]
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid shipping type %s", fields[1])
+		}
+		so := &pb.Listing_ShippingOption{
+			Name: strings.TrimSpace(fields[0]),
+			Type: pb.Listing_ShippingOption_ShippingType(typ),
+		}
+		for _, r := range strings.Split(fields[2], ",") {
+			if r = strings.TrimSpace(r); r == "" {
+				continue
+			}
+			code, ok := pb.CountryCode_value[strings.ToUpper(r)]
+			if !ok {
+				return nil, nil, fmt.Errorf("invalid shipping region %s", r)
+			}
+			so.Regions = append(so.Regions, pb.CountryCode(code))
+		}
+		for _, service := range strings.Split(fields[3], ",") {
+			if service = strings.TrimSpace(service); service == "" {
+				continue
+			}
+			parts := strings.SplitN(service, ":", 4)
+			if len(parts) != 4 {
+				return nil, nil, fmt.Errorf("invalid shipping service %s", service)
+			}
+			so.Services = append(so.Services, &pb.Listing_ShippingOption_Service{
+				Name:                strings.TrimSpace(parts[0]),
+				Price:               strings.TrimSpace(parts[1]),
+				AdditionalItemPrice: strings.TrimSpace(parts[2]),
+				EstimatedDelivery:   strings.TrimSpace(parts[3]),
+			})
+		}
+		listing.ShippingOptions = append(listing.ShippingOptions, so)
+	}
+
+	return listing, split("images"), nil
+}
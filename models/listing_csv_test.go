@@ -0,0 +1,196 @@
+package models
+
+import (
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"testing"
+)
+
+func TestParseListingCSVHeader(t *testing.T) {
+	tests := []struct {
+		row       []string
+		expectErr bool
+	}{
+		{
+			row:       ListingCSVHeader,
+			expectErr: false,
+		},
+		{
+			row:       []string{"price", "title"},
+			expectErr: false,
+		},
+		{
+			row:       []string{"slug", "price"},
+			expectErr: true,
+		},
+		{
+			row:       []string{"title", "color"},
+			expectErr: true,
+		},
+		{
+			row:       []string{"title", "title"},
+			expectErr: true,
+		},
+	}
+
+	for i, test := range tests {
+		header, err := ParseListingCSVHeader(test.row)
+		if test.expectErr && err == nil {
+			t.Errorf("Test %d: expected error got nil", i)
+		} else if !test.expectErr && err != nil {
+			t.Errorf("Test %d: unexpected error: %s", i, err)
+		}
+		if err == nil && header["title"] != indexOf(test.row, "title") {
+			t.Errorf("Test %d: incorrect title index %d", i, header["title"])
+		}
+	}
+}
+
+func TestListingCSVRecord(t *testing.T) {
+	listing := &pb.Listing{
+		Slug:               "ron-swanson-tshirt",
+		TermsAndConditions: "No returns",
+		RefundPolicy:       "Refunds within 30 days",
+		Metadata: &pb.Listing_Metadata{
+			ContractType:       pb.Listing_Metadata_PHYSICAL_GOOD,
+			Format:             pb.Listing_Metadata_FIXED_PRICE,
+			AcceptedCurrencies: []string{"BTC", "BCH"},
+			PricingCurrency:    &pb.Currency{Code: "USD", Divisibility: 2},
+			Expiry:             &timestamp.Timestamp{Seconds: 2147483647},
+		},
+		Item: &pb.Listing_Item{
+			Title:          "Ron Swanson Tshirt",
+			Description:    "A shirt, with a comma",
+			Condition:      "new",
+			Price:          "100",
+			ProcessingTime: "3 days",
+			Grams:          14.5,
+			Nsfw:           true,
+			Tags:           []string{"tshirts", "swanson"},
+			Categories:     []string{"clothing"},
+			Options: []*pb.Listing_Item_Option{
+				{
+					Name: "Size",
+					Variants: []*pb.Listing_Item_Option_Variant{
+						{Name: "Small"},
+						{Name: "Large"},
+					},
+				},
+				{
+					Name: "Color",
+					Variants: []*pb.Listing_Item_Option_Variant{
+						{Name: "Red"},
+					},
+				},
+			},
+			Skus: []*pb.Listing_Item_Sku{
+				{
+					Selections: []*pb.Listing_Item_Sku_Selection{
+						{Option: "Size", Variant: "Small"},
+						{Option: "Color", Variant: "Red"},
+					},
+					Quantity:  "12",
+					Surcharge: "0",
+					ProductID: "SHIRT-SR",
+				},
+				{
+					Selections: []*pb.Listing_Item_Sku_Selection{
+						{Option: "Size", Variant: "Large"},
+						{Option: "Color", Variant: "Red"},
+					},
+					Quantity:  "4",
+					Surcharge: "50",
+					ProductID: "SHIRT-LR",
+				},
+			},
+		},
+		ShippingOptions: []*pb.Listing_ShippingOption{
+			{
+				Name:    "Domestic",
+				Type:    pb.Listing_ShippingOption_FIXED_PRICE,
+				Regions: []pb.CountryCode{pb.CountryCode_UNITED_STATES, pb.CountryCode_CANADA},
+				Services: []*pb.Listing_ShippingOption_Service{
+					{Name: "Standard", Price: "500", AdditionalItemPrice: "100", EstimatedDelivery: "5 days"},
+					{Name: "Express", Price: "1500", AdditionalItemPrice: "0", EstimatedDelivery: "1 day"},
+				},
+			},
+		},
+	}
+
+	record := ListingToCSVRecord(listing)
+	if len(record) != len(ListingCSVHeader) {
+		t.Fatalf("Expected %d columns, got %d", len(ListingCSVHeader), len(record))
+	}
+
+	header, err := ParseListingCSVHeader(ListingCSVHeader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, images, err := ListingFromCSVRecord(header, record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(images) != 0 {
+		t.Errorf("Expected no images, got %d", len(images))
+	}
+	if !proto.Equal(listing, parsed) {
+		t.Errorf("Parsed listing does not match.\nExpected: %s\nGot: %s", listing, parsed)
+	}
+
+	// Image references are returned rather than set on the listing.
+	listing.Item.Images = []*pb.Listing_Item_Image{
+		{Filename: "shirt.jpg", Original: "QmdHkAQN4nBnKCMtRjAhm4zo6Nid5zNyvQ2Tuqjyd1wZVB"},
+	}
+	_, images, err = ListingFromCSVRecord(header, ListingToCSVRecord(listing))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(images) != 1 || images[0] != "ipfs://QmdHkAQN4nBnKCMtRjAhm4zo6Nid5zNyvQ2Tuqjyd1wZVB/shirt.jpg" {
+		t.Errorf("Incorrect image references %v", images)
+	}
+}
+
+func TestListingFromCSVRecord_Errors(t *testing.T) {
+	header, err := ParseListingCSVHeader(ListingCSVHeader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		column string
+		value  string
+	}{
+		{column: "contractType", value: "NOT_A_TYPE"},
+		{column: "format", value: "AUCTION"},
+		{column: "pricingCurrency", value: "XYZ123"},
+		{column: "grams", value: "heavy"},
+		{column: "nsfw", value: "maybe"},
+		{column: "expiry", value: "tomorrow"},
+		{column: "options", value: "Size"},
+		{column: "skus", value: "Size:Large|1|0|id|extra"},
+		{column: "skus", value: "Size|1|0|id"},
+		{column: "shippingOptions", value: "Domestic|FIXED_PRICE"},
+		{column: "shippingOptions", value: "Domestic|FREE|UNITED_STATES|Standard:0:0:1 day"},
+		{column: "shippingOptions", value: "Domestic|FIXED_PRICE|NARNIA|Standard:0:0:1 day"},
+		{column: "shippingOptions", value: "Domestic|FIXED_PRICE|UNITED_STATES|Standard"},
+	}
+
+	for i, test := range tests {
+		record := make([]string, len(ListingCSVHeader))
+		record[header["title"]] = "Ron Swanson Tshirt"
+		record[header[test.column]] = test.value
+		if _, _, err := ListingFromCSVRecord(header, record); err == nil {
+			t.Errorf("Test %d: expected error for %s %s", i, test.column, test.value)
+		}
+	}
+}
+
+func indexOf(row []string, col string) int {
+	for i, c := range row {
+		if c == col {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/OpenBazaar/jsonpb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/golang/protobuf/proto"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ListingVersion is a version of one of our listings saved in the
+// listing history. A new version is recorded each time a listing is
+// saved with a different CID.
+type ListingVersion struct {
+	ID        uint      `gorm:"primary_key" json:"-"`
+	Slug      string    `gorm:"index" json:"slug"`
+	CID       string    `json:"cid"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// Summary is a short description of the fields which changed
+	// from the previous version.
+	Summary string `json:"summary"`
+
+	SerializedListing []byte `json:"-"`
+}
+
+// SignedListing returns the signed listing saved with this version.
+func (v *ListingVersion) SignedListing() (*pb.SignedListing, error) {
+	sl := new(pb.SignedListing)
+	if err := proto.Unmarshal(v.SerializedListing, sl); err != nil {
+		return nil, err
+	}
+	return sl, nil
+}
+
+// ListingChange is a single field which differs between two versions of
+// a listing. The path uses the JSON field names, for example
+// item.skus[0].quantity. Old is nil if the field was added and New is nil
+// if it was removed.
+type ListingChange struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old"`
+	New  interface{} `json:"new"`
+}
+
+// ListingDiff is the structured difference between two versions of a
+// listing.
+type ListingDiff struct {
+	Slug    string          `json:"slug"`
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	Changes []ListingChange `json:"changes"`
+}
+
+// DiffListings returns each of the fields which differ between the two
+// listings. Either listing may be nil in which case every field in the
+// other is returned as a change.
+func DiffListings(from, to *pb.Listing) ([]ListingChange, error) {
+	a, err := listingToJSONObject(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := listingToJSONObject(to)
+	if err != nil {
+		return nil, err
+	}
+	changes := []ListingChange{}
+	diffJSONValues("", a, b, &changes)
+	return changes, nil
+}
+
+// SummarizeListingChanges returns a short description of the changes
+// listing the top level fields which changed, for example
+// "changed item.price, item.skus".
+func SummarizeListingChanges(changes []ListingChange) string {
+	if len(changes) == 0 {
+		return "no changes"
+	}
+	var (
+		fields []string
+		seen   = make(map[string]bool)
+	)
+	for _, change := range changes {
+		parts := strings.SplitN(change.Path, ".", 3)
+		field := parts[0]
+		if len(parts) > 1 {
+			field += "." + parts[1]
+		}
+		if i := strings.Index(field, "["); i >= 0 {
+			field = field[:i]
+		}
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return "changed " + strings.Join(fields, ", ")
+}
+
+// listingToJSONObject converts the listing into its generic JSON form so
+// that it can be compared field by field.
+func listingToJSONObject(listing *pb.Listing) (interface{}, error) {
+	if listing == nil {
+		return map[string]interface{}{}, nil
+	}
+	m := jsonpb.Marshaler{}
+	ser, err := m.MarshalToString(listing)
+	if err != nil {
+		return nil, err
+	}
+	var obj interface{}
+	if err := json.Unmarshal([]byte(ser), &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// diffJSONValues recursively compares two generic JSON values and appends
+// a change for each leaf that differs. Objects are compared key by key and
+// arrays index by index. Anything else is compared as a whole.
+func diffJSONValues(path string, a, b interface{}, changes *[]ListingChange) {
+	mapA, okA := a.(map[string]interface{})
+	mapB, okB := b.(map[string]interface{})
+	if okA && okB {
+		keys := make([]string, 0, len(mapA)+len(mapB))
+		for k := range mapA {
+			keys = append(keys, k)
+		}
+		for k := range mapB {
+			if _, ok := mapA[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			diffJSONValues(p, mapA[k], mapB[k], changes)
+		}
+		return
+	}
+
+	sliceA, okA := a.([]interface{})
+	sliceB, okB := b.([]interface{})
+	if okA && okB {
+		n := len(sliceA)
+		if len(sliceB) > n {
+			n = len(sliceB)
+		}
+		for i := 0; i < n; i++ {
+			var va, vb interface{}
+			if i < len(sliceA) {
+				va = sliceA[i]
+			}
+			if i < len(sliceB) {
+				vb = sliceB[i]
+			}
+			diffJSONValues(fmt.Sprintf("%s[%d]", path, i), va, vb, changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, ListingChange{Path: path, Old: a, New: b})
+	}
+}
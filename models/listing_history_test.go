@@ -0,0 +1,71 @@
+package models
+
+import (
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"reflect"
+	"testing"
+)
+
+func TestDiffListings(t *testing.T) {
+	from := &pb.Listing{
+		Slug: "ron-swanson-tshirt",
+		Item: &pb.Listing_Item{
+			Title: "Ron Swanson Tshirt",
+			Price: "100",
+			Tags:  []string{"tshirts"},
+			Skus: []*pb.Listing_Item_Sku{
+				{Quantity: "12", ProductID: "1"},
+			},
+		},
+	}
+	to := &pb.Listing{
+		Slug: "ron-swanson-tshirt",
+		Item: &pb.Listing_Item{
+			Title:       "Ron Swanson Tshirt",
+			Description: "Example item",
+			Price:       "200",
+			Tags:        []string{"tshirts", "swanson"},
+			Skus: []*pb.Listing_Item_Sku{
+				{Quantity: "10", ProductID: "1"},
+			},
+		},
+	}
+
+	changes, err := DiffListings(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []ListingChange{
+		{Path: "item.description", Old: nil, New: "Example item"},
+		{Path: "item.price", Old: "100", New: "200"},
+		{Path: "item.skus[0].quantity", Old: "12", New: "10"},
+		{Path: "item.tags[1]", Old: nil, New: "swanson"},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected changes %v, got %v", expected, changes)
+	}
+
+	summary := SummarizeListingChanges(changes)
+	if summary != "changed item.description, item.price, item.skus, item.tags" {
+		t.Errorf("Incorrect summary: %s", summary)
+	}
+
+	changes, err = DiffListings(from, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+	if summary := SummarizeListingChanges(changes); summary != "no changes" {
+		t.Errorf("Incorrect summary: %s", summary)
+	}
+
+	changes, err = DiffListings(nil, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 || changes[0].Path != "item" || changes[1].Path != "slug" {
+		t.Errorf("Incorrect changes from nil listing %v", changes)
+	}
+}
@@ -75,6 +75,7 @@ type ListingMetadata struct {
 	Slug               string           `json:"slug"`
 	Title              string           `json:"title"`
 	Categories         []string         `json:"categories"`
+	Tags               []string         `json:"tags"`
 	NSFW               bool             `json:"nsfw"`
 	ContractType       string           `json:"contractType"`
 	Description        string           `json:"description"`
@@ -130,6 +131,7 @@ func NewListingMetadataFromListing(listing *pb.Listing, cid cid.Cid) (*ListingMe
 		Slug:         listing.Slug,
 		Title:        listing.Item.Title,
 		Categories:   listing.Item.Categories,
+		Tags:         listing.Item.Tags,
 		NSFW:         listing.Item.Nsfw,
 		CoinType:     listing.Metadata.PricingCurrency.Code,
 		ContractType: listing.Metadata.ContractType.String(),
@@ -10,7 +10,39 @@ import (
 // make this model suitable for the database.
 type NotificationRecord struct {
 	ID           string          `gorm:"primary_key" json:"-"`
+	Type         string          `gorm:"index" json:"-"`
 	Timestamp    time.Time       `json:"timestamp"`
 	Read         bool            `json:"read"`
 	Notification json.RawMessage `json:"notification"`
 }
+
+// NotificationQuery is used to filter and paginate the notifications
+// returned from GetNotifications. Zero values are ignored.
+type NotificationQuery struct {
+	// Types restricts the results to notifications of any of these types.
+	Types []string `json:"types"`
+
+	// UnreadOnly restricts the results to unread notifications.
+	UnreadOnly bool `json:"unreadOnly"`
+
+	// Limit is the maximum number of notifications returned. Zero or less
+	// means no limit.
+	Limit int `json:"limit"`
+
+	// OffsetID is the ID of the last notification in the previous page.
+	OffsetID string `json:"offsetID"`
+}
+
+// NotificationList is a page of notifications matching a NotificationQuery,
+// newest first.
+type NotificationList struct {
+	Notifications []NotificationRecord `json:"notifications"`
+
+	// Total is the number of notifications matching the query across
+	// all pages.
+	Total int `json:"total"`
+
+	// NextOffsetID is the OffsetID to use to fetch the next page. It is
+	// empty if there are no more pages.
+	NextOffsetID string `json:"nextOffsetID"`
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"encoding/json"
+	"github.com/OpenBazaar/jsonpb"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/golang/protobuf/proto"
+)
+
+// OrderMessageRecord is a single order message as returned when
+// inspecting the messages of an order.
+type OrderMessageRecord struct {
+	MessageType string          `json:"messageType"`
+	Message     json.RawMessage `json:"message"`
+	Error       string          `json:"error,omitempty"`
+}
+
+// OrderMessages holds all the messages we have for an order split by
+// whether they were processed, are parked waiting on a message which
+// has not arrived yet, or failed processing.
+type OrderMessages struct {
+	OrderID   OrderID              `json:"orderID"`
+	Processed []OrderMessageRecord `json:"processed"`
+	Parked    []OrderMessageRecord `json:"parked"`
+	Errored   []OrderMessageRecord `json:"errored"`
+}
+
+// Messages returns the processed, parked and errored messages for the order.
+func (o *Order) Messages() (*OrderMessages, error) {
+	messages := &OrderMessages{
+		OrderID:   o.ID,
+		Processed: []OrderMessageRecord{},
+		Parked:    []OrderMessageRecord{},
+		Errored:   []OrderMessageRecord{},
+	}
+
+	single := []struct {
+		messageType npb.OrderMessage_MessageType
+		serialized  json.RawMessage
+	}{
+		{npb.OrderMessage_ORDER_OPEN, o.SerializedOrderOpen},
+		{npb.OrderMessage_ORDER_REJECT, o.SerializedOrderReject},
+		{npb.OrderMessage_ORDER_CANCEL, o.SerializedOrderCancel},
+		{npb.OrderMessage_ORDER_CONFIRMATION, o.SerializedOrderConfirmation},
+		{npb.OrderMessage_RATING_SIGNATURES, o.SerializedRatingSignatures},
+		{npb.OrderMessage_ORDER_COMPLETE, o.SerializedOrderComplete},
+		{npb.OrderMessage_DISPUTE_OPEN, o.SerializedDisputeOpen},
+		{npb.OrderMessage_DISPUTE_UPDATE, o.SerializedDisputeUpdate},
+		{npb.OrderMessage_DISPUTE_CLOSE, o.SerializedDisputeClosed},
+		{npb.OrderMessage_PAYMENT_FINALIZED, o.SerializedPaymentFinalized},
+	}
+	for _, m := range single {
+		if m.serialized == nil {
+			continue
+		}
+		messages.Processed = append(messages.Processed, OrderMessageRecord{
+			MessageType: m.messageType.String(),
+			Message:     m.serialized,
+		})
+	}
+
+	appendProcessed := func(messageType npb.OrderMessage_MessageType, message proto.Message) error {
+		ser, err := marshaler.MarshalToString(message)
+		if err != nil {
+			return err
+		}
+		messages.Processed = append(messages.Processed, OrderMessageRecord{
+			MessageType: messageType.String(),
+			Message:     json.RawMessage(ser),
+		})
+		return nil
+	}
+	if o.SerializedPaymentSent != nil {
+		paymentSentList := new(pb.PaymentSentList)
+		if err := jsonpb.UnmarshalString(string(o.SerializedPaymentSent), paymentSentList); err != nil {
+			return nil, err
+		}
+		for _, payment := range paymentSentList.Messages {
+			if err := appendProcessed(npb.OrderMessage_PAYMENT_SENT, payment.PaymentSentMessage); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if o.SerializedOrderFulfillments != nil {
+		fulfillmentList := new(pb.FulfillmentList)
+		if err := jsonpb.UnmarshalString(string(o.SerializedOrderFulfillments), fulfillmentList); err != nil {
+			return nil, err
+		}
+		for _, fulfillment := range fulfillmentList.Messages {
+			if err := appendProcessed(npb.OrderMessage_ORDER_FULFILLMENT, fulfillment.FulfillmentMessage); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if o.SerializedRefunds != nil {
+		refundList := new(pb.RefundList)
+		if err := jsonpb.UnmarshalString(string(o.SerializedRefunds), refundList); err != nil {
+			return nil, err
+		}
+		for _, refund := range refundList.Messages {
+			if err := appendProcessed(npb.OrderMessage_REFUND, refund.RefundMessage); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	parked, err := o.GetParkedMessages()
+	if err != nil {
+		return nil, err
+	}
+	for _, message := range parked {
+		ser, err := marshaler.MarshalToString(message)
+		if err != nil {
+			return nil, err
+		}
+		messages.Parked = append(messages.Parked, OrderMessageRecord{
+			MessageType: message.MessageType.String(),
+			Message:     json.RawMessage(ser),
+		})
+	}
+
+	if len(o.ErroredMessages) > 0 {
+		errored := new(npb.OrderList)
+		if err := proto.Unmarshal(o.ErroredMessages, errored); err != nil {
+			return nil, err
+		}
+		for i, message := range errored.Messages {
+			ser, err := marshaler.MarshalToString(message)
+			if err != nil {
+				return nil, err
+			}
+			record := OrderMessageRecord{
+				MessageType: message.MessageType.String(),
+				Message:     json.RawMessage(ser),
+			}
+			if i < len(errored.Errors) {
+				record.Error = errored.Errors[i]
+			}
+			messages.Errored = append(messages.Errored, record)
+		}
+	}
+	return messages, nil
+}
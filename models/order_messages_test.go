@@ -0,0 +1,75 @@
+package models
+
+import (
+	"errors"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	"github.com/golang/protobuf/ptypes"
+	"testing"
+)
+
+func TestOrder_Messages(t *testing.T) {
+	order := &Order{ID: "1234"}
+
+	if err := order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{})); err != nil {
+		t.Fatal(err)
+	}
+	if err := order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderFulfillment{})); err != nil {
+		t.Fatal(err)
+	}
+
+	confirmation, err := ptypes.MarshalAny(&pb.OrderConfirmation{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reject, err := ptypes.MarshalAny(&pb.OrderReject{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := order.ParkMessage(&npb.OrderMessage{
+		OrderID:     "1234",
+		MessageType: npb.OrderMessage_ORDER_CONFIRMATION,
+		Message:     confirmation,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := order.PutErrorMessage(&npb.OrderMessage{
+		OrderID:     "1234",
+		MessageType: npb.OrderMessage_ORDER_REJECT,
+		Message:     reject,
+	}, errors.New("invalid signature")); err != nil {
+		t.Fatal(err)
+	}
+
+	messages, err := order.Messages()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if messages.OrderID != order.ID {
+		t.Errorf("Expected order ID %s, got %s", order.ID, messages.OrderID)
+	}
+	if len(messages.Processed) != 2 {
+		t.Fatalf("Expected 2 processed messages, got %d", len(messages.Processed))
+	}
+	if messages.Processed[0].MessageType != npb.OrderMessage_ORDER_OPEN.String() {
+		t.Errorf("Expected ORDER_OPEN, got %s", messages.Processed[0].MessageType)
+	}
+	if messages.Processed[1].MessageType != npb.OrderMessage_ORDER_FULFILLMENT.String() {
+		t.Errorf("Expected ORDER_FULFILLMENT, got %s", messages.Processed[1].MessageType)
+	}
+	if len(messages.Parked) != 1 || messages.Parked[0].MessageType != npb.OrderMessage_ORDER_CONFIRMATION.String() {
+		t.Errorf("Incorrect parked messages returned: %v", messages.Parked)
+	}
+	if len(messages.Errored) != 1 || messages.Errored[0].MessageType != npb.OrderMessage_ORDER_REJECT.String() {
+		t.Fatalf("Incorrect errored messages returned: %v", messages.Errored)
+	}
+	if messages.Errored[0].Error != "invalid signature" {
+		t.Errorf("Expected error string invalid signature, got %s", messages.Errored[0].Error)
+	}
+
+	if _, err := order.MarshalJSON(); err != nil {
+		t.Errorf("Error marshalling order with parked and errored messages: %s", err)
+	}
+}
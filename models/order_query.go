@@ -0,0 +1,130 @@
+package models
+
+import (
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"time"
+)
+
+// OrderQuery is used to filter and paginate the orders returned
+// from GetSales and GetPurchases. Zero values are ignored.
+type OrderQuery struct {
+	// States restricts the results to orders in any of these states.
+	States []OrderState `json:"states"`
+
+	// From and To restrict the results to orders opened in this
+	// time range (inclusive).
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	// Coin restricts the results to orders paid in this coin.
+	Coin string `json:"coin"`
+
+	// PeerID restricts the results to orders with this counterparty.
+	// For sales this is the buyer and for purchases the vendor.
+	PeerID string `json:"peerID"`
+
+	// Search matches against the listing titles and shipping name.
+	Search string `json:"search"`
+
+	// SortAscending sorts the orders oldest first. The default is
+	// newest first.
+	SortAscending bool `json:"sortAscending"`
+
+	// Limit is the maximum number of orders returned. Zero or less means no limit.
+	Limit int `json:"limit"`
+
+	// OffsetID is the ID of the last order in the previous page.
+	OffsetID string `json:"offsetID"`
+}
+
+// OrderSummary is a condensed view of an order used when returning
+// lists of orders.
+type OrderSummary struct {
+	OrderID      OrderID        `json:"orderID"`
+	Timestamp    time.Time      `json:"timestamp"`
+	State        OrderState     `json:"state"`
+	Title        string         `json:"title"`
+	Slug         string         `json:"slug"`
+	Thumbnail    OrderThumbnail `json:"thumbnail"`
+	Total        string         `json:"total"`
+	PaymentCoin  string         `json:"paymentCoin"`
+	BuyerID      string         `json:"buyerID"`
+	BuyerHandle  string         `json:"buyerHandle"`
+	VendorID     string         `json:"vendorID"`
+	VendorHandle string         `json:"vendorHandle"`
+	ShipTo       string         `json:"shipTo"`
+	Moderated    bool           `json:"moderated"`
+
+	AllowedActions []OrderAction `json:"allowedActions"`
+}
+
+// OrderThumbnail holds the thumbnail image hashes for an order summary.
+type OrderThumbnail struct {
+	Tiny  string `json:"tiny"`
+	Small string `json:"small"`
+}
+
+// OrderList is a page of order summaries matching an OrderQuery.
+type OrderList struct {
+	Orders []OrderSummary `json:"orders"`
+
+	// Total is the number of orders matching the query across all pages.
+	Total int `json:"total"`
+
+	// Counts is the number of orders in each state matching all the
+	// query filters except for the state filter.
+	Counts map[OrderState]int `json:"counts"`
+
+	// NextOffsetID is the OffsetID to use to fetch the next page. It is
+	// empty if there are no more pages.
+	NextOffsetID string `json:"nextOffsetID"`
+}
+
+// Summary returns an OrderSummary for the order including the
+// actions we are allowed to take next.
+func (o *Order) Summary(ourPeerID peer.ID) (*OrderSummary, error) {
+	orderOpen, err := o.OrderOpenMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &OrderSummary{
+		OrderID:     o.ID,
+		Timestamp:   o.OpenedAt,
+		State:       o.State(),
+		PaymentCoin: o.PaymentCoin,
+		BuyerID:     o.BuyerID,
+		VendorID:    o.VendorID,
+
+		AllowedActions: o.AllowedActions(ourPeerID),
+	}
+
+	if len(orderOpen.Listings) > 0 && orderOpen.Listings[0].Listing != nil {
+		listing := orderOpen.Listings[0].Listing
+		summary.Slug = listing.Slug
+		if listing.VendorID != nil {
+			summary.VendorHandle = listing.VendorID.Handle
+		}
+		if listing.Item != nil {
+			summary.Title = listing.Item.Title
+			if len(listing.Item.Images) > 0 {
+				summary.Thumbnail = OrderThumbnail{
+					Tiny:  listing.Item.Images[0].Tiny,
+					Small: listing.Item.Images[0].Small,
+				}
+			}
+		}
+	}
+	if orderOpen.BuyerID != nil {
+		summary.BuyerHandle = orderOpen.BuyerID.Handle
+	}
+	if orderOpen.Shipping != nil {
+		summary.ShipTo = orderOpen.Shipping.ShipTo
+	}
+	if orderOpen.Payment != nil {
+		summary.Total = orderOpen.Payment.Amount
+		summary.Moderated = orderOpen.Payment.Method == pb.OrderOpen_Payment_MODERATED
+	}
+	return summary, nil
+}
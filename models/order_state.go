@@ -0,0 +1,168 @@
+package models
+
+import (
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+// OrderState is the state of the order as derived from the messages
+// saved in the order.
+type OrderState string
+
+const (
+	// OrderStateUnknown means the order open message has not been received yet.
+	OrderStateUnknown OrderState = "UNKNOWN"
+	// OrderStateAwaitingPayment means the order has been opened but is not yet funded.
+	OrderStateAwaitingPayment OrderState = "AWAITING_PAYMENT"
+	// OrderStatePending means the order is funded and waiting on the vendor.
+	OrderStatePending OrderState = "PENDING"
+	// OrderStateConfirmed means the vendor confirmed the order.
+	OrderStateConfirmed OrderState = "CONFIRMED"
+	// OrderStatePartiallyFulfilled means some, but not all, of the items were fulfilled.
+	OrderStatePartiallyFulfilled OrderState = "PARTIALLY_FULFILLED"
+	// OrderStateFulfilled means all the items in the order were fulfilled.
+	OrderStateFulfilled OrderState = "FULFILLED"
+	// OrderStateCompleted means the buyer completed the order.
+	OrderStateCompleted OrderState = "COMPLETED"
+	// OrderStateCanceled means the buyer canceled the order.
+	OrderStateCanceled OrderState = "CANCELED"
+	// OrderStateDeclined means the vendor rejected the order.
+	OrderStateDeclined OrderState = "DECLINED"
+	// OrderStateDisputed means a dispute is open and waiting on the moderator.
+	OrderStateDisputed OrderState = "DISPUTED"
+	// OrderStateDecided means the moderator closed the dispute but the funds
+	// have not yet been released.
+	OrderStateDecided OrderState = "DECIDED"
+	// OrderStateResolved means the funds were released following the moderator's decision.
+	OrderStateResolved OrderState = "RESOLVED"
+	// OrderStateRefunded means the vendor refunded the order.
+	OrderStateRefunded OrderState = "REFUNDED"
+	// OrderStatePaymentFinalized means the buyer released the payment to the vendor
+	// before completing the order.
+	OrderStatePaymentFinalized OrderState = "PAYMENT_FINALIZED"
+)
+
+// State returns the state of the order as derived from the saved messages. Since
+// the state is derived only from messages that both parties hold, the buyer and
+// vendor will calculate the same state given the same set of messages.
+func (o *Order) State() OrderState {
+	orderOpen, err := o.OrderOpenMessage()
+	if err != nil {
+		return OrderStateUnknown
+	}
+
+	switch {
+	case o.SerializedOrderReject != nil:
+		return OrderStateDeclined
+	case o.SerializedOrderCancel != nil:
+		return OrderStateCanceled
+	case o.SerializedDisputeOpen != nil && o.SerializedDisputeClosed == nil:
+		return OrderStateDisputed
+	case o.SerializedDisputeClosed != nil:
+		if o.SerializedOrderComplete != nil {
+			return OrderStateCompleted
+		}
+		if o.spentFromPaymentAddress(orderOpen) {
+			return OrderStateResolved
+		}
+		return OrderStateDecided
+	case o.SerializedOrderComplete != nil:
+		return OrderStateCompleted
+	case o.isRefunded():
+		return OrderStateRefunded
+	case o.SerializedPaymentFinalized != nil:
+		return OrderStatePaymentFinalized
+	case o.SerializedOrderFulfillments != nil:
+		fulfilled, err := o.IsFulfilled()
+		if err == nil && fulfilled {
+			return OrderStateFulfilled
+		}
+		return OrderStatePartiallyFulfilled
+	case o.SerializedOrderConfirmation != nil:
+		return OrderStateConfirmed
+	}
+
+	funded, err := o.IsFunded()
+	if err == nil && funded {
+		return OrderStatePending
+	}
+	return OrderStateAwaitingPayment
+}
+
+// spentFromPaymentAddress returns whether any of the order's transactions
+// spend from the payment address.
+func (o *Order) spentFromPaymentAddress(orderOpen *pb.OrderOpen) bool {
+	if orderOpen.Payment == nil {
+		return false
+	}
+	txs, err := o.GetTransactions()
+	if err != nil {
+		return false
+	}
+	for _, tx := range txs {
+		for _, from := range tx.From {
+			if from.Address.String() == orderOpen.Payment.Address {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// OrderAction is an action a user may take on an order.
+type OrderAction string
+
+const (
+	// OrderActionConfirm confirms the order.
+	OrderActionConfirm OrderAction = "CONFIRM"
+	// OrderActionReject rejects the order.
+	OrderActionReject OrderAction = "REJECT"
+	// OrderActionCancel cancels the order.
+	OrderActionCancel OrderAction = "CANCEL"
+	// OrderActionRefund refunds the order.
+	OrderActionRefund OrderAction = "REFUND"
+	// OrderActionFulfill fulfills the order.
+	OrderActionFulfill OrderAction = "FULFILL"
+	// OrderActionComplete completes the order.
+	OrderActionComplete OrderAction = "COMPLETE"
+	// OrderActionFinalizePayment releases the payment to the vendor.
+	OrderActionFinalizePayment OrderAction = "FINALIZE_PAYMENT"
+	// OrderActionOpenDispute opens a dispute.
+	OrderActionOpenDispute OrderAction = "OPEN_DISPUTE"
+	// OrderActionCloseDispute closes a dispute.
+	OrderActionCloseDispute OrderAction = "CLOSE_DISPUTE"
+	// OrderActionReleaseFunds releases the escrowed funds following a dispute.
+	OrderActionReleaseFunds OrderAction = "RELEASE_FUNDS"
+	// OrderActionReleaseFundsAfterTimeout releases the escrowed funds to the
+	// vendor after the escrow timeout.
+	OrderActionReleaseFundsAfterTimeout OrderAction = "RELEASE_FUNDS_AFTER_TIMEOUT"
+)
+
+// AllowedActions returns the actions that we can take next on this order
+// given our role in it.
+func (o *Order) AllowedActions(ourPeerID peer.ID) []OrderAction {
+	checks := []struct {
+		action  OrderAction
+		allowed func(peer.ID) bool
+	}{
+		{OrderActionConfirm, o.CanConfirm},
+		{OrderActionReject, o.CanReject},
+		{OrderActionCancel, o.CanCancel},
+		{OrderActionRefund, o.CanRefund},
+		{OrderActionFulfill, o.CanFulfill},
+		{OrderActionComplete, o.CanComplete},
+		{OrderActionFinalizePayment, o.CanFinalizePayment},
+		{OrderActionOpenDispute, o.CanDispute},
+		{OrderActionCloseDispute, o.CanCloseDispute},
+		{OrderActionReleaseFunds, o.CanReleaseFunds},
+		{OrderActionReleaseFundsAfterTimeout, o.CanReleaseFundsAfterTimeout},
+	}
+
+	actions := []OrderAction{}
+	for _, check := range checks {
+		if check.allowed(ourPeerID) {
+			actions = append(actions, check.action)
+		}
+	}
+	return actions
+}
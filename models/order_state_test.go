@@ -0,0 +1,303 @@
+package models
+
+import (
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	iwallet "github.com/cpacia/wallet-interface"
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"reflect"
+	"testing"
+)
+
+// permutations returns every ordering of the indexes 0 through n-1.
+func permutations(n int) [][]int {
+	var (
+		ret  [][]int
+		perm = make([]int, n)
+	)
+	for i := range perm {
+		perm[i] = i
+	}
+	var generate func(k int)
+	generate = func(k int) {
+		if k == 1 {
+			cpy := make([]int, n)
+			copy(cpy, perm)
+			ret = append(ret, cpy)
+			return
+		}
+		generate(k - 1)
+		for i := 0; i < k-1; i++ {
+			if k%2 == 0 {
+				perm[i], perm[k-1] = perm[k-1], perm[i]
+			} else {
+				perm[0], perm[k-1] = perm[k-1], perm[0]
+			}
+			generate(k - 1)
+		}
+	}
+	if n > 0 {
+		generate(n)
+	}
+	return ret
+}
+
+// TestOrder_StateConformance feeds each set of messages into a buyer's and a
+// vendor's order in every possible order and checks that both sides derive
+// the same state.
+func TestOrder_StateConformance(t *testing.T) {
+	var (
+		buyerID  = "QmT5NvUtoM5nWFfrQdVrFtvGfKFmG7AHE8P34isapyhCxX"
+		vendorID = "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4"
+	)
+
+	type input func(order *Order) error
+
+	message := func(msg proto.Message) input {
+		return func(order *Order) error {
+			return order.PutMessage(utils.MustWrapOrderMessage(msg))
+		}
+	}
+	transaction := func(tx iwallet.Transaction) input {
+		return func(order *Order) error {
+			return order.PutTransaction(tx)
+		}
+	}
+
+	var (
+		orderOpen = message(&pb.OrderOpen{
+			Listings: []*pb.SignedListing{
+				{
+					Listing: &pb.Listing{
+						VendorID: &pb.ID{PeerID: vendorID},
+					},
+				},
+			},
+			BuyerID: &pb.ID{PeerID: buyerID},
+			Items:   []*pb.OrderOpen_Item{{}, {}},
+			Payment: &pb.OrderOpen_Payment{
+				Method:  pb.OrderOpen_Payment_MODERATED,
+				Amount:  "1000",
+				Address: "abc",
+			},
+		})
+		funding = transaction(iwallet.Transaction{
+			ID: "1",
+			To: []iwallet.SpendInfo{
+				{
+					Address: iwallet.NewAddress("abc", iwallet.CtMock),
+					Amount:  iwallet.NewAmount(1000),
+				},
+			},
+		})
+		spend = transaction(iwallet.Transaction{
+			ID: "2",
+			From: []iwallet.SpendInfo{
+				{
+					Address: iwallet.NewAddress("abc", iwallet.CtMock),
+					Amount:  iwallet.NewAmount(1000),
+				},
+			},
+		})
+		confirmation = message(&pb.OrderConfirmation{})
+		fulfillment0 = message(&pb.OrderFulfillment{
+			Fulfillments: []*pb.OrderFulfillment_FulfilledItem{{ItemIndex: 0}},
+		})
+		fulfillment1 = message(&pb.OrderFulfillment{
+			Fulfillments: []*pb.OrderFulfillment_FulfilledItem{{ItemIndex: 1}},
+		})
+		complete     = message(&pb.OrderComplete{})
+		reject       = message(&pb.OrderReject{})
+		cancel       = message(&pb.OrderCancel{})
+		refund       = message(&pb.Refund{})
+		disputeOpen  = message(&pb.DisputeOpen{})
+		disputeClose = message(&pb.DisputeClose{})
+		finalized    = message(&pb.PaymentFinalized{})
+	)
+
+	tests := []struct {
+		name     string
+		inputs   []input
+		expected OrderState
+	}{
+		{"awaiting payment", []input{orderOpen}, OrderStateAwaitingPayment},
+		{"pending", []input{orderOpen, funding}, OrderStatePending},
+		{"confirmed", []input{orderOpen, funding, confirmation}, OrderStateConfirmed},
+		{"partially fulfilled", []input{orderOpen, funding, confirmation, fulfillment0}, OrderStatePartiallyFulfilled},
+		{"fulfilled", []input{orderOpen, funding, confirmation, fulfillment0, fulfillment1}, OrderStateFulfilled},
+		{"completed", []input{orderOpen, funding, confirmation, fulfillment0, fulfillment1, complete}, OrderStateCompleted},
+		{"declined", []input{orderOpen, funding, reject}, OrderStateDeclined},
+		{"canceled", []input{orderOpen, funding, cancel}, OrderStateCanceled},
+		{"refunded", []input{orderOpen, funding, confirmation, refund}, OrderStateRefunded},
+		{"disputed", []input{orderOpen, funding, confirmation, fulfillment0, disputeOpen}, OrderStateDisputed},
+		{"decided", []input{orderOpen, funding, confirmation, fulfillment0, disputeOpen, disputeClose}, OrderStateDecided},
+		{"resolved", []input{orderOpen, funding, confirmation, fulfillment0, disputeOpen, disputeClose, spend}, OrderStateResolved},
+		{"completed after dispute", []input{orderOpen, funding, confirmation, fulfillment0, disputeOpen, disputeClose, complete}, OrderStateCompleted},
+		{"payment finalized", []input{orderOpen, funding, confirmation, fulfillment0, fulfillment1, finalized}, OrderStatePaymentFinalized},
+	}
+
+	for _, test := range tests {
+		for _, perm := range permutations(len(test.inputs)) {
+			var (
+				buyerOrder  = Order{ID: "1234"}
+				vendorOrder = Order{ID: "1234"}
+			)
+			buyerOrder.SetRole(RoleBuyer)
+			vendorOrder.SetRole(RoleVendor)
+
+			for _, i := range perm {
+				if err := test.inputs[i](&buyerOrder); err != nil {
+					t.Fatalf("%s: buyer input %d error: %s", test.name, i, err)
+				}
+				if err := test.inputs[i](&vendorOrder); err != nil {
+					t.Fatalf("%s: vendor input %d error: %s", test.name, i, err)
+				}
+			}
+
+			buyerState, vendorState := buyerOrder.State(), vendorOrder.State()
+			if buyerState != vendorState {
+				t.Errorf("%s: permutation %v buyer derived %s, vendor derived %s", test.name, perm, buyerState, vendorState)
+			}
+			if buyerState != test.expected {
+				t.Errorf("%s: permutation %v expected %s, got %s", test.name, perm, test.expected, buyerState)
+			}
+		}
+	}
+}
+
+func Test_permutations(t *testing.T) {
+	perms := permutations(4)
+	if len(perms) != 24 {
+		t.Fatalf("Expected 24 permutations, got %d", len(perms))
+	}
+	seen := make(map[[4]int]bool)
+	for _, p := range perms {
+		var key [4]int
+		copy(key[:], p)
+		if seen[key] {
+			t.Errorf("Duplicate permutation %v", p)
+		}
+		seen[key] = true
+	}
+}
+
+func TestOrder_AllowedActions(t *testing.T) {
+	var (
+		buyerID  = "QmT5NvUtoM5nWFfrQdVrFtvGfKFmG7AHE8P34isapyhCxX"
+		vendorID = "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4"
+		modID    = "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub"
+	)
+	newOrder := func(method pb.OrderOpen_Payment_Method) *Order {
+		order := &Order{}
+		err := order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+			Listings: []*pb.SignedListing{
+				{
+					Listing: &pb.Listing{
+						VendorID: &pb.ID{PeerID: vendorID},
+					},
+				},
+			},
+			BuyerID: &pb.ID{PeerID: buyerID},
+			Items:   []*pb.OrderOpen_Item{{}},
+			Payment: &pb.OrderOpen_Payment{
+				Method:    method,
+				Moderator: modID,
+				Amount:    "1000",
+				Address:   "abc",
+			},
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return order
+	}
+	fund := func(order *Order) *Order {
+		err := order.PutTransaction(iwallet.Transaction{
+			ID: "1",
+			To: []iwallet.SpendInfo{
+				{
+					Address: iwallet.NewAddress("abc", iwallet.CtMock),
+					Amount:  iwallet.NewAmount(1000),
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return order
+	}
+	put := func(order *Order, msg proto.Message) *Order {
+		if err := order.PutMessage(utils.MustWrapOrderMessage(msg)); err != nil {
+			t.Fatal(err)
+		}
+		return order
+	}
+
+	tests := []struct {
+		name     string
+		order    *Order
+		ourID    string
+		expected []OrderAction
+	}{
+		{
+			name:     "Buyer unfunded cancelable",
+			order:    newOrder(pb.OrderOpen_Payment_CANCELABLE),
+			ourID:    buyerID,
+			expected: []OrderAction{OrderActionCancel},
+		},
+		{
+			name:     "Vendor unfunded moderated",
+			order:    newOrder(pb.OrderOpen_Payment_MODERATED),
+			ourID:    vendorID,
+			expected: []OrderAction{OrderActionConfirm, OrderActionReject},
+		},
+		{
+			name:     "Buyer funded moderated",
+			order:    fund(newOrder(pb.OrderOpen_Payment_MODERATED)),
+			ourID:    buyerID,
+			expected: []OrderAction{OrderActionOpenDispute},
+		},
+		{
+			name:     "Vendor funded moderated",
+			order:    fund(newOrder(pb.OrderOpen_Payment_MODERATED)),
+			ourID:    vendorID,
+			expected: []OrderAction{OrderActionConfirm, OrderActionReject, OrderActionRefund},
+		},
+		{
+			name:     "Buyer unfunded direct",
+			order:    newOrder(pb.OrderOpen_Payment_DIRECT),
+			ourID:    buyerID,
+			expected: []OrderAction{},
+		},
+		{
+			name:     "Vendor confirmed",
+			order:    put(fund(newOrder(pb.OrderOpen_Payment_MODERATED)), &pb.OrderConfirmation{}),
+			ourID:    vendorID,
+			expected: []OrderAction{OrderActionRefund, OrderActionFulfill},
+		},
+		{
+			name:     "Moderator disputed",
+			order:    put(fund(newOrder(pb.OrderOpen_Payment_MODERATED)), &pb.DisputeOpen{}),
+			ourID:    modID,
+			expected: []OrderAction{OrderActionCloseDispute},
+		},
+		{
+			name:     "No order open",
+			order:    &Order{},
+			ourID:    buyerID,
+			expected: []OrderAction{},
+		},
+	}
+
+	for _, test := range tests {
+		pid, err := peer.IDB58Decode(test.ourID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actions := test.order.AllowedActions(pid)
+		if !reflect.DeepEqual(actions, test.expected) {
+			t.Errorf("%s: expected actions %v, got %v", test.name, test.expected, actions)
+		}
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	peer "github.com/libp2p/go-libp2p-peer"
+	"math/big"
+	"strings"
 	"time"
 )
 
@@ -77,6 +79,19 @@ type Order struct {
 	LastCheckForPayments time.Time
 	RescanPerformed      bool
 
+	// EscrowTimeoutNotifications is the number of escrow timeout
+	// notifications that have been emitted for this order.
+	EscrowTimeoutNotifications int
+
+	// The following are derived from the order messages when the order
+	// is saved so that orders can be efficiently queried.
+	CurrentState OrderState `gorm:"index"`
+	OpenedAt     time.Time  `gorm:"index"`
+	PaymentCoin  string     `gorm:"index"`
+	BuyerID      string     `gorm:"index"`
+	VendorID     string     `gorm:"index"`
+	SearchTerms  string
+
 	SerializedOrderOpen json.RawMessage
 	OrderOpenSignature  string
 	OrderOpenAcked      bool
@@ -130,6 +145,48 @@ type Order struct {
 	ErroredMessages []byte
 }
 
+// BeforeSave is called by gorm before the order is saved. It updates the
+// columns which are derived from the order messages.
+func (o *Order) BeforeSave() error {
+	o.CurrentState = o.State()
+
+	orderOpen, err := o.OrderOpenMessage()
+	if err != nil {
+		return nil
+	}
+
+	if orderOpen.Timestamp != nil {
+		o.OpenedAt, err = ptypes.Timestamp(orderOpen.Timestamp)
+		if err != nil {
+			return err
+		}
+	}
+	if orderOpen.Payment != nil {
+		o.PaymentCoin = orderOpen.Payment.Coin
+	}
+	if orderOpen.BuyerID != nil {
+		o.BuyerID = orderOpen.BuyerID.PeerID
+	}
+
+	var terms []string
+	for _, sl := range orderOpen.Listings {
+		if sl.Listing == nil {
+			continue
+		}
+		if sl.Listing.VendorID != nil {
+			o.VendorID = sl.Listing.VendorID.PeerID
+		}
+		if sl.Listing.Item != nil {
+			terms = append(terms, sl.Listing.Item.Title)
+		}
+	}
+	if orderOpen.Shipping != nil {
+		terms = append(terms, orderOpen.Shipping.ShipTo)
+	}
+	o.SearchTerms = strings.ToLower(strings.Join(terms, " "))
+	return nil
+}
+
 // Role returns the role of the user for this order.
 func (o *Order) Role() OrderRole {
 	return OrderRole(o.MyRole)
@@ -179,6 +236,54 @@ func (o *Order) Timestamp() (time.Time, error) {
 	return ptypes.Timestamp(orderOpen.Timestamp)
 }
 
+// EscrowTimeout returns the escrow timeout of the order. This is the longest
+// timeout of all the listings in the order. Zero is returned if the order is
+// not moderated or the listings have no timeout.
+func (o *Order) EscrowTimeout() (time.Duration, error) {
+	orderOpen, err := o.OrderOpenMessage()
+	if err != nil {
+		return 0, err
+	}
+	if orderOpen.Payment == nil || orderOpen.Payment.Method != pb.OrderOpen_Payment_MODERATED {
+		return 0, nil
+	}
+	var escrowTimeoutHours uint32
+	for _, sl := range orderOpen.Listings {
+		if sl.Listing == nil || sl.Listing.Metadata == nil {
+			continue
+		}
+		if sl.Listing.Metadata.EscrowTimeoutHours > escrowTimeoutHours {
+			escrowTimeoutHours = sl.Listing.Metadata.EscrowTimeoutHours
+		}
+	}
+	return time.Hour * time.Duration(escrowTimeoutHours), nil
+}
+
+// FundingTimestamp returns the time of the first payment into the order's
+// payment address. The escrow timeout runs from this point.
+func (o *Order) FundingTimestamp() (time.Time, error) {
+	orderOpen, err := o.OrderOpenMessage()
+	if err != nil {
+		return time.Time{}, err
+	}
+	txs, err := o.GetTransactions()
+	if err != nil {
+		return time.Time{}, err
+	}
+	var funded time.Time
+	for _, tx := range txs {
+		for _, to := range tx.To {
+			if to.Address.String() == orderOpen.Payment.Address && (funded.IsZero() || tx.Timestamp.Before(funded)) {
+				funded = tx.Timestamp
+			}
+		}
+	}
+	if funded.IsZero() {
+		return time.Time{}, ErrMessageDoesNotExist
+	}
+	return funded, nil
+}
+
 // GetTransactions returns all the transactions associated with this order.
 func (o *Order) GetTransactions() ([]iwallet.Transaction, error) {
 	if o.Transactions == nil || len(o.Transactions) == 0 {
@@ -550,6 +655,95 @@ func (o *Order) PutMessage(message *npb.OrderMessage) error {
 	return nil
 }
 
+// Contract returns all the order messages saved in this order, along with
+// their signatures, as an OrderList. The dispute messages themselves are not
+// included. This is used when opening a dispute to give the moderator our
+// copy of the order.
+func (o *Order) Contract() (*npb.OrderList, error) {
+	var (
+		contract = new(npb.OrderList)
+		orderID  = o.ID.String()
+	)
+	appendMessage := func(messageType npb.OrderMessage_MessageType, msg proto.Message, sig []byte) error {
+		a, err := ptypes.MarshalAny(msg)
+		if err != nil {
+			return err
+		}
+		contract.Messages = append(contract.Messages, &npb.OrderMessage{
+			OrderID:     orderID,
+			MessageType: messageType,
+			Message:     a,
+			Signature:   sig,
+		})
+		return nil
+	}
+
+	messages := []struct {
+		serialized  json.RawMessage
+		signature   string
+		msg         proto.Message
+		messageType npb.OrderMessage_MessageType
+	}{
+		{o.SerializedOrderOpen, o.OrderOpenSignature, new(pb.OrderOpen), npb.OrderMessage_ORDER_OPEN},
+		{o.SerializedOrderReject, o.OrderRejectSignature, new(pb.OrderReject), npb.OrderMessage_ORDER_REJECT},
+		{o.SerializedOrderCancel, o.OrderCancelSignature, new(pb.OrderCancel), npb.OrderMessage_ORDER_CANCEL},
+		{o.SerializedOrderConfirmation, o.OrderConfirmationSignature, new(pb.OrderConfirmation), npb.OrderMessage_ORDER_CONFIRMATION},
+		{o.SerializedRatingSignatures, o.RatingSignaturesSignature, new(pb.RatingSignatures), npb.OrderMessage_RATING_SIGNATURES},
+		{o.SerializedOrderComplete, o.OrderCompleteSignature, new(pb.OrderComplete), npb.OrderMessage_ORDER_COMPLETE},
+		{o.SerializedPaymentFinalized, o.PaymentFinalizedSignature, new(pb.PaymentFinalized), npb.OrderMessage_PAYMENT_FINALIZED},
+	}
+	for _, m := range messages {
+		if m.serialized == nil || len(m.serialized) == 0 {
+			continue
+		}
+		if err := jsonpb.UnmarshalString(string(m.serialized), m.msg); err != nil {
+			return nil, err
+		}
+		sig, err := base64.StdEncoding.DecodeString(m.signature)
+		if err != nil {
+			return nil, err
+		}
+		if err := appendMessage(m.messageType, m.msg, sig); err != nil {
+			return nil, err
+		}
+	}
+
+	if o.SerializedOrderFulfillments != nil {
+		fulfillmentList := new(pb.FulfillmentList)
+		if err := jsonpb.UnmarshalString(string(o.SerializedOrderFulfillments), fulfillmentList); err != nil {
+			return nil, err
+		}
+		for _, m := range fulfillmentList.Messages {
+			if err := appendMessage(npb.OrderMessage_ORDER_FULFILLMENT, m.FulfillmentMessage, m.Signature); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if o.SerializedRefunds != nil {
+		refundList := new(pb.RefundList)
+		if err := jsonpb.UnmarshalString(string(o.SerializedRefunds), refundList); err != nil {
+			return nil, err
+		}
+		for _, m := range refundList.Messages {
+			if err := appendMessage(npb.OrderMessage_REFUND, m.RefundMessage, m.Signature); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if o.SerializedPaymentSent != nil {
+		paymentList := new(pb.PaymentSentList)
+		if err := jsonpb.UnmarshalString(string(o.SerializedPaymentSent), paymentList); err != nil {
+			return nil, err
+		}
+		for _, m := range paymentList.Messages {
+			if err := appendMessage(npb.OrderMessage_PAYMENT_SENT, m.PaymentSentMessage, m.Signature); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return contract, nil
+}
+
 // ParkMessage adds the message to our list of parked messages.
 func (o *Order) ParkMessage(message *npb.OrderMessage) error {
 	parkedMessages := new(npb.OrderList)
@@ -601,15 +795,25 @@ func (o *Order) GetParkedMessages() ([]*npb.OrderMessage, error) {
 	return parkedMessages.Messages, nil
 }
 
-// PutErrorMessage adds the message to our list of errored messages.
-func (o *Order) PutErrorMessage(message *npb.OrderMessage) error {
+// PutErrorMessage adds the message to our list of errored messages along
+// with the error that was returned when processing it.
+func (o *Order) PutErrorMessage(message *npb.OrderMessage, processingErr error) error {
 	erroredMessages := new(npb.OrderList)
 	if o.ErroredMessages != nil {
 		if err := proto.Unmarshal(o.ErroredMessages, erroredMessages); err != nil {
 			return err
 		}
 	}
+	// Messages errored before we started recording errors won't have one.
+	for len(erroredMessages.Errors) < len(erroredMessages.Messages) {
+		erroredMessages.Errors = append(erroredMessages.Errors, "")
+	}
+	errStr := ""
+	if processingErr != nil {
+		errStr = processingErr.Error()
+	}
 	erroredMessages.Messages = append(erroredMessages.Messages, message)
+	erroredMessages.Errors = append(erroredMessages.Errors, errStr)
 	ser, err := proto.Marshal(erroredMessages)
 	if err != nil {
 		return err
@@ -630,6 +834,12 @@ func (o *Order) GetErroredMessages() ([]*npb.OrderMessage, error) {
 	return erroredMessages.Messages, nil
 }
 
+// isModerator returns whether the peer is the moderator of the order.
+func isModerator(orderOpen *pb.OrderOpen, ourPeerID peer.ID) bool {
+	return orderOpen.Payment != nil && orderOpen.Payment.Moderator != "" &&
+		orderOpen.Payment.Moderator == ourPeerID.Pretty()
+}
+
 // CanReject returns whether or not this order is in a state where the user can
 // reject the order.
 func (o *Order) CanReject(ourPeerID peer.ID) bool {
@@ -642,7 +852,7 @@ func (o *Order) CanReject(ourPeerID peer.ID) bool {
 		return false
 	}
 	// Only vendors can reject.
-	if orderOpen.BuyerID.PeerID == ourPeerID.Pretty() {
+	if orderOpen.BuyerID.PeerID == ourPeerID.Pretty() || isModerator(orderOpen, ourPeerID) {
 		return false
 	}
 
@@ -651,7 +861,7 @@ func (o *Order) CanReject(ourPeerID peer.ID) bool {
 		o.SerializedOrderConfirmation != nil || o.SerializedOrderFulfillments != nil ||
 		o.SerializedOrderComplete != nil || o.SerializedDisputeOpen != nil ||
 		o.SerializedDisputeUpdate != nil || o.SerializedDisputeClosed != nil ||
-		o.SerializedRefunds != nil || o.SerializedPaymentFinalized != nil {
+		o.isRefunded() || o.SerializedPaymentFinalized != nil {
 
 		return false
 	}
@@ -670,7 +880,7 @@ func (o *Order) CanConfirm(ourPeerID peer.ID) bool {
 		return false
 	}
 	// Only vendors can confirm.
-	if orderOpen.BuyerID.PeerID == ourPeerID.Pretty() {
+	if orderOpen.BuyerID.PeerID == ourPeerID.Pretty() || isModerator(orderOpen, ourPeerID) {
 		return false
 	}
 
@@ -679,7 +889,7 @@ func (o *Order) CanConfirm(ourPeerID peer.ID) bool {
 		o.SerializedOrderConfirmation != nil || o.SerializedOrderFulfillments != nil ||
 		o.SerializedOrderComplete != nil || o.SerializedDisputeOpen != nil ||
 		o.SerializedDisputeUpdate != nil || o.SerializedDisputeClosed != nil ||
-		o.SerializedRefunds != nil || o.SerializedPaymentFinalized != nil {
+		o.isRefunded() || o.SerializedPaymentFinalized != nil {
 
 		return false
 	}
@@ -701,8 +911,13 @@ func (o *Order) CanCancel(ourPeerID peer.ID) bool {
 		return false
 	}
 
-	// Only buyers can confirm.
-	if orderOpen.Listings[0].Listing.VendorID.PeerID == ourPeerID.Pretty() {
+	// Only buyers can cancel.
+	if orderOpen.Listings[0].Listing.VendorID.PeerID == ourPeerID.Pretty() || isModerator(orderOpen, ourPeerID) {
+		return false
+	}
+
+	// Only cancelable orders can be canceled.
+	if orderOpen.Payment == nil || orderOpen.Payment.Method != pb.OrderOpen_Payment_CANCELABLE {
 		return false
 	}
 
@@ -711,7 +926,7 @@ func (o *Order) CanCancel(ourPeerID peer.ID) bool {
 		o.SerializedOrderConfirmation != nil || o.SerializedOrderFulfillments != nil ||
 		o.SerializedOrderComplete != nil || o.SerializedDisputeOpen != nil ||
 		o.SerializedDisputeUpdate != nil || o.SerializedDisputeClosed != nil ||
-		o.SerializedRefunds != nil || o.SerializedPaymentFinalized != nil {
+		o.isRefunded() || o.SerializedPaymentFinalized != nil {
 
 		return false
 	}
@@ -730,7 +945,7 @@ func (o *Order) CanRefund(ourPeerID peer.ID) bool {
 		return false
 	}
 	// Only vendors can refund.
-	if orderOpen.BuyerID.PeerID == ourPeerID.Pretty() {
+	if orderOpen.BuyerID.PeerID == ourPeerID.Pretty() || isModerator(orderOpen, ourPeerID) {
 		return false
 	}
 
@@ -739,11 +954,21 @@ func (o *Order) CanRefund(ourPeerID peer.ID) bool {
 		return false
 	}
 
-	// Cannot refund if the order has been completed or canceled.
-	if o.SerializedOrderComplete != nil || o.SerializedPaymentFinalized != nil || o.SerializedOrderCancel != nil {
+	// Cannot refund if the order has been completed, canceled or the dispute closed.
+	if o.SerializedOrderComplete != nil || o.SerializedPaymentFinalized != nil || o.SerializedOrderCancel != nil ||
+		o.SerializedDisputeClosed != nil {
 		return false
 	}
 
+	// Moderated orders are refunded out of escrow so there must be
+	// something in it.
+	if orderOpen.Payment.Method == pb.OrderOpen_Payment_MODERATED {
+		funded, err := o.FundingTotal()
+		if err != nil || funded.Cmp(iwallet.NewAmount(0)) <= 0 {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -759,7 +984,7 @@ func (o *Order) CanFulfill(ourPeerID peer.ID) bool {
 		return false
 	}
 	// Only vendors can fulfill.
-	if orderOpen.BuyerID.PeerID == ourPeerID.Pretty() {
+	if orderOpen.BuyerID.PeerID == ourPeerID.Pretty() || isModerator(orderOpen, ourPeerID) {
 		return false
 	}
 
@@ -788,11 +1013,292 @@ func (o *Order) CanFulfill(ourPeerID peer.ID) bool {
 		return false
 	}
 
-	// Cannot fulfill if the order has been completed or canceled.
-	if o.SerializedOrderComplete != nil || o.SerializedPaymentFinalized != nil || o.SerializedOrderCancel != nil {
+	// Cannot fulfill if the order has been completed, canceled or the dispute closed.
+	if o.SerializedOrderComplete != nil || o.SerializedPaymentFinalized != nil || o.SerializedOrderCancel != nil ||
+		o.SerializedDisputeClosed != nil {
+		return false
+	}
+
+	return true
+}
+
+// CanComplete returns whether or not this order is in a state where the user can
+// complete the order.
+func (o *Order) CanComplete(ourPeerID peer.ID) bool {
+	// OrderOpen must exist.
+	orderOpen, err := o.OrderOpenMessage()
+	if err != nil {
+		return false
+	}
+	if orderOpen.BuyerID == nil {
+		return false
+	}
+
+	// Only buyers can complete.
+	if orderOpen.BuyerID.PeerID != ourPeerID.Pretty() {
+		return false
+	}
+
+	// The order must be fulfilled.
+	if o.SerializedOrderFulfillments == nil {
+		return false
+	}
+
+	// An open dispute must be closed before the order can be completed.
+	if o.SerializedDisputeOpen != nil && o.SerializedDisputeClosed == nil {
+		return false
+	}
+
+	if o.SerializedOrderComplete != nil || o.SerializedOrderCancel != nil ||
+		o.SerializedOrderReject != nil {
+
+		return false
+	}
+	return true
+}
+
+// CanDispute returns whether or not this order is in a state where the user can
+// open a dispute with the moderator.
+func (o *Order) CanDispute(ourPeerID peer.ID) bool {
+	// OrderOpen must exist.
+	orderOpen, err := o.OrderOpenMessage()
+	if err != nil {
+		return false
+	}
+	if orderOpen.BuyerID == nil || orderOpen.Payment == nil ||
+		len(orderOpen.Listings) == 0 ||
+		orderOpen.Listings[0].Listing == nil ||
+		orderOpen.Listings[0].Listing.VendorID == nil {
+		return false
+	}
+
+	// Only moderated orders can be disputed.
+	if orderOpen.Payment.Method != pb.OrderOpen_Payment_MODERATED {
+		return false
+	}
+
+	// Only the buyer and vendor can dispute.
+	var (
+		isBuyer  = orderOpen.BuyerID.PeerID == ourPeerID.Pretty()
+		isVendor = orderOpen.Listings[0].Listing.VendorID.PeerID == ourPeerID.Pretty()
+	)
+	if !isBuyer && !isVendor {
+		return false
+	}
+
+	// Vendors can only dispute after they've fulfilled the order.
+	if isVendor && o.SerializedOrderFulfillments == nil {
+		return false
+	}
+
+	// Order must be funded.
+	funded, err := o.IsFunded()
+	if err != nil {
+		return false
+	}
+
+	if !funded {
+		return false
+	}
+
+	// Cannot dispute if a dispute was already opened or the order has been
+	// completed, canceled or refunded.
+	if o.SerializedDisputeOpen != nil || o.SerializedDisputeClosed != nil ||
+		o.SerializedOrderComplete != nil || o.SerializedPaymentFinalized != nil ||
+		o.SerializedOrderCancel != nil || o.isRefunded() {
+
+		return false
+	}
+	return true
+}
+
+// CanCloseDispute returns whether or not this order is in a state where the user can
+// close the dispute.
+func (o *Order) CanCloseDispute(ourPeerID peer.ID) bool {
+	// OrderOpen must exist.
+	orderOpen, err := o.OrderOpenMessage()
+	if err != nil {
+		return false
+	}
+	if orderOpen.Payment == nil {
+		return false
+	}
+
+	// Only the moderator can close the dispute.
+	if orderOpen.Payment.Moderator != ourPeerID.Pretty() {
+		return false
+	}
+
+	// Dispute must be open and not yet closed.
+	if o.SerializedDisputeOpen == nil || o.SerializedDisputeClosed != nil {
+		return false
+	}
+	return true
+}
+
+// CanReleaseFunds returns whether or not this order is in a state where the user can
+// co-sign the moderator's payout and release the funds from escrow.
+func (o *Order) CanReleaseFunds(ourPeerID peer.ID) bool {
+	// OrderOpen must exist.
+	orderOpen, err := o.OrderOpenMessage()
+	if err != nil {
+		return false
+	}
+	if orderOpen.BuyerID == nil || orderOpen.Payment == nil ||
+		len(orderOpen.Listings) == 0 ||
+		orderOpen.Listings[0].Listing == nil ||
+		orderOpen.Listings[0].Listing.VendorID == nil {
+		return false
+	}
+
+	// Dispute must be closed with a payout.
+	disputeClose, err := o.DisputeClosedMessage()
+	if err != nil || disputeClose.ReleaseInfo == nil {
+		return false
+	}
+
+	// Only a party that was awarded funds can release them.
+	var (
+		isBuyer  = orderOpen.BuyerID.PeerID == ourPeerID.Pretty()
+		isVendor = orderOpen.Listings[0].Listing.VendorID.PeerID == ourPeerID.Pretty()
+	)
+	if !(isBuyer && disputeClose.BuyerPercentage > 0) && !(isVendor && disputeClose.VendorPercentage > 0) {
+		return false
+	}
+
+	// Cannot release if the funds have already been spent from escrow.
+	txs, err := o.GetTransactions()
+	if err != nil && !IsMessageNotExistError(err) {
+		return false
+	}
+	for _, tx := range txs {
+		for _, from := range tx.From {
+			if from.Address.String() == orderOpen.Payment.Address {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CanReleaseFundsAfterTimeout returns whether or not this order is in a state where
+// the vendor can claim the escrowed funds unilaterally after the escrow timeout.
+func (o *Order) CanReleaseFundsAfterTimeout(ourPeerID peer.ID) bool {
+	// OrderOpen must exist.
+	orderOpen, err := o.OrderOpenMessage()
+	if err != nil {
+		return false
+	}
+	if orderOpen.Payment == nil || len(orderOpen.Listings) == 0 ||
+		orderOpen.Listings[0].Listing == nil ||
+		orderOpen.Listings[0].Listing.VendorID == nil {
+		return false
+	}
+
+	// Only the vendor can release after the timeout.
+	if orderOpen.Listings[0].Listing.VendorID.PeerID != ourPeerID.Pretty() {
+		return false
+	}
+
+	// The order must be fulfilled.
+	if o.SerializedOrderFulfillments == nil {
+		return false
+	}
+
+	// Cannot release if the order was completed, finalized, refunded or
+	// the moderator already decided the dispute.
+	if o.SerializedOrderComplete != nil || o.SerializedPaymentFinalized != nil ||
+		o.isRefunded() || o.SerializedDisputeClosed != nil {
+
+		return false
+	}
+
+	// The timeout must have passed.
+	timeout, err := o.EscrowTimeout()
+	if err != nil || timeout == 0 {
+		return false
+	}
+	funded, err := o.FundingTimestamp()
+	if err != nil {
+		return false
+	}
+	if time.Now().Before(funded.Add(timeout)) {
+		return false
+	}
+
+	// Cannot release if the funds have already been spent from escrow.
+	txs, err := o.GetTransactions()
+	if err != nil {
 		return false
 	}
+	for _, tx := range txs {
+		for _, from := range tx.From {
+			if from.Address.String() == orderOpen.Payment.Address {
+				return false
+			}
+		}
+	}
+	return true
+}
 
+// CanFinalizePayment returns whether or not this order is in a state where the
+// buyer can release the payment to the vendor ahead of completion. Moderated
+// orders must have been fulfilled so that the vendor's payout address is known.
+func (o *Order) CanFinalizePayment(ourPeerID peer.ID) bool {
+	// OrderOpen must exist.
+	orderOpen, err := o.OrderOpenMessage()
+	if err != nil {
+		return false
+	}
+	if orderOpen.BuyerID == nil || orderOpen.Payment == nil {
+		return false
+	}
+
+	// Only buyers can finalize.
+	if orderOpen.BuyerID.PeerID != ourPeerID.Pretty() {
+		return false
+	}
+
+	if o.SerializedOrderReject != nil || o.SerializedOrderCancel != nil ||
+		o.SerializedOrderComplete != nil || o.SerializedPaymentFinalized != nil ||
+		o.SerializedDisputeOpen != nil || o.SerializedDisputeClosed != nil ||
+		o.isRefunded() {
+
+		return false
+	}
+
+	switch orderOpen.Payment.Method {
+	case pb.OrderOpen_Payment_CANCELABLE:
+		// Once confirmed the vendor has already moved the funds.
+		if o.SerializedOrderConfirmation != nil {
+			return false
+		}
+	case pb.OrderOpen_Payment_MODERATED:
+		fulfillments, err := o.OrderFulfillmentMessages()
+		if err != nil || len(fulfillments) == 0 || fulfillments[0].ReleaseInfo == nil {
+			return false
+		}
+	default:
+		return false
+	}
+
+	funded, err := o.IsFunded()
+	if err != nil || !funded {
+		return false
+	}
+
+	// Cannot finalize if the funds have already been moved.
+	txs, err := o.GetTransactions()
+	if err != nil {
+		return false
+	}
+	for _, tx := range txs {
+		for _, from := range tx.From {
+			if from.Address.String() == orderOpen.Payment.Address {
+				return false
+			}
+		}
+	}
 	return true
 }
 
@@ -803,6 +1309,10 @@ func (o *Order) IsFunded() (bool, error) {
 		return false, err
 	}
 
+	if orderOpen.Payment == nil {
+		return false, nil
+	}
+
 	var (
 		requestedAmount = iwallet.NewAmount(orderOpen.Payment.Amount)
 		paymentAddress  = orderOpen.Payment.Address
@@ -820,9 +1330,85 @@ func (o *Order) IsFunded() (bool, error) {
 			}
 		}
 	}
+	// The mispayment buffer is recorded in the order so the buyer and
+	// vendor agree on whether it is funded.
+	if orderOpen.Payment.MispaymentBuffer > 0 {
+		requested := big.Int(requestedAmount)
+		buffer, _ := new(big.Float).Mul(new(big.Float).SetInt(&requested), big.NewFloat(float64(orderOpen.Payment.MispaymentBuffer)/100)).Int(nil)
+		requestedAmount = requestedAmount.Sub(iwallet.NewAmount(buffer))
+	}
 	return totalPaid.Cmp(requestedAmount) >= 0, nil
 }
 
+// AmountOwed returns the amount of the order total that has not yet been
+// paid. This ignores the mispayment buffer.
+func (o *Order) AmountOwed() (iwallet.Amount, error) {
+	orderOpen, err := o.OrderOpenMessage()
+	if err != nil {
+		return iwallet.NewAmount(0), err
+	}
+	if orderOpen.Payment == nil {
+		return iwallet.NewAmount(0), errors.New("order open has no payment")
+	}
+	totalPaid, err := o.FundingTotal()
+	if err != nil {
+		return iwallet.NewAmount(0), err
+	}
+	requestedAmount := iwallet.NewAmount(orderOpen.Payment.Amount)
+	if totalPaid.Cmp(requestedAmount) >= 0 {
+		return iwallet.NewAmount(0), nil
+	}
+	return requestedAmount.Sub(totalPaid), nil
+}
+
+// Overpayment returns the amount paid in excess of the order total which
+// has not yet been refunded.
+func (o *Order) Overpayment() (iwallet.Amount, error) {
+	orderOpen, err := o.OrderOpenMessage()
+	if err != nil {
+		return iwallet.NewAmount(0), err
+	}
+	if orderOpen.Payment == nil {
+		return iwallet.NewAmount(0), errors.New("order open has no payment")
+	}
+	totalPaid, err := o.FundingTotal()
+	if err != nil {
+		return iwallet.NewAmount(0), err
+	}
+	excess := totalPaid.Sub(iwallet.NewAmount(orderOpen.Payment.Amount))
+
+	refunds, err := o.Refunds()
+	if err != nil && !IsMessageNotExistError(err) {
+		return iwallet.NewAmount(0), err
+	}
+	for _, refund := range refunds {
+		if refund.Overpayment {
+			excess = excess.Sub(iwallet.NewAmount(refund.Amount))
+		}
+	}
+	if excess.Cmp(iwallet.NewAmount(0)) <= 0 {
+		return iwallet.NewAmount(0), nil
+	}
+	return excess, nil
+}
+
+// isRefunded returns whether the order has been refunded. Refunds of just the
+// overpaid portion of the payment are ignored as the order carries on.
+func (o *Order) isRefunded() bool {
+	refunds, err := o.Refunds()
+	if IsMessageNotExistError(err) {
+		return false
+	} else if err != nil {
+		return true
+	}
+	for _, refund := range refunds {
+		if !refund.Overpayment {
+			return true
+		}
+	}
+	return false
+}
+
 // IsFulfilled returns whether a fulfillment message is saved for each item in the order.
 func (o *Order) IsFulfilled() (bool, error) {
 	orderOpen, err := o.OrderOpenMessage()
@@ -883,6 +1469,7 @@ func (o *Order) MarshalJSON() ([]byte, error) {
 	out := make(map[string]interface{})
 	out["orderID"] = o.ID.String()
 	out["role"] = string(o.Role())
+	out["state"] = string(o.State())
 
 	if o.SerializedOrderOpen != nil {
 		out["orderOpen"] = o.SerializedOrderOpen
@@ -970,25 +1557,25 @@ func (o *Order) MarshalJSON() ([]byte, error) {
 	}
 	if o.ParkedMessages != nil {
 		parked := new(npb.OrderList)
-		if err := json.Unmarshal(o.ParkedMessages, parked); err != nil {
+		if err := proto.Unmarshal(o.ParkedMessages, parked); err != nil {
 			return nil, err
 		}
 		m, err := marshaler.MarshalToString(parked)
 		if err != nil {
 			return nil, err
 		}
-		out["parkedMessages"] = m
+		out["parkedMessages"] = json.RawMessage(m)
 	}
 	if o.ErroredMessages != nil {
 		errored := new(npb.OrderList)
-		if err := json.Unmarshal(o.ParkedMessages, errored); err != nil {
+		if err := proto.Unmarshal(o.ErroredMessages, errored); err != nil {
 			return nil, err
 		}
 		m, err := marshaler.MarshalToString(errored)
 		if err != nil {
 			return nil, err
 		}
-		out["erroredMessages"] = m
+		out["erroredMessages"] = json.RawMessage(m)
 	}
 
 	return json.Marshal(out)
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"bytes"
+	"errors"
 	"github.com/OpenBazaar/jsonpb"
 	npb "github.com/cpacia/openbazaar3.0/net/pb"
 	"github.com/cpacia/openbazaar3.0/orders/pb"
@@ -645,6 +647,9 @@ func TestOrder_CanCancel(t *testing.T) {
 							},
 						},
 					},
+					Payment: &pb.OrderOpen_Payment{
+						Method: pb.OrderOpen_Payment_CANCELABLE,
+					},
 				}))
 				return err
 			},
@@ -887,6 +892,28 @@ func TestOrder_CanCancel(t *testing.T) {
 			ourID:     "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4",
 			canCancel: false,
 		},
+		{
+			// Not cancelable
+			setup: func(order *Order) error {
+				err := order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+					Listings: []*pb.SignedListing{
+						{
+							Listing: &pb.Listing{
+								VendorID: &pb.ID{
+									PeerID: "QmT5NvUtoM5nWFfrQdVrFtvGfKFmG7AHE8P34isapyhCxX",
+								},
+							},
+						},
+					},
+					Payment: &pb.OrderOpen_Payment{
+						Method: pb.OrderOpen_Payment_MODERATED,
+					},
+				}))
+				return err
+			},
+			ourID:     "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4",
+			canCancel: false,
+		},
 	}
 
 	for i, test := range tests {
@@ -928,10 +955,10 @@ func TestOrder_ErroredMessages(t *testing.T) {
 		t.Error("Messages should be nil")
 	}
 
-	if err := order.PutErrorMessage(msg1); err != nil {
+	if err := order.PutErrorMessage(msg1, errors.New("error 1")); err != nil {
 		t.Fatal(err)
 	}
-	if err := order.PutErrorMessage(msg2); err != nil {
+	if err := order.PutErrorMessage(msg2, errors.New("error 2")); err != nil {
 		t.Fatal(err)
 	}
 
@@ -1280,6 +1307,51 @@ func TestOrder_CanRefund(t *testing.T) {
 			ourID:     "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4",
 			canRefund: false,
 		},
+		{
+			// Moderated unfunded
+			setup: func(order *Order) error {
+				err := order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+					BuyerID: &pb.ID{
+						PeerID: "QmT5NvUtoM5nWFfrQdVrFtvGfKFmG7AHE8P34isapyhCxX",
+					},
+					Payment: &pb.OrderOpen_Payment{
+						Method:  pb.OrderOpen_Payment_MODERATED,
+						Address: "abc",
+					},
+				}))
+				return err
+			},
+			ourID:     "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4",
+			canRefund: false,
+		},
+		{
+			// Moderated funded
+			setup: func(order *Order) error {
+				err := order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+					BuyerID: &pb.ID{
+						PeerID: "QmT5NvUtoM5nWFfrQdVrFtvGfKFmG7AHE8P34isapyhCxX",
+					},
+					Payment: &pb.OrderOpen_Payment{
+						Method:  pb.OrderOpen_Payment_MODERATED,
+						Address: "abc",
+					},
+				}))
+				if err != nil {
+					return err
+				}
+				return order.PutTransaction(iwallet.Transaction{
+					ID: "1",
+					To: []iwallet.SpendInfo{
+						{
+							Address: iwallet.NewAddress("abc", iwallet.CtMock),
+							Amount:  iwallet.NewAmount(1000),
+						},
+					},
+				})
+			},
+			ourID:     "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4",
+			canRefund: true,
+		},
 	}
 
 	for i, test := range tests {
@@ -2037,3 +2109,1238 @@ func TestOrder_FundingTotal(t *testing.T) {
 		}
 	}
 }
+
+func TestOrder_Mispayment(t *testing.T) {
+	newOrder := func(paid string, buffer float32) *Order {
+		order := &Order{}
+		err := order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+			Payment: &pb.OrderOpen_Payment{
+				Amount:           "1000",
+				Address:          "aaaaaa",
+				MispaymentBuffer: buffer,
+			},
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = order.PutTransaction(iwallet.Transaction{
+			ID: "1",
+			To: []iwallet.SpendInfo{
+				{
+					Address: iwallet.NewAddress("aaaaaa", iwallet.CtMock),
+					Amount:  iwallet.NewAmount(paid),
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return order
+	}
+
+	tests := []struct {
+		paid        string
+		buffer      float32
+		isFunded    bool
+		owed        iwallet.Amount
+		overpayment iwallet.Amount
+	}{
+		{"1000", 0, true, iwallet.NewAmount(0), iwallet.NewAmount(0)},
+		{"995", 0, false, iwallet.NewAmount(5), iwallet.NewAmount(0)},
+		{"995", 1, true, iwallet.NewAmount(5), iwallet.NewAmount(0)},
+		{"985", 1, false, iwallet.NewAmount(15), iwallet.NewAmount(0)},
+		{"1200", 0, true, iwallet.NewAmount(0), iwallet.NewAmount(200)},
+	}
+
+	for i, test := range tests {
+		order := newOrder(test.paid, test.buffer)
+
+		funded, err := order.IsFunded()
+		if err != nil {
+			t.Errorf("Test %d: is funded error: %s", i, err)
+		}
+		if funded != test.isFunded {
+			t.Errorf("Test %d: expected funded %t, got %t", i, test.isFunded, funded)
+		}
+
+		owed, err := order.AmountOwed()
+		if err != nil {
+			t.Errorf("Test %d: amount owed error: %s", i, err)
+		}
+		if owed.Cmp(test.owed) != 0 {
+			t.Errorf("Test %d: expected owed %s, got %s", i, test.owed, owed)
+		}
+
+		overpayment, err := order.Overpayment()
+		if err != nil {
+			t.Errorf("Test %d: overpayment error: %s", i, err)
+		}
+		if overpayment.Cmp(test.overpayment) != 0 {
+			t.Errorf("Test %d: expected overpayment %s, got %s", i, test.overpayment, overpayment)
+		}
+	}
+
+	// Refunding the overpayment should zero it out without
+	// marking the order as refunded.
+	order := newOrder("1200", 0)
+	err := order.PutMessage(utils.MustWrapOrderMessage(&pb.Refund{
+		RefundInfo:  &pb.Refund_TransactionID{TransactionID: "2"},
+		Amount:      "200",
+		Overpayment: true,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	overpayment, err := order.Overpayment()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overpayment.Cmp(iwallet.NewAmount(0)) != 0 {
+		t.Errorf("Expected zero overpayment after refund, got %s", overpayment)
+	}
+	if order.State() == OrderStateRefunded {
+		t.Error("Overpayment refund marked the order as refunded")
+	}
+}
+
+func TestOrder_CanDispute(t *testing.T) {
+	var (
+		buyerID     = "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4"
+		vendorID    = "QmT5NvUtoM5nWFfrQdVrFtvGfKFmG7AHE8P34isapyhCxX"
+		moderatorID = "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub"
+	)
+	putFundedOrderOpen := func(order *Order, method pb.OrderOpen_Payment_Method) error {
+		err := order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+			BuyerID: &pb.ID{
+				PeerID: buyerID,
+			},
+			Listings: []*pb.SignedListing{
+				{
+					Listing: &pb.Listing{
+						VendorID: &pb.ID{
+							PeerID: vendorID,
+						},
+					},
+				},
+			},
+			Payment: &pb.OrderOpen_Payment{
+				Method:    method,
+				Moderator: moderatorID,
+				Amount:    "1000",
+				Address:   "aaaaaa",
+			},
+		}))
+		if err != nil {
+			return err
+		}
+		return order.PutTransaction(iwallet.Transaction{
+			To: []iwallet.SpendInfo{
+				{
+					Address: iwallet.NewAddress("aaaaaa", iwallet.CtMock),
+					Amount:  iwallet.NewAmount("1000"),
+				},
+			},
+		})
+	}
+
+	tests := []struct {
+		setup      func(order *Order) error
+		ourID      string
+		canDispute bool
+	}{
+		{
+			// Buyer success
+			setup: func(order *Order) error {
+				return putFundedOrderOpen(order, pb.OrderOpen_Payment_MODERATED)
+			},
+			ourID:      buyerID,
+			canDispute: true,
+		},
+		{
+			// Vendor success
+			setup: func(order *Order) error {
+				order.SerializedOrderFulfillments = []byte{0x00}
+				return putFundedOrderOpen(order, pb.OrderOpen_Payment_MODERATED)
+			},
+			ourID:      vendorID,
+			canDispute: true,
+		},
+		{
+			// Vendor not fulfilled
+			setup: func(order *Order) error {
+				return putFundedOrderOpen(order, pb.OrderOpen_Payment_MODERATED)
+			},
+			ourID:      vendorID,
+			canDispute: false,
+		},
+		{
+			// Moderator
+			setup: func(order *Order) error {
+				return putFundedOrderOpen(order, pb.OrderOpen_Payment_MODERATED)
+			},
+			ourID:      moderatorID,
+			canDispute: false,
+		},
+		{
+			// Not moderated
+			setup: func(order *Order) error {
+				return putFundedOrderOpen(order, pb.OrderOpen_Payment_DIRECT)
+			},
+			ourID:      buyerID,
+			canDispute: false,
+		},
+		{
+			// Not funded
+			setup: func(order *Order) error {
+				return order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+					BuyerID: &pb.ID{
+						PeerID: buyerID,
+					},
+					Listings: []*pb.SignedListing{
+						{
+							Listing: &pb.Listing{
+								VendorID: &pb.ID{
+									PeerID: vendorID,
+								},
+							},
+						},
+					},
+					Payment: &pb.OrderOpen_Payment{
+						Method:  pb.OrderOpen_Payment_MODERATED,
+						Amount:  "1000",
+						Address: "aaaaaa",
+					},
+				}))
+			},
+			ourID:      buyerID,
+			canDispute: false,
+		},
+		{
+			// Dispute already open
+			setup: func(order *Order) error {
+				order.SerializedDisputeOpen = []byte{0x00}
+				return putFundedOrderOpen(order, pb.OrderOpen_Payment_MODERATED)
+			},
+			ourID:      buyerID,
+			canDispute: false,
+		},
+		{
+			// Order complete
+			setup: func(order *Order) error {
+				order.SerializedOrderComplete = []byte{0x00}
+				return putFundedOrderOpen(order, pb.OrderOpen_Payment_MODERATED)
+			},
+			ourID:      buyerID,
+			canDispute: false,
+		},
+		{
+			// Order is nil
+			setup: func(order *Order) error {
+				return nil
+			},
+			ourID:      buyerID,
+			canDispute: false,
+		},
+	}
+
+	for i, test := range tests {
+		var order Order
+		if err := test.setup(&order); err != nil {
+			t.Errorf("Test %d setup failed: %s", i, err)
+		}
+
+		pid, err := peer.IDB58Decode(test.ourID)
+		if err != nil {
+			t.Errorf("Test %d peerID decode error: %s", i, err)
+		}
+
+		canDispute := order.CanDispute(pid)
+		if canDispute != test.canDispute {
+			t.Errorf("Test %d: Got incorrect result. Expected %t, got %t", i, test.canDispute, canDispute)
+		}
+	}
+}
+
+func TestOrder_CanCloseDispute(t *testing.T) {
+	var (
+		buyerID     = "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4"
+		moderatorID = "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub"
+	)
+	putOrderOpen := func(order *Order) error {
+		return order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+			Payment: &pb.OrderOpen_Payment{
+				Method:    pb.OrderOpen_Payment_MODERATED,
+				Moderator: moderatorID,
+			},
+		}))
+	}
+	tests := []struct {
+		setup           func(order *Order) error
+		ourID           string
+		canCloseDispute bool
+	}{
+		{
+			// Success
+			setup: func(order *Order) error {
+				order.SerializedDisputeOpen = []byte{0x00}
+				return putOrderOpen(order)
+			},
+			ourID:           moderatorID,
+			canCloseDispute: true,
+		},
+		{
+			// Not moderator
+			setup: func(order *Order) error {
+				order.SerializedDisputeOpen = []byte{0x00}
+				return putOrderOpen(order)
+			},
+			ourID:           buyerID,
+			canCloseDispute: false,
+		},
+		{
+			// No dispute open
+			setup: func(order *Order) error {
+				return putOrderOpen(order)
+			},
+			ourID:           moderatorID,
+			canCloseDispute: false,
+		},
+		{
+			// Already closed
+			setup: func(order *Order) error {
+				order.SerializedDisputeOpen = []byte{0x00}
+				order.SerializedDisputeClosed = []byte{0x00}
+				return putOrderOpen(order)
+			},
+			ourID:           moderatorID,
+			canCloseDispute: false,
+		},
+	}
+
+	for i, test := range tests {
+		var order Order
+		if err := test.setup(&order); err != nil {
+			t.Errorf("Test %d setup failed: %s", i, err)
+		}
+
+		pid, err := peer.IDB58Decode(test.ourID)
+		if err != nil {
+			t.Errorf("Test %d peerID decode error: %s", i, err)
+		}
+
+		canCloseDispute := order.CanCloseDispute(pid)
+		if canCloseDispute != test.canCloseDispute {
+			t.Errorf("Test %d: Got incorrect result. Expected %t, got %t", i, test.canCloseDispute, canCloseDispute)
+		}
+	}
+}
+
+func TestOrder_Contract(t *testing.T) {
+	var order Order
+	order.ID = "abc"
+	messages := []*npb.OrderMessage{
+		utils.MustWrapOrderMessage(&pb.OrderOpen{}),
+		utils.MustWrapOrderMessage(&pb.OrderConfirmation{}),
+		utils.MustWrapOrderMessage(&pb.OrderFulfillment{}),
+		utils.MustWrapOrderMessage(&pb.PaymentSent{}),
+		utils.MustWrapOrderMessage(&pb.DisputeOpen{}),
+	}
+	for _, m := range messages {
+		if err := order.PutMessage(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	contract, err := order.Contract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contract.Messages) != 4 {
+		t.Fatalf("Expected 4 messages, got %d", len(contract.Messages))
+	}
+	for i, m := range contract.Messages {
+		if m.MessageType != messages[i].MessageType {
+			t.Errorf("Message %d: expected type %s, got %s", i, messages[i].MessageType, m.MessageType)
+		}
+		if !bytes.Equal(m.Signature, messages[i].Signature) {
+			t.Errorf("Message %d: incorrect signature", i)
+		}
+		if m.OrderID != order.ID.String() {
+			t.Errorf("Message %d: incorrect order ID", i)
+		}
+	}
+}
+
+func TestOrder_CanReleaseFunds(t *testing.T) {
+	var (
+		buyerID  = "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4"
+		vendorID = "QmT5NvUtoM5nWFfrQdVrFtvGfKFmG7AHE8P34isapyhCxX"
+	)
+	putMessages := func(order *Order, buyerPercentage, vendorPercentage float32) error {
+		err := order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+			BuyerID: &pb.ID{
+				PeerID: buyerID,
+			},
+			Listings: []*pb.SignedListing{
+				{
+					Listing: &pb.Listing{
+						VendorID: &pb.ID{
+							PeerID: vendorID,
+						},
+					},
+				},
+			},
+			Payment: &pb.OrderOpen_Payment{
+				Method:  pb.OrderOpen_Payment_MODERATED,
+				Address: "aaaaaa",
+			},
+		}))
+		if err != nil {
+			return err
+		}
+		return order.PutMessage(utils.MustWrapOrderMessage(&pb.DisputeClose{
+			BuyerPercentage:  buyerPercentage,
+			VendorPercentage: vendorPercentage,
+			ReleaseInfo:      &pb.EscrowPayout{},
+		}))
+	}
+	tests := []struct {
+		setup           func(order *Order) error
+		ourID           string
+		canReleaseFunds bool
+	}{
+		{
+			// Buyer success
+			setup: func(order *Order) error {
+				return putMessages(order, 100, 0)
+			},
+			ourID:           buyerID,
+			canReleaseFunds: true,
+		},
+		{
+			// Vendor success
+			setup: func(order *Order) error {
+				return putMessages(order, 40, 60)
+			},
+			ourID:           vendorID,
+			canReleaseFunds: true,
+		},
+		{
+			// Vendor awarded nothing
+			setup: func(order *Order) error {
+				return putMessages(order, 100, 0)
+			},
+			ourID:           vendorID,
+			canReleaseFunds: false,
+		},
+		{
+			// Dispute not closed
+			setup: func(order *Order) error {
+				if err := putMessages(order, 100, 0); err != nil {
+					return err
+				}
+				order.SerializedDisputeClosed = nil
+				return nil
+			},
+			ourID:           buyerID,
+			canReleaseFunds: false,
+		},
+		{
+			// Funds already released
+			setup: func(order *Order) error {
+				if err := putMessages(order, 100, 0); err != nil {
+					return err
+				}
+				return order.PutTransaction(iwallet.Transaction{
+					From: []iwallet.SpendInfo{
+						{
+							Address: iwallet.NewAddress("aaaaaa", iwallet.CtMock),
+							Amount:  iwallet.NewAmount("1000"),
+						},
+					},
+				})
+			},
+			ourID:           buyerID,
+			canReleaseFunds: false,
+		},
+	}
+
+	for i, test := range tests {
+		var order Order
+		if err := test.setup(&order); err != nil {
+			t.Errorf("Test %d setup failed: %s", i, err)
+		}
+
+		pid, err := peer.IDB58Decode(test.ourID)
+		if err != nil {
+			t.Errorf("Test %d peerID decode error: %s", i, err)
+		}
+
+		canReleaseFunds := order.CanReleaseFunds(pid)
+		if canReleaseFunds != test.canReleaseFunds {
+			t.Errorf("Test %d: Got incorrect result. Expected %t, got %t", i, test.canReleaseFunds, canReleaseFunds)
+		}
+	}
+}
+
+func TestOrder_EscrowTimeout(t *testing.T) {
+	order := &Order{}
+	err := order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+		Listings: []*pb.SignedListing{
+			{
+				Listing: &pb.Listing{
+					Metadata: &pb.Listing_Metadata{
+						EscrowTimeoutHours: 24,
+					},
+				},
+			},
+			{
+				Listing: &pb.Listing{
+					Metadata: &pb.Listing_Metadata{
+						EscrowTimeoutHours: 48,
+					},
+				},
+			},
+		},
+		Payment: &pb.OrderOpen_Payment{
+			Method: pb.OrderOpen_Payment_MODERATED,
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeout, err := order.EscrowTimeout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeout != time.Hour*48 {
+		t.Errorf("Expected timeout of 48 hours, got %s", timeout)
+	}
+
+	orderOpen, err := order.OrderOpenMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderOpen.Payment.Method = pb.OrderOpen_Payment_DIRECT
+	if err := order.PutMessage(utils.MustWrapOrderMessage(orderOpen)); err != nil {
+		t.Fatal(err)
+	}
+	timeout, err = order.EscrowTimeout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeout != 0 {
+		t.Errorf("Expected zero timeout for direct order, got %s", timeout)
+	}
+}
+
+func TestOrder_FundingTimestamp(t *testing.T) {
+	order := &Order{}
+	err := order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+		Payment: &pb.OrderOpen_Payment{
+			Address: "aaaaaa",
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := order.FundingTimestamp(); !IsMessageNotExistError(err) {
+		t.Errorf("Expected message not exist error, got %v", err)
+	}
+
+	var (
+		first  = time.Unix(100000, 0)
+		second = time.Unix(200000, 0)
+	)
+	for i, ts := range []time.Time{second, first} {
+		err := order.PutTransaction(iwallet.Transaction{
+			ID: iwallet.TransactionID([]string{"1111", "2222"}[i]),
+			To: []iwallet.SpendInfo{
+				{
+					Address: iwallet.NewAddress("aaaaaa", iwallet.CtMock),
+					Amount:  iwallet.NewAmount(1000),
+				},
+			},
+			Timestamp: ts,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	funded, err := order.FundingTimestamp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !funded.Equal(first) {
+		t.Errorf("Expected funding timestamp %s, got %s", first, funded)
+	}
+}
+
+func TestOrder_CanReleaseFundsAfterTimeout(t *testing.T) {
+	var (
+		buyerID  = "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4"
+		vendorID = "QmT5NvUtoM5nWFfrQdVrFtvGfKFmG7AHE8P34isapyhCxX"
+	)
+	putMessages := func(order *Order, funded time.Time) error {
+		err := order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+			BuyerID: &pb.ID{
+				PeerID: buyerID,
+			},
+			Listings: []*pb.SignedListing{
+				{
+					Listing: &pb.Listing{
+						VendorID: &pb.ID{
+							PeerID: vendorID,
+						},
+						Metadata: &pb.Listing_Metadata{
+							EscrowTimeoutHours: 24,
+						},
+					},
+				},
+			},
+			Payment: &pb.OrderOpen_Payment{
+				Method:  pb.OrderOpen_Payment_MODERATED,
+				Address: "aaaaaa",
+			},
+		}))
+		if err != nil {
+			return err
+		}
+		order.SerializedOrderFulfillments = []byte{0x00}
+		return order.PutTransaction(iwallet.Transaction{
+			ID: "1111",
+			To: []iwallet.SpendInfo{
+				{
+					Address: iwallet.NewAddress("aaaaaa", iwallet.CtMock),
+					Amount:  iwallet.NewAmount("1000"),
+				},
+			},
+			Timestamp: funded,
+		})
+	}
+	expired := time.Now().Add(-time.Hour * 25)
+	tests := []struct {
+		setup      func(order *Order) error
+		ourID      string
+		canRelease bool
+	}{
+		{
+			// Success
+			setup: func(order *Order) error {
+				return putMessages(order, expired)
+			},
+			ourID:      vendorID,
+			canRelease: true,
+		},
+		{
+			// Buyer cannot release
+			setup: func(order *Order) error {
+				return putMessages(order, expired)
+			},
+			ourID:      buyerID,
+			canRelease: false,
+		},
+		{
+			// Timeout not yet passed
+			setup: func(order *Order) error {
+				return putMessages(order, time.Now().Add(-time.Hour))
+			},
+			ourID:      vendorID,
+			canRelease: false,
+		},
+		{
+			// Not fulfilled
+			setup: func(order *Order) error {
+				if err := putMessages(order, expired); err != nil {
+					return err
+				}
+				order.SerializedOrderFulfillments = nil
+				return nil
+			},
+			ourID:      vendorID,
+			canRelease: false,
+		},
+		{
+			// Dispute closed
+			setup: func(order *Order) error {
+				if err := putMessages(order, expired); err != nil {
+					return err
+				}
+				order.SerializedDisputeClosed = []byte{0x00}
+				return nil
+			},
+			ourID:      vendorID,
+			canRelease: false,
+		},
+		{
+			// Funds already spent
+			setup: func(order *Order) error {
+				if err := putMessages(order, expired); err != nil {
+					return err
+				}
+				return order.PutTransaction(iwallet.Transaction{
+					ID: "2222",
+					From: []iwallet.SpendInfo{
+						{
+							Address: iwallet.NewAddress("aaaaaa", iwallet.CtMock),
+							Amount:  iwallet.NewAmount("1000"),
+						},
+					},
+				})
+			},
+			ourID:      vendorID,
+			canRelease: false,
+		},
+	}
+
+	for i, test := range tests {
+		var order Order
+		if err := test.setup(&order); err != nil {
+			t.Errorf("Test %d setup failed: %s", i, err)
+		}
+
+		pid, err := peer.IDB58Decode(test.ourID)
+		if err != nil {
+			t.Errorf("Test %d peerID decode error: %s", i, err)
+		}
+
+		canRelease := order.CanReleaseFundsAfterTimeout(pid)
+		if canRelease != test.canRelease {
+			t.Errorf("Test %d: Got incorrect result. Expected %t, got %t", i, test.canRelease, canRelease)
+		}
+	}
+}
+
+func TestOrder_CanFinalizePayment(t *testing.T) {
+	var (
+		buyerID  = "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4"
+		vendorID = "QmT5NvUtoM5nWFfrQdVrFtvGfKFmG7AHE8P34isapyhCxX"
+	)
+	putMessages := func(order *Order, method pb.OrderOpen_Payment_Method) error {
+		err := order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+			BuyerID: &pb.ID{
+				PeerID: buyerID,
+			},
+			Listings: []*pb.SignedListing{
+				{
+					Listing: &pb.Listing{
+						VendorID: &pb.ID{
+							PeerID: vendorID,
+						},
+					},
+				},
+			},
+			Payment: &pb.OrderOpen_Payment{
+				Method:  method,
+				Address: "aaaaaa",
+				Amount:  "1000",
+			},
+		}))
+		if err != nil {
+			return err
+		}
+		if method == pb.OrderOpen_Payment_MODERATED {
+			err := order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderFulfillment{
+				ReleaseInfo: &pb.EscrowRelease{
+					ToAddress: "bbbbbb",
+				},
+			}))
+			if err != nil {
+				return err
+			}
+		}
+		return order.PutTransaction(iwallet.Transaction{
+			ID: "1111",
+			To: []iwallet.SpendInfo{
+				{
+					Address: iwallet.NewAddress("aaaaaa", iwallet.CtMock),
+					Amount:  iwallet.NewAmount("1000"),
+				},
+			},
+		})
+	}
+	tests := []struct {
+		setup       func(order *Order) error
+		ourID       string
+		canFinalize bool
+	}{
+		{
+			// Moderated success
+			setup: func(order *Order) error {
+				return putMessages(order, pb.OrderOpen_Payment_MODERATED)
+			},
+			ourID:       buyerID,
+			canFinalize: true,
+		},
+		{
+			// Cancelable success
+			setup: func(order *Order) error {
+				return putMessages(order, pb.OrderOpen_Payment_CANCELABLE)
+			},
+			ourID:       buyerID,
+			canFinalize: true,
+		},
+		{
+			// Vendor cannot finalize
+			setup: func(order *Order) error {
+				return putMessages(order, pb.OrderOpen_Payment_MODERATED)
+			},
+			ourID:       vendorID,
+			canFinalize: false,
+		},
+		{
+			// Direct order
+			setup: func(order *Order) error {
+				return putMessages(order, pb.OrderOpen_Payment_DIRECT)
+			},
+			ourID:       buyerID,
+			canFinalize: false,
+		},
+		{
+			// Moderated order not fulfilled
+			setup: func(order *Order) error {
+				if err := putMessages(order, pb.OrderOpen_Payment_MODERATED); err != nil {
+					return err
+				}
+				order.SerializedOrderFulfillments = nil
+				return nil
+			},
+			ourID:       buyerID,
+			canFinalize: false,
+		},
+		{
+			// Cancelable order already confirmed
+			setup: func(order *Order) error {
+				if err := putMessages(order, pb.OrderOpen_Payment_CANCELABLE); err != nil {
+					return err
+				}
+				order.SerializedOrderConfirmation = []byte{0x00}
+				return nil
+			},
+			ourID:       buyerID,
+			canFinalize: false,
+		},
+		{
+			// Dispute open
+			setup: func(order *Order) error {
+				if err := putMessages(order, pb.OrderOpen_Payment_MODERATED); err != nil {
+					return err
+				}
+				order.SerializedDisputeOpen = []byte{0x00}
+				return nil
+			},
+			ourID:       buyerID,
+			canFinalize: false,
+		},
+		{
+			// Already finalized
+			setup: func(order *Order) error {
+				if err := putMessages(order, pb.OrderOpen_Payment_MODERATED); err != nil {
+					return err
+				}
+				order.SerializedPaymentFinalized = []byte{0x00}
+				return nil
+			},
+			ourID:       buyerID,
+			canFinalize: false,
+		},
+		{
+			// Not funded
+			setup: func(order *Order) error {
+				if err := putMessages(order, pb.OrderOpen_Payment_MODERATED); err != nil {
+					return err
+				}
+				order.Transactions = nil
+				return nil
+			},
+			ourID:       buyerID,
+			canFinalize: false,
+		},
+		{
+			// Funds already spent
+			setup: func(order *Order) error {
+				if err := putMessages(order, pb.OrderOpen_Payment_MODERATED); err != nil {
+					return err
+				}
+				return order.PutTransaction(iwallet.Transaction{
+					ID: "2222",
+					From: []iwallet.SpendInfo{
+						{
+							Address: iwallet.NewAddress("aaaaaa", iwallet.CtMock),
+							Amount:  iwallet.NewAmount("1000"),
+						},
+					},
+				})
+			},
+			ourID:       buyerID,
+			canFinalize: false,
+		},
+	}
+
+	for i, test := range tests {
+		var order Order
+		if err := test.setup(&order); err != nil {
+			t.Errorf("Test %d setup failed: %s", i, err)
+		}
+
+		pid, err := peer.IDB58Decode(test.ourID)
+		if err != nil {
+			t.Errorf("Test %d peerID decode error: %s", i, err)
+		}
+
+		canFinalize := order.CanFinalizePayment(pid)
+		if canFinalize != test.canFinalize {
+			t.Errorf("Test %d: Got incorrect result. Expected %t, got %t", i, test.canFinalize, canFinalize)
+		}
+	}
+}
+
+func TestOrder_CanComplete(t *testing.T) {
+	putOrderOpen := func(order *Order) error {
+		return order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+			BuyerID: &pb.ID{
+				PeerID: "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4",
+			},
+		}))
+	}
+	tests := []struct {
+		setup       func(order *Order) error
+		ourID       string
+		canComplete bool
+	}{
+		{
+			// Success
+			setup: func(order *Order) error {
+				order.SerializedOrderFulfillments = []byte{0x00}
+				return putOrderOpen(order)
+			},
+			ourID:       "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4",
+			canComplete: true,
+		},
+		{
+			// Not buyer
+			setup: func(order *Order) error {
+				order.SerializedOrderFulfillments = []byte{0x00}
+				return putOrderOpen(order)
+			},
+			ourID:       "QmT5NvUtoM5nWFfrQdVrFtvGfKFmG7AHE8P34isapyhCxX",
+			canComplete: false,
+		},
+		{
+			// Order is nil
+			setup: func(order *Order) error {
+				return nil
+			},
+			ourID:       "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4",
+			canComplete: false,
+		},
+		{
+			// Not fulfilled
+			setup: func(order *Order) error {
+				return putOrderOpen(order)
+			},
+			ourID:       "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4",
+			canComplete: false,
+		},
+		{
+			// Dispute open
+			setup: func(order *Order) error {
+				order.SerializedOrderFulfillments = []byte{0x00}
+				order.SerializedDisputeOpen = []byte{0x00}
+				return putOrderOpen(order)
+			},
+			ourID:       "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4",
+			canComplete: false,
+		},
+		{
+			// Dispute closed
+			setup: func(order *Order) error {
+				order.SerializedOrderFulfillments = []byte{0x00}
+				order.SerializedDisputeOpen = []byte{0x00}
+				order.SerializedDisputeClosed = []byte{0x00}
+				return putOrderOpen(order)
+			},
+			ourID:       "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4",
+			canComplete: true,
+		},
+		{
+			// Already complete
+			setup: func(order *Order) error {
+				order.SerializedOrderFulfillments = []byte{0x00}
+				order.SerializedOrderComplete = []byte{0x00}
+				return putOrderOpen(order)
+			},
+			ourID:       "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4",
+			canComplete: false,
+		},
+		{
+			// Non nil cancel
+			setup: func(order *Order) error {
+				order.SerializedOrderFulfillments = []byte{0x00}
+				order.SerializedOrderCancel = []byte{0x00}
+				return putOrderOpen(order)
+			},
+			ourID:       "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4",
+			canComplete: false,
+		},
+	}
+
+	for i, test := range tests {
+		var order Order
+		if err := test.setup(&order); err != nil {
+			t.Errorf("Test %d setup failed: %s", i, err)
+		}
+
+		pid, err := peer.IDB58Decode(test.ourID)
+		if err != nil {
+			t.Errorf("Test %d peerID decode error: %s", i, err)
+		}
+
+		canComplete := order.CanComplete(pid)
+		if canComplete != test.canComplete {
+			t.Errorf("Test %d: Got incorrect result. Expected %t, got %t", i, test.canComplete, canComplete)
+		}
+	}
+}
+
+func TestOrder_State(t *testing.T) {
+	orderOpen := &pb.OrderOpen{
+		Items: []*pb.OrderOpen_Item{{}, {}},
+		Payment: &pb.OrderOpen_Payment{
+			Amount:  "1000",
+			Address: "abc",
+		},
+	}
+	fund := func(order *Order) error {
+		return order.PutTransaction(iwallet.Transaction{
+			ID: "1",
+			To: []iwallet.SpendInfo{
+				{
+					Address: iwallet.NewAddress("abc", iwallet.CtMock),
+					Amount:  iwallet.NewAmount(1000),
+				},
+			},
+		})
+	}
+	fulfill := func(order *Order, indexes ...uint32) error {
+		fulfillment := &pb.OrderFulfillment{}
+		for _, i := range indexes {
+			fulfillment.Fulfillments = append(fulfillment.Fulfillments, &pb.OrderFulfillment_FulfilledItem{ItemIndex: i})
+		}
+		return order.PutMessage(utils.MustWrapOrderMessage(fulfillment))
+	}
+
+	tests := []struct {
+		setup    func(order *Order) error
+		expected OrderState
+	}{
+		{
+			setup:    func(order *Order) error { return nil },
+			expected: OrderStateUnknown,
+		},
+		{
+			setup: func(order *Order) error {
+				return order.PutMessage(utils.MustWrapOrderMessage(orderOpen))
+			},
+			expected: OrderStateAwaitingPayment,
+		},
+		{
+			setup: func(order *Order) error {
+				if err := order.PutMessage(utils.MustWrapOrderMessage(orderOpen)); err != nil {
+					return err
+				}
+				return fund(order)
+			},
+			expected: OrderStatePending,
+		},
+		{
+			setup: func(order *Order) error {
+				if err := order.PutMessage(utils.MustWrapOrderMessage(orderOpen)); err != nil {
+					return err
+				}
+				return order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderConfirmation{}))
+			},
+			expected: OrderStateConfirmed,
+		},
+		{
+			setup: func(order *Order) error {
+				if err := order.PutMessage(utils.MustWrapOrderMessage(orderOpen)); err != nil {
+					return err
+				}
+				return fulfill(order, 0)
+			},
+			expected: OrderStatePartiallyFulfilled,
+		},
+		{
+			setup: func(order *Order) error {
+				if err := order.PutMessage(utils.MustWrapOrderMessage(orderOpen)); err != nil {
+					return err
+				}
+				return fulfill(order, 0, 1)
+			},
+			expected: OrderStateFulfilled,
+		},
+		{
+			setup: func(order *Order) error {
+				if err := order.PutMessage(utils.MustWrapOrderMessage(orderOpen)); err != nil {
+					return err
+				}
+				return order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderReject{}))
+			},
+			expected: OrderStateDeclined,
+		},
+		{
+			setup: func(order *Order) error {
+				if err := order.PutMessage(utils.MustWrapOrderMessage(orderOpen)); err != nil {
+					return err
+				}
+				return order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderCancel{}))
+			},
+			expected: OrderStateCanceled,
+		},
+		{
+			setup: func(order *Order) error {
+				if err := order.PutMessage(utils.MustWrapOrderMessage(orderOpen)); err != nil {
+					return err
+				}
+				return order.PutMessage(utils.MustWrapOrderMessage(&pb.DisputeOpen{}))
+			},
+			expected: OrderStateDisputed,
+		},
+		{
+			setup: func(order *Order) error {
+				if err := order.PutMessage(utils.MustWrapOrderMessage(orderOpen)); err != nil {
+					return err
+				}
+				if err := order.PutMessage(utils.MustWrapOrderMessage(&pb.DisputeOpen{})); err != nil {
+					return err
+				}
+				return order.PutMessage(utils.MustWrapOrderMessage(&pb.DisputeClose{}))
+			},
+			expected: OrderStateDecided,
+		},
+		{
+			setup: func(order *Order) error {
+				if err := order.PutMessage(utils.MustWrapOrderMessage(orderOpen)); err != nil {
+					return err
+				}
+				if err := order.PutMessage(utils.MustWrapOrderMessage(&pb.DisputeOpen{})); err != nil {
+					return err
+				}
+				if err := order.PutMessage(utils.MustWrapOrderMessage(&pb.DisputeClose{})); err != nil {
+					return err
+				}
+				return order.PutTransaction(iwallet.Transaction{
+					ID: "2",
+					From: []iwallet.SpendInfo{
+						{
+							Address: iwallet.NewAddress("abc", iwallet.CtMock),
+							Amount:  iwallet.NewAmount(1000),
+						},
+					},
+				})
+			},
+			expected: OrderStateResolved,
+		},
+		{
+			setup: func(order *Order) error {
+				if err := order.PutMessage(utils.MustWrapOrderMessage(orderOpen)); err != nil {
+					return err
+				}
+				if err := fulfill(order, 0, 1); err != nil {
+					return err
+				}
+				return order.PutMessage(utils.MustWrapOrderMessage(&pb.PaymentFinalized{}))
+			},
+			expected: OrderStatePaymentFinalized,
+		},
+		{
+			setup: func(order *Order) error {
+				if err := order.PutMessage(utils.MustWrapOrderMessage(orderOpen)); err != nil {
+					return err
+				}
+				return order.PutMessage(utils.MustWrapOrderMessage(&pb.Refund{}))
+			},
+			expected: OrderStateRefunded,
+		},
+		{
+			setup: func(order *Order) error {
+				if err := order.PutMessage(utils.MustWrapOrderMessage(orderOpen)); err != nil {
+					return err
+				}
+				if err := fulfill(order, 0, 1); err != nil {
+					return err
+				}
+				return order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderComplete{}))
+			},
+			expected: OrderStateCompleted,
+		},
+	}
+
+	for i, test := range tests {
+		var order Order
+		if err := test.setup(&order); err != nil {
+			t.Errorf("Test %d setup failed: %s", i, err)
+			continue
+		}
+		if state := order.State(); state != test.expected {
+			t.Errorf("Test %d: expected state %s, got %s", i, test.expected, state)
+		}
+	}
+}
+
+func TestOrder_BeforeSave(t *testing.T) {
+	ts := time.Now().UTC()
+	tsProto, err := ptypes.TimestampProto(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order Order
+	err = order.PutMessage(utils.MustWrapOrderMessage(&pb.OrderOpen{
+		Listings: []*pb.SignedListing{
+			{
+				Listing: &pb.Listing{
+					VendorID: &pb.ID{PeerID: "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4"},
+					Item:     &pb.Listing_Item{Title: "Red Shirt"},
+				},
+			},
+		},
+		BuyerID:   &pb.ID{PeerID: "QmT5NvUtoM5nWFfrQdVrFtvGfKFmG7AHE8P34isapyhCxX"},
+		Timestamp: tsProto,
+		Shipping:  &pb.OrderOpen_Shipping{ShipTo: "Alice Smith"},
+		Payment: &pb.OrderOpen_Payment{
+			Coin:    "BTC",
+			Amount:  "1000",
+			Address: "abc",
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := order.BeforeSave(); err != nil {
+		t.Fatal(err)
+	}
+
+	if order.CurrentState != OrderStateAwaitingPayment {
+		t.Errorf("Expected state %s, got %s", OrderStateAwaitingPayment, order.CurrentState)
+	}
+	if !order.OpenedAt.Equal(ts) {
+		t.Errorf("Expected timestamp %s, got %s", ts, order.OpenedAt)
+	}
+	if order.PaymentCoin != "BTC" {
+		t.Errorf("Expected coin BTC, got %s", order.PaymentCoin)
+	}
+	if order.BuyerID != "QmT5NvUtoM5nWFfrQdVrFtvGfKFmG7AHE8P34isapyhCxX" {
+		t.Errorf("Incorrect buyer ID %s", order.BuyerID)
+	}
+	if order.VendorID != "QmPFZPt6FJMZFQABX44RnxmZGh2XGW8ev7KKEMpL8YMxd4" {
+		t.Errorf("Incorrect vendor ID %s", order.VendorID)
+	}
+	if order.SearchTerms != "red shirt alice smith" {
+		t.Errorf("Incorrect search terms %s", order.SearchTerms)
+	}
+}
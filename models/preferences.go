@@ -2,7 +2,11 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	iwallet "github.com/cpacia/wallet-interface"
 	peer "github.com/libp2p/go-libp2p-peer"
+	"math/big"
 )
 
 // UserPreferences are set by the client and persisted in the database.
@@ -21,10 +25,33 @@ type UserPreferences struct {
 	Mods               json.RawMessage `json:"storeModerators"`
 	MisPaymentBuffer   float32         `json:"mispaymentBuffer"`
 	AutoConfirm        bool            `json:"autoConfirm"`
+	ConfirmRules       json.RawMessage `json:"autoConfirmRules"`
+	AutoRenewListings  bool            `json:"autoRenewListings"`
 	EmailNotifications string          `json:"emailNotifications"`
+	EmailTypes         json.RawMessage `json:"emailNotificationTypes"`
 	PrefCurrencies     json.RawMessage `json:"preferredCurrencies"`
 }
 
+// AutoConfirmRules restrict which funded orders are automatically
+// confirmed when the AutoConfirm preference is in effect.
+type AutoConfirmRules struct {
+	// MaxOrderValue maps a coin code to the largest order total, in the
+	// coin's base units, that will be automatically confirmed.
+	MaxOrderValue map[string]string `json:"maxOrderValue"`
+
+	// AllowedCoins restricts auto-confirm to orders paid in these coins.
+	// If empty orders paid in any coin are allowed.
+	AllowedCoins []string `json:"allowedCoins"`
+
+	// ExcludeModerated disables auto-confirm for moderated orders.
+	ExcludeModerated bool `json:"excludeModerated"`
+
+	// ListingOverrides maps a listing slug to whether or not orders for
+	// that listing are auto-confirmed. This takes precedence over the
+	// AutoConfirm preference.
+	ListingOverrides map[string]bool `json:"listingOverrides"`
+}
+
 type shippingAddress struct {
 	Name           string `json:"name"`
 	Company        string `json:"company"`
@@ -50,7 +77,10 @@ type prefsJSON struct {
 	StoreModerators     []string          `json:"storeModerators"`
 	MisPaymentBuffer    float32           `json:"mispaymentBuffer"`
 	AutoConfirm         bool              `json:"autoConfirm"`
+	AutoConfirmRules    *AutoConfirmRules `json:"autoConfirmRules"`
+	AutoRenewListings   bool              `json:"autoRenewListings"`
 	EmailNotifications  string            `json:"emailNotifications"`
+	EmailTypes          []string          `json:"emailNotificationTypes"`
 	PreferredCurrencies []string          `json:"preferredCurrencies"`
 }
 
@@ -103,6 +133,86 @@ func (prefs *UserPreferences) PreferredCurrencies() ([]string, error) {
 	return prefCurrencies, nil
 }
 
+// EmailNotificationTypes returns the types of notifications which are sent
+// to the EmailNotifications address. If empty all types are sent.
+func (prefs *UserPreferences) EmailNotificationTypes() ([]string, error) {
+	var types []string
+	if prefs.EmailTypes != nil {
+		if err := json.Unmarshal(prefs.EmailTypes, &types); err != nil {
+			return nil, err
+		}
+	}
+	return types, nil
+}
+
+// AutoConfirmRules returns the rules used to decide which orders are
+// automatically confirmed.
+func (prefs *UserPreferences) AutoConfirmRules() (*AutoConfirmRules, error) {
+	rules := new(AutoConfirmRules)
+	if prefs.ConfirmRules != nil {
+		if err := json.Unmarshal(prefs.ConfirmRules, rules); err != nil {
+			return nil, err
+		}
+	}
+	for coin, max := range rules.MaxOrderValue {
+		if _, ok := new(big.Int).SetString(max, 10); !ok {
+			return nil, fmt.Errorf("invalid max order value for %s", coin)
+		}
+	}
+	return rules, nil
+}
+
+// ShouldAutoConfirm returns whether the order should be confirmed without
+// waiting on the vendor. The AutoConfirm preference, or the override for
+// the listing if one is set, must be enabled for every listing in the
+// order and the order must pass each of the AutoConfirmRules.
+func (prefs *UserPreferences) ShouldAutoConfirm(orderOpen *pb.OrderOpen) (bool, error) {
+	rules, err := prefs.AutoConfirmRules()
+	if err != nil {
+		return false, err
+	}
+	if orderOpen.Payment == nil || len(orderOpen.Listings) == 0 {
+		return false, nil
+	}
+
+	for _, sl := range orderOpen.Listings {
+		if sl.Listing == nil {
+			return false, nil
+		}
+		enabled, ok := rules.ListingOverrides[sl.Listing.Slug]
+		if !ok {
+			enabled = prefs.AutoConfirm
+		}
+		if !enabled {
+			return false, nil
+		}
+	}
+
+	if rules.ExcludeModerated && orderOpen.Payment.Method == pb.OrderOpen_Payment_MODERATED {
+		return false, nil
+	}
+
+	if len(rules.AllowedCoins) > 0 {
+		allowed := false
+		for _, coin := range rules.AllowedCoins {
+			if coin == orderOpen.Payment.Coin {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false, nil
+		}
+	}
+
+	if max, ok := rules.MaxOrderValue[orderOpen.Payment.Coin]; ok {
+		if iwallet.NewAmount(orderOpen.Payment.Amount).Cmp(iwallet.NewAmount(max)) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // UnmarshalJSON unmarshals the JSON object into a UserPreferences object.
 func (prefs *UserPreferences) UnmarshalJSON(b []byte) error {
 	var c0 prefsJSON
@@ -125,6 +235,20 @@ func (prefs *UserPreferences) UnmarshalJSON(b []byte) error {
 		if err != nil {
 			return err
 		}
+		var emailTypes json.RawMessage
+		if c0.EmailTypes != nil {
+			emailTypes, err = json.Marshal(c0.EmailTypes)
+			if err != nil {
+				return err
+			}
+		}
+		var confirmRules json.RawMessage
+		if c0.AutoConfirmRules != nil {
+			confirmRules, err = json.Marshal(c0.AutoConfirmRules)
+			if err != nil {
+				return err
+			}
+		}
 
 		prefs.PaymentDataInQR = c0.PaymentDataInQR
 		prefs.ShowNotifications = c0.ShowNotifications
@@ -138,7 +262,10 @@ func (prefs *UserPreferences) UnmarshalJSON(b []byte) error {
 		prefs.Mods = storeModerators
 		prefs.MisPaymentBuffer = c0.MisPaymentBuffer
 		prefs.AutoConfirm = c0.AutoConfirm
+		prefs.ConfirmRules = confirmRules
+		prefs.AutoRenewListings = c0.AutoRenewListings
 		prefs.EmailNotifications = c0.EmailNotifications
+		prefs.EmailTypes = emailTypes
 		prefs.PrefCurrencies = preferredCurrencies
 	}
 
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"encoding/json"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"testing"
+)
+
+func TestUserPreferences_ShouldAutoConfirm(t *testing.T) {
+	newOrderOpen := func(method pb.OrderOpen_Payment_Method, coin, amount string, slugs ...string) *pb.OrderOpen {
+		orderOpen := &pb.OrderOpen{
+			Payment: &pb.OrderOpen_Payment{
+				Method: method,
+				Coin:   coin,
+				Amount: amount,
+			},
+		}
+		for _, slug := range slugs {
+			orderOpen.Listings = append(orderOpen.Listings, &pb.SignedListing{
+				Listing: &pb.Listing{Slug: slug},
+			})
+		}
+		return orderOpen
+	}
+
+	tests := []struct {
+		name        string
+		autoConfirm bool
+		rules       string
+		orderOpen   *pb.OrderOpen
+		expected    bool
+	}{
+		{
+			name:        "Enabled no rules",
+			autoConfirm: true,
+			orderOpen:   newOrderOpen(pb.OrderOpen_Payment_DIRECT, "BTC", "1000", "shirt"),
+			expected:    true,
+		},
+		{
+			name:        "Disabled",
+			autoConfirm: false,
+			orderOpen:   newOrderOpen(pb.OrderOpen_Payment_DIRECT, "BTC", "1000", "shirt"),
+			expected:    false,
+		},
+		{
+			name:        "Listing override enables",
+			autoConfirm: false,
+			rules:       `{"listingOverrides": {"shirt": true}}`,
+			orderOpen:   newOrderOpen(pb.OrderOpen_Payment_DIRECT, "BTC", "1000", "shirt"),
+			expected:    true,
+		},
+		{
+			name:        "Listing override disables",
+			autoConfirm: true,
+			rules:       `{"listingOverrides": {"hat": false}}`,
+			orderOpen:   newOrderOpen(pb.OrderOpen_Payment_DIRECT, "BTC", "1000", "shirt", "hat"),
+			expected:    false,
+		},
+		{
+			name:        "Moderated excluded",
+			autoConfirm: true,
+			rules:       `{"excludeModerated": true}`,
+			orderOpen:   newOrderOpen(pb.OrderOpen_Payment_MODERATED, "BTC", "1000", "shirt"),
+			expected:    false,
+		},
+		{
+			name:        "Coin not allowed",
+			autoConfirm: true,
+			rules:       `{"allowedCoins": ["LTC"]}`,
+			orderOpen:   newOrderOpen(pb.OrderOpen_Payment_DIRECT, "BTC", "1000", "shirt"),
+			expected:    false,
+		},
+		{
+			name:        "Coin allowed",
+			autoConfirm: true,
+			rules:       `{"allowedCoins": ["LTC", "BTC"]}`,
+			orderOpen:   newOrderOpen(pb.OrderOpen_Payment_DIRECT, "BTC", "1000", "shirt"),
+			expected:    true,
+		},
+		{
+			name:        "Under max order value",
+			autoConfirm: true,
+			rules:       `{"maxOrderValue": {"BTC": "1000"}}`,
+			orderOpen:   newOrderOpen(pb.OrderOpen_Payment_DIRECT, "BTC", "1000", "shirt"),
+			expected:    true,
+		},
+		{
+			name:        "Over max order value",
+			autoConfirm: true,
+			rules:       `{"maxOrderValue": {"BTC": "999"}}`,
+			orderOpen:   newOrderOpen(pb.OrderOpen_Payment_DIRECT, "BTC", "1000", "shirt"),
+			expected:    false,
+		},
+		{
+			name:        "Max order value for other coin",
+			autoConfirm: true,
+			rules:       `{"maxOrderValue": {"LTC": "999"}}`,
+			orderOpen:   newOrderOpen(pb.OrderOpen_Payment_DIRECT, "BTC", "1000", "shirt"),
+			expected:    true,
+		},
+	}
+
+	for _, test := range tests {
+		prefs := &UserPreferences{AutoConfirm: test.autoConfirm}
+		if test.rules != "" {
+			prefs.ConfirmRules = json.RawMessage(test.rules)
+		}
+		confirm, err := prefs.ShouldAutoConfirm(test.orderOpen)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+			continue
+		}
+		if confirm != test.expected {
+			t.Errorf("%s: expected %t, got %t", test.name, test.expected, confirm)
+		}
+	}
+
+	prefs := &UserPreferences{
+		AutoConfirm:  true,
+		ConfirmRules: json.RawMessage(`{"maxOrderValue": {"BTC": "abc"}}`),
+	}
+	if _, err := prefs.ShouldAutoConfirm(newOrderOpen(pb.OrderOpen_Payment_DIRECT, "BTC", "1000", "shirt")); err == nil {
+		t.Error("Expected error for invalid max order value")
+	}
+}
@@ -0,0 +1,268 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// searchFieldSeparator separates the entries of list fields stored in
+	// a single column of the search index.
+	searchFieldSeparator = "\n"
+
+	// ratingBoostMaxCount is the number of vendor ratings at which the
+	// rating boost is at its maximum.
+	ratingBoostMaxCount = 50
+)
+
+// Weights given to a search term matching each field of a listing.
+const (
+	searchWeightTitle       = 4
+	searchWeightTags        = 3
+	searchWeightCategories  = 2
+	searchWeightDescription = 1
+)
+
+// The fields of a listing a search term can be found in. These are
+// combined into the bit set saved with each SearchTerm.
+const (
+	SearchFieldTitle = 1 << iota
+	SearchFieldTags
+	SearchFieldCategories
+	SearchFieldDescription
+)
+
+// SearchPeer records when the listings of a peer were last added to the
+// local search index and the IPNS value they were indexed from. A peer is
+// only re-indexed when its IPNS record changes.
+type SearchPeer struct {
+	PeerID      string    `gorm:"primary_key" json:"peerID"`
+	RootCID     string    `json:"rootCID"`
+	LastIndexed time.Time `json:"lastIndexed"`
+}
+
+// SearchListing is a listing in the local search index. The list fields
+// are stored newline separated so they can be matched in a single column.
+type SearchListing struct {
+	PeerID string `gorm:"primary_key"`
+	Slug   string `gorm:"primary_key"`
+
+	Title        string
+	Description  string
+	Tags         string
+	Categories   string
+	ShipsTo      string
+	ContractType string
+	NSFW         bool
+
+	// PriceCurrency and Price are the pricing currency of the listing and
+	// the price in whole units of that currency, for example 1.50 USD.
+	PriceCurrency string `gorm:"index"`
+	Price         float64
+
+	VendorRating      float32
+	VendorRatingCount uint32
+
+	// Metadata is the JSON serialized ListingMetadata returned in the
+	// search results.
+	Metadata []byte
+
+	IndexedAt time.Time
+}
+
+// SearchTerm is a word in one of the listings in the local search index.
+// Search queries look up listings by the start of their words in this table
+// rather than scanning every listing.
+type SearchTerm struct {
+	Term   string `gorm:"primary_key"`
+	PeerID string `gorm:"primary_key;index"`
+	Slug   string `gorm:"primary_key"`
+
+	// Fields is the bit set of SearchField values the term is found in.
+	Fields int
+}
+
+// Weight returns the relevance of a term found in the fields.
+func (t *SearchTerm) Weight() float64 {
+	var weight float64
+	if t.Fields&SearchFieldTitle != 0 {
+		weight += searchWeightTitle
+	}
+	if t.Fields&SearchFieldTags != 0 {
+		weight += searchWeightTags
+	}
+	if t.Fields&SearchFieldCategories != 0 {
+		weight += searchWeightCategories
+	}
+	if t.Fields&SearchFieldDescription != 0 {
+		weight += searchWeightDescription
+	}
+	return weight
+}
+
+// NewSearchListing returns a search index entry for the listing metadata.
+// The vendor stats should be calculated from ratings we have validated
+// rather than taken from the vendor's profile. They may be nil.
+func NewSearchListing(peerID string, lmd *ListingMetadata, stats *ProfileStats) (*SearchListing, error) {
+	ser, err := json.Marshal(lmd)
+	if err != nil {
+		return nil, err
+	}
+	sl := &SearchListing{
+		PeerID:       peerID,
+		Slug:         lmd.Slug,
+		Title:        lmd.Title,
+		Description:  lmd.Description,
+		Tags:         strings.Join(lmd.Tags, searchFieldSeparator),
+		Categories:   strings.Join(lmd.Categories, searchFieldSeparator),
+		ShipsTo:      strings.Join(lmd.ShipsTo, searchFieldSeparator),
+		ContractType: lmd.ContractType,
+		NSFW:         lmd.NSFW,
+		Metadata:     ser,
+		IndexedAt:    time.Now(),
+	}
+	if lmd.Price.Currency != nil {
+		sl.PriceCurrency = lmd.Price.Currency.Code.String()
+		amount := big.Int(lmd.Price.Amount)
+		price := new(big.Float).SetInt(&amount)
+		price.Quo(price, big.NewFloat(math.Pow10(int(lmd.Price.Currency.Divisibility))))
+		sl.Price, _ = price.Float64()
+	}
+	if stats != nil {
+		sl.VendorRating = stats.AverageRating
+		sl.VendorRatingCount = stats.RatingCount
+	}
+	return sl, nil
+}
+
+// ListingMetadata returns the metadata saved with the index entry.
+func (l *SearchListing) ListingMetadata() (*ListingMetadata, error) {
+	lmd := new(ListingMetadata)
+	if err := json.Unmarshal(l.Metadata, lmd); err != nil {
+		return nil, err
+	}
+	return lmd, nil
+}
+
+// Matches returns whether the listing passes the category and shipping
+// filters of the query. The other filters are applied in the database.
+func (l *SearchListing) Matches(query *SearchQuery) bool {
+	if query.Category != "" && !containsFold(strings.Split(l.Categories, searchFieldSeparator), query.Category) {
+		return false
+	}
+	if query.ShipsTo != "" {
+		regions := strings.Split(l.ShipsTo, searchFieldSeparator)
+		if !containsFold(regions, query.ShipsTo) && !containsFold(regions, "ALL") {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchTerms returns the words in the listing to save in the search index.
+func (l *SearchListing) SearchTerms() []SearchTerm {
+	fields := make(map[string]int)
+	for field, text := range map[int]string{
+		SearchFieldTitle:       l.Title,
+		SearchFieldTags:        l.Tags,
+		SearchFieldCategories:  l.Categories,
+		SearchFieldDescription: l.Description,
+	} {
+		for _, word := range searchWords(text) {
+			fields[word] |= field
+		}
+	}
+	terms := make([]SearchTerm, 0, len(fields))
+	for word, f := range fields {
+		terms = append(terms, SearchTerm{
+			Term:   word,
+			PeerID: l.PeerID,
+			Slug:   l.Slug,
+			Fields: f,
+		})
+	}
+	return terms
+}
+
+// Score returns the text relevance of the listing boosted by the vendor's
+// rating. The relevance is the sum of the weights of the search terms the
+// listing matched.
+func (l *SearchListing) Score(relevance float64) float64 {
+	// A vendor with a perfect rating and at least ratingBoostMaxCount
+	// ratings doubles the score. Fewer ratings count for proportionally
+	// less so that a single five star rating isn't worth much.
+	count := math.Min(float64(l.VendorRatingCount), ratingBoostMaxCount)
+	boost := 1 + (float64(l.VendorRating)/5)*(count/ratingBoostMaxCount)
+	return relevance * boost
+}
+
+// SearchQuery is used to search the local search index. Zero values
+// are ignored.
+type SearchQuery struct {
+	// Query is the free text to search for. Each word must match the
+	// start of a word in the title, tags, categories or description of
+	// a listing.
+	Query string `json:"query"`
+
+	// Category restricts the results to listings in this category.
+	Category string `json:"category"`
+
+	// Currency restricts the results to listings priced in this
+	// currency. MinPrice and MaxPrice are in whole units of this
+	// currency and it is required if either is set.
+	Currency string   `json:"currency"`
+	MinPrice *float64 `json:"minPrice"`
+	MaxPrice *float64 `json:"maxPrice"`
+
+	// ShipsTo restricts the results to listings which ship to this
+	// country code.
+	ShipsTo string `json:"shipsTo"`
+
+	// NSFW includes listings marked NSFW in the results.
+	NSFW bool `json:"nsfw"`
+
+	// Limit is the maximum number of results. Zero or less means no limit.
+	Limit int `json:"limit"`
+
+	// Offset is the number of results to skip.
+	Offset int `json:"offset"`
+}
+
+// Terms returns the lower case words in the query text.
+func (q *SearchQuery) Terms() []string {
+	return searchWords(q.Query)
+}
+
+// SearchResult is a single listing returned from a search.
+type SearchResult struct {
+	PeerID  string          `json:"peerID"`
+	Score   float64         `json:"score"`
+	Listing ListingMetadata `json:"listing"`
+}
+
+// SearchResults is a page of search results. Total is the number of
+// results before the limit and offset were applied.
+type SearchResults struct {
+	Total   int            `json:"total"`
+	Results []SearchResult `json:"results"`
+}
+
+func containsFold(list []string, s string) bool {
+	for _, e := range list {
+		if strings.EqualFold(e, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchWords splits the text into lower case words.
+func searchWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
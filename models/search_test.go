@@ -0,0 +1,126 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewSearchListing(t *testing.T) {
+	lmd := &ListingMetadata{
+		Slug:       "ron-swanson-shirt",
+		Title:      "Ron Swanson Shirt",
+		Tags:       []string{"tshirts", "swanson"},
+		Categories: []string{"Clothing"},
+		ShipsTo:    []string{"UNITED_STATES", "CANADA"},
+		Price:      *NewCurrencyValue("1550", CurrencyDefinitions["USD"]),
+	}
+	sl, err := NewSearchListing("QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub", lmd, &ProfileStats{AverageRating: 4.5, RatingCount: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sl.PriceCurrency != "USD" {
+		t.Errorf("Expected price currency USD, got %s", sl.PriceCurrency)
+	}
+	if sl.Price != 15.5 {
+		t.Errorf("Expected price 15.5, got %f", sl.Price)
+	}
+	if sl.VendorRating != 4.5 || sl.VendorRatingCount != 10 {
+		t.Errorf("Incorrect vendor rating %f (%d)", sl.VendorRating, sl.VendorRatingCount)
+	}
+
+	ret, err := sl.ListingMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret.Slug != lmd.Slug || !reflect.DeepEqual(ret.Tags, lmd.Tags) || ret.Price.Amount.String() != "1550" {
+		t.Errorf("Incorrect metadata returned %v", ret)
+	}
+
+	tests := []struct {
+		query    *SearchQuery
+		expected bool
+	}{
+		{query: &SearchQuery{}, expected: true},
+		{query: &SearchQuery{Category: "clothing"}, expected: true},
+		{query: &SearchQuery{Category: "kitchen"}, expected: false},
+		{query: &SearchQuery{ShipsTo: "canada"}, expected: true},
+		{query: &SearchQuery{ShipsTo: "MEXICO"}, expected: false},
+	}
+	for i, test := range tests {
+		if matches := sl.Matches(test.query); matches != test.expected {
+			t.Errorf("Test %d: expected matches %t, got %t", i, test.expected, matches)
+		}
+	}
+}
+
+func TestSearchListing_SearchTerms(t *testing.T) {
+	sl := &SearchListing{
+		PeerID:      "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub",
+		Slug:        "ron-swanson-shirt",
+		Title:       "Ron Swanson Shirt",
+		Tags:        "tshirts\nswanson",
+		Categories:  "Clothing",
+		Description: "A shirt for breakfast lovers",
+	}
+
+	terms := make(map[string]SearchTerm)
+	for _, term := range sl.SearchTerms() {
+		if term.PeerID != sl.PeerID || term.Slug != sl.Slug {
+			t.Errorf("Incorrect listing for term %s", term.Term)
+		}
+		terms[term.Term] = term
+	}
+	if len(terms) != 9 {
+		t.Errorf("Expected 9 terms, got %d", len(terms))
+	}
+
+	tests := []struct {
+		term     string
+		expected float64
+	}{
+		{term: "ron", expected: searchWeightTitle},
+		{term: "swanson", expected: searchWeightTitle + searchWeightTags},
+		{term: "shirt", expected: searchWeightTitle + searchWeightDescription},
+		{term: "tshirts", expected: searchWeightTags},
+		{term: "clothing", expected: searchWeightCategories},
+		{term: "breakfast", expected: searchWeightDescription},
+	}
+	for _, test := range tests {
+		term, ok := terms[test.term]
+		if !ok {
+			t.Errorf("Term %s not found", test.term)
+			continue
+		}
+		if weight := term.Weight(); weight != test.expected {
+			t.Errorf("Term %s: expected weight %f, got %f", test.term, test.expected, weight)
+		}
+	}
+}
+
+func TestSearchListing_Score(t *testing.T) {
+	sl := &SearchListing{}
+	if score := sl.Score(5); score != 5 {
+		t.Errorf("Incorrect unboosted score %f", score)
+	}
+
+	// A perfect rating with enough ratings doubles the score.
+	sl.VendorRating = 5
+	sl.VendorRatingCount = ratingBoostMaxCount
+	if score := sl.Score(5); score != 10 {
+		t.Errorf("Incorrect boosted score %f", score)
+	}
+
+	// Fewer ratings count for proportionally less.
+	sl.VendorRatingCount = ratingBoostMaxCount / 2
+	if score := sl.Score(5); score != 7.5 {
+		t.Errorf("Incorrect partially boosted score %f", score)
+	}
+}
+
+func TestSearchQuery_Terms(t *testing.T) {
+	q := &SearchQuery{Query: "  Ron-Swanson, SHIRT! "}
+	expected := []string{"ron", "swanson", "shirt"}
+	if terms := q.Terms(); !reflect.DeepEqual(terms, expected) {
+		t.Errorf("Expected terms %v, got %v", expected, terms)
+	}
+}
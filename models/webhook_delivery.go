@@ -0,0 +1,65 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// WebhookDeliveryPending is the status of a delivery which has not
+	// yet succeeded and will be attempted again.
+	WebhookDeliveryPending = "pending"
+
+	// WebhookDeliveryDelivered is the status of a delivery which the
+	// webhook accepted.
+	WebhookDeliveryDelivered = "delivered"
+
+	// WebhookDeliveryFailed is the status of a delivery which ran out of
+	// attempts.
+	WebhookDeliveryFailed = "failed"
+)
+
+// WebhookDelivery is a single event sent to a webhook URL. Pending
+// deliveries make up the retry queue and delivered and failed deliveries
+// are kept as a log for a week.
+type WebhookDelivery struct {
+	ID           string          `gorm:"primary_key" json:"deliveryID"`
+	URL          string          `json:"url"`
+	EventType    string          `gorm:"index" json:"eventType"`
+	Payload      json.RawMessage `json:"payload"`
+	Status       string          `gorm:"index" json:"status"`
+	Attempts     int             `json:"attempts"`
+	ResponseCode int             `json:"responseCode"`
+	LastError    string          `json:"lastError"`
+	Timestamp    time.Time       `json:"timestamp"`
+	LastAttempt  time.Time       `json:"lastAttempt"`
+	NextAttempt  time.Time       `gorm:"index" json:"nextAttempt"`
+}
+
+// WebhookDeliveryQuery is used to filter and paginate the deliveries
+// returned from GetWebhookDeliveries. Zero values are ignored.
+type WebhookDeliveryQuery struct {
+	// Status restricts the results to deliveries with this status.
+	Status string `json:"status"`
+
+	// Limit is the maximum number of deliveries returned. Zero or less
+	// means no limit.
+	Limit int `json:"limit"`
+
+	// OffsetID is the ID of the last delivery in the previous page.
+	OffsetID string `json:"offsetID"`
+}
+
+// WebhookDeliveryList is a page of deliveries matching a
+// WebhookDeliveryQuery, newest first.
+type WebhookDeliveryList struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+
+	// Total is the number of deliveries matching the query across
+	// all pages.
+	Total int `json:"total"`
+
+	// NextOffsetID is the OffsetID to use to fetch the next page. It is
+	// empty if there are no more pages.
+	NextOffsetID string `json:"nextOffsetID"`
+}
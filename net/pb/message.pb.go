@@ -25,42 +25,48 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type Message_MessageType int32
 
 const (
-	Message_ACK              Message_MessageType = 0
-	Message_PING             Message_MessageType = 1
-	Message_PONG             Message_MessageType = 2
-	Message_CHAT             Message_MessageType = 3
-	Message_FOLLOW           Message_MessageType = 4
-	Message_UNFOLLOW         Message_MessageType = 5
-	Message_STORE            Message_MessageType = 6
-	Message_ORDER            Message_MessageType = 7
-	Message_ADDRESS_REQUEST  Message_MessageType = 8
-	Message_ADDRESS_RESPONSE Message_MessageType = 9
+	Message_ACK                Message_MessageType = 0
+	Message_PING               Message_MessageType = 1
+	Message_PONG               Message_MessageType = 2
+	Message_CHAT               Message_MessageType = 3
+	Message_FOLLOW             Message_MessageType = 4
+	Message_UNFOLLOW           Message_MessageType = 5
+	Message_STORE              Message_MessageType = 6
+	Message_ORDER              Message_MessageType = 7
+	Message_ADDRESS_REQUEST    Message_MessageType = 8
+	Message_ADDRESS_RESPONSE   Message_MessageType = 9
+	Message_INVENTORY_REQUEST  Message_MessageType = 10
+	Message_INVENTORY_RESPONSE Message_MessageType = 11
 )
 
 var Message_MessageType_name = map[int32]string{
-	0: "ACK",
-	1: "PING",
-	2: "PONG",
-	3: "CHAT",
-	4: "FOLLOW",
-	5: "UNFOLLOW",
-	6: "STORE",
-	7: "ORDER",
-	8: "ADDRESS_REQUEST",
-	9: "ADDRESS_RESPONSE",
+	0:  "ACK",
+	1:  "PING",
+	2:  "PONG",
+	3:  "CHAT",
+	4:  "FOLLOW",
+	5:  "UNFOLLOW",
+	6:  "STORE",
+	7:  "ORDER",
+	8:  "ADDRESS_REQUEST",
+	9:  "ADDRESS_RESPONSE",
+	10: "INVENTORY_REQUEST",
+	11: "INVENTORY_RESPONSE",
 }
 
 var Message_MessageType_value = map[string]int32{
-	"ACK":              0,
-	"PING":             1,
-	"PONG":             2,
-	"CHAT":             3,
-	"FOLLOW":           4,
-	"UNFOLLOW":         5,
-	"STORE":            6,
-	"ORDER":            7,
-	"ADDRESS_REQUEST":  8,
-	"ADDRESS_RESPONSE": 9,
+	"ACK":                0,
+	"PING":               1,
+	"PONG":               2,
+	"CHAT":               3,
+	"FOLLOW":             4,
+	"UNFOLLOW":           5,
+	"STORE":              6,
+	"ORDER":              7,
+	"ADDRESS_REQUEST":    8,
+	"ADDRESS_RESPONSE":   9,
+	"INVENTORY_REQUEST":  10,
+	"INVENTORY_RESPONSE": 11,
 }
 
 func (x Message_MessageType) String() string {
@@ -226,10 +232,12 @@ type ChatMessage struct {
 	Timestamp *timestamp.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Flag      ChatMessage_Flag     `protobuf:"varint,4,opt,name=flag,proto3,enum=ChatMessage_Flag" json:"flag,omitempty"`
 	// Only used when Flag is READ.
-	ReadID               string   `protobuf:"bytes,5,opt,name=readID,proto3" json:"readID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ReadID string `protobuf:"bytes,5,opt,name=readID,proto3" json:"readID,omitempty"`
+	// Optional request for payment into an order.
+	PaymentRequest       *ChatMessage_PaymentRequest `protobuf:"bytes,6,opt,name=paymentRequest,proto3" json:"paymentRequest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
 }
 
 func (m *ChatMessage) Reset()         { *m = ChatMessage{} }
@@ -292,6 +300,68 @@ func (m *ChatMessage) GetReadID() string {
 	return ""
 }
 
+func (m *ChatMessage) GetPaymentRequest() *ChatMessage_PaymentRequest {
+	if m != nil {
+		return m.PaymentRequest
+	}
+	return nil
+}
+
+type ChatMessage_PaymentRequest struct {
+	Coin                 string   `protobuf:"bytes,1,opt,name=coin,proto3" json:"coin,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Amount               string   `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChatMessage_PaymentRequest) Reset()         { *m = ChatMessage_PaymentRequest{} }
+func (m *ChatMessage_PaymentRequest) String() string { return proto.CompactTextString(m) }
+func (*ChatMessage_PaymentRequest) ProtoMessage()    {}
+func (*ChatMessage_PaymentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{1, 0}
+}
+
+func (m *ChatMessage_PaymentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChatMessage_PaymentRequest.Unmarshal(m, b)
+}
+func (m *ChatMessage_PaymentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChatMessage_PaymentRequest.Marshal(b, m, deterministic)
+}
+func (m *ChatMessage_PaymentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChatMessage_PaymentRequest.Merge(m, src)
+}
+func (m *ChatMessage_PaymentRequest) XXX_Size() int {
+	return xxx_messageInfo_ChatMessage_PaymentRequest.Size(m)
+}
+func (m *ChatMessage_PaymentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChatMessage_PaymentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChatMessage_PaymentRequest proto.InternalMessageInfo
+
+func (m *ChatMessage_PaymentRequest) GetCoin() string {
+	if m != nil {
+		return m.Coin
+	}
+	return ""
+}
+
+func (m *ChatMessage_PaymentRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ChatMessage_PaymentRequest) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
 type StoreMessage struct {
 	Cids                 [][]byte `protobuf:"bytes,1,rep,name=cids,proto3" json:"cids,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -434,10 +504,13 @@ func (m *OrderMessage) GetSignature() []byte {
 }
 
 type OrderList struct {
-	Messages             []*OrderMessage `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Messages []*OrderMessage `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	// Errors holds the processing error for the message at the same
+	// index. It is only used for errored messages.
+	Errors               []string `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *OrderList) Reset()         { *m = OrderList{} }
@@ -472,6 +545,13 @@ func (m *OrderList) GetMessages() []*OrderMessage {
 	return nil
 }
 
+func (m *OrderList) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
 type AddressRequestMessage struct {
 	Coin                 string   `protobuf:"bytes,1,opt,name=coin,proto3" json:"coin,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -558,6 +638,141 @@ func (m *AddressResponseMessage) GetCoin() string {
 	return ""
 }
 
+type InventoryRequestMessage struct {
+	Slug                 string   `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InventoryRequestMessage) Reset()         { *m = InventoryRequestMessage{} }
+func (m *InventoryRequestMessage) String() string { return proto.CompactTextString(m) }
+func (*InventoryRequestMessage) ProtoMessage()    {}
+func (*InventoryRequestMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{8}
+}
+
+func (m *InventoryRequestMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InventoryRequestMessage.Unmarshal(m, b)
+}
+func (m *InventoryRequestMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InventoryRequestMessage.Marshal(b, m, deterministic)
+}
+func (m *InventoryRequestMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InventoryRequestMessage.Merge(m, src)
+}
+func (m *InventoryRequestMessage) XXX_Size() int {
+	return xxx_messageInfo_InventoryRequestMessage.Size(m)
+}
+func (m *InventoryRequestMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_InventoryRequestMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InventoryRequestMessage proto.InternalMessageInfo
+
+func (m *InventoryRequestMessage) GetSlug() string {
+	if m != nil {
+		return m.Slug
+	}
+	return ""
+}
+
+// InventoryResponseMessage holds the stock available for each tracked
+// SKU of the listing. SKUs which are not tracked are left out.
+type InventoryResponseMessage struct {
+	Slug                 string                          `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Skus                 []*InventoryResponseMessage_Sku `protobuf:"bytes,2,rep,name=skus,proto3" json:"skus,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *InventoryResponseMessage) Reset()         { *m = InventoryResponseMessage{} }
+func (m *InventoryResponseMessage) String() string { return proto.CompactTextString(m) }
+func (*InventoryResponseMessage) ProtoMessage()    {}
+func (*InventoryResponseMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{9}
+}
+
+func (m *InventoryResponseMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InventoryResponseMessage.Unmarshal(m, b)
+}
+func (m *InventoryResponseMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InventoryResponseMessage.Marshal(b, m, deterministic)
+}
+func (m *InventoryResponseMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InventoryResponseMessage.Merge(m, src)
+}
+func (m *InventoryResponseMessage) XXX_Size() int {
+	return xxx_messageInfo_InventoryResponseMessage.Size(m)
+}
+func (m *InventoryResponseMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_InventoryResponseMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InventoryResponseMessage proto.InternalMessageInfo
+
+func (m *InventoryResponseMessage) GetSlug() string {
+	if m != nil {
+		return m.Slug
+	}
+	return ""
+}
+
+func (m *InventoryResponseMessage) GetSkus() []*InventoryResponseMessage_Sku {
+	if m != nil {
+		return m.Skus
+	}
+	return nil
+}
+
+type InventoryResponseMessage_Sku struct {
+	Sku                  string   `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Available            int64    `protobuf:"varint,2,opt,name=available,proto3" json:"available,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InventoryResponseMessage_Sku) Reset()         { *m = InventoryResponseMessage_Sku{} }
+func (m *InventoryResponseMessage_Sku) String() string { return proto.CompactTextString(m) }
+func (*InventoryResponseMessage_Sku) ProtoMessage()    {}
+func (*InventoryResponseMessage_Sku) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{9, 0}
+}
+
+func (m *InventoryResponseMessage_Sku) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InventoryResponseMessage_Sku.Unmarshal(m, b)
+}
+func (m *InventoryResponseMessage_Sku) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InventoryResponseMessage_Sku.Marshal(b, m, deterministic)
+}
+func (m *InventoryResponseMessage_Sku) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InventoryResponseMessage_Sku.Merge(m, src)
+}
+func (m *InventoryResponseMessage_Sku) XXX_Size() int {
+	return xxx_messageInfo_InventoryResponseMessage_Sku.Size(m)
+}
+func (m *InventoryResponseMessage_Sku) XXX_DiscardUnknown() {
+	xxx_messageInfo_InventoryResponseMessage_Sku.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InventoryResponseMessage_Sku proto.InternalMessageInfo
+
+func (m *InventoryResponseMessage_Sku) GetSku() string {
+	if m != nil {
+		return m.Sku
+	}
+	return ""
+}
+
+func (m *InventoryResponseMessage_Sku) GetAvailable() int64 {
+	if m != nil {
+		return m.Available
+	}
+	return 0
+}
+
 type Envelope struct {
 	SenderPubkey         []byte   `protobuf:"bytes,1,opt,name=senderPubkey,proto3" json:"senderPubkey,omitempty"`
 	Message              *Message `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
@@ -571,7 +786,7 @@ func (m *Envelope) Reset()         { *m = Envelope{} }
 func (m *Envelope) String() string { return proto.CompactTextString(m) }
 func (*Envelope) ProtoMessage()    {}
 func (*Envelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{8}
+	return fileDescriptor_33c57e4bae7b9afd, []int{10}
 }
 
 func (m *Envelope) XXX_Unmarshal(b []byte) error {
@@ -619,66 +834,80 @@ func init() {
 	proto.RegisterEnum("OrderMessage_MessageType", OrderMessage_MessageType_name, OrderMessage_MessageType_value)
 	proto.RegisterType((*Message)(nil), "Message")
 	proto.RegisterType((*ChatMessage)(nil), "ChatMessage")
+	proto.RegisterType((*ChatMessage_PaymentRequest)(nil), "ChatMessage.PaymentRequest")
 	proto.RegisterType((*StoreMessage)(nil), "StoreMessage")
 	proto.RegisterType((*AckMessage)(nil), "AckMessage")
 	proto.RegisterType((*OrderMessage)(nil), "OrderMessage")
 	proto.RegisterType((*OrderList)(nil), "OrderList")
 	proto.RegisterType((*AddressRequestMessage)(nil), "AddressRequestMessage")
 	proto.RegisterType((*AddressResponseMessage)(nil), "AddressResponseMessage")
+	proto.RegisterType((*InventoryRequestMessage)(nil), "InventoryRequestMessage")
+	proto.RegisterType((*InventoryResponseMessage)(nil), "InventoryResponseMessage")
+	proto.RegisterType((*InventoryResponseMessage_Sku)(nil), "InventoryResponseMessage.Sku")
 	proto.RegisterType((*Envelope)(nil), "Envelope")
 }
 
 func init() { proto.RegisterFile("message.proto", fileDescriptor_33c57e4bae7b9afd) }
 
 var fileDescriptor_33c57e4bae7b9afd = []byte{
-	// 770 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xed, 0x6e, 0xa3, 0x46,
-	0x14, 0x0d, 0xd8, 0x89, 0xe1, 0x42, 0xdc, 0xc9, 0x34, 0x1b, 0x79, 0xa3, 0x95, 0x6a, 0x21, 0xb5,
-	0xf2, 0xaa, 0x12, 0x2b, 0xb9, 0xd5, 0xaa, 0x52, 0x7f, 0x51, 0x33, 0xb8, 0xb4, 0x18, 0xe8, 0x80,
-	0x55, 0xed, 0xfe, 0x89, 0x48, 0x98, 0x75, 0xad, 0x38, 0x40, 0x01, 0x57, 0xf2, 0x23, 0xf4, 0x57,
-	0x9f, 0xa8, 0xea, 0xd3, 0xf4, 0x3d, 0xaa, 0x01, 0xc6, 0x1f, 0xa9, 0xb6, 0xfd, 0xe5, 0x39, 0xe7,
-	0x1e, 0xee, 0x5c, 0xce, 0x3d, 0x06, 0x2e, 0x9f, 0x58, 0x55, 0x25, 0x2b, 0x66, 0x16, 0x65, 0x5e,
-	0xe7, 0xb7, 0x2f, 0x57, 0x79, 0xbe, 0xda, 0xb0, 0x37, 0x0d, 0xba, 0xdf, 0x7e, 0x78, 0x93, 0x64,
-	0xbb, 0xae, 0xf4, 0xd9, 0xf3, 0x52, 0xbd, 0x7e, 0x62, 0x55, 0x9d, 0x3c, 0x15, 0xad, 0xc0, 0xf8,
-	0x53, 0x86, 0xc1, 0xa2, 0xed, 0x86, 0xdf, 0x82, 0xd6, 0x35, 0x8e, 0x77, 0x05, 0x1b, 0x49, 0x63,
-	0x69, 0x32, 0x9c, 0x5e, 0x9b, 0x5d, 0x59, 0xfc, 0xf2, 0x1a, 0x3d, 0x16, 0xe2, 0x57, 0xa0, 0x76,
-	0xd0, 0xb5, 0x47, 0xf2, 0x58, 0x9a, 0xa8, 0xf4, 0x40, 0xe0, 0x5b, 0x50, 0x2a, 0xf6, 0xeb, 0x96,
-	0x65, 0x0f, 0x6c, 0xd4, 0x1b, 0x4b, 0x93, 0x4b, 0xba, 0xc7, 0xd8, 0x84, 0x41, 0x91, 0xec, 0x36,
-	0x79, 0x92, 0x8e, 0xfa, 0x63, 0x69, 0xa2, 0x4d, 0xaf, 0xcd, 0x76, 0x60, 0x53, 0x0c, 0x6c, 0x5a,
-	0xd9, 0x8e, 0x0a, 0x91, 0xf1, 0x87, 0x04, 0xda, 0xd1, 0x18, 0x78, 0x00, 0x3d, 0x6b, 0xf6, 0x23,
-	0x3a, 0xc3, 0x0a, 0xf4, 0x43, 0xd7, 0x9f, 0x23, 0xa9, 0x39, 0x05, 0xfe, 0x1c, 0xc9, 0xfc, 0x34,
-	0xfb, 0xde, 0x8a, 0x51, 0x0f, 0x03, 0x5c, 0x38, 0x81, 0xe7, 0x05, 0x3f, 0xa3, 0x3e, 0xd6, 0x41,
-	0x59, 0xfa, 0x1d, 0x3a, 0xc7, 0x2a, 0x9c, 0x47, 0x71, 0x40, 0x09, 0xba, 0xe0, 0xc7, 0x80, 0xda,
-	0x84, 0xa2, 0x01, 0xfe, 0x14, 0x3e, 0xb1, 0x6c, 0x9b, 0x92, 0x28, 0xba, 0xa3, 0xe4, 0xa7, 0x25,
-	0x89, 0x62, 0xa4, 0xe0, 0x6b, 0x40, 0x07, 0x32, 0x0a, 0x03, 0x3f, 0x22, 0x48, 0x35, 0xfe, 0x96,
-	0x40, 0x9b, 0xfd, 0x92, 0xd4, 0xc2, 0xc3, 0x11, 0x0c, 0xf2, 0x32, 0x65, 0xa5, 0x6b, 0x37, 0xfe,
-	0xa9, 0x54, 0x40, 0x5e, 0xe9, 0x4c, 0xe9, 0x3c, 0x12, 0x10, 0x7f, 0x03, 0xea, 0x7e, 0x2d, 0x8d,
-	0x45, 0xda, 0xf4, 0xf6, 0x5f, 0x3e, 0xc4, 0x42, 0x41, 0x0f, 0x62, 0xfc, 0x39, 0xf4, 0x3f, 0x6c,
-	0x92, 0x55, 0x63, 0xde, 0x70, 0x7a, 0x65, 0x1e, 0x4d, 0x62, 0x3a, 0x9b, 0x64, 0x45, 0x9b, 0x32,
-	0xbe, 0x81, 0x8b, 0x92, 0x25, 0xa9, 0x6b, 0x8f, 0xce, 0x9b, 0x9b, 0x3b, 0x64, 0xbc, 0x86, 0x3e,
-	0x57, 0x61, 0x0d, 0x06, 0x0b, 0x12, 0x45, 0xd6, 0x9c, 0xa0, 0x33, 0x6e, 0x56, 0xfc, 0xee, 0x60,
-	0x26, 0x25, 0x96, 0x8d, 0x64, 0xc3, 0x00, 0x3d, 0xaa, 0xf3, 0x92, 0x89, 0xf7, 0xc4, 0xd0, 0x7f,
-	0x58, 0xa7, 0xd5, 0x48, 0x1a, 0xf7, 0x26, 0x3a, 0x6d, 0xce, 0xc6, 0xd7, 0x00, 0xd6, 0xc3, 0xa3,
-	0x50, 0x7c, 0x01, 0xc3, 0xe4, 0xe1, 0x91, 0xa5, 0x8b, 0x7d, 0x34, 0x5a, 0x43, 0x9e, 0xb1, 0xc6,
-	0x5f, 0x3d, 0xd0, 0x03, 0xee, 0xd1, 0xff, 0x5b, 0xf8, 0xed, 0x69, 0x40, 0xe5, 0xe6, 0xad, 0x5f,
-	0x9a, 0xc7, 0x4f, 0x7f, 0x3c, 0xa5, 0xe6, 0xc1, 0xff, 0xde, 0x7f, 0x65, 0x4d, 0x6c, 0xe5, 0x15,
-	0xa8, 0xd5, 0x7a, 0x95, 0x25, 0xf5, 0xb6, 0x64, 0x8d, 0xc1, 0x3a, 0x3d, 0x10, 0xc6, 0xef, 0xf2,
-	0x69, 0x12, 0x87, 0x00, 0x4d, 0x7a, 0xee, 0x82, 0x90, 0xf8, 0xe8, 0x0c, 0x23, 0xd0, 0x5b, 0x4c,
-	0xc9, 0x0f, 0x64, 0x16, 0x23, 0xe9, 0xc0, 0xcc, 0x2c, 0x7f, 0x46, 0x3c, 0x24, 0xe3, 0x1b, 0xc0,
-	0x1d, 0x13, 0xf8, 0x8e, 0x4b, 0x17, 0x56, 0xec, 0x06, 0x3e, 0xea, 0xe1, 0x17, 0x70, 0x45, 0xad,
-	0xd8, 0xf5, 0xe7, 0x77, 0x91, 0x3b, 0xf7, 0xad, 0x78, 0x49, 0x49, 0x84, 0xfa, 0x9c, 0x6e, 0xe5,
-	0xce, 0xd2, 0x73, 0x5c, 0xcf, 0x5b, 0x10, 0x3f, 0x46, 0xe7, 0x18, 0xc3, 0x50, 0x74, 0x59, 0x84,
-	0x1e, 0x89, 0x79, 0x96, 0x11, 0xe8, 0xb6, 0x1b, 0x85, 0xcb, 0x98, 0xb4, 0xf3, 0x0c, 0xb8, 0x4a,
-	0x30, 0xcb, 0xd0, 0xb6, 0x62, 0x82, 0x14, 0x7c, 0x05, 0x97, 0x82, 0x9b, 0x79, 0x01, 0x8f, 0x33,
-	0x5f, 0x3e, 0x25, 0xce, 0xd2, 0xb7, 0x11, 0xf0, 0x26, 0xa1, 0xf5, 0x8e, 0xdf, 0x72, 0x17, 0xf1,
-	0xab, 0x34, 0x3e, 0x81, 0x60, 0x1c, 0xd7, 0xb7, 0x3c, 0xf7, 0x3d, 0xb1, 0x91, 0x6e, 0xbc, 0x05,
-	0xb5, 0x59, 0x81, 0xb7, 0xae, 0x6a, 0xfc, 0x1a, 0x94, 0xce, 0xc1, 0x36, 0x1c, 0xda, 0xf4, 0xf2,
-	0x64, 0x41, 0x74, 0x5f, 0x36, 0xbe, 0x84, 0x17, 0x56, 0x9a, 0x96, 0xac, 0xaa, 0x28, 0xff, 0x20,
-	0x54, 0xf5, 0x71, 0xb8, 0xf2, 0x75, 0xd6, 0xad, 0xbf, 0x39, 0x1b, 0x0e, 0xdc, 0xec, 0xc5, 0x55,
-	0x91, 0x67, 0x15, 0x3b, 0xca, 0x4b, 0xd2, 0x56, 0x44, 0x5e, 0x3a, 0xb8, 0xef, 0x23, 0x1f, 0xf5,
-	0x29, 0x40, 0x21, 0xd9, 0x6f, 0x6c, 0x93, 0x17, 0x0c, 0x1b, 0xa0, 0x57, 0x2c, 0x4b, 0x59, 0x19,
-	0x6e, 0xef, 0x1f, 0xd9, 0xae, 0x79, 0x5c, 0xa7, 0x27, 0x1c, 0x36, 0x4e, 0xff, 0xb6, 0xda, 0x54,
-	0x11, 0x11, 0xfb, 0x48, 0x54, 0x7a, 0xcf, 0xa2, 0xf2, 0x5d, 0xff, 0xbd, 0x5c, 0xdc, 0xdf, 0x5f,
-	0x34, 0x29, 0xfb, 0xea, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x0c, 0x87, 0x32, 0xa3, 0xbc, 0x05,
-	0x00, 0x00,
+	// 932 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xdb, 0x8e, 0xe3, 0x44,
+	0x13, 0x1e, 0xc7, 0x99, 0x1c, 0xca, 0x9e, 0xfc, 0x3d, 0xfd, 0xcf, 0x0e, 0xd9, 0x61, 0x11, 0x23,
+	0x4b, 0xa0, 0xac, 0x10, 0x5e, 0x11, 0x0e, 0x42, 0xe2, 0xca, 0xc4, 0x9d, 0x60, 0x70, 0xec, 0xd0,
+	0x76, 0x16, 0xcd, 0xde, 0x8c, 0x9c, 0x49, 0x6f, 0x88, 0x92, 0xd8, 0xc1, 0x87, 0x91, 0x72, 0xcb,
+	0x1d, 0x6f, 0xc0, 0xd3, 0xc0, 0x0b, 0xf1, 0x10, 0xa8, 0xdb, 0x76, 0x12, 0x67, 0x19, 0xb8, 0xab,
+	0xc3, 0xe7, 0xaa, 0xea, 0xaa, 0xef, 0x33, 0x5c, 0x6c, 0x58, 0x92, 0x04, 0x0b, 0xa6, 0x6f, 0xe3,
+	0x28, 0x8d, 0x6e, 0x9e, 0x2f, 0xa2, 0x68, 0xb1, 0x66, 0xaf, 0x84, 0x37, 0xcb, 0xde, 0xbe, 0x0a,
+	0xc2, 0x5d, 0x91, 0xfa, 0xf0, 0x34, 0x95, 0x2e, 0x37, 0x2c, 0x49, 0x83, 0xcd, 0x36, 0x07, 0x68,
+	0x7f, 0xd5, 0xa0, 0x39, 0xce, 0xab, 0xe1, 0xaf, 0x40, 0x29, 0x0a, 0xfb, 0xbb, 0x2d, 0xeb, 0x4a,
+	0xb7, 0x52, 0xaf, 0xd3, 0xbf, 0xd2, 0x8b, 0xb4, 0x3e, 0x3e, 0xe4, 0xe8, 0x31, 0x10, 0xbf, 0x80,
+	0x76, 0xe1, 0x5a, 0x66, 0xb7, 0x76, 0x2b, 0xf5, 0xda, 0xf4, 0x10, 0xc0, 0x37, 0xd0, 0x4a, 0xd8,
+	0x2f, 0x19, 0x0b, 0x1f, 0x58, 0x57, 0xbe, 0x95, 0x7a, 0x17, 0x74, 0xef, 0x63, 0x1d, 0x9a, 0xdb,
+	0x60, 0xb7, 0x8e, 0x82, 0x79, 0xb7, 0x7e, 0x2b, 0xf5, 0x94, 0xfe, 0x95, 0x9e, 0x0f, 0xac, 0x97,
+	0x03, 0xeb, 0x46, 0xb8, 0xa3, 0x25, 0x48, 0xfb, 0x43, 0x02, 0xe5, 0x68, 0x0c, 0xdc, 0x04, 0xd9,
+	0x18, 0xfc, 0x80, 0xce, 0x70, 0x0b, 0xea, 0x13, 0xcb, 0x19, 0x21, 0x49, 0x58, 0xae, 0x33, 0x42,
+	0x35, 0x6e, 0x0d, 0xbe, 0x33, 0x7c, 0x24, 0x63, 0x80, 0xc6, 0xd0, 0xb5, 0x6d, 0xf7, 0x27, 0x54,
+	0xc7, 0x2a, 0xb4, 0xa6, 0x4e, 0xe1, 0x9d, 0xe3, 0x36, 0x9c, 0x7b, 0xbe, 0x4b, 0x09, 0x6a, 0x70,
+	0xd3, 0xa5, 0x26, 0xa1, 0xa8, 0x89, 0xff, 0x0f, 0xff, 0x33, 0x4c, 0x93, 0x12, 0xcf, 0xbb, 0xa7,
+	0xe4, 0xc7, 0x29, 0xf1, 0x7c, 0xd4, 0xc2, 0x57, 0x80, 0x0e, 0x41, 0x6f, 0xe2, 0x3a, 0x1e, 0x41,
+	0x6d, 0xfc, 0x0c, 0x2e, 0x2d, 0xe7, 0x35, 0x71, 0x7c, 0x97, 0xde, 0xed, 0xc1, 0x80, 0xaf, 0x01,
+	0x1f, 0x87, 0x0b, 0xb8, 0xa2, 0xfd, 0x2a, 0x83, 0x32, 0xf8, 0x39, 0x48, 0xcb, 0x95, 0x77, 0xa1,
+	0x19, 0xc5, 0x73, 0x16, 0x5b, 0xa6, 0x58, 0x77, 0x9b, 0x96, 0x2e, 0xcf, 0x14, 0x3b, 0x2c, 0x56,
+	0x5a, 0xba, 0xf8, 0x6b, 0x68, 0xef, 0xaf, 0x28, 0x36, 0xaa, 0xf4, 0x6f, 0xde, 0x59, 0x9b, 0x5f,
+	0x22, 0xe8, 0x01, 0x8c, 0x3f, 0x82, 0xfa, 0xdb, 0x75, 0xb0, 0x10, 0xbb, 0xee, 0xf4, 0x2f, 0xf5,
+	0xa3, 0x49, 0xf4, 0xe1, 0x3a, 0x58, 0x50, 0x91, 0xc6, 0xd7, 0xd0, 0x88, 0x59, 0x30, 0xb7, 0xcc,
+	0xee, 0xb9, 0xe8, 0x5c, 0x78, 0x78, 0x00, 0x9d, 0x6d, 0xb0, 0xdb, 0xb0, 0x30, 0xa5, 0xfc, 0x80,
+	0x49, 0xda, 0x6d, 0x88, 0xee, 0xef, 0x57, 0x0a, 0x4d, 0x2a, 0x10, 0x7a, 0xf2, 0xc9, 0xcd, 0x6b,
+	0xe8, 0x54, 0x11, 0x18, 0x43, 0xfd, 0x21, 0x5a, 0x86, 0xc5, 0x02, 0x84, 0xcd, 0x5f, 0x1f, 0xcc,
+	0xe7, 0x31, 0x4b, 0x92, 0xf2, 0xf5, 0x85, 0xcb, 0x87, 0x0b, 0x36, 0x51, 0x16, 0xa6, 0xe2, 0xe9,
+	0x6d, 0x5a, 0x78, 0xda, 0x4b, 0xa8, 0xf3, 0x27, 0x60, 0x05, 0x9a, 0x63, 0xe2, 0x79, 0xc6, 0x88,
+	0xa0, 0x33, 0x7e, 0x78, 0xff, 0xee, 0x40, 0x0c, 0x4a, 0x0c, 0x13, 0xd5, 0x34, 0x0d, 0x54, 0x2f,
+	0x8d, 0x62, 0x56, 0x1e, 0x81, 0x0f, 0xb0, 0x9c, 0x27, 0x5d, 0xe9, 0x56, 0xee, 0xa9, 0x54, 0xd8,
+	0xda, 0x17, 0x00, 0xc6, 0xc3, 0xaa, 0x44, 0x7c, 0x0c, 0x9d, 0xe0, 0x61, 0xc5, 0xe6, 0xe3, 0x3d,
+	0xcd, 0xf3, 0x61, 0x4f, 0xa2, 0xda, 0x9f, 0x32, 0xa8, 0x2e, 0x3f, 0xe0, 0x7f, 0xdf, 0xf7, 0x9b,
+	0xaa, 0xd8, 0x6a, 0xe2, 0x24, 0xcf, 0xf5, 0xe3, 0xaf, 0x9f, 0x56, 0x9c, 0x7e, 0x20, 0x87, 0xfc,
+	0x6f, 0xba, 0x29, 0x29, 0xf3, 0x02, 0xda, 0xc9, 0x72, 0x11, 0x06, 0x69, 0x16, 0x33, 0x71, 0x7d,
+	0x95, 0x1e, 0x02, 0xda, 0x6f, 0xb5, 0xaa, 0xaa, 0x3a, 0x00, 0x42, 0x09, 0xf7, 0xee, 0x84, 0x38,
+	0xe8, 0x0c, 0x23, 0x50, 0x73, 0x9f, 0x92, 0xef, 0xc9, 0xc0, 0x47, 0xd2, 0x21, 0x32, 0x30, 0x9c,
+	0x01, 0xb1, 0x51, 0x8d, 0x13, 0xbe, 0x88, 0xb8, 0xce, 0xd0, 0xa2, 0x63, 0xc3, 0xb7, 0x5c, 0x07,
+	0xc9, 0x5c, 0x1f, 0xd4, 0xf0, 0x2d, 0x67, 0x74, 0xef, 0x59, 0x23, 0xc7, 0xf0, 0xa7, 0x94, 0x78,
+	0xa8, 0xce, 0xc3, 0x39, 0x7c, 0x38, 0xb5, 0x87, 0x96, 0x6d, 0x8f, 0x89, 0xe3, 0xa3, 0x73, 0x8c,
+	0xa1, 0x53, 0x56, 0x19, 0x4f, 0x6c, 0xe2, 0x73, 0x5d, 0x22, 0x50, 0x4d, 0xcb, 0x9b, 0x4c, 0x7d,
+	0x92, 0xcf, 0xd3, 0xe4, 0xa8, 0x32, 0x32, 0x9d, 0x98, 0x86, 0x4f, 0x50, 0x0b, 0x5f, 0xc2, 0x45,
+	0x19, 0x1b, 0xd8, 0xae, 0x90, 0x26, 0x40, 0x83, 0x92, 0xe1, 0xd4, 0x31, 0x11, 0xf0, 0x22, 0x13,
+	0xe3, 0x8e, 0x77, 0xb9, 0xf7, 0x78, 0x2b, 0x85, 0x4f, 0x50, 0x46, 0x86, 0x96, 0x63, 0xd8, 0xd6,
+	0x1b, 0x62, 0x22, 0x55, 0x73, 0xa0, 0x2d, 0x4e, 0x60, 0x2f, 0x93, 0x14, 0xbf, 0x84, 0x56, 0xb1,
+	0xc1, 0x9c, 0x1c, 0x4a, 0xff, 0xa2, 0x72, 0x20, 0xba, 0x4f, 0x73, 0x5a, 0xb2, 0x38, 0x8e, 0x62,
+	0xce, 0x57, 0x99, 0xd3, 0x32, 0xf7, 0xb4, 0x4f, 0xe0, 0x99, 0x91, 0x33, 0xb7, 0xa0, 0xfb, 0x31,
+	0xe9, 0x4e, 0x58, 0xaf, 0x0d, 0xe1, 0x7a, 0x0f, 0x4e, 0xb6, 0x51, 0x98, 0xb0, 0x23, 0x1e, 0x95,
+	0x7a, 0x90, 0xaa, 0x7a, 0x28, 0xeb, 0xd4, 0x8e, 0xea, 0x7c, 0x0a, 0xef, 0x59, 0xe1, 0x23, 0x0b,
+	0xd3, 0x28, 0xde, 0xbd, 0xdb, 0x36, 0x59, 0x67, 0x8b, 0xb2, 0x2d, 0xb7, 0xb5, 0xdf, 0x25, 0xe8,
+	0x1e, 0xe1, 0xab, 0x9d, 0xff, 0xe1, 0x03, 0xfc, 0x19, 0xd4, 0x93, 0x55, 0x96, 0x3f, 0x55, 0xe9,
+	0x7f, 0xa0, 0x3f, 0xf5, 0xb1, 0xee, 0xad, 0x32, 0x2a, 0xa0, 0x37, 0x5f, 0x82, 0xec, 0xad, 0x32,
+	0x8c, 0x40, 0x4e, 0x56, 0x59, 0x51, 0x8c, 0x9b, 0x9c, 0x9a, 0xc1, 0x63, 0xb0, 0x5c, 0x07, 0xb3,
+	0x75, 0xae, 0x02, 0x99, 0x1e, 0x02, 0xda, 0x16, 0x5a, 0x24, 0x7c, 0x64, 0xeb, 0x68, 0xcb, 0xb0,
+	0x06, 0x6a, 0xc2, 0xc2, 0x39, 0x8b, 0x27, 0xd9, 0x6c, 0xc5, 0x76, 0xa2, 0x88, 0x4a, 0x2b, 0x31,
+	0xac, 0x55, 0xff, 0x9a, 0x4a, 0xbf, 0x55, 0x8a, 0xe8, 0x09, 0x31, 0xc8, 0x27, 0x62, 0xf8, 0xb6,
+	0xfe, 0xa6, 0xb6, 0x9d, 0xcd, 0x1a, 0x42, 0x47, 0x9f, 0xff, 0x3d, 0x00, 0xd3, 0xfe, 0xec, 0x21,
+	0x6a, 0x07, 0x00, 0x00,
 }
@@ -0,0 +1,518 @@
+package notifications
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/jinzhu/gorm"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+const (
+	// EmailSecurityNone sends emails over an unencrypted connection.
+	EmailSecurityNone = "none"
+
+	// EmailSecurityStartTLS upgrades the connection to the SMTP server
+	// using STARTTLS.
+	EmailSecurityStartTLS = "starttls"
+
+	// EmailSecurityTLS connects to the SMTP server over TLS.
+	EmailSecurityTLS = "tls"
+
+	emailTimeout = time.Second * 30
+
+	// emailCheckInterval is how often the queued emails are checked to
+	// see if any are ready to send.
+	emailCheckInterval = time.Second * 5
+
+	// defaultEmailRetryInterval is the delay before retrying after the
+	// pending emails fail to send. The delay doubles with each further
+	// failure.
+	defaultEmailRetryInterval = time.Second * 30
+
+	// maxEmailRetryInterval caps the delay between attempts.
+	maxEmailRetryInterval = time.Hour
+
+	// maxPendingEmails is the most emails kept waiting to be sent. The
+	// oldest are dropped if the SMTP server is unreachable for long
+	// enough to exceed it.
+	maxPendingEmails = 100
+
+	digestTemplate = "digest"
+)
+
+// EmailNotificationTypes are the event types which can be sent as emails.
+var EmailNotificationTypes = []string{
+	"NewOrder",
+	"OrderFunded",
+	"DisputeOpen",
+	"ChatMessage",
+}
+
+// defaultEmailTemplates are used for any template which is not overridden
+// in the template directory. Each template must define a subject and a
+// body. The event templates are executed with the event and the digest
+// template with a list of the rendered emails.
+var defaultEmailTemplates = map[string]string{
+	"NewOrder": `{{define "subject"}}New order for {{.Title}}{{end}}
+{{define "body"}}You received a new order for {{.Title}} from {{if .BuyerHandle}}{{.BuyerHandle}}{{else}}{{.BuyerID}}{{end}}.
+
+Order ID: {{.OrderID}}
+{{end}}`,
+	"OrderFunded": `{{define "subject"}}Order for {{.Title}} funded{{end}}
+{{define "body"}}The order for {{.Title}} from {{if .BuyerHandle}}{{.BuyerHandle}}{{else}}{{.BuyerID}}{{end}} has been funded.
+
+Order ID: {{.OrderID}}
+{{end}}`,
+	"DisputeOpen": `{{define "subject"}}Dispute opened on order {{.OrderID}}{{end}}
+{{define "body"}}{{if .DisputerHandle}}{{.DisputerHandle}}{{else}}{{.DisputerID}}{{end}} opened a dispute on order {{.OrderID}}.
+{{end}}`,
+	"ChatMessage": `{{define "subject"}}New message from {{.PeerID}}{{end}}
+{{define "body"}}{{.PeerID}} sent you a message{{if .OrderID}} about order {{.OrderID}}{{end}}:
+
+{{.Message}}
+{{end}}`,
+	digestTemplate: `{{define "subject"}}{{len .}} new notifications{{end}}
+{{define "body"}}{{range .}}{{.Subject}}
+
+{{.Body}}
+----------------------------------------
+
+{{end}}{{end}}`,
+}
+
+// Email is a rendered email. The digest template is executed with a list
+// of these.
+type Email struct {
+	Subject string
+	Body    string
+}
+
+// queuedChat is a chat message email waiting to see if the message is
+// read before it is sent.
+type queuedChat struct {
+	email     Email
+	messageID string
+	due       time.Time
+}
+
+// EmailConfig holds the options for an EmailNotifier.
+type EmailConfig struct {
+	DB database.Database
+
+	// Server is the host:port of the SMTP server.
+	Server   string
+	Username string
+	Password string
+	From     string
+
+	// Security is one of EmailSecurityNone, EmailSecurityStartTLS or
+	// EmailSecurityTLS.
+	Security string
+
+	// DigestInterval is how long to collect emails for before sending
+	// them. If more than one email is collected they are sent as a
+	// single digest.
+	DigestInterval time.Duration
+
+	// ChatDelay is how long to wait before emailing a chat message. The
+	// email is only sent if the message is still unread.
+	ChatDelay time.Duration
+
+	// TemplateDir is checked for <type>.tmpl files which override the
+	// default templates.
+	TemplateDir string
+}
+
+// EmailNotifier sends emails for events to the address in the
+// EmailNotifications preference.
+type EmailNotifier struct {
+	db             database.Database
+	server         string
+	host           string
+	username       string
+	password       string
+	from           string
+	security       string
+	tlsConfig      *tls.Config
+	digestInterval time.Duration
+	chatDelay      time.Duration
+	checkInterval  time.Duration
+	retryInterval  time.Duration
+	templates      map[string]*template.Template
+
+	mtx         sync.Mutex
+	pending     []Email
+	digestStart time.Time
+	chats       []queuedChat
+	sending     int
+	failures    int
+	retryAt     time.Time
+
+	shutdown chan struct{}
+}
+
+// NewEmailNotifier returns a new EmailNotifier.
+func NewEmailNotifier(cfg *EmailConfig) (*EmailNotifier, error) {
+	host, _, err := net.SplitHostPort(cfg.Server)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP server: %s", err)
+	}
+	switch cfg.Security {
+	case EmailSecurityNone, EmailSecurityStartTLS, EmailSecurityTLS:
+	default:
+		return nil, fmt.Errorf("unknown SMTP security %s", cfg.Security)
+	}
+	if cfg.From == "" {
+		return nil, fmt.Errorf("an email from address is required")
+	}
+	templates, err := loadEmailTemplates(cfg.TemplateDir)
+	if err != nil {
+		return nil, err
+	}
+	return &EmailNotifier{
+		db:             cfg.DB,
+		server:         cfg.Server,
+		host:           host,
+		username:       cfg.Username,
+		password:       cfg.Password,
+		from:           cfg.From,
+		security:       cfg.Security,
+		tlsConfig:      &tls.Config{ServerName: host},
+		digestInterval: cfg.DigestInterval,
+		chatDelay:      cfg.ChatDelay,
+		checkInterval:  emailCheckInterval,
+		retryInterval:  defaultEmailRetryInterval,
+		templates:      templates,
+		shutdown:       make(chan struct{}),
+	}, nil
+}
+
+// loadEmailTemplates parses the default templates replacing any which have
+// an override in the template directory.
+func loadEmailTemplates(dir string) (map[string]*template.Template, error) {
+	templates := make(map[string]*template.Template)
+	for name, text := range defaultEmailTemplates {
+		if dir != "" {
+			override, err := ioutil.ReadFile(path.Join(dir, name+".tmpl"))
+			if err == nil {
+				text = string(override)
+			} else if !os.IsNotExist(err) {
+				return nil, err
+			}
+		}
+		tmpl, err := template.New(name).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("email template %s: %s", name, err)
+		}
+		if tmpl.Lookup("subject") == nil || tmpl.Lookup("body") == nil {
+			return nil, fmt.Errorf("email template %s must define a subject and body", name)
+		}
+		templates[name] = tmpl
+	}
+	return templates, nil
+}
+
+// Enqueue queues an email for the event if its type is one the user has
+// opted in to.
+func (e *EmailNotifier) Enqueue(eventType string, event interface{}) error {
+	if _, ok := e.templates[eventType]; !ok || eventType == digestTemplate {
+		return nil
+	}
+	if chat, ok := event.(*events.ChatMessage); ok && chat.Outgoing {
+		return nil
+	}
+	to, types, err := e.preferences()
+	if err != nil {
+		return err
+	}
+	if to == "" {
+		return nil
+	}
+	if len(types) > 0 {
+		optedIn := false
+		for _, typ := range types {
+			if typ == eventType {
+				optedIn = true
+				break
+			}
+		}
+		if !optedIn {
+			return nil
+		}
+	}
+
+	email, err := e.render(eventType, event)
+	if err != nil {
+		return err
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if chat, ok := event.(*events.ChatMessage); ok {
+		e.chats = append(e.chats, queuedChat{
+			email:     email,
+			messageID: chat.MessageID,
+			due:       time.Now().Add(e.chatDelay),
+		})
+		return nil
+	}
+	e.queue(email)
+	return nil
+}
+
+// queue adds the email to the next digest. The mutex must be held.
+func (e *EmailNotifier) queue(email Email) {
+	if len(e.pending) == 0 {
+		e.digestStart = time.Now()
+	}
+	if len(e.pending) >= maxPendingEmails {
+		log.Warningf("Dropping email notification %q as too many are waiting to be sent", e.pending[0].Subject)
+		e.pending = e.pending[1:]
+		if e.sending > 0 {
+			e.sending--
+		}
+	}
+	e.pending = append(e.pending, email)
+}
+
+// Start sends the queued emails until the notifier is stopped. This
+// should use it's own goroutine.
+func (e *EmailNotifier) Start() {
+	ticker := time.NewTicker(e.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := e.flush(time.Now()); err != nil {
+				log.Errorf("Error sending email notification: %s", err)
+			}
+		case <-e.shutdown:
+			return
+		}
+	}
+}
+
+// Stop shuts down the notifier.
+func (e *EmailNotifier) Stop() {
+	close(e.shutdown)
+}
+
+// flush queues the chat emails which are due and whose messages are still
+// unread, then sends the pending emails if the digest interval has passed.
+// The emails stay pending until they are sent. If sending fails it is not
+// tried again until the retry backoff has passed.
+func (e *EmailNotifier) flush(now time.Time) error {
+	e.mtx.Lock()
+	var due []queuedChat
+	for i := 0; i < len(e.chats); i++ {
+		if !e.chats[i].due.After(now) {
+			due = append(due, e.chats[i])
+			e.chats = append(e.chats[:i], e.chats[i+1:]...)
+			i--
+		}
+	}
+	e.mtx.Unlock()
+
+	for _, chat := range due {
+		var count int
+		err := e.db.View(func(tx database.Tx) error {
+			return tx.Read().Model(&models.ChatMessage{}).Where("message_id = ? AND read = ?", chat.messageID, true).Count(&count).Error
+		})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			e.mtx.Lock()
+			e.queue(chat.email)
+			e.mtx.Unlock()
+		}
+	}
+
+	e.mtx.Lock()
+	if len(e.pending) == 0 || now.Sub(e.digestStart) < e.digestInterval || now.Before(e.retryAt) {
+		e.mtx.Unlock()
+		return nil
+	}
+	emails := e.pending
+	e.sending = len(emails)
+	e.mtx.Unlock()
+
+	if err := e.sendEmails(emails); err != nil {
+		e.mtx.Lock()
+		e.sending = 0
+		e.failures++
+		e.retryAt = now.Add(e.backoff(e.failures))
+		e.mtx.Unlock()
+		return err
+	}
+	e.sent(now)
+	return nil
+}
+
+// sendEmails sends the emails to the address in the user's preferences.
+// More than one email is sent as a digest. If email notifications have been
+// turned off the emails are dropped.
+func (e *EmailNotifier) sendEmails(emails []Email) error {
+	to, _, err := e.preferences()
+	if err != nil {
+		return err
+	}
+	if to == "" {
+		return nil
+	}
+
+	email := emails[0]
+	if len(emails) > 1 {
+		email, err = e.render(digestTemplate, emails)
+		if err != nil {
+			return err
+		}
+	}
+	return e.send(to, email)
+}
+
+// sent removes the pending emails which have been sent and resets the
+// retry backoff. Any emails queued while they were being sent start a new
+// digest.
+func (e *EmailNotifier) sent(now time.Time) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.pending = e.pending[e.sending:]
+	e.sending = 0
+	if len(e.pending) == 0 {
+		e.pending = nil
+	} else {
+		e.digestStart = now
+	}
+	e.failures = 0
+	e.retryAt = time.Time{}
+}
+
+// backoff returns the delay before the next attempt after the given
+// number of failed attempts.
+func (e *EmailNotifier) backoff(failures int) time.Duration {
+	wait := e.retryInterval
+	for i := 1; i < failures; i++ {
+		wait *= 2
+		if wait >= maxEmailRetryInterval {
+			return maxEmailRetryInterval
+		}
+	}
+	return wait
+}
+
+// preferences returns the email address and types from the user's
+// preferences.
+func (e *EmailNotifier) preferences() (string, []string, error) {
+	var prefs models.UserPreferences
+	err := e.db.View(func(tx database.Tx) error {
+		return tx.Read().First(&prefs).Error
+	})
+	if gorm.IsRecordNotFoundError(err) {
+		return "", nil, nil
+	} else if err != nil {
+		return "", nil, err
+	}
+	types, err := prefs.EmailNotificationTypes()
+	if err != nil {
+		return "", nil, err
+	}
+	return prefs.EmailNotifications, types, nil
+}
+
+// render executes the named template.
+func (e *EmailNotifier) render(name string, data interface{}) (Email, error) {
+	var subject, body bytes.Buffer
+	tmpl := e.templates[name]
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Email{}, err
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return Email{}, err
+	}
+	return Email{
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Body:    strings.TrimSpace(body.String()),
+	}, nil
+}
+
+// send delivers the email to the SMTP server.
+func (e *EmailNotifier) send(to string, email Email) error {
+	var (
+		conn net.Conn
+		err  error
+	)
+	dialer := &net.Dialer{Timeout: emailTimeout}
+	if e.security == EmailSecurityTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", e.server, e.tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", e.server)
+	}
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(emailTimeout))
+
+	c, err := smtp.NewClient(conn, e.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if e.security == EmailSecurityStartTLS {
+		if err := c.StartTLS(e.tlsConfig); err != nil {
+			return err
+		}
+	}
+	if e.username != "" {
+		if err := c.Auth(smtp.PlainAuth("", e.username, e.password, e.host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(e.from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(buildEmailMessage(e.from, to, email)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// buildEmailMessage returns the email with its headers.
+func buildEmailMessage(from, to string, email Email) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.Replace(email.Body, "\n", "\r\n", -1))
+	msg.WriteString("\r\n")
+	return msg.Bytes()
+}
@@ -0,0 +1,510 @@
+package notifications
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/repo"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/textproto"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smtpMessage is a message received by the smtpServer.
+type smtpMessage struct {
+	from string
+	to   string
+	auth string
+	tls  bool
+	data string
+}
+
+// smtpServer is a minimal SMTP server which records the messages sent
+// to it.
+type smtpServer struct {
+	ln          net.Listener
+	tlsConfig   *tls.Config
+	implicitTLS bool
+	startTLS    bool
+	received    chan smtpMessage
+}
+
+func newSMTPServer(t *testing.T, security string) (*smtpServer, *tls.Config) {
+	cert, pool := newTestCertificate(t)
+	s := &smtpServer{
+		tlsConfig:   &tls.Config{Certificates: []tls.Certificate{cert}},
+		implicitTLS: security == EmailSecurityTLS,
+		startTLS:    security == EmailSecurityStartTLS,
+		received:    make(chan smtpMessage, 10),
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.implicitTLS {
+		ln = tls.NewListener(ln, s.tlsConfig)
+	}
+	s.ln = ln
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.handle(conn)
+		}
+	}()
+	return s, &tls.Config{RootCAs: pool, ServerName: "127.0.0.1"}
+}
+
+func (s *smtpServer) Close() {
+	s.ln.Close()
+}
+
+func (s *smtpServer) handle(conn net.Conn) {
+	defer conn.Close()
+	var (
+		tp  = textproto.NewConn(conn)
+		msg = smtpMessage{tls: s.implicitTLS}
+	)
+	tp.PrintfLine("220 127.0.0.1 ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.Fields(line + " ")[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250-127.0.0.1")
+			if s.startTLS && !msg.tls {
+				tp.PrintfLine("250-STARTTLS")
+			}
+			tp.PrintfLine("250 AUTH PLAIN")
+		case "STARTTLS":
+			tp.PrintfLine("220 Ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn, tp, msg.tls = tlsConn, textproto.NewConn(tlsConn), true
+		case "AUTH":
+			fields := strings.Fields(line)
+			decoded, _ := base64.StdEncoding.DecodeString(fields[len(fields)-1])
+			msg.auth = string(decoded)
+			tp.PrintfLine("235 Authenticated")
+		case "MAIL":
+			msg.from = line[strings.Index(line, "<")+1 : strings.Index(line, ">")]
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			msg.to = line[strings.Index(line, "<")+1 : strings.Index(line, ">")]
+			tp.PrintfLine("250 OK")
+		case "DATA":
+			tp.PrintfLine("354 Go ahead")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.data = string(data)
+			tp.PrintfLine("250 OK")
+			s.received <- msg
+		case "QUIT":
+			tp.PrintfLine("221 Bye")
+			return
+		default:
+			tp.PrintfLine("250 OK")
+		}
+	}
+}
+
+func (s *smtpServer) wait(t *testing.T) smtpMessage {
+	select {
+	case msg := <-s.received:
+		return msg
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timed out waiting on email")
+	}
+	return smtpMessage{}
+}
+
+// subject returns the subject header of the message.
+func (m smtpMessage) subject() string {
+	r := textproto.NewReader(bufio.NewReader(strings.NewReader(m.data)))
+	header, _ := r.ReadMIMEHeader()
+	return header.Get("Subject")
+}
+
+func newTestCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(parsed)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pool
+}
+
+func newTestEmailNotifier(t *testing.T, db database.Database, server *smtpServer, tlsConfig *tls.Config, security, username string) *EmailNotifier {
+	e, err := NewEmailNotifier(&EmailConfig{
+		DB:       db,
+		Server:   server.ln.Addr().String(),
+		Username: username,
+		Password: "letmein",
+		From:     "store@example.com",
+		Security: security,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.tlsConfig = tlsConfig
+	return e
+}
+
+func saveEmailPreferences(t *testing.T, db database.Database, address string, types string) {
+	err := db.Update(func(tx database.Tx) error {
+		prefs := &models.UserPreferences{
+			ID:                 1,
+			EmailNotifications: address,
+		}
+		if types != "" {
+			prefs.EmailTypes = []byte(types)
+		}
+		return tx.Save(prefs)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEmailNotifier(t *testing.T) {
+	tests := []struct {
+		security string
+		username string
+	}{
+		{security: EmailSecurityNone},
+		{security: EmailSecurityStartTLS, username: "store"},
+		{security: EmailSecurityTLS, username: "store"},
+	}
+	for _, test := range tests {
+		db, err := repo.MockDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+		saveEmailPreferences(t, db, "vendor@example.com", "")
+
+		server, tlsConfig := newSMTPServer(t, test.security)
+		e := newTestEmailNotifier(t, db, server, tlsConfig, test.security, test.username)
+
+		if err := e.Enqueue("NewOrder", &events.NewOrder{Title: "Ron Swanson Shirt", BuyerHandle: "@tom", OrderID: "1234"}); err != nil {
+			t.Fatal(err)
+		}
+		// Events which can't be emailed are ignored.
+		if err := e.Enqueue("Follow", &events.Follow{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := e.flush(time.Now()); err != nil {
+			t.Fatalf("%s: %s", test.security, err)
+		}
+
+		msg := server.wait(t)
+		if msg.from != "store@example.com" || msg.to != "vendor@example.com" {
+			t.Errorf("%s: incorrect addresses: from %s to %s", test.security, msg.from, msg.to)
+		}
+		if msg.subject() != "New order for Ron Swanson Shirt" {
+			t.Errorf("%s: incorrect subject: %s", test.security, msg.subject())
+		}
+		if !strings.Contains(msg.data, "from @tom") || !strings.Contains(msg.data, "Order ID: 1234") {
+			t.Errorf("%s: incorrect body: %s", test.security, msg.data)
+		}
+		if msg.tls != (test.security != EmailSecurityNone) {
+			t.Errorf("%s: expected tls %t", test.security, test.security != EmailSecurityNone)
+		}
+		if test.username != "" && msg.auth != "\x00store\x00letmein" {
+			t.Errorf("%s: incorrect auth: %q", test.security, msg.auth)
+		}
+		server.Close()
+	}
+}
+
+func TestEmailNotifier_Digest(t *testing.T) {
+	db, err := repo.MockDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saveEmailPreferences(t, db, "vendor@example.com", "")
+
+	server, tlsConfig := newSMTPServer(t, EmailSecurityNone)
+	defer server.Close()
+	e := newTestEmailNotifier(t, db, server, tlsConfig, EmailSecurityNone, "")
+	e.digestInterval = time.Hour
+
+	if err := e.Enqueue("NewOrder", &events.NewOrder{Title: "Ron Swanson Shirt"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Enqueue("OrderFunded", &events.OrderFunded{Title: "Ron Swanson Shirt"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing is sent until the digest interval has passed.
+	if err := e.flush(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if len(e.pending) != 2 {
+		t.Fatalf("Expected 2 pending emails, got %d", len(e.pending))
+	}
+
+	if err := e.flush(time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	msg := server.wait(t)
+	if msg.subject() != "2 new notifications" {
+		t.Errorf("Incorrect subject: %s", msg.subject())
+	}
+	if !strings.Contains(msg.data, "New order for Ron Swanson Shirt") || !strings.Contains(msg.data, "Order for Ron Swanson Shirt funded") {
+		t.Errorf("Digest is missing notifications: %s", msg.data)
+	}
+	if len(e.pending) != 0 {
+		t.Errorf("Expected no pending emails, got %d", len(e.pending))
+	}
+}
+
+func TestEmailNotifier_Retry(t *testing.T) {
+	db, err := repo.MockDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saveEmailPreferences(t, db, "vendor@example.com", "")
+
+	// The first server is down when the email is sent.
+	down, tlsConfig := newSMTPServer(t, EmailSecurityNone)
+	down.Close()
+	e := newTestEmailNotifier(t, db, down, tlsConfig, EmailSecurityNone, "")
+
+	if err := e.Enqueue("NewOrder", &events.NewOrder{Title: "Ron Swanson Shirt"}); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	if err := e.flush(now); err == nil {
+		t.Fatal("Expected sending to fail")
+	}
+	if len(e.pending) != 1 {
+		t.Fatalf("Expected 1 pending email, got %d", len(e.pending))
+	}
+
+	server, _ := newSMTPServer(t, EmailSecurityNone)
+	defer server.Close()
+	e.server = server.ln.Addr().String()
+
+	// Nothing is sent until the retry backoff has passed.
+	if err := e.flush(now.Add(e.retryInterval / 2)); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-server.received:
+		t.Fatal("Email sent before the retry backoff passed")
+	default:
+	}
+
+	if err := e.flush(now.Add(e.retryInterval)); err != nil {
+		t.Fatal(err)
+	}
+	msg := server.wait(t)
+	if msg.subject() != "New order for Ron Swanson Shirt" {
+		t.Errorf("Incorrect subject: %s", msg.subject())
+	}
+	if len(e.pending) != 0 || e.failures != 0 {
+		t.Errorf("Expected no pending emails or failures, got %d and %d", len(e.pending), e.failures)
+	}
+}
+
+func TestEmailNotifier_sent(t *testing.T) {
+	e := &EmailNotifier{
+		pending:  []Email{{Subject: "a"}, {Subject: "b"}, {Subject: "c"}},
+		sending:  2,
+		failures: 1,
+		retryAt:  time.Now(),
+	}
+
+	// The email queued while the first two were being sent is kept.
+	e.sent(time.Now())
+	if len(e.pending) != 1 || e.pending[0].Subject != "c" {
+		t.Errorf("Incorrect pending emails: %v", e.pending)
+	}
+	if e.sending != 0 || e.failures != 0 || !e.retryAt.IsZero() {
+		t.Error("Expected the send state to be reset")
+	}
+}
+
+func TestEmailNotifier_backoff(t *testing.T) {
+	e := &EmailNotifier{retryInterval: time.Second}
+	tests := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{1, time.Second},
+		{2, time.Second * 2},
+		{5, time.Second * 16},
+		{100, maxEmailRetryInterval},
+	}
+	for _, test := range tests {
+		if wait := e.backoff(test.failures); wait != test.expected {
+			t.Errorf("%d failures: expected %s, got %s", test.failures, test.expected, wait)
+		}
+	}
+}
+
+func TestEmailNotifier_Preferences(t *testing.T) {
+	db, err := repo.MockDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server, tlsConfig := newSMTPServer(t, EmailSecurityNone)
+	defer server.Close()
+	e := newTestEmailNotifier(t, db, server, tlsConfig, EmailSecurityNone, "")
+
+	// No emails are queued without an email address.
+	if err := e.Enqueue("NewOrder", &events.NewOrder{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(e.pending) != 0 {
+		t.Errorf("Expected no pending emails, got %d", len(e.pending))
+	}
+
+	// Only the types opted in to are queued.
+	saveEmailPreferences(t, db, "vendor@example.com", `["DisputeOpen"]`)
+	if err := e.Enqueue("NewOrder", &events.NewOrder{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Enqueue("DisputeOpen", &events.DisputeOpen{OrderID: "1234", DisputerID: "abc"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(e.pending) != 1 || e.pending[0].Subject != "Dispute opened on order 1234" {
+		t.Errorf("Incorrect pending emails: %v", e.pending)
+	}
+}
+
+func TestEmailNotifier_Chat(t *testing.T) {
+	db, err := repo.MockDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saveEmailPreferences(t, db, "vendor@example.com", "")
+
+	server, tlsConfig := newSMTPServer(t, EmailSecurityNone)
+	defer server.Close()
+	e := newTestEmailNotifier(t, db, server, tlsConfig, EmailSecurityNone, "")
+	e.chatDelay = time.Hour
+
+	err = db.Update(func(tx database.Tx) error {
+		if err := tx.Save(&models.ChatMessage{MessageID: "unread", PeerID: "abc", Message: "hello"}); err != nil {
+			return err
+		}
+		return tx.Save(&models.ChatMessage{MessageID: "read", PeerID: "abc", Message: "hi", Read: true})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chats := []*events.ChatMessage{
+		{MessageID: "unread", PeerID: "abc", Message: "hello"},
+		{MessageID: "read", PeerID: "abc", Message: "hi"},
+		{MessageID: "outgoing", PeerID: "abc", Message: "hey", Outgoing: true},
+	}
+	for _, chat := range chats {
+		if err := e.Enqueue("ChatMessage", chat); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(e.chats) != 2 {
+		t.Fatalf("Expected 2 queued chats, got %d", len(e.chats))
+	}
+
+	// The chats are held until the delay has passed.
+	if err := e.flush(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if len(e.chats) != 2 || len(e.pending) != 0 {
+		t.Fatalf("Expected chats to be held, got %d chats and %d pending", len(e.chats), len(e.pending))
+	}
+
+	// Only the message which is still unread is emailed.
+	if err := e.flush(time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	msg := server.wait(t)
+	if msg.subject() != "New message from abc" || !strings.Contains(msg.data, "hello") {
+		t.Errorf("Incorrect email: %s", msg.data)
+	}
+	if len(e.chats) != 0 {
+		t.Errorf("Expected no queued chats, got %d", len(e.chats))
+	}
+}
+
+func Test_loadEmailTemplates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "email-templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	override := `{{define "subject"}}Order {{.OrderID}}{{end}}{{define "body"}}Ship it{{end}}`
+	if err := ioutil.WriteFile(path.Join(dir, "NewOrder.tmpl"), []byte(override), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	templates, err := loadEmailTemplates(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := &EmailNotifier{templates: templates}
+	email, err := e.render("NewOrder", &events.NewOrder{OrderID: "1234"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if email.Subject != "Order 1234" || email.Body != "Ship it" {
+		t.Errorf("Incorrect email: %v", email)
+	}
+	if _, ok := templates["OrderFunded"]; !ok {
+		t.Error("Default template missing")
+	}
+
+	if err := ioutil.WriteFile(path.Join(dir, "digest.tmpl"), []byte(`{{define "subject"}}Digest{{end}}`), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadEmailTemplates(dir); err == nil {
+		t.Error("Expected error for template without a body")
+	}
+}
@@ -39,12 +39,19 @@ type statusWrapper struct {
 
 type notifierStarted struct{}
 
+// Sink receives the events handled by the notifier in addition to the
+// websocket. The event type is the name of the event struct.
+type Sink interface {
+	Enqueue(eventType string, event interface{}) error
+}
+
 // Notifier manages translating events into notifications and
 // sending them to websockets.
 type Notifier struct {
 	notifyFunc func(interface{}) error
 	bus        events.Bus
 	db         database.Database
+	sinks      []Sink
 	shutdown   chan struct{}
 }
 
@@ -58,16 +65,26 @@ func NewNotifier(bus events.Bus, db database.Database, notifyFunc func(interface
 	}
 }
 
+// AddSink adds a sink which the notifier will queue events with.
+// This must be called before Start.
+func (n *Notifier) AddSink(sink Sink) {
+	n.sinks = append(n.sinks, sink)
+}
+
 // Start will start up the notifier. This should use it's own goroutine.
 func (n *Notifier) Start() {
 	notifications := []interface{}{
 		&events.NewOrder{},
 		&events.OrderFunded{},
+		&events.OrderAutoConfirmed{},
 		&events.OrderPaymentReceived{},
+		&events.OrderUnderpaid{},
+		&events.OrderOverpaid{},
 		&events.OrderConfirmation{},
 		&events.OrderDeclined{},
 		&events.OrderCancel{},
 		&events.Refund{},
+		&events.OverpaymentRefund{},
 		&events.OrderFulfillment{},
 		&events.OrderCompletion{},
 		&events.DisputeOpen{},
@@ -75,8 +92,15 @@ func (n *Notifier) Start() {
 		&events.DisputeClose{},
 		&events.DisputeAccepted{},
 		&events.VendorFinalizedPayment{},
+		&events.VendorDisputeTimeout{},
+		&events.BuyerDisputeTimeout{},
+		&events.BuyerDisputeExpiry{},
+		&events.ModeratorDisputeExpiry{},
 		&events.Follow{},
 		&events.Unfollow{},
+		&events.ListingExpiring{},
+		&events.ListingRenewed{},
+		&events.ListingExpired{},
 	}
 
 	notificationSub, err := n.bus.Subscribe(notifications)
@@ -106,11 +130,22 @@ func (n *Notifier) Start() {
 		log.Errorf("Error subscribing to events: %s", err)
 	}
 
+	wallets := []interface{}{
+		&events.TransactionReceived{},
+		&events.SpendFromPaymentAddress{},
+		&events.BlockReceived{},
+	}
+
+	walletSub, err := n.bus.Subscribe(wallets)
+	if err != nil {
+		log.Errorf("Error subscribing to events: %s", err)
+	}
+
 	n.bus.Emit(&notifierStarted{})
 	for {
 		select {
 		case event := <-notificationSub.Out():
-			id := convertToNotification(event)
+			id, typ := convertToNotification(event)
 
 			out, err := json.MarshalIndent(event, "", "    ")
 			if err != nil {
@@ -121,6 +156,7 @@ func (n *Notifier) Start() {
 			err = n.db.Update(func(tx database.Tx) error {
 				return tx.Save(&models.NotificationRecord{
 					ID:           id,
+					Type:         typ,
 					Timestamp:    time.Now(),
 					Read:         false,
 					Notification: out,
@@ -134,20 +170,35 @@ func (n *Notifier) Start() {
 			if err := n.notifyFunc(notificationWrapper{event}); err != nil {
 				log.Errorf("Error sending notification: %s", err)
 			}
+			n.queueSinks(typ, event)
 		case event := <-chatSub.Out():
-			var i interface{}
+			var (
+				i   interface{}
+				typ string
+			)
 			switch event.(type) {
 			case *events.ChatMessage:
-				i = chatMessageWrapper{event}
+				i, typ = chatMessageWrapper{event}, "ChatMessage"
 			case *events.ChatRead:
-				i = messageReadWrapper{event}
+				i, typ = messageReadWrapper{event}, "ChatRead"
 			case *events.ChatTyping:
-				i = messageTypingWrapper{event}
+				i, typ = messageTypingWrapper{event}, "ChatTyping"
 			}
 
 			if err := n.notifyFunc(i); err != nil {
 				log.Errorf("Error sending notification: %s", err)
 			}
+			n.queueSinks(typ, event)
+		case event := <-walletSub.Out():
+			// Wallet events are not sent to the websocket.
+			switch event.(type) {
+			case *events.TransactionReceived:
+				n.queueSinks("TransactionReceived", event)
+			case *events.SpendFromPaymentAddress:
+				n.queueSinks("SpendFromPaymentAddress", event)
+			case *events.BlockReceived:
+				n.queueSinks("BlockReceived", event)
+			}
 		case event := <-publishSub.Out():
 			var i interface{}
 			switch event.(type) {
@@ -173,61 +224,107 @@ func (n *Notifier) Stop() {
 	close(n.shutdown)
 }
 
-func convertToNotification(event interface{}) string {
+// queueSinks queues the event with each of the sinks.
+func (n *Notifier) queueSinks(typ string, event interface{}) {
+	for _, sink := range n.sinks {
+		if err := sink.Enqueue(typ, event); err != nil {
+			log.Errorf("Error queuing event %s: %s", typ, err)
+		}
+	}
+}
+
+// convertToNotification sets the ID and type on the notification event and
+// returns them.
+func convertToNotification(event interface{}) (string, string) {
 	r := make([]byte, 20)
 	rand.Read(r)
-	id := hex.EncodeToString(r)
-
+	var (
+		id  = hex.EncodeToString(r)
+		typ string
+	)
 	switch e := event.(type) {
 	case *events.NewOrder:
-		e.Typ = "NewOrder"
-		e.ID = id
+		typ = "NewOrder"
+		e.Typ, e.ID = typ, id
 	case *events.OrderFunded:
-		e.Typ = "OrderFunded"
-		e.ID = id
+		typ = "OrderFunded"
+		e.Typ, e.ID = typ, id
+	case *events.OrderAutoConfirmed:
+		typ = "OrderAutoConfirmed"
+		e.Typ, e.ID = typ, id
 	case *events.OrderPaymentReceived:
-		e.Typ = "OrderPaymentReceived"
-		e.ID = id
+		typ = "OrderPaymentReceived"
+		e.Typ, e.ID = typ, id
+	case *events.OrderUnderpaid:
+		typ = "OrderUnderpaid"
+		e.Typ, e.ID = typ, id
+	case *events.OrderOverpaid:
+		typ = "OrderOverpaid"
+		e.Typ, e.ID = typ, id
 	case *events.OrderConfirmation:
-		e.Typ = "OrderConfirmation"
-		e.ID = id
+		typ = "OrderConfirmation"
+		e.Typ, e.ID = typ, id
 	case *events.OrderDeclined:
-		e.Typ = "OrderDeclined"
-		e.ID = id
+		typ = "OrderDeclined"
+		e.Typ, e.ID = typ, id
 	case *events.OrderCancel:
-		e.Typ = "OrderCancel"
-		e.ID = id
+		typ = "OrderCancel"
+		e.Typ, e.ID = typ, id
 	case *events.Refund:
-		e.Typ = "Refund"
-		e.ID = id
+		typ = "Refund"
+		e.Typ, e.ID = typ, id
+	case *events.OverpaymentRefund:
+		typ = "OverpaymentRefund"
+		e.Typ, e.ID = typ, id
 	case *events.OrderFulfillment:
-		e.Typ = "OrderFulfillment"
-		e.ID = id
+		typ = "OrderFulfillment"
+		e.Typ, e.ID = typ, id
 	case *events.OrderCompletion:
-		e.Typ = "OrderCompletion"
-		e.ID = id
+		typ = "OrderCompletion"
+		e.Typ, e.ID = typ, id
 	case *events.DisputeOpen:
-		e.Typ = "DisputeOpen"
-		e.ID = id
+		typ = "DisputeOpen"
+		e.Typ, e.ID = typ, id
 	case *events.DisputeUpdate:
-		e.Typ = "DisputeUpdate"
-		e.ID = id
+		typ = "DisputeUpdate"
+		e.Typ, e.ID = typ, id
 	case *events.DisputeClose:
-		e.Typ = "DisputeClose"
-		e.ID = id
+		typ = "DisputeClose"
+		e.Typ, e.ID = typ, id
 	case *events.DisputeAccepted:
-		e.Typ = "DisputeAccepted"
-		e.ID = id
+		typ = "DisputeAccepted"
+		e.Typ, e.ID = typ, id
 	case *events.VendorFinalizedPayment:
-		e.Typ = "VendorFinalizedPayment"
-		e.ID = id
+		typ = "VendorFinalizedPayment"
+		e.Typ, e.ID = typ, id
+	case *events.VendorDisputeTimeout:
+		typ = "VendorDisputeTimeout"
+		e.Typ, e.ID = typ, id
+	case *events.BuyerDisputeTimeout:
+		typ = "BuyerDisputeTimeout"
+		e.Typ, e.ID = typ, id
+	case *events.BuyerDisputeExpiry:
+		typ = "BuyerDisputeExpiry"
+		e.Typ, e.ID = typ, id
+	case *events.ModeratorDisputeExpiry:
+		typ = "ModeratorDisputeExpiry"
+		e.Typ, e.ID = typ, id
 	case *events.Follow:
-		e.Typ = "Follow"
-		e.ID = id
+		typ = "Follow"
+		e.Typ, e.ID = typ, id
 	case *events.Unfollow:
-		e.Typ = "Unfollow"
-		e.ID = id
+		typ = "Unfollow"
+		e.Typ, e.ID = typ, id
+	case *events.ListingExpiring:
+		typ = "ListingExpiring"
+		e.Typ, e.ID = typ, id
+	case *events.ListingRenewed:
+		typ = "ListingRenewed"
+		e.Typ, e.ID = typ, id
+	case *events.ListingExpired:
+		typ = "ListingExpired"
+		e.Typ, e.ID = typ, id
 	}
 
-	return id
+	return id, typ
 }
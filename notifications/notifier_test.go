@@ -1,7 +1,9 @@
 package notifications
 
 import (
+	"github.com/cpacia/openbazaar3.0/database"
 	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
 	"github.com/cpacia/openbazaar3.0/repo"
 	"testing"
 	"time"
@@ -37,11 +39,15 @@ func TestNotifier(t *testing.T) {
 	tests := []interface{}{
 		&events.NewOrder{},
 		&events.OrderFunded{},
+		&events.OrderAutoConfirmed{},
 		&events.OrderPaymentReceived{},
+		&events.OrderUnderpaid{},
+		&events.OrderOverpaid{},
 		&events.OrderConfirmation{},
 		&events.OrderDeclined{},
 		&events.OrderCancel{},
 		&events.Refund{},
+		&events.OverpaymentRefund{},
 		&events.OrderFulfillment{},
 		&events.OrderCompletion{},
 		&events.DisputeOpen{},
@@ -49,8 +55,15 @@ func TestNotifier(t *testing.T) {
 		&events.DisputeClose{},
 		&events.DisputeAccepted{},
 		&events.VendorFinalizedPayment{},
+		&events.VendorDisputeTimeout{},
+		&events.BuyerDisputeTimeout{},
+		&events.BuyerDisputeExpiry{},
+		&events.ModeratorDisputeExpiry{},
 		&events.Follow{},
 		&events.Unfollow{},
+		&events.ListingExpiring{},
+		&events.ListingRenewed{},
+		&events.ListingExpired{},
 	}
 
 	for _, test := range tests {
@@ -72,6 +85,26 @@ func TestNotifier(t *testing.T) {
 		}
 	}
 
+	// Each notification should be saved with its type.
+	var counts []struct {
+		Type  string
+		Count int
+	}
+	err = db.View(func(tx database.Tx) error {
+		return tx.Read().Model(&models.NotificationRecord{}).Select("type, count(*) as count").Group("type").Scan(&counts).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != len(tests) {
+		t.Errorf("Expected %d notification types, got %d", len(tests), len(counts))
+	}
+	for _, c := range counts {
+		if c.Type == "" || c.Count != 1 {
+			t.Errorf("Incorrect notification records for type %q: %d", c.Type, c.Count)
+		}
+	}
+
 	test := &events.ChatMessage{}
 	bus.Emit(test)
 
@@ -0,0 +1,477 @@
+package notifications
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultWebhookRetryInterval is the delay before the first retry of a
+	// failed delivery. The delay doubles with each further attempt.
+	defaultWebhookRetryInterval = time.Second * 10
+
+	// maxWebhookRetryInterval caps the delay between attempts.
+	maxWebhookRetryInterval = time.Hour
+
+	// webhookIdleInterval is how often the queue is checked when there
+	// are no pending deliveries.
+	webhookIdleInterval = time.Minute
+
+	// webhookDeliveryRetention is how long delivered and failed deliveries
+	// are kept in the delivery log.
+	webhookDeliveryRetention = time.Hour * 24 * 7
+
+	// webhookPruneInterval is how often old deliveries are removed from
+	// the delivery log.
+	webhookPruneInterval = time.Hour
+
+	// webhookTimeout is how long to wait for a webhook to respond. A slow
+	// webhook only delays its own deliveries as each webhook URL is
+	// delivered to concurrently.
+	webhookTimeout = time.Second * 10
+
+	// WebhookSignatureHeader holds the HMAC-SHA256 of the request body
+	// keyed with the webhook secret. It is formatted as "sha256=" followed
+	// by the hex encoded HMAC.
+	WebhookSignatureHeader = "X-OpenBazaar-Signature"
+
+	// WebhookEventHeader holds the type of the event being delivered.
+	WebhookEventHeader = "X-OpenBazaar-Event"
+
+	// WebhookDeliveryHeader holds the ID of the delivery. It is the same
+	// across retries so receivers can ignore duplicates.
+	WebhookDeliveryHeader = "X-OpenBazaar-Delivery"
+)
+
+// webhookEventTypes is the set of event types which can be delivered
+// to webhooks.
+var webhookEventTypes = map[string]bool{
+	"NewOrder":                true,
+	"OrderFunded":             true,
+	"OrderAutoConfirmed":      true,
+	"OrderPaymentReceived":    true,
+	"OrderUnderpaid":          true,
+	"OrderOverpaid":           true,
+	"OrderConfirmation":       true,
+	"OrderDeclined":           true,
+	"OrderCancel":             true,
+	"Refund":                  true,
+	"OverpaymentRefund":       true,
+	"OrderFulfillment":        true,
+	"OrderCompletion":         true,
+	"DisputeOpen":             true,
+	"DisputeUpdate":           true,
+	"DisputeClose":            true,
+	"DisputeAccepted":         true,
+	"VendorFinalizedPayment":  true,
+	"VendorDisputeTimeout":    true,
+	"BuyerDisputeTimeout":     true,
+	"BuyerDisputeExpiry":      true,
+	"ModeratorDisputeExpiry":  true,
+	"Follow":                  true,
+	"Unfollow":                true,
+	"ListingExpiring":         true,
+	"ListingRenewed":          true,
+	"ListingExpired":          true,
+	"ChatMessage":             true,
+	"ChatRead":                true,
+	"ChatTyping":              true,
+	"TransactionReceived":     true,
+	"SpendFromPaymentAddress": true,
+	"BlockReceived":           true,
+}
+
+// noisyWebhookEventTypes are event types which are sent so often they are
+// only delivered to webhooks which ask for them by name.
+var noisyWebhookEventTypes = map[string]bool{
+	"ChatTyping":    true,
+	"BlockReceived": true,
+}
+
+// Webhook is a URL which events are POSTed to.
+type Webhook struct {
+	URL string
+
+	// EventTypes restricts the events sent to the webhook. If it
+	// is empty all events except the noisy ones are sent.
+	EventTypes []string
+}
+
+// ParseWebhook parses a webhook from the config format. This is the URL
+// optionally followed by a space and a comma separated list of event
+// types. Without a list every event except ChatTyping and BlockReceived
+// is sent. For example:
+//
+//	https://example.com/hook NewOrder,OrderFunded
+func ParseWebhook(s string) (Webhook, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return Webhook{}, fmt.Errorf("invalid webhook %q", s)
+	}
+	u, err := url.Parse(fields[0])
+	if err != nil {
+		return Webhook{}, fmt.Errorf("invalid webhook URL %q: %s", fields[0], err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Webhook{}, fmt.Errorf("invalid webhook URL %q", fields[0])
+	}
+	webhook := Webhook{URL: fields[0]}
+	if len(fields) == 2 {
+		for _, typ := range strings.Split(fields[1], ",") {
+			if !webhookEventTypes[typ] {
+				return Webhook{}, fmt.Errorf("unknown webhook event type %q", typ)
+			}
+			webhook.EventTypes = append(webhook.EventTypes, typ)
+		}
+	}
+	return webhook, nil
+}
+
+// wants returns whether the event type should be sent to the webhook.
+func (w Webhook) wants(eventType string) bool {
+	if len(w.EventTypes) == 0 {
+		return !noisyWebhookEventTypes[eventType]
+	}
+	for _, typ := range w.EventTypes {
+		if typ == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookPayload is the JSON body POSTed to webhooks.
+type webhookPayload struct {
+	Type      string          `json:"type"`
+	Timestamp time.Time       `json:"timestamp"`
+	Event     json.RawMessage `json:"event"`
+}
+
+// WebhookConfig holds the options for a WebhookDispatcher.
+type WebhookConfig struct {
+	DB       database.Database
+	Webhooks []Webhook
+
+	// Secret is the key used to sign the payloads.
+	Secret string
+
+	// MaxAttempts is the number of times a delivery is attempted before
+	// it is marked as failed.
+	MaxAttempts int
+}
+
+// WebhookDispatcher delivers events to webhooks. Deliveries are queued in
+// the database and retried with exponential backoff until they succeed
+// or run out of attempts. Each webhook is sent its deliveries in order,
+// holding back later deliveries while an earlier one awaits a retry, and
+// different webhooks are delivered to concurrently. Deliveries still
+// pending when the node shuts down are resumed when it starts up again.
+type WebhookDispatcher struct {
+	db            database.Database
+	webhooks      []Webhook
+	secret        []byte
+	maxAttempts   int
+	retryInterval time.Duration
+	client        *http.Client
+	lastPrune     time.Time
+	queued        chan struct{}
+	delivering    map[string]bool
+	mtx           sync.Mutex
+	shutdown      chan struct{}
+}
+
+// NewWebhookDispatcher returns a new WebhookDispatcher.
+func NewWebhookDispatcher(cfg *WebhookConfig) (*WebhookDispatcher, error) {
+	if cfg.Secret == "" {
+		return nil, fmt.Errorf("a webhook secret is required")
+	}
+	if cfg.MaxAttempts <= 0 {
+		return nil, fmt.Errorf("webhook max attempts must be greater than zero")
+	}
+	return &WebhookDispatcher{
+		db:            cfg.DB,
+		webhooks:      cfg.Webhooks,
+		secret:        []byte(cfg.Secret),
+		maxAttempts:   cfg.MaxAttempts,
+		retryInterval: defaultWebhookRetryInterval,
+		client:        &http.Client{Timeout: webhookTimeout},
+		queued:        make(chan struct{}, 1),
+		delivering:    make(map[string]bool),
+		shutdown:      make(chan struct{}),
+	}, nil
+}
+
+// Enqueue queues the event for delivery to each webhook that wants
+// events of this type.
+func (d *WebhookDispatcher) Enqueue(eventType string, event interface{}) error {
+	var webhooks []Webhook
+	for _, webhook := range d.webhooks {
+		if webhook.wants(eventType) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	payload, err := json.Marshal(webhookPayload{
+		Type:      eventType,
+		Timestamp: now,
+		Event:     raw,
+	})
+	if err != nil {
+		return err
+	}
+
+	var deliveries []*models.WebhookDelivery
+	for _, webhook := range webhooks {
+		r := make([]byte, 20)
+		rand.Read(r)
+		deliveries = append(deliveries, &models.WebhookDelivery{
+			ID:          hex.EncodeToString(r),
+			URL:         webhook.URL,
+			EventType:   eventType,
+			Payload:     payload,
+			Status:      models.WebhookDeliveryPending,
+			Timestamp:   now,
+			NextAttempt: now,
+		})
+	}
+	err = d.db.Update(func(tx database.Tx) error {
+		for _, delivery := range deliveries {
+			if err := tx.Save(delivery); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	select {
+	case d.queued <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Start delivers the queued events until the dispatcher is stopped.
+// This should use it's own goroutine.
+func (d *WebhookDispatcher) Start() {
+	for {
+		if time.Since(d.lastPrune) >= webhookPruneInterval {
+			if err := d.prune(); err != nil {
+				log.Errorf("Error pruning webhook deliveries: %s", err)
+			}
+			d.lastPrune = time.Now()
+		}
+
+		wait, err := d.deliverPending()
+		if err != nil {
+			log.Errorf("Error delivering webhooks: %s", err)
+			wait = webhookIdleInterval
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-d.queued:
+		case <-timer.C:
+		case <-d.shutdown:
+			timer.Stop()
+			return
+		}
+		timer.Stop()
+	}
+}
+
+// Stop shuts down the dispatcher.
+func (d *WebhookDispatcher) Stop() {
+	close(d.shutdown)
+}
+
+// prune removes delivered and failed deliveries older than the
+// webhookDeliveryRetention from the delivery log.
+func (d *WebhookDispatcher) prune() error {
+	cutoff := map[string]interface{}{"last_attempt < ?": time.Now().Add(-webhookDeliveryRetention)}
+	return d.db.Update(func(tx database.Tx) error {
+		for _, status := range []string{models.WebhookDeliveryDelivered, models.WebhookDeliveryFailed} {
+			if err := tx.Delete("status", status, cutoff, &models.WebhookDelivery{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// deliverPending starts delivering the deliveries that are due and returns
+// how long to wait until the next one is. Each webhook URL is delivered to
+// by its own goroutine, which attempts the webhook's deliveries in the order
+// they were queued and wakes the dispatcher when it is done. A URL whose
+// oldest pending delivery is waiting to be retried is not sent any of its
+// later deliveries until then. Deliveries to a URL which is still being
+// delivered to are left for that URL's next round.
+func (d *WebhookDispatcher) deliverPending() (time.Duration, error) {
+	var pending []models.WebhookDelivery
+	err := d.db.View(func(tx database.Tx) error {
+		return tx.Read().Where("status = ?", models.WebhookDeliveryPending).
+			Order("timestamp asc").Find(&pending).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	var (
+		urls  []string
+		byURL = make(map[string][]*models.WebhookDelivery)
+	)
+	for i := range pending {
+		if d.delivering[pending[i].URL] {
+			continue
+		}
+		if _, ok := byURL[pending[i].URL]; !ok {
+			urls = append(urls, pending[i].URL)
+		}
+		byURL[pending[i].URL] = append(byURL[pending[i].URL], &pending[i])
+	}
+
+	var (
+		wait    = webhookIdleInterval
+		waiting = false
+		now     = time.Now()
+	)
+	for _, webhookURL := range urls {
+		deliveries := byURL[webhookURL]
+		if next := deliveries[0].NextAttempt.Sub(now); next > 0 {
+			if !waiting || next < wait {
+				wait = next
+				waiting = true
+			}
+			continue
+		}
+		d.delivering[webhookURL] = true
+		go d.deliver(webhookURL, deliveries)
+	}
+	return wait, nil
+}
+
+// deliver attempts the deliveries to the webhook URL in order and saves the
+// results. It stops early if a delivery needs to be retried, holding back
+// the rest until the retry, or if the dispatcher is shut down.
+func (d *WebhookDispatcher) deliver(webhookURL string, deliveries []*models.WebhookDelivery) {
+	defer func() {
+		d.mtx.Lock()
+		delete(d.delivering, webhookURL)
+		d.mtx.Unlock()
+
+		select {
+		case d.queued <- struct{}{}:
+		default:
+		}
+	}()
+	for _, delivery := range deliveries {
+		select {
+		case <-d.shutdown:
+			return
+		default:
+		}
+		d.attempt(delivery)
+		if err := d.db.Update(func(tx database.Tx) error { return tx.Save(delivery) }); err != nil {
+			log.Errorf("Error saving webhook delivery %s: %s", delivery.ID, err)
+			return
+		}
+		if delivery.Status == models.WebhookDeliveryPending {
+			return
+		}
+	}
+}
+
+// attempt POSTs the delivery to its webhook and updates the delivery
+// with the result.
+func (d *WebhookDispatcher) attempt(delivery *models.WebhookDelivery) {
+	delivery.Attempts++
+	delivery.LastAttempt = time.Now()
+
+	code, err := d.post(delivery)
+	delivery.ResponseCode = code
+	if err == nil {
+		delivery.Status = models.WebhookDeliveryDelivered
+		delivery.LastError = ""
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= d.maxAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+		log.Warningf("Webhook delivery %s to %s failed after %d attempts: %s", delivery.ID, delivery.URL, delivery.Attempts, err)
+		return
+	}
+	delivery.NextAttempt = delivery.LastAttempt.Add(d.backoff(delivery.Attempts))
+	log.Debugf("Webhook delivery %s to %s failed, retrying at %s: %s", delivery.ID, delivery.URL, delivery.NextAttempt, err)
+}
+
+// post sends the delivery and returns the response status code.
+func (d *WebhookDispatcher) post(delivery *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(d.secret, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff returns the delay before the next attempt after the given
+// number of failed attempts.
+func (d *WebhookDispatcher) backoff(attempts int) time.Duration {
+	wait := d.retryInterval
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= maxWebhookRetryInterval {
+			return maxWebhookRetryInterval
+		}
+	}
+	return wait
+}
+
+// SignWebhookPayload returns the signature of the payload which is sent
+// in the WebhookSignatureHeader.
+func SignWebhookPayload(secret, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
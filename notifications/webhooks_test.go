@@ -0,0 +1,506 @@
+package notifications
+
+import (
+	"encoding/json"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/repo"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseWebhook(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Webhook
+		valid    bool
+	}{
+		{
+			input:    "https://example.com/hook",
+			expected: Webhook{URL: "https://example.com/hook"},
+			valid:    true,
+		},
+		{
+			input:    "http://localhost:8080/hook  NewOrder,ChatMessage",
+			expected: Webhook{URL: "http://localhost:8080/hook", EventTypes: []string{"NewOrder", "ChatMessage"}},
+			valid:    true,
+		},
+		{
+			input: "",
+			valid: false,
+		},
+		{
+			input: "ftp://example.com/hook",
+			valid: false,
+		},
+		{
+			input: "example.com/hook",
+			valid: false,
+		},
+		{
+			input: "https://example.com/hook NewOrder,NotAnEvent",
+			valid: false,
+		},
+		{
+			input: "https://example.com/hook NewOrder Follow",
+			valid: false,
+		},
+	}
+	for _, test := range tests {
+		webhook, err := ParseWebhook(test.input)
+		if test.valid && err != nil {
+			t.Errorf("%q: failed when it should not have: %s", test.input, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%q: did not fail when it should have", test.input)
+		}
+		if test.valid && !reflect.DeepEqual(webhook, test.expected) {
+			t.Errorf("%q: expected %v, got %v", test.input, test.expected, webhook)
+		}
+	}
+}
+
+// webhookServer records the requests it receives and fails the first
+// failures requests.
+type webhookServer struct {
+	*httptest.Server
+
+	mtx      sync.Mutex
+	failures int
+	requests []*http.Request
+	bodies   [][]byte
+	received chan struct{}
+}
+
+func newWebhookServer(failures int) *webhookServer {
+	s := &webhookServer{
+		failures: failures,
+		received: make(chan struct{}, 100),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		s.mtx.Lock()
+		s.requests = append(s.requests, r)
+		s.bodies = append(s.bodies, body)
+		fail := len(s.requests) <= s.failures
+		s.mtx.Unlock()
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		s.received <- struct{}{}
+	}))
+	return s
+}
+
+func (s *webhookServer) wait(t *testing.T, n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case <-s.received:
+		case <-time.After(time.Second * 10):
+			t.Fatal("Timed out waiting on webhook")
+		}
+	}
+}
+
+func waitForDeliveryStatus(t *testing.T, db database.Database, id, status string) models.WebhookDelivery {
+	var delivery models.WebhookDelivery
+	for i := 0; i < 100; i++ {
+		err := db.View(func(tx database.Tx) error {
+			return tx.Read().Where("id = ?", id).First(&delivery).Error
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if delivery.Status == status {
+			return delivery
+		}
+		time.Sleep(time.Millisecond * 50)
+	}
+	t.Fatalf("Expected delivery status %s, got %s", status, delivery.Status)
+	return delivery
+}
+
+func newTestDispatcher(t *testing.T, db database.Database, maxAttempts int, webhooks ...Webhook) *WebhookDispatcher {
+	d, err := NewWebhookDispatcher(&WebhookConfig{
+		DB:          db,
+		Webhooks:    webhooks,
+		Secret:      "letmein",
+		MaxAttempts: maxAttempts,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.retryInterval = time.Millisecond * 10
+	return d
+}
+
+func TestWebhookDispatcher(t *testing.T) {
+	db, err := repo.MockDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all := newWebhookServer(1)
+	defer all.Close()
+	follows := newWebhookServer(0)
+	defer follows.Close()
+
+	d := newTestDispatcher(t, db, 5,
+		Webhook{URL: all.URL},
+		Webhook{URL: follows.URL, EventTypes: []string{"Follow"}},
+	)
+	go d.Start()
+	defer d.Stop()
+
+	if err := d.Enqueue("NewOrder", &events.NewOrder{OrderID: "1234"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first attempt fails and is retried.
+	all.wait(t, 2)
+
+	var deliveries []models.WebhookDelivery
+	err = db.View(func(tx database.Tx) error {
+		return tx.Read().Find(&deliveries).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("Expected 1 delivery, got %d", len(deliveries))
+	}
+	delivery := waitForDeliveryStatus(t, db, deliveries[0].ID, models.WebhookDeliveryDelivered)
+	if delivery.Attempts != 2 || delivery.ResponseCode != http.StatusOK || delivery.LastError != "" {
+		t.Errorf("Incorrect delivery: %d attempts, code %d, error %s", delivery.Attempts, delivery.ResponseCode, delivery.LastError)
+	}
+
+	all.mtx.Lock()
+	req, body := all.requests[1], all.bodies[1]
+	all.mtx.Unlock()
+	if req.Header.Get(WebhookSignatureHeader) != SignWebhookPayload([]byte("letmein"), body) {
+		t.Error("Incorrect signature")
+	}
+	if req.Header.Get(WebhookEventHeader) != "NewOrder" {
+		t.Errorf("Incorrect event header: %s", req.Header.Get(WebhookEventHeader))
+	}
+	if req.Header.Get(WebhookDeliveryHeader) != delivery.ID {
+		t.Errorf("Incorrect delivery header: %s", req.Header.Get(WebhookDeliveryHeader))
+	}
+
+	var payload struct {
+		Type  string          `json:"type"`
+		Event events.NewOrder `json:"event"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Type != "NewOrder" || payload.Event.OrderID != "1234" {
+		t.Errorf("Incorrect payload: %s", string(body))
+	}
+
+	if err := d.Enqueue("Follow", &events.Follow{PeerID: "abc"}); err != nil {
+		t.Fatal(err)
+	}
+	all.wait(t, 1)
+	follows.wait(t, 1)
+
+	follows.mtx.Lock()
+	if len(follows.requests) != 1 || follows.requests[0].Header.Get(WebhookEventHeader) != "Follow" {
+		t.Error("Filtered webhook received the wrong events")
+	}
+	follows.mtx.Unlock()
+}
+
+func TestWebhookDispatcher_MaxAttempts(t *testing.T) {
+	db, err := repo.MockDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := newWebhookServer(100)
+	defer server.Close()
+
+	d := newTestDispatcher(t, db, 3, Webhook{URL: server.URL})
+	go d.Start()
+	defer d.Stop()
+
+	if err := d.Enqueue("Follow", &events.Follow{}); err != nil {
+		t.Fatal(err)
+	}
+	server.wait(t, 3)
+
+	var deliveries []models.WebhookDelivery
+	err = db.View(func(tx database.Tx) error {
+		return tx.Read().Find(&deliveries).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	delivery := waitForDeliveryStatus(t, db, deliveries[0].ID, models.WebhookDeliveryFailed)
+	if delivery.Attempts != 3 || delivery.ResponseCode != http.StatusInternalServerError || delivery.LastError == "" {
+		t.Errorf("Incorrect delivery: %d attempts, code %d, error %s", delivery.Attempts, delivery.ResponseCode, delivery.LastError)
+	}
+}
+
+func TestWebhookDispatcher_ResumesPending(t *testing.T) {
+	db, err := repo.MockDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := newWebhookServer(0)
+	defer server.Close()
+
+	// A delivery left in the queue from before a restart.
+	err = db.Update(func(tx database.Tx) error {
+		return tx.Save(&models.WebhookDelivery{
+			ID:          "abc",
+			URL:         server.URL,
+			EventType:   "Follow",
+			Payload:     []byte(`{}`),
+			Status:      models.WebhookDeliveryPending,
+			Attempts:    1,
+			Timestamp:   time.Now().Add(-time.Minute),
+			NextAttempt: time.Now().Add(-time.Second),
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := newTestDispatcher(t, db, 3, Webhook{URL: server.URL})
+	go d.Start()
+	defer d.Stop()
+
+	server.wait(t, 1)
+	delivery := waitForDeliveryStatus(t, db, "abc", models.WebhookDeliveryDelivered)
+	if delivery.Attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", delivery.Attempts)
+	}
+}
+
+func TestWebhookDispatcher_SlowWebhook(t *testing.T) {
+	db, err := repo.MockDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	fast := newWebhookServer(0)
+	defer fast.Close()
+
+	d := newTestDispatcher(t, db, 3, Webhook{URL: slow.URL}, Webhook{URL: fast.URL})
+	go d.Start()
+	defer d.Stop()
+
+	// The fast webhook receives both events while the slow webhook is
+	// still stuck on the first.
+	for _, typ := range []string{"NewOrder", "Follow"} {
+		if err := d.Enqueue(typ, struct{}{}); err != nil {
+			t.Fatal(err)
+		}
+		fast.wait(t, 1)
+	}
+}
+
+func TestWebhookDispatcher_HoldsBackLaterDeliveries(t *testing.T) {
+	db, err := repo.MockDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := newWebhookServer(1)
+	defer server.Close()
+
+	d := newTestDispatcher(t, db, 5, Webhook{URL: server.URL})
+	d.retryInterval = time.Millisecond * 500
+	go d.Start()
+	defer d.Stop()
+
+	if err := d.Enqueue("NewOrder", struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+	server.wait(t, 1)
+
+	// The second event is not sent while the first awaits its retry.
+	if err := d.Enqueue("Follow", struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+	server.wait(t, 2)
+
+	server.mtx.Lock()
+	defer server.mtx.Unlock()
+	var received []string
+	for _, req := range server.requests {
+		received = append(received, req.Header.Get(WebhookEventHeader))
+	}
+	expected := []string{"NewOrder", "NewOrder", "Follow"}
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("Expected events %v, got %v", expected, received)
+	}
+}
+
+func TestWebhookDispatcher_NoisyEvents(t *testing.T) {
+	db, err := repo.MockDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := newTestDispatcher(t, db, 3,
+		Webhook{URL: "https://example.com/all"},
+		Webhook{URL: "https://example.com/blocks", EventTypes: []string{"BlockReceived"}},
+	)
+
+	// Noisy events are only queued for webhooks which ask for them.
+	for _, typ := range []string{"BlockReceived", "ChatTyping", "NewOrder"} {
+		if err := d.Enqueue(typ, struct{}{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var deliveries []models.WebhookDelivery
+	err = db.View(func(tx database.Tx) error {
+		return tx.Read().Order("url asc").Find(&deliveries).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 2 {
+		t.Fatalf("Expected 2 deliveries, got %d", len(deliveries))
+	}
+	if deliveries[0].URL != "https://example.com/all" || deliveries[0].EventType != "NewOrder" {
+		t.Errorf("Incorrect delivery: %s %s", deliveries[0].URL, deliveries[0].EventType)
+	}
+	if deliveries[1].URL != "https://example.com/blocks" || deliveries[1].EventType != "BlockReceived" {
+		t.Errorf("Incorrect delivery: %s %s", deliveries[1].URL, deliveries[1].EventType)
+	}
+}
+
+func TestWebhookDispatcher_prune(t *testing.T) {
+	db, err := repo.MockDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := time.Now().Add(-webhookDeliveryRetention - time.Hour)
+	deliveries := []models.WebhookDelivery{
+		{ID: "old-delivered", Status: models.WebhookDeliveryDelivered, LastAttempt: old},
+		{ID: "old-failed", Status: models.WebhookDeliveryFailed, LastAttempt: old},
+		{ID: "old-pending", Status: models.WebhookDeliveryPending, LastAttempt: old},
+		{ID: "new-delivered", Status: models.WebhookDeliveryDelivered, LastAttempt: time.Now()},
+	}
+	err = db.Update(func(tx database.Tx) error {
+		for i := range deliveries {
+			if err := tx.Save(&deliveries[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := newTestDispatcher(t, db, 3)
+	if err := d.prune(); err != nil {
+		t.Fatal(err)
+	}
+
+	var remaining []models.WebhookDelivery
+	err = db.View(func(tx database.Tx) error {
+		return tx.Read().Order("id asc").Find(&remaining).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 2 || remaining[0].ID != "new-delivered" || remaining[1].ID != "old-pending" {
+		t.Errorf("Incorrect deliveries remaining: %v", remaining)
+	}
+}
+
+func TestWebhookDispatcher_backoff(t *testing.T) {
+	d := &WebhookDispatcher{retryInterval: time.Second}
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, time.Second},
+		{2, time.Second * 2},
+		{5, time.Second * 16},
+		{100, maxWebhookRetryInterval},
+	}
+	for _, test := range tests {
+		if wait := d.backoff(test.attempts); wait != test.expected {
+			t.Errorf("%d attempts: expected %s, got %s", test.attempts, test.expected, wait)
+		}
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	expected := "sha256=14af61ed9c29e0dc343731df0fbe80f2273ed1b4a77b808e6012a180a222ee93"
+	if sig := SignWebhookPayload([]byte("letmein"), []byte("{}")); sig != expected {
+		t.Errorf("Expected signature %s, got %s", expected, sig)
+	}
+}
+
+func TestNotifier_Webhooks(t *testing.T) {
+	bus := events.NewBus()
+	db, err := repo.MockDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := newWebhookServer(0)
+	defer server.Close()
+
+	d := newTestDispatcher(t, db, 3, Webhook{URL: server.URL})
+	go d.Start()
+	defer d.Stop()
+
+	sub, err := bus.Subscribe(&notifierStarted{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notifier := NewNotifier(bus, db, func(i interface{}) error { return nil })
+	notifier.AddSink(d)
+	go notifier.Start()
+	defer notifier.Stop()
+
+	select {
+	case <-sub.Out():
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timed out waiting on channel")
+	}
+
+	tests := []struct {
+		event    interface{}
+		expected string
+	}{
+		{&events.NewOrder{}, "NewOrder"},
+		{&events.ChatMessage{}, "ChatMessage"},
+		{&events.TransactionReceived{}, "TransactionReceived"},
+	}
+	for _, test := range tests {
+		bus.Emit(test.event)
+		server.wait(t, 1)
+
+		server.mtx.Lock()
+		typ := server.requests[len(server.requests)-1].Header.Get(WebhookEventHeader)
+		server.mtx.Unlock()
+		if typ != test.expected {
+			t.Errorf("Expected event type %s, got %s", test.expected, typ)
+		}
+	}
+}
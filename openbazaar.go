@@ -24,6 +24,27 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	listings, err := parser.AddCommand("listings",
+		"import or export listings",
+		"The listings command imports or exports listings using the API of a running node.",
+		&cmd.Listings{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	_, err = listings.AddCommand("import",
+		"import listings from a CSV or JSON file",
+		"The import command saves each listing in the file to the node and publishes once all the listings are saved.",
+		&cmd.ImportListings{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	_, err = listings.AddCommand("export",
+		"export listings to a CSV or JSON file",
+		"The export command writes all of the node's listings to the file or to stdout if no file is given.",
+		&cmd.ExportListings{})
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	if _, err := parser.Parse(); err != nil {
 		os.Exit(1)
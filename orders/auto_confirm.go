@@ -0,0 +1,81 @@
+package orders
+
+import (
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	iwallet "github.com/cpacia/wallet-interface"
+	"github.com/jinzhu/gorm"
+)
+
+// autoConfirmOrder confirms a funded order on the vendor's behalf if the
+// vendor's preferences allow it. It is triggered once the transaction that
+// processed the funding payment commits so it applies both to payments
+// detected by the wallet and to PAYMENT_SENT messages which were parked
+// while we were offline.
+//
+// The wallet transaction sweeping the funds of a CANCELABLE order is only
+// committed after the database transaction is released as committing it
+// notifies the wallet subscribers which need the database themselves.
+func (op *OrderProcessor) autoConfirmOrder(orderID models.OrderID) {
+	var wTx iwallet.Tx
+	err := op.db.Update(func(dbtx database.Tx) error {
+		var order models.Order
+		if err := dbtx.Read().Where("id = ?", orderID.String()).First(&order).Error; err != nil {
+			return err
+		}
+		if !order.CanConfirm(op.identity) {
+			return nil
+		}
+
+		var prefs models.UserPreferences
+		if err := dbtx.Read().First(&prefs).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+			return err
+		}
+
+		orderOpen, err := order.OrderOpenMessage()
+		if err != nil {
+			return err
+		}
+
+		confirm, err := prefs.ShouldAutoConfirm(orderOpen)
+		if err != nil {
+			return err
+		}
+		if !confirm {
+			return nil
+		}
+
+		wTx, err = op.ConfirmOrder(dbtx, &order, nil)
+		if err != nil {
+			return err
+		}
+
+		dbtx.RegisterCommitHook(func() {
+			op.bus.Emit(&events.OrderAutoConfirmed{
+				BuyerHandle: orderOpen.BuyerID.Handle,
+				BuyerID:     orderOpen.BuyerID.PeerID,
+				OrderID:     order.ID.String(),
+				Thumbnail: events.Thumbnail{
+					Tiny:  orderOpen.Listings[0].Listing.Item.Images[0].Tiny,
+					Small: orderOpen.Listings[0].Listing.Item.Images[0].Small,
+				},
+				Title: orderOpen.Listings[0].Listing.Item.Title,
+			})
+		})
+		log.Infof("Auto-confirmed order %s", order.ID)
+		return nil
+	})
+	if err != nil {
+		if wTx != nil {
+			wTx.Rollback()
+		}
+		log.Errorf("Error auto-confirming order %s: %s", orderID, err)
+		return
+	}
+	if wTx != nil {
+		if err := wTx.Commit(); err != nil {
+			log.Errorf("Error committing auto-confirm transaction for order %s: %s", orderID, err)
+		}
+	}
+}
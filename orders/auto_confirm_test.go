@@ -0,0 +1,160 @@
+package orders
+
+import (
+	"encoding/json"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/models/factory"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	iwallet "github.com/cpacia/wallet-interface"
+	"testing"
+	"time"
+)
+
+func TestOrderProcessor_autoConfirmOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        pb.OrderOpen_Payment_Method
+		autoConfirm   bool
+		rules         *models.AutoConfirmRules
+		expectConfirm bool
+	}{
+		{
+			name:          "Auto-confirm enabled",
+			method:        pb.OrderOpen_Payment_DIRECT,
+			autoConfirm:   true,
+			expectConfirm: true,
+		},
+		{
+			name:          "Auto-confirm disabled",
+			method:        pb.OrderOpen_Payment_DIRECT,
+			autoConfirm:   false,
+			expectConfirm: false,
+		},
+		{
+			name:        "Listing override enabled",
+			method:      pb.OrderOpen_Payment_DIRECT,
+			autoConfirm: false,
+			rules: &models.AutoConfirmRules{
+				ListingOverrides: map[string]bool{"test-listing": true},
+			},
+			expectConfirm: true,
+		},
+		{
+			name:        "Over max order value",
+			method:      pb.OrderOpen_Payment_DIRECT,
+			autoConfirm: true,
+			rules: &models.AutoConfirmRules{
+				MaxOrderValue: map[string]string{iwallet.CtMock: "1000"},
+			},
+			expectConfirm: false,
+		},
+		{
+			name:          "Cancelable",
+			method:        pb.OrderOpen_Payment_CANCELABLE,
+			autoConfirm:   true,
+			expectConfirm: true,
+		},
+	}
+
+	for _, test := range tests {
+		op, teardown, err := newMockOrderProcessor()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = op.db.Update(func(tx database.Tx) error {
+			var prefs models.UserPreferences
+			if err := tx.Read().First(&prefs).Error; err != nil {
+				return err
+			}
+			prefs.AutoConfirm = test.autoConfirm
+			if test.rules != nil {
+				prefs.ConfirmRules, err = json.Marshal(test.rules)
+				if err != nil {
+					return err
+				}
+			}
+			if err := tx.Save(&prefs); err != nil {
+				return err
+			}
+
+			orderOpen, err := factory.NewOrder()
+			if err != nil {
+				return err
+			}
+			orderOpen.Payment.Address = "abcd"
+			orderOpen.Payment.Method = test.method
+			orderOpen.Listings[0].Listing.Slug = "test-listing"
+			order := models.Order{
+				ID:             "1234",
+				PaymentAddress: "abcd",
+			}
+			order.SetRole(models.RoleVendor)
+			if err := order.PutMessage(&npb.OrderMessage{
+				Signature: []byte("abc"),
+				Message:   mustBuildAny(orderOpen),
+			}); err != nil {
+				return err
+			}
+			return tx.Save(&order)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sub, err := op.bus.Subscribe(&events.OrderAutoConfirmed{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		op.processWalletTransaction(iwallet.Transaction{
+			ID: "5678",
+			To: []iwallet.SpendInfo{
+				{
+					ID:      make([]byte, 36),
+					Address: iwallet.NewAddress("abcd", iwallet.CtMock),
+					Amount:  iwallet.NewAmount(4992221),
+				},
+			},
+		})
+
+		select {
+		case <-sub.Out():
+			if !test.expectConfirm {
+				t.Errorf("%s: unexpected auto-confirm event", test.name)
+			}
+		case <-time.After(time.Second * 2):
+			if test.expectConfirm {
+				t.Errorf("%s: timed out waiting on event", test.name)
+			}
+		}
+
+		var order models.Order
+		err = op.db.View(func(tx database.Tx) error {
+			return tx.Read().Where("id = ?", "1234").First(&order).Error
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		confirmed := order.SerializedOrderConfirmation != nil
+		if confirmed != test.expectConfirm {
+			t.Errorf("%s: expected confirmed %t, got %t", test.name, test.expectConfirm, confirmed)
+		}
+
+		if confirmed && test.method == pb.OrderOpen_Payment_CANCELABLE {
+			confirmation, err := order.OrderConfirmationMessage()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if confirmation.TransactionID == "" {
+				t.Errorf("%s: expected cancelable funds to be swept", test.name)
+			}
+		}
+		sub.Close()
+		teardown()
+	}
+}
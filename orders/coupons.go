@@ -0,0 +1,223 @@
+package orders
+
+import (
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	iwallet "github.com/cpacia/wallet-interface"
+	"github.com/golang/protobuf/ptypes"
+	"time"
+)
+
+// appliedCoupon is a listing coupon which was applied to an order.
+type appliedCoupon struct {
+	slug   string
+	hash   string
+	coupon *pb.Listing_Coupon
+}
+
+// matchCoupon returns the listing coupon which the code redeems along with
+// the hash of the code. The coupon is nil if the code does not match any of
+// the listing's coupons.
+func matchCoupon(listing *pb.Listing, code string) (*pb.Listing_Coupon, string, error) {
+	couponHash, err := utils.MultihashSha256([]byte(code))
+	if err != nil {
+		return nil, "", err
+	}
+	for _, coupon := range listing.Coupons {
+		if code == coupon.GetDiscountCode() || couponHash.B58String() == coupon.GetHash() {
+			return coupon, couponHash.B58String(), nil
+		}
+	}
+	return nil, couponHash.B58String(), nil
+}
+
+// couponApplies returns whether the coupon can be used on the selected SKU
+// at the given time. The minimum order value is checked against the subtotal
+// of the items bought from the listing, so that the buyer and vendor always
+// agree on the order total. The redemption limits are enforced separately by
+// the vendor.
+func couponApplies(coupon *pb.Listing_Coupon, sku *pb.Listing_Item_Sku, at time.Time, subtotal iwallet.Amount) bool {
+	if coupon.Expiry != nil {
+		expiry, err := ptypes.Timestamp(coupon.Expiry)
+		if err != nil {
+			return false
+		}
+		if at.After(expiry) {
+			return false
+		}
+	}
+	if len(coupon.Skus) > 0 {
+		found := false
+		for _, productID := range coupon.Skus {
+			if productID != "" && productID == sku.ProductID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if coupon.MinimumOrderValue != "" && subtotal.Cmp(iwallet.NewAmount(coupon.MinimumOrderValue)) < 0 {
+		return false
+	}
+	return true
+}
+
+// orderTime returns the time the buyer placed the order. The current time is
+// returned if the order timestamp is not set.
+func orderTime(order *pb.OrderOpen) time.Time {
+	if order.Timestamp != nil {
+		if t, err := ptypes.Timestamp(order.Timestamp); err == nil {
+			return t
+		}
+	}
+	return time.Now()
+}
+
+// listingSubtotals returns the total of the items bought from each listing
+// in the order keyed by listing hash. The totals are in the pricing currency
+// of the listing and include any price tiers but are before any coupons,
+// taxes or shipping. Cryptocurrency listings cannot have coupons so they are
+// skipped.
+func listingSubtotals(order *pb.OrderOpen) (map[string]iwallet.Amount, error) {
+	quantities, err := newOrderQuantities(order)
+	if err != nil {
+		return nil, err
+	}
+	subtotals := make(map[string]iwallet.Amount)
+	for _, item := range order.Items {
+		listing, err := extractListing(item.ListingHash, order.Listings)
+		if err != nil {
+			return nil, err
+		}
+		if listing.Metadata.ContractType == pb.Listing_Metadata_CRYPTOCURRENCY {
+			continue
+		}
+		sku, err := getSelectedSku(listing, item.Options)
+		if err != nil {
+			return nil, err
+		}
+		itemTotal := quantities.unitPrice(item.ListingHash, listing, sku).Mul(iwallet.NewAmount(item.Quantity))
+
+		subtotal, ok := subtotals[item.ListingHash]
+		if !ok {
+			subtotal = iwallet.NewAmount(0)
+		}
+		subtotals[item.ListingHash] = subtotal.Add(itemTotal)
+	}
+	return subtotals, nil
+}
+
+// appliedCoupons returns the coupons which apply to the items in the order.
+// A coupon used on more than one item is only returned once.
+func appliedCoupons(order *pb.OrderOpen) ([]appliedCoupon, error) {
+	subtotals, err := listingSubtotals(order)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		applied []appliedCoupon
+		seen    = make(map[string]bool)
+	)
+	for _, item := range order.Items {
+		if len(item.CouponCodes) == 0 {
+			continue
+		}
+		listing, err := extractListing(item.ListingHash, order.Listings)
+		if err != nil {
+			return nil, err
+		}
+		sku, err := getSelectedSku(listing, item.Options)
+		if err != nil {
+			return nil, err
+		}
+		for _, code := range item.CouponCodes {
+			coupon, hash, err := matchCoupon(listing, code)
+			if err != nil {
+				return nil, err
+			}
+			if coupon == nil || !couponApplies(coupon, sku, orderTime(order), subtotals[item.ListingHash]) {
+				continue
+			}
+			if seen[listing.Slug+"/"+hash] {
+				continue
+			}
+			seen[listing.Slug+"/"+hash] = true
+			applied = append(applied, appliedCoupon{
+				slug:   listing.Slug,
+				hash:   hash,
+				coupon: coupon,
+			})
+		}
+	}
+	return applied, nil
+}
+
+// checkCouponRedemptions returns an error if any of the coupons applied to
+// the order have reached their total or per buyer redemption limit.
+func (op *OrderProcessor) checkCouponRedemptions(dbtx database.Tx, order *pb.OrderOpen, orderID models.OrderID) error {
+	applied, err := appliedCoupons(order)
+	if err != nil {
+		return err
+	}
+	for _, ac := range applied {
+		if max := ac.coupon.MaxRedemptions; max > 0 {
+			var count int
+			err := dbtx.Read().Model(&models.CouponRedemption{}).
+				Where("slug = ? AND coupon_hash = ? AND order_id <> ?", ac.slug, ac.hash, orderID.String()).
+				Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count >= int(max) {
+				return fmt.Errorf("coupon %s for item %s has reached its redemption limit", ac.coupon.Title, ac.slug)
+			}
+		}
+		if max := ac.coupon.MaxRedemptionsPerBuyer; max > 0 {
+			var count int
+			err := dbtx.Read().Model(&models.CouponRedemption{}).
+				Where("slug = ? AND coupon_hash = ? AND buyer_id = ? AND order_id <> ?", ac.slug, ac.hash, order.BuyerID.PeerID, orderID.String()).
+				Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count >= int(max) {
+				return fmt.Errorf("coupon %s for item %s has reached its redemption limit for this buyer", ac.coupon.Title, ac.slug)
+			}
+		}
+	}
+	return nil
+}
+
+// redeemCoupons records the coupons applied to the order so that they count
+// towards the coupons' redemption limits.
+func (op *OrderProcessor) redeemCoupons(dbtx database.Tx, order *models.Order, orderOpen *pb.OrderOpen) error {
+	applied, err := appliedCoupons(orderOpen)
+	if err != nil {
+		return err
+	}
+	for _, ac := range applied {
+		redemption := models.CouponRedemption{
+			OrderID:    order.ID.String(),
+			Slug:       ac.slug,
+			CouponHash: ac.hash,
+			BuyerID:    orderOpen.BuyerID.PeerID,
+			Timestamp:  time.Now(),
+		}
+		if err := dbtx.Save(&redemption); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseCoupons removes the order's coupon redemptions so they no longer
+// count towards the redemption limits. This is used when the order is
+// canceled or declined.
+func (op *OrderProcessor) releaseCoupons(dbtx database.Tx, order *models.Order) error {
+	return dbtx.Delete("order_id", order.ID.String(), nil, &models.CouponRedemption{})
+}
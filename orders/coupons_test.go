@@ -0,0 +1,107 @@
+package orders
+
+import (
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/models/factory"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	"strings"
+	"testing"
+)
+
+func TestOrderProcessor_couponRedemptions(t *testing.T) {
+	op, teardown, err := newMockOrderProcessor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	orderOpen, err := factory.NewOrder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderOpen.Listings[0].Listing.Coupons[0].MaxRedemptions = 2
+	orderOpen.Listings[0].Listing.Coupons[0].MaxRedemptionsPerBuyer = 1
+	hash, err := utils.HashListing(orderOpen.Listings[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderOpen.Items[0].ListingHash = hash.B58String()
+
+	applied, err := appliedCoupons(orderOpen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("Expected no coupons applied, got %d", len(applied))
+	}
+
+	// Using the same coupon on two items only redeems it once.
+	orderOpen.Items = append(orderOpen.Items, orderOpen.Items[0])
+	orderOpen.Items[0].CouponCodes = []string{"insider"}
+	applied, err = appliedCoupons(orderOpen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 1 {
+		t.Fatalf("Expected 1 coupon applied, got %d", len(applied))
+	}
+	if applied[0].slug != orderOpen.Listings[0].Listing.Slug {
+		t.Errorf("Expected slug %s, got %s", orderOpen.Listings[0].Listing.Slug, applied[0].slug)
+	}
+
+	buyer1 := orderOpen.BuyerID.PeerID
+	buyer2 := "QmfQkD8pBSBCBxWEwFSu4XaDVSWK6bjnNuaWZjMyQbyDub"
+	buyer3 := "QmTbpxKFfuGHQiXWaYJpzUbGbm6M2aZ2mEDb24YdiHpbEJ"
+
+	err = op.db.Update(func(tx database.Tx) error {
+		if err := op.checkCouponRedemptions(tx, orderOpen, "1"); err != nil {
+			t.Errorf("Coupon check failed: %s", err)
+		}
+		if err := op.redeemCoupons(tx, &models.Order{ID: "1"}, orderOpen); err != nil {
+			return err
+		}
+
+		// Checking the same order again should not count its own redemption.
+		if err := op.checkCouponRedemptions(tx, orderOpen, "1"); err != nil {
+			t.Errorf("Coupon check failed: %s", err)
+		}
+
+		err := op.checkCouponRedemptions(tx, orderOpen, "2")
+		if err == nil || !strings.Contains(err.Error(), "for this buyer") {
+			t.Errorf("Expected per buyer limit error, got %v", err)
+		}
+
+		orderOpen.BuyerID.PeerID = buyer2
+		if err := op.checkCouponRedemptions(tx, orderOpen, "2"); err != nil {
+			t.Errorf("Coupon check failed: %s", err)
+		}
+		if err := op.redeemCoupons(tx, &models.Order{ID: "2"}, orderOpen); err != nil {
+			return err
+		}
+
+		orderOpen.BuyerID.PeerID = buyer3
+		err = op.checkCouponRedemptions(tx, orderOpen, "3")
+		if err == nil || !strings.Contains(err.Error(), "has reached its redemption limit") {
+			t.Errorf("Expected redemption limit error, got %v", err)
+		}
+
+		// Releasing an order frees up a redemption.
+		if err := op.releaseCoupons(tx, &models.Order{ID: "2"}); err != nil {
+			return err
+		}
+		if err := op.checkCouponRedemptions(tx, orderOpen, "3"); err != nil {
+			t.Errorf("Coupon check failed: %s", err)
+		}
+
+		orderOpen.BuyerID.PeerID = buyer1
+		err = op.checkCouponRedemptions(tx, orderOpen, "3")
+		if err == nil || !strings.Contains(err.Error(), "for this buyer") {
+			t.Errorf("Expected per buyer limit error, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,98 @@
+package orders
+
+import (
+	"errors"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/golang/protobuf/ptypes"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"math"
+)
+
+func (op *OrderProcessor) processDisputeCloseMessage(dbtx database.Tx, order *models.Order, peer peer.ID, message *npb.OrderMessage) (interface{}, error) {
+	disputeClose := new(pb.DisputeClose)
+	if err := ptypes.UnmarshalAny(message.Message, disputeClose); err != nil {
+		return nil, err
+	}
+	dup, err := isDuplicate(disputeClose, order.SerializedDisputeClosed)
+	if err != nil {
+		return nil, err
+	}
+	if order.SerializedDisputeClosed != nil && !dup {
+		log.Errorf("Duplicate DISPUTE_CLOSE message does not match original for order: %s", order.ID)
+		return nil, ErrChangedMessage
+	} else if dup {
+		return nil, nil
+	}
+
+	if order.SerializedOrderComplete != nil {
+		log.Warningf("Possible race: Received DISPUTE_CLOSE message for order %s after ORDER_COMPLETE", order.ID)
+	}
+
+	orderOpen, err := order.OrderOpenMessage()
+	if models.IsMessageNotExistError(err) {
+		return nil, order.ParkMessage(message)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := order.DisputeOpenMessage(); models.IsMessageNotExistError(err) {
+		return nil, order.ParkMessage(message)
+	} else if err != nil {
+		return nil, err
+	}
+
+	moderator, err := order.Moderator()
+	if err != nil {
+		return nil, err
+	}
+	if peer != moderator {
+		return nil, errors.New("dispute close message not sent by the moderator")
+	}
+
+	if disputeClose.BuyerPercentage < 0 || disputeClose.VendorPercentage < 0 ||
+		math.Abs(float64(disputeClose.BuyerPercentage+disputeClose.VendorPercentage)-100) > 0.0001 {
+
+		return nil, errors.New("dispute close percentages do not add up to 100")
+	}
+
+	if disputeClose.ReleaseInfo == nil || len(disputeClose.ReleaseInfo.Outputs) == 0 || len(disputeClose.ReleaseInfo.FromIDs) == 0 {
+		return nil, errors.New("dispute close is missing escrow payout")
+	}
+
+	// The buyer and vendor check the moderator paid out the escrowed funds
+	// according to the percentages before accepting the decision.
+	if order.Role() != models.RoleModerator {
+		if err := ValidateDisputePayout(order, disputeClose); err != nil {
+			return nil, err
+		}
+	}
+
+	otherParty := orderOpen.BuyerID
+	if order.Role() == models.RoleBuyer {
+		otherParty = orderOpen.Listings[0].Listing.VendorID
+	}
+
+	event := &events.DisputeClose{
+		OrderID: order.ID.String(),
+		Thumbnail: events.Thumbnail{
+			Tiny:  orderOpen.Listings[0].Listing.Item.Images[0].Tiny,
+			Small: orderOpen.Listings[0].Listing.Item.Images[0].Small,
+		},
+		OtherPartyID:     otherParty.PeerID,
+		OtherPartyHandle: otherParty.Handle,
+		Buyer:            orderOpen.BuyerID.PeerID,
+	}
+
+	if order.Role() == models.RoleModerator {
+		log.Infof("Processed own DISPUTE_CLOSE for orderID: %s", order.ID)
+	} else {
+		log.Infof("Received DISPUTE_CLOSE message for order %s", order.ID)
+	}
+
+	return event, order.PutMessage(message)
+}
@@ -0,0 +1,370 @@
+package orders
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	iwallet "github.com/cpacia/wallet-interface"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/libp2p/go-libp2p-crypto"
+	"github.com/libp2p/go-libp2p-peer"
+	"reflect"
+	"testing"
+)
+
+func TestOrderProcessor_processDisputeCloseMessage(t *testing.T) {
+	op, teardown, err := newMockOrderProcessor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	_, modPub, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moderator, err := peer.IDFromPublicKey(modPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, vendorPub, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vendor, err := peer.IDFromPublicKey(vendorPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orderOpen := &pb.OrderOpen{
+		Listings: []*pb.SignedListing{
+			{
+				Listing: &pb.Listing{
+					VendorID: &pb.ID{
+						PeerID: vendor.Pretty(),
+						Handle: "vendor",
+					},
+					Item: &pb.Listing_Item{
+						Images: []*pb.Listing_Item_Image{
+							{
+								Small: "aaaa",
+								Tiny:  "bbbb",
+							},
+						},
+					},
+				},
+			},
+		},
+		BuyerID: &pb.ID{
+			PeerID: op.identity.Pretty(),
+			Handle: "buyer",
+		},
+		RefundAddress: "abc",
+		Payment: &pb.OrderOpen_Payment{
+			Method:           pb.OrderOpen_Payment_MODERATED,
+			Moderator:        moderator.Pretty(),
+			Address:          "escrow",
+			EscrowReleaseFee: "0",
+			ModeratorFee:     &pb.OrderOpen_Payment_ModeratorFee{Fixed: "0", Percentage: 10},
+		},
+	}
+
+	orderID := "1234"
+
+	disputeClose := &pb.DisputeClose{
+		Timestamp:        ptypes.TimestampNow(),
+		Resolution:       "vendor did not ship",
+		BuyerPercentage:  75,
+		VendorPercentage: 25,
+		ReleaseInfo: &pb.EscrowPayout{
+			FromIDs: [][]byte{{0x01}},
+			Outputs: []*pb.EscrowPayout_Output{
+				{Address: "abc", Amount: "675"},
+				{Address: "def", Amount: "225"},
+				{Address: "mod", Amount: "100"},
+			},
+		},
+	}
+
+	badPercentages := &pb.DisputeClose{
+		BuyerPercentage:  75,
+		VendorPercentage: 75,
+	}
+
+	missingPayout := &pb.DisputeClose{
+		BuyerPercentage:  75,
+		VendorPercentage: 25,
+	}
+
+	wrongAddress := &pb.DisputeClose{
+		BuyerPercentage:  75,
+		VendorPercentage: 25,
+		ReleaseInfo: &pb.EscrowPayout{
+			FromIDs: [][]byte{{0x01}},
+			Outputs: []*pb.EscrowPayout_Output{
+				{Address: "xyz", Amount: "675"},
+				{Address: "def", Amount: "225"},
+				{Address: "mod", Amount: "100"},
+			},
+		},
+	}
+
+	wrongAmount := &pb.DisputeClose{
+		BuyerPercentage:  75,
+		VendorPercentage: 25,
+		ReleaseInfo: &pb.EscrowPayout{
+			FromIDs: [][]byte{{0x01}},
+			Outputs: []*pb.EscrowPayout_Output{
+				{Address: "abc", Amount: "600"},
+				{Address: "def", Amount: "225"},
+				{Address: "mod", Amount: "175"},
+			},
+		},
+	}
+
+	vendorShareTaken := &pb.DisputeClose{
+		BuyerPercentage:  75,
+		VendorPercentage: 25,
+		ReleaseInfo: &pb.EscrowPayout{
+			FromIDs: [][]byte{{0x01}},
+			Outputs: []*pb.EscrowPayout_Output{
+				{Address: "abc", Amount: "675"},
+				{Address: "mod", Amount: "325"},
+			},
+		},
+	}
+
+	excessFee := &pb.DisputeClose{
+		BuyerPercentage:  75,
+		VendorPercentage: 25,
+		ReleaseInfo: &pb.EscrowPayout{
+			FromIDs: [][]byte{{0x01}},
+			Outputs: []*pb.EscrowPayout_Output{
+				{Address: "abc", Amount: "675"},
+				{Address: "def", Amount: "225"},
+				{Address: "mod", Amount: "100"},
+				{Address: "mod2", Amount: "100"},
+			},
+		},
+	}
+
+	unknownInput := &pb.DisputeClose{
+		BuyerPercentage:  75,
+		VendorPercentage: 25,
+		ReleaseInfo: &pb.EscrowPayout{
+			FromIDs: [][]byte{{0x01}, {0x02}},
+			Outputs: []*pb.EscrowPayout_Output{
+				{Address: "abc", Amount: "675"},
+				{Address: "def", Amount: "225"},
+				{Address: "mod", Amount: "100"},
+			},
+		},
+	}
+
+	tests := []struct {
+		setup         func(order *models.Order) error
+		message       *pb.DisputeClose
+		sender        peer.ID
+		expectedError error
+		expectedEvent interface{}
+	}{
+		{
+			// Normal case where dispute open exists.
+			setup: func(order *models.Order) error {
+				order.ID = models.OrderID(orderID)
+				order.SetRole(models.RoleBuyer)
+				if err := order.PutTransaction(iwallet.Transaction{
+					ID: "5678",
+					To: []iwallet.SpendInfo{
+						{
+							ID:      []byte{0x01},
+							Address: iwallet.NewAddress("escrow", iwallet.CtMock),
+							Amount:  iwallet.NewAmount(1000),
+						},
+					},
+				}); err != nil {
+					return err
+				}
+				if err := order.PutMessage(&npb.OrderMessage{
+					Signature:   []byte("abc"),
+					Message:     mustBuildAny(orderOpen),
+					MessageType: npb.OrderMessage_ORDER_OPEN,
+				}); err != nil {
+					return err
+				}
+				return order.PutMessage(&npb.OrderMessage{
+					Signature:   []byte("abc"),
+					Message:     mustBuildAny(&pb.DisputeOpen{}),
+					MessageType: npb.OrderMessage_DISPUTE_OPEN,
+				})
+			},
+			message:       disputeClose,
+			sender:        moderator,
+			expectedError: nil,
+			expectedEvent: &events.DisputeClose{
+				OrderID: orderID,
+				Thumbnail: events.Thumbnail{
+					Tiny:  "bbbb",
+					Small: "aaaa",
+				},
+				OtherPartyID:     vendor.Pretty(),
+				OtherPartyHandle: "vendor",
+				Buyer:            op.identity.Pretty(),
+			},
+		},
+		{
+			// Not sent by the moderator.
+			setup: func(order *models.Order) error {
+				order.SerializedDisputeClosed = nil
+				return nil
+			},
+			message:       disputeClose,
+			sender:        vendor,
+			expectedError: errors.New("dispute close message not sent by the moderator"),
+			expectedEvent: nil,
+		},
+		{
+			// Percentages don't add up.
+			setup: func(order *models.Order) error {
+				return nil
+			},
+			message:       badPercentages,
+			sender:        moderator,
+			expectedError: errors.New("dispute close percentages do not add up to 100"),
+			expectedEvent: nil,
+		},
+		{
+			// Missing payout.
+			setup: func(order *models.Order) error {
+				return nil
+			},
+			message:       missingPayout,
+			sender:        moderator,
+			expectedError: errors.New("dispute close is missing escrow payout"),
+			expectedEvent: nil,
+		},
+		{
+			// Payout doesn't pay the buyer.
+			setup: func(order *models.Order) error {
+				return nil
+			},
+			message:       wrongAddress,
+			sender:        moderator,
+			expectedError: errors.New("dispute close payout does not pay our share"),
+			expectedEvent: nil,
+		},
+		{
+			// Payout underpays the buyer.
+			setup: func(order *models.Order) error {
+				return nil
+			},
+			message:       wrongAmount,
+			sender:        moderator,
+			expectedError: errors.New("dispute close payout does not pay our share"),
+			expectedEvent: nil,
+		},
+		{
+			// Payout redirects the vendor's share to the moderator.
+			setup: func(order *models.Order) error {
+				return nil
+			},
+			message:       vendorShareTaken,
+			sender:        moderator,
+			expectedError: errors.New("dispute close payout does not pay the other party's share"),
+			expectedEvent: nil,
+		},
+		{
+			// Payout pays more than the moderator fee.
+			setup: func(order *models.Order) error {
+				return nil
+			},
+			message:       excessFee,
+			sender:        moderator,
+			expectedError: errors.New("dispute close payout exceeds the moderator fee"),
+			expectedEvent: nil,
+		},
+		{
+			// Payout spends an output that isn't in escrow.
+			setup: func(order *models.Order) error {
+				return nil
+			},
+			message:       unknownInput,
+			sender:        moderator,
+			expectedError: errors.New("dispute close payout spends an unknown escrow output"),
+			expectedEvent: nil,
+		},
+		{
+			// Duplicate dispute close.
+			setup: func(order *models.Order) error {
+				return order.PutMessage(&npb.OrderMessage{
+					Signature:   []byte("abc"),
+					Message:     mustBuildAny(disputeClose),
+					MessageType: npb.OrderMessage_DISPUTE_CLOSE,
+				})
+			},
+			message:       disputeClose,
+			sender:        moderator,
+			expectedError: nil,
+			expectedEvent: nil,
+		},
+		{
+			// Different duplicate dispute close.
+			setup: func(order *models.Order) error {
+				return nil
+			},
+			message:       badPercentages,
+			sender:        moderator,
+			expectedError: ErrChangedMessage,
+			expectedEvent: nil,
+		},
+		{
+			// Out of order.
+			setup: func(order *models.Order) error {
+				order.SerializedDisputeClosed = nil
+				order.SerializedDisputeOpen = nil
+				return nil
+			},
+			message:       disputeClose,
+			sender:        moderator,
+			expectedError: nil,
+			expectedEvent: nil,
+		},
+	}
+
+	order := &models.Order{}
+	for i, test := range tests {
+		if err := test.setup(order); err != nil {
+			t.Errorf("Test %d setup error: %s", i, err)
+			continue
+		}
+		orderMsg := &npb.OrderMessage{
+			OrderID:     orderID,
+			MessageType: npb.OrderMessage_DISPUTE_CLOSE,
+			Message:     mustBuildAny(test.message),
+		}
+		err := op.db.Update(func(tx database.Tx) error {
+			event, err := op.processDisputeCloseMessage(tx, order, test.sender, orderMsg)
+			if test.expectedError != nil {
+				if err == nil || err.Error() != test.expectedError.Error() {
+					return fmt.Errorf("incorrect error returned. Expected %v, got %v", test.expectedError, err)
+				}
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if !reflect.DeepEqual(event, test.expectedEvent) {
+				return fmt.Errorf("incorrect event returned")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf("Error executing db update in test %d: %s", i, err)
+		}
+	}
+}
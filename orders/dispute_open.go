@@ -0,0 +1,204 @@
+package orders
+
+import (
+	"errors"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	iwallet "github.com/cpacia/wallet-interface"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+func (op *OrderProcessor) processDisputeOpenMessage(dbtx database.Tx, order *models.Order, peer peer.ID, message *npb.OrderMessage) (interface{}, error) {
+	order.ID = models.OrderID(message.OrderID)
+
+	disputeOpen := new(pb.DisputeOpen)
+	if err := ptypes.UnmarshalAny(message.Message, disputeOpen); err != nil {
+		return nil, err
+	}
+	dup, err := isDuplicate(disputeOpen, order.SerializedDisputeOpen)
+	if err != nil {
+		return nil, err
+	}
+	if order.SerializedDisputeOpen != nil && !dup {
+		log.Errorf("Duplicate DISPUTE_OPEN message does not match original for order: %s", order.ID)
+		return nil, ErrChangedMessage
+	} else if dup {
+		return nil, nil
+	}
+
+	if order.SerializedDisputeClosed != nil {
+		log.Errorf("Received DISPUTE_OPEN message for order %s after DISPUTE_CLOSE", order.ID)
+		return nil, ErrUnexpectedMessage
+	}
+
+	orderOpen, err := order.OrderOpenMessage()
+	if models.IsMessageNotExistError(err) {
+		// If we don't have the order it's likely because we are the moderator
+		// and this is the first we're hearing about it. The order open is taken
+		// from the disputer's contract.
+		orderOpen, err = orderOpenFromContract(order.ID, disputeOpen.Contract)
+		if err != nil {
+			return nil, err
+		}
+		if orderOpen.Payment == nil || orderOpen.Payment.Moderator != op.identity.Pretty() {
+			return nil, order.ParkMessage(message)
+		}
+		if err := op.initializeModeratorOrder(dbtx, order, disputeOpen.Contract); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if orderOpen.Payment.Method != pb.OrderOpen_Payment_MODERATED {
+		return nil, errors.New("dispute opened on an order that is not moderated")
+	}
+
+	var (
+		buyer    = orderOpen.BuyerID
+		vendor   = orderOpen.Listings[0].Listing.VendorID
+		disputer = buyer
+		disputee = vendor
+	)
+	if disputeOpen.OpenedBy == pb.DisputeOpen_VENDOR {
+		disputer, disputee = vendor, buyer
+	}
+	if peer.Pretty() != disputer.PeerID {
+		return nil, errors.New("dispute open message not sent by the disputer")
+	}
+
+	if order.Role() != models.RoleModerator {
+		if _, err := orderOpenFromContract(order.ID, disputeOpen.Contract); err != nil {
+			return nil, err
+		}
+	}
+
+	// If we are the other party to the dispute we need to send our copy of the
+	// order to the moderator.
+	if order.Role() != models.RoleModerator && peer != op.identity {
+		if err := op.sendDisputeUpdate(dbtx, order); err != nil {
+			return nil, err
+		}
+	}
+
+	event := &events.DisputeOpen{
+		OrderID: order.ID.String(),
+		Thumbnail: events.Thumbnail{
+			Tiny:  orderOpen.Listings[0].Listing.Item.Images[0].Tiny,
+			Small: orderOpen.Listings[0].Listing.Item.Images[0].Small,
+		},
+		DisputerID:     disputer.PeerID,
+		DisputerHandle: disputer.Handle,
+		DisputeeID:     disputee.PeerID,
+		DisputeeHandle: disputee.Handle,
+		Buyer:          buyer.PeerID,
+	}
+
+	if peer == op.identity {
+		log.Infof("Processed own DISPUTE_OPEN for orderID: %s", order.ID)
+	} else {
+		log.Infof("Received DISPUTE_OPEN message for order %s", order.ID)
+	}
+
+	return event, order.PutMessage(message)
+}
+
+// initializeModeratorOrder sets up a new order for the moderator using the
+// contract provided in a dispute message. The funding transactions are loaded
+// from the wallet so the moderator can evaluate the escrowed funds.
+func (op *OrderProcessor) initializeModeratorOrder(dbtx database.Tx, order *models.Order, contract []byte) error {
+	orderList := new(npb.OrderList)
+	if err := proto.Unmarshal(contract, orderList); err != nil {
+		return err
+	}
+	for _, m := range orderList.Messages {
+		if m.MessageType == npb.OrderMessage_ORDER_OPEN {
+			if err := order.PutMessage(m); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	orderOpen, err := order.OrderOpenMessage()
+	if err != nil {
+		return err
+	}
+
+	order.SetRole(models.RoleModerator)
+	order.Open = true
+	order.PaymentAddress = orderOpen.Payment.Address
+
+	wallet, err := op.multiwallet.WalletForCurrencyCode(orderOpen.Payment.Coin)
+	if err != nil {
+		return err
+	}
+	addr := iwallet.NewAddress(orderOpen.Payment.Address, iwallet.CoinType(orderOpen.Payment.Coin))
+	wtx, err := wallet.Begin()
+	if err != nil {
+		return err
+	}
+	if err := wallet.WatchAddress(wtx, addr); err != nil {
+		return err
+	}
+	if err := wtx.Commit(); err != nil {
+		return err
+	}
+
+	// If this fails it's OK as the processor's payment checking loop will
+	// retry at it's next interval.
+	txs, err := wallet.GetAddressTransactions(addr)
+	if err != nil {
+		log.Errorf("Error loading transactions for disputed order %s: %s", order.ID, err)
+		return nil
+	}
+	for _, tx := range txs {
+		if err := order.PutTransaction(tx); err != nil && !models.IsDuplicateTransactionError(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// orderOpenFromContract extracts the order open message from the serialized
+// contract, validates the signature and checks that it matches the order ID.
+func orderOpenFromContract(orderID models.OrderID, contract []byte) (*pb.OrderOpen, error) {
+	orderList := new(npb.OrderList)
+	if err := proto.Unmarshal(contract, orderList); err != nil {
+		return nil, err
+	}
+	for _, m := range orderList.Messages {
+		if m.MessageType != npb.OrderMessage_ORDER_OPEN {
+			continue
+		}
+		orderOpen := new(pb.OrderOpen)
+		if err := ptypes.UnmarshalAny(m.Message, orderOpen); err != nil {
+			return nil, err
+		}
+		if orderOpen.BuyerID == nil || orderOpen.Payment == nil || len(orderOpen.Listings) == 0 ||
+			orderOpen.Listings[0].Listing == nil || orderOpen.Listings[0].Listing.VendorID == nil {
+			return nil, errors.New("contract order open is malformed")
+		}
+		orderHash, err := utils.CalcOrderID(orderOpen)
+		if err != nil {
+			return nil, err
+		}
+		if orderHash.B58String() != orderID.String() {
+			return nil, errors.New("contract order ID does not match")
+		}
+		buyer, err := peer.IDB58Decode(orderOpen.BuyerID.PeerID)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifyOrderMessageSignature(buyer, m); err != nil {
+			return nil, err
+		}
+		return orderOpen, nil
+	}
+	return nil, errors.New("contract does not contain an order open message")
+}
@@ -0,0 +1,272 @@
+package orders
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	iwallet "github.com/cpacia/wallet-interface"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/libp2p/go-libp2p-crypto"
+	"github.com/libp2p/go-libp2p-peer"
+	"reflect"
+	"testing"
+)
+
+// mockDisputeContract builds a moderated order open signed by the buyer
+// and returns it along with the serialized contract and order ID.
+func mockDisputeContract(buyerKey crypto.PrivKey, vendorID, moderatorID peer.ID) (*pb.OrderOpen, *npb.OrderMessage, []byte, models.OrderID, error) {
+	buyerID, err := peer.IDFromPrivateKey(buyerKey)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	orderOpen := &pb.OrderOpen{
+		Listings: []*pb.SignedListing{
+			{
+				Listing: &pb.Listing{
+					VendorID: &pb.ID{
+						PeerID: vendorID.Pretty(),
+						Handle: "vendor",
+					},
+					Item: &pb.Listing_Item{
+						Images: []*pb.Listing_Item_Image{
+							{
+								Small: "aaaa",
+								Tiny:  "bbbb",
+							},
+						},
+					},
+				},
+			},
+		},
+		BuyerID: &pb.ID{
+			PeerID: buyerID.Pretty(),
+			Handle: "buyer",
+		},
+		Payment: &pb.OrderOpen_Payment{
+			Coin:      iwallet.CtMock,
+			Method:    pb.OrderOpen_Payment_MODERATED,
+			Moderator: moderatorID.Pretty(),
+			Address:   "abc",
+		},
+	}
+
+	orderHash, err := utils.CalcOrderID(orderOpen)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	orderID := models.OrderID(orderHash.B58String())
+
+	orderOpenMsg := &npb.OrderMessage{
+		OrderID:     orderID.String(),
+		MessageType: npb.OrderMessage_ORDER_OPEN,
+		Message:     mustBuildAny(orderOpen),
+	}
+	if err := utils.SignOrderMessage(orderOpenMsg, buyerKey); err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	contract, err := proto.Marshal(&npb.OrderList{Messages: []*npb.OrderMessage{orderOpenMsg}})
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	return orderOpen, orderOpenMsg, contract, orderID, nil
+}
+
+func TestOrderProcessor_processDisputeOpenMessage(t *testing.T) {
+	op, teardown, err := newMockOrderProcessor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	buyerKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buyer, err := peer.IDFromPrivateKey(buyerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherPub, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := peer.IDFromPublicKey(otherPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		// setup returns the peer who sent the dispute and the moderator.
+		setup         func(order *models.Order) (peer.ID, peer.ID, error)
+		expectedError error
+		expectedEvent func(orderID models.OrderID) interface{}
+		check         func(order *models.Order) error
+	}{
+		{
+			// We are the moderator and don't have the order yet.
+			setup: func(order *models.Order) (peer.ID, peer.ID, error) {
+				return buyer, op.identity, nil
+			},
+			expectedError: nil,
+			expectedEvent: func(orderID models.OrderID) interface{} {
+				return &events.DisputeOpen{
+					OrderID: orderID.String(),
+					Thumbnail: events.Thumbnail{
+						Tiny:  "bbbb",
+						Small: "aaaa",
+					},
+					DisputerID:     buyer.Pretty(),
+					DisputerHandle: "buyer",
+					DisputeeID:     other.Pretty(),
+					DisputeeHandle: "vendor",
+					Buyer:          buyer.Pretty(),
+				}
+			},
+			check: func(order *models.Order) error {
+				if order.Role() != models.RoleModerator {
+					return errors.New("role not set to moderator")
+				}
+				if order.SerializedOrderOpen == nil || order.SerializedDisputeOpen == nil {
+					return errors.New("order messages not saved")
+				}
+				if !order.Open {
+					return errors.New("order not set to open")
+				}
+				return nil
+			},
+		},
+		{
+			// We are not the moderator and don't have the order.
+			setup: func(order *models.Order) (peer.ID, peer.ID, error) {
+				return buyer, other, nil
+			},
+			expectedError: nil,
+			expectedEvent: func(orderID models.OrderID) interface{} { return nil },
+			check: func(order *models.Order) error {
+				parked, err := order.GetParkedMessages()
+				if err != nil {
+					return err
+				}
+				if len(parked) != 1 {
+					return errors.New("message not parked")
+				}
+				return nil
+			},
+		},
+		{
+			// We are the vendor and must send a dispute update.
+			setup: func(order *models.Order) (peer.ID, peer.ID, error) {
+				return buyer, other, nil
+			},
+			expectedError: nil,
+			expectedEvent: func(orderID models.OrderID) interface{} {
+				return &events.DisputeOpen{
+					OrderID: orderID.String(),
+					Thumbnail: events.Thumbnail{
+						Tiny:  "bbbb",
+						Small: "aaaa",
+					},
+					DisputerID:     buyer.Pretty(),
+					DisputerHandle: "buyer",
+					DisputeeID:     op.identity.Pretty(),
+					DisputeeHandle: "vendor",
+					Buyer:          buyer.Pretty(),
+				}
+			},
+			check: func(order *models.Order) error {
+				if order.SerializedDisputeUpdate == nil {
+					return errors.New("dispute update not saved")
+				}
+				return nil
+			},
+		},
+		{
+			// Dispute not sent by the disputer.
+			setup: func(order *models.Order) (peer.ID, peer.ID, error) {
+				return other, op.identity, nil
+			},
+			expectedError: errors.New("dispute open message not sent by the disputer"),
+			expectedEvent: func(orderID models.OrderID) interface{} { return nil },
+		},
+		{
+			// Dispute already closed.
+			setup: func(order *models.Order) (peer.ID, peer.ID, error) {
+				order.SerializedDisputeClosed = []byte{0x00}
+				return buyer, op.identity, nil
+			},
+			expectedError: ErrUnexpectedMessage,
+			expectedEvent: func(orderID models.OrderID) interface{} { return nil },
+		},
+	}
+
+	for i, test := range tests {
+		order := &models.Order{}
+		sender, moderator, err := test.setup(order)
+		if err != nil {
+			t.Errorf("Test %d setup error: %s", i, err)
+			continue
+		}
+
+		vendor := other
+		if moderator == other {
+			vendor = op.identity
+		}
+
+		_, orderOpenMsg, contract, orderID, err := mockDisputeContract(buyerKey, vendor, moderator)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The vendor case already has the order.
+		if i == 2 {
+			order.ID = orderID
+			if err := order.PutMessage(orderOpenMsg); err != nil {
+				t.Fatal(err)
+			}
+			order.SetRole(models.RoleVendor)
+		}
+
+		disputeOpen := &pb.DisputeOpen{
+			Timestamp: ptypes.TimestampNow(),
+			OpenedBy:  pb.DisputeOpen_BUYER,
+			Reason:    "item never arrived",
+			Contract:  contract,
+		}
+		orderMsg := &npb.OrderMessage{
+			OrderID:     orderID.String(),
+			MessageType: npb.OrderMessage_DISPUTE_OPEN,
+			Message:     mustBuildAny(disputeOpen),
+		}
+
+		err = op.db.Update(func(tx database.Tx) error {
+			event, err := op.processDisputeOpenMessage(tx, order, sender, orderMsg)
+			if test.expectedError != nil {
+				if err == nil || err.Error() != test.expectedError.Error() {
+					return fmt.Errorf("incorrect error returned. Expected %v, got %v", test.expectedError, err)
+				}
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if !reflect.DeepEqual(event, test.expectedEvent(orderID)) {
+				return fmt.Errorf("incorrect event returned")
+			}
+			if test.check != nil {
+				return test.check(order)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf("Error executing db update in test %d: %s", i, err)
+		}
+	}
+}
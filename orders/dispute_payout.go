@@ -0,0 +1,186 @@
+package orders
+
+import (
+	"encoding/hex"
+	"errors"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	iwallet "github.com/cpacia/wallet-interface"
+	"math/big"
+)
+
+// PercentageOf returns the given percentage of the amount rounded down.
+func PercentageOf(amount iwallet.Amount, percentage float32) iwallet.Amount {
+	f, _ := new(big.Float).SetString(amount.String())
+	f.Mul(f, big.NewFloat(float64(percentage)/100))
+	i, _ := f.Int(nil)
+	return iwallet.NewAmount(i)
+}
+
+// ModeratorFee returns the moderator's fee for the given amount of escrowed
+// funds using the fee recorded in the order when it was placed. The fee is
+// capped at the amount available.
+func ModeratorFee(orderOpen *pb.OrderOpen, available iwallet.Amount) iwallet.Amount {
+	fee := iwallet.NewAmount(0)
+	if orderOpen.Payment == nil || orderOpen.Payment.ModeratorFee == nil {
+		return fee
+	}
+	feeInfo := orderOpen.Payment.ModeratorFee
+	if feeInfo.Fixed != "" {
+		fee = fee.Add(iwallet.NewAmount(feeInfo.Fixed))
+	}
+	fee = fee.Add(PercentageOf(available, feeInfo.Percentage))
+
+	if fee.Cmp(available) > 0 {
+		fee = available
+	}
+	return fee
+}
+
+// DisputePayoutAmounts returns the amounts paid to the buyer, the vendor and
+// the moderator when a dispute over the given amount of escrowed funds is
+// closed. The transaction fee and the moderator's fee are taken off the top
+// and the remainder is split between the buyer and vendor.
+func DisputePayoutAmounts(orderOpen *pb.OrderOpen, totalIn iwallet.Amount, buyerPercentage float32) (buyer, vendor, moderator iwallet.Amount, err error) {
+	available := totalIn.Sub(iwallet.NewAmount(orderOpen.Payment.EscrowReleaseFee))
+	if available.Cmp(iwallet.NewAmount(0)) <= 0 {
+		return buyer, vendor, moderator, errors.New("escrowed funds do not cover the transaction fee")
+	}
+
+	moderator = ModeratorFee(orderOpen, available)
+	remaining := available.Sub(moderator)
+	buyer = PercentageOf(remaining, buyerPercentage)
+	vendor = remaining.Sub(buyer)
+	return buyer, vendor, moderator, nil
+}
+
+// DisputePayoutAddress returns the address the given party asked to be paid to.
+// The vendor falls back to the address from their fulfillment if they never
+// responded to the dispute.
+func DisputePayoutAddress(order *models.Order, party pb.DisputeOpen_Party) (string, error) {
+	orderOpen, err := order.OrderOpenMessage()
+	if err != nil {
+		return "", err
+	}
+	disputeOpen, err := order.DisputeOpenMessage()
+	if err != nil {
+		return "", err
+	}
+	if disputeOpen.OpenedBy == party && disputeOpen.PayoutAddress != "" {
+		return disputeOpen.PayoutAddress, nil
+	}
+	if disputeOpen.OpenedBy != party {
+		disputeUpdate, err := order.DisputeUpdateMessage()
+		if err == nil && disputeUpdate.PayoutAddress != "" {
+			return disputeUpdate.PayoutAddress, nil
+		}
+	}
+	if party == pb.DisputeOpen_BUYER {
+		return orderOpen.RefundAddress, nil
+	}
+	fulfillments, err := order.OrderFulfillmentMessages()
+	if err == nil {
+		for _, f := range fulfillments {
+			if f.ReleaseInfo != nil && f.ReleaseInfo.ToAddress != "" {
+				return f.ReleaseInfo.ToAddress, nil
+			}
+		}
+	}
+	return "", errors.New("vendor payout address is unknown")
+}
+
+// ValidateDisputePayout checks the moderator's payout transaction against the
+// percentages in the dispute close. The amount paid to our own address must be
+// exactly our share and the remaining outputs may only pay the other party's
+// share and the moderator's fee.
+func ValidateDisputePayout(order *models.Order, disputeClose *pb.DisputeClose) error {
+	orderOpen, err := order.OrderOpenMessage()
+	if err != nil {
+		return err
+	}
+
+	payout := disputeClose.ReleaseInfo
+	if payout == nil || len(payout.Outputs) == 0 || len(payout.FromIDs) == 0 {
+		return errors.New("dispute close is missing escrow payout")
+	}
+
+	txs, err := order.GetTransactions()
+	if err != nil && !models.IsMessageNotExistError(err) {
+		return err
+	}
+	escrowed := make(map[string]iwallet.Amount)
+	for _, tx := range txs {
+		for _, to := range tx.To {
+			if to.Address.String() == orderOpen.Payment.Address {
+				escrowed[hex.EncodeToString(to.ID)] = to.Amount
+			}
+		}
+	}
+	totalIn := iwallet.NewAmount(0)
+	spent := make(map[string]bool)
+	for _, id := range payout.FromIDs {
+		key := hex.EncodeToString(id)
+		amount, ok := escrowed[key]
+		if !ok || spent[key] {
+			return errors.New("dispute close payout spends an unknown escrow output")
+		}
+		spent[key] = true
+		totalIn = totalIn.Add(amount)
+	}
+
+	buyerAmount, vendorAmount, moderatorFee, err := DisputePayoutAmounts(orderOpen, totalIn, disputeClose.BuyerPercentage)
+	if err != nil {
+		return err
+	}
+
+	ourParty, otherParty := pb.DisputeOpen_BUYER, pb.DisputeOpen_VENDOR
+	ourAmount, otherAmount := buyerAmount, vendorAmount
+	if order.Role() == models.RoleVendor {
+		ourParty, otherParty = otherParty, ourParty
+		ourAmount, otherAmount = otherAmount, ourAmount
+	}
+	ourAddress, err := DisputePayoutAddress(order, ourParty)
+	if err != nil {
+		return err
+	}
+	// We may not know the other party's address if they didn't open the
+	// dispute so in that case their output is matched by amount.
+	otherAddress, _ := DisputePayoutAddress(order, otherParty)
+
+	var (
+		foundOurs, foundOthers bool
+		rest                   = iwallet.NewAmount(0)
+		restOutputs            int
+		zero                   = iwallet.NewAmount(0)
+	)
+	for _, out := range payout.Outputs {
+		amount, ok := new(big.Int).SetString(out.Amount, 10)
+		if !ok || amount.Sign() <= 0 {
+			return errors.New("dispute close payout contains invalid amount")
+		}
+		amt := iwallet.NewAmount(amount)
+		switch {
+		case out.Address == ourAddress:
+			if foundOurs || amt.Cmp(ourAmount) != 0 {
+				return errors.New("dispute close payout does not pay our share")
+			}
+			foundOurs = true
+		case !foundOthers && otherAmount.Cmp(zero) > 0 && amt.Cmp(otherAmount) == 0 &&
+			(otherAddress == "" || out.Address == otherAddress):
+			foundOthers = true
+		default:
+			rest = rest.Add(amt)
+			restOutputs++
+		}
+	}
+	if ourAmount.Cmp(zero) > 0 && !foundOurs {
+		return errors.New("dispute close payout does not pay our share")
+	}
+	if otherAmount.Cmp(zero) > 0 && !foundOthers {
+		return errors.New("dispute close payout does not pay the other party's share")
+	}
+	if restOutputs > 1 || rest.Cmp(moderatorFee) > 0 {
+		return errors.New("dispute close payout exceeds the moderator fee")
+	}
+	return nil
+}
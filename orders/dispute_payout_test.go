@@ -0,0 +1,87 @@
+package orders
+
+import (
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	iwallet "github.com/cpacia/wallet-interface"
+	"testing"
+)
+
+func TestDisputePayoutAmounts(t *testing.T) {
+	tests := []struct {
+		name            string
+		fee             *pb.OrderOpen_Payment_ModeratorFee
+		totalIn         int
+		buyerPercentage float32
+		buyer           string
+		vendor          string
+		moderator       string
+		valid           bool
+	}{
+		{
+			name:            "No moderator fee",
+			totalIn:         1010,
+			buyerPercentage: 50,
+			buyer:           "500",
+			vendor:          "500",
+			moderator:       "0",
+			valid:           true,
+		},
+		{
+			name:            "Percentage fee",
+			fee:             &pb.OrderOpen_Payment_ModeratorFee{Fixed: "0", Percentage: 10},
+			totalIn:         1010,
+			buyerPercentage: 100,
+			buyer:           "900",
+			vendor:          "0",
+			moderator:       "100",
+			valid:           true,
+		},
+		{
+			name:            "Fixed plus percentage fee",
+			fee:             &pb.OrderOpen_Payment_ModeratorFee{Fixed: "50", Percentage: 10},
+			totalIn:         1010,
+			buyerPercentage: 25,
+			buyer:           "212",
+			vendor:          "638",
+			moderator:       "150",
+			valid:           true,
+		},
+		{
+			name:            "Fee capped at available",
+			fee:             &pb.OrderOpen_Payment_ModeratorFee{Fixed: "5000"},
+			totalIn:         1010,
+			buyerPercentage: 50,
+			buyer:           "0",
+			vendor:          "0",
+			moderator:       "1000",
+			valid:           true,
+		},
+		{
+			name:    "Does not cover transaction fee",
+			totalIn: 10,
+			valid:   false,
+		},
+	}
+
+	for _, test := range tests {
+		orderOpen := &pb.OrderOpen{
+			Payment: &pb.OrderOpen_Payment{
+				EscrowReleaseFee: "10",
+				ModeratorFee:     test.fee,
+			},
+		}
+		buyer, vendor, moderator, err := DisputePayoutAmounts(orderOpen, iwallet.NewAmount(test.totalIn), test.buyerPercentage)
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+			continue
+		} else if !test.valid {
+			if err == nil {
+				t.Errorf("%s: expected error", test.name)
+			}
+			continue
+		}
+		if buyer.String() != test.buyer || vendor.String() != test.vendor || moderator.String() != test.moderator {
+			t.Errorf("%s: expected %s/%s/%s, got %s/%s/%s", test.name, test.buyer, test.vendor, test.moderator, buyer, vendor, moderator)
+		}
+	}
+}
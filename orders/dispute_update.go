@@ -0,0 +1,171 @@
+package orders
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+func (op *OrderProcessor) processDisputeUpdateMessage(dbtx database.Tx, order *models.Order, peer peer.ID, message *npb.OrderMessage) (interface{}, error) {
+	disputeUpdate := new(pb.DisputeUpdate)
+	if err := ptypes.UnmarshalAny(message.Message, disputeUpdate); err != nil {
+		return nil, err
+	}
+	dup, err := isDuplicate(disputeUpdate, order.SerializedDisputeUpdate)
+	if err != nil {
+		return nil, err
+	}
+	if order.SerializedDisputeUpdate != nil && !dup {
+		log.Errorf("Duplicate DISPUTE_UPDATE message does not match original for order: %s", order.ID)
+		return nil, ErrChangedMessage
+	} else if dup {
+		return nil, nil
+	}
+
+	orderOpen, err := order.OrderOpenMessage()
+	if models.IsMessageNotExistError(err) {
+		return nil, order.ParkMessage(message)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	disputeOpen, err := order.DisputeOpenMessage()
+	if models.IsMessageNotExistError(err) {
+		return nil, order.ParkMessage(message)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The disputee sends the update to the moderator. They also save a copy
+	// of it when it's sent.
+	var (
+		buyer    = orderOpen.BuyerID
+		vendor   = orderOpen.Listings[0].Listing.VendorID
+		disputer = buyer
+		disputee = vendor
+	)
+	if disputeOpen.OpenedBy == pb.DisputeOpen_VENDOR {
+		disputer, disputee = vendor, buyer
+	}
+	if peer.Pretty() != disputee.PeerID {
+		return nil, errors.New("dispute update message not sent by the disputee")
+	}
+
+	if _, err := orderOpenFromContract(order.ID, disputeUpdate.Contract); err != nil {
+		return nil, err
+	}
+
+	event := &events.DisputeUpdate{
+		OrderID: order.ID.String(),
+		Thumbnail: events.Thumbnail{
+			Tiny:  orderOpen.Listings[0].Listing.Item.Images[0].Tiny,
+			Small: orderOpen.Listings[0].Listing.Item.Images[0].Small,
+		},
+		DisputerID:     disputer.PeerID,
+		DisputerHandle: disputer.Handle,
+		DisputeeID:     disputee.PeerID,
+		DisputeeHandle: disputee.Handle,
+		Buyer:          buyer.PeerID,
+	}
+
+	if peer == op.identity {
+		log.Infof("Processed own DISPUTE_UPDATE for orderID: %s", order.ID)
+	} else {
+		log.Infof("Received DISPUTE_UPDATE message for order %s", order.ID)
+	}
+
+	return event, order.PutMessage(message)
+}
+
+// sendDisputeUpdate sends our copy of the contract to the moderator. This is
+// done by the disputee right after the dispute is opened.
+func (op *OrderProcessor) sendDisputeUpdate(dbtx database.Tx, order *models.Order) error {
+	contract, err := order.Contract()
+	if err != nil {
+		return err
+	}
+
+	ser, err := proto.Marshal(contract)
+	if err != nil {
+		return err
+	}
+
+	orderOpen, err := order.OrderOpenMessage()
+	if err != nil {
+		return err
+	}
+
+	// The buyer is paid out to their refund address while the vendor
+	// uses a fresh address from their wallet.
+	payoutAddress := orderOpen.RefundAddress
+	if order.Role() == models.RoleVendor {
+		wallet, err := op.multiwallet.WalletForCurrencyCode(orderOpen.Payment.Coin)
+		if err != nil {
+			return err
+		}
+		addr, err := wallet.CurrentAddress()
+		if err != nil {
+			return err
+		}
+		payoutAddress = addr.String()
+	}
+
+	update := &pb.DisputeUpdate{
+		Timestamp:     ptypes.TimestampNow(),
+		Contract:      ser,
+		PayoutAddress: payoutAddress,
+	}
+
+	updateAny, err := ptypes.MarshalAny(update)
+	if err != nil {
+		return err
+	}
+
+	om := npb.OrderMessage{
+		OrderID:     order.ID.String(),
+		MessageType: npb.OrderMessage_DISPUTE_UPDATE,
+		Message:     updateAny,
+	}
+
+	if err := utils.SignOrderMessage(&om, op.identityPrivateKey); err != nil {
+		return err
+	}
+
+	payload, err := ptypes.MarshalAny(&om)
+	if err != nil {
+		return err
+	}
+
+	messageID := make([]byte, 20)
+	if _, err := rand.Read(messageID); err != nil {
+		return err
+	}
+
+	message := npb.Message{
+		MessageType: npb.Message_ORDER,
+		MessageID:   hex.EncodeToString(messageID),
+		Payload:     payload,
+	}
+
+	moderator, err := order.Moderator()
+	if err != nil {
+		return err
+	}
+
+	if err := op.messenger.ReliablySendMessage(dbtx, moderator, &message, nil); err != nil {
+		return err
+	}
+
+	return order.PutMessage(&om)
+}
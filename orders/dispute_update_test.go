@@ -0,0 +1,162 @@
+package orders
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/libp2p/go-libp2p-crypto"
+	"github.com/libp2p/go-libp2p-peer"
+	"reflect"
+	"testing"
+)
+
+func TestOrderProcessor_processDisputeUpdateMessage(t *testing.T) {
+	op, teardown, err := newMockOrderProcessor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	buyerKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buyer, err := peer.IDFromPrivateKey(buyerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, vendorPub, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vendor, err := peer.IDFromPublicKey(vendorPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, orderOpenMsg, contract, orderID, err := mockDisputeContract(buyerKey, vendor, op.identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	disputeOpen := &pb.DisputeOpen{
+		Timestamp: ptypes.TimestampNow(),
+		OpenedBy:  pb.DisputeOpen_BUYER,
+		Contract:  contract,
+	}
+
+	disputeUpdate := &pb.DisputeUpdate{
+		Timestamp: ptypes.TimestampNow(),
+		Contract:  contract,
+	}
+
+	orderMsg := &npb.OrderMessage{
+		OrderID:     orderID.String(),
+		MessageType: npb.OrderMessage_DISPUTE_UPDATE,
+		Message:     mustBuildAny(disputeUpdate),
+	}
+
+	tests := []struct {
+		setup         func(order *models.Order) error
+		sender        peer.ID
+		expectedError error
+		expectedEvent interface{}
+	}{
+		{
+			// Normal case where dispute open exists.
+			setup: func(order *models.Order) error {
+				order.ID = orderID
+				order.SetRole(models.RoleModerator)
+				if err := order.PutMessage(orderOpenMsg); err != nil {
+					return err
+				}
+				return order.PutMessage(&npb.OrderMessage{
+					Signature:   []byte("abc"),
+					Message:     mustBuildAny(disputeOpen),
+					MessageType: npb.OrderMessage_DISPUTE_OPEN,
+				})
+			},
+			sender:        vendor,
+			expectedError: nil,
+			expectedEvent: &events.DisputeUpdate{
+				OrderID: orderID.String(),
+				Thumbnail: events.Thumbnail{
+					Tiny:  "bbbb",
+					Small: "aaaa",
+				},
+				DisputerID:     buyer.Pretty(),
+				DisputerHandle: "buyer",
+				DisputeeID:     vendor.Pretty(),
+				DisputeeHandle: "vendor",
+				Buyer:          buyer.Pretty(),
+			},
+		},
+		{
+			// Update not sent by the disputee.
+			setup: func(order *models.Order) error {
+				order.SerializedDisputeUpdate = nil
+				return nil
+			},
+			sender:        buyer,
+			expectedError: errors.New("dispute update message not sent by the disputee"),
+			expectedEvent: nil,
+		},
+		{
+			// Duplicate dispute update.
+			setup: func(order *models.Order) error {
+				return order.PutMessage(&npb.OrderMessage{
+					Signature:   []byte("abc"),
+					Message:     mustBuildAny(disputeUpdate),
+					MessageType: npb.OrderMessage_DISPUTE_UPDATE,
+				})
+			},
+			sender:        vendor,
+			expectedError: nil,
+			expectedEvent: nil,
+		},
+		{
+			// Out of order.
+			setup: func(order *models.Order) error {
+				order.SerializedDisputeUpdate = nil
+				order.SerializedDisputeOpen = nil
+				return nil
+			},
+			sender:        vendor,
+			expectedError: nil,
+			expectedEvent: nil,
+		},
+	}
+
+	order := &models.Order{}
+	for i, test := range tests {
+		if err := test.setup(order); err != nil {
+			t.Errorf("Test %d setup error: %s", i, err)
+			continue
+		}
+		err := op.db.Update(func(tx database.Tx) error {
+			event, err := op.processDisputeUpdateMessage(tx, order, test.sender, orderMsg)
+			if test.expectedError != nil {
+				if err == nil || err.Error() != test.expectedError.Error() {
+					return fmt.Errorf("incorrect error returned. Expected %v, got %v", test.expectedError, err)
+				}
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if !reflect.DeepEqual(event, test.expectedEvent) {
+				return fmt.Errorf("incorrect event returned")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf("Error executing db update in test %d: %s", i, err)
+		}
+	}
+}
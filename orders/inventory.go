@@ -0,0 +1,196 @@
+package orders
+
+import (
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/jinzhu/gorm"
+	"strconv"
+)
+
+// OrderInventory returns the quantity of each listing SKU purchased in
+// the order. Cryptocurrency listings are skipped as their quantity is an
+// amount of coins rather than a number of units.
+func OrderInventory(orderOpen *pb.OrderOpen) ([]models.InventoryReservation, error) {
+	var (
+		reservations []models.InventoryReservation
+		index        = make(map[string]int)
+	)
+	for i, item := range orderOpen.Items {
+		listing, err := extractListing(item.ListingHash, orderOpen.Listings)
+		if err != nil {
+			return nil, err
+		}
+		if listing.Metadata.ContractType == pb.Listing_Metadata_CRYPTOCURRENCY {
+			continue
+		}
+		sku, err := getSelectedSku(listing, item.Options)
+		if err != nil {
+			return nil, err
+		}
+		quantity, err := strconv.ParseInt(item.Quantity, 10, 64)
+		if err != nil || quantity <= 0 {
+			return nil, fmt.Errorf("item %d quantity must be a positive integer", i)
+		}
+
+		key := models.SKUKey(sku)
+		if j, ok := index[listing.Slug+"/"+key]; ok {
+			reservations[j].Quantity += quantity
+			continue
+		}
+		index[listing.Slug+"/"+key] = len(reservations)
+		reservations = append(reservations, models.InventoryReservation{
+			Slug:     listing.Slug,
+			SKU:      key,
+			Quantity: quantity,
+		})
+	}
+	return reservations, nil
+}
+
+// getInventoryItem loads the inventory for the listing SKU. If we do not
+// have any inventory saved for it, it is returned as not tracked.
+func getInventoryItem(dbtx database.Tx, slug, sku string) (*models.InventoryItem, error) {
+	var item models.InventoryItem
+	err := dbtx.Read().Where("slug = ? AND sku = ?", slug, sku).First(&item).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return &models.InventoryItem{Slug: slug, SKU: sku, Quantity: -1}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// checkInventory returns an error if any of the items in the order exceed
+// the stock we have available.
+func (op *OrderProcessor) checkInventory(dbtx database.Tx, orderOpen *pb.OrderOpen) error {
+	needed, err := OrderInventory(orderOpen)
+	if err != nil {
+		return err
+	}
+	for _, n := range needed {
+		item, err := getInventoryItem(dbtx, n.Slug, n.SKU)
+		if err != nil {
+			return err
+		}
+		if item.Tracked() && n.Quantity > item.Available() {
+			return fmt.Errorf("insufficient inventory for item %s: %d available", n.Slug, item.Available())
+		}
+	}
+	return nil
+}
+
+// reserveInventory holds the stock purchased in the order so that it
+// cannot be sold again while the vendor confirms the order. It does
+// nothing if the order has already reserved its stock.
+func (op *OrderProcessor) reserveInventory(dbtx database.Tx, order *models.Order) error {
+	var existing []models.InventoryReservation
+	if err := dbtx.Read().Where("order_id = ?", order.ID.String()).Find(&existing).Error; err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	orderOpen, err := order.OrderOpenMessage()
+	if err != nil {
+		return err
+	}
+	needed, err := OrderInventory(orderOpen)
+	if err != nil {
+		return err
+	}
+	for _, reservation := range needed {
+		reservation.OrderID = order.ID.String()
+		item, err := getInventoryItem(dbtx, reservation.Slug, reservation.SKU)
+		if err != nil {
+			return err
+		}
+		if !item.Tracked() {
+			continue
+		}
+		if reservation.Quantity > item.Available() {
+			log.Warningf("Order %s reserves more of %s than is in stock", order.ID, reservation.Slug)
+		}
+		item.Reserved += reservation.Quantity
+		if err := dbtx.Save(item); err != nil {
+			return err
+		}
+		if err := dbtx.Save(&reservation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commitInventory takes the stock purchased in the order out of the
+// inventory. If the order was confirmed before it was funded the stock
+// is reserved first.
+func (op *OrderProcessor) commitInventory(dbtx database.Tx, order *models.Order) error {
+	if err := op.reserveInventory(dbtx, order); err != nil {
+		return err
+	}
+
+	var reservations []models.InventoryReservation
+	if err := dbtx.Read().Where("order_id = ?", order.ID.String()).Find(&reservations).Error; err != nil {
+		return err
+	}
+	for _, reservation := range reservations {
+		if reservation.Committed {
+			continue
+		}
+		item, err := getInventoryItem(dbtx, reservation.Slug, reservation.SKU)
+		if err != nil {
+			return err
+		}
+		if item.Tracked() {
+			item.Quantity -= reservation.Quantity
+			if item.Quantity < 0 {
+				item.Quantity = 0
+			}
+			item.Reserved -= reservation.Quantity
+			if item.Reserved < 0 {
+				item.Reserved = 0
+			}
+			if err := dbtx.Save(item); err != nil {
+				return err
+			}
+		}
+		reservation.Committed = true
+		if err := dbtx.Save(&reservation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseInventory returns the stock held by the order to the inventory.
+// This is used when the order is canceled, declined or refunded.
+func (op *OrderProcessor) releaseInventory(dbtx database.Tx, order *models.Order) error {
+	var reservations []models.InventoryReservation
+	if err := dbtx.Read().Where("order_id = ?", order.ID.String()).Find(&reservations).Error; err != nil {
+		return err
+	}
+	for _, reservation := range reservations {
+		item, err := getInventoryItem(dbtx, reservation.Slug, reservation.SKU)
+		if err != nil {
+			return err
+		}
+		if !item.Tracked() {
+			continue
+		}
+		if reservation.Committed {
+			item.Quantity += reservation.Quantity
+		} else {
+			item.Reserved -= reservation.Quantity
+			if item.Reserved < 0 {
+				item.Reserved = 0
+			}
+		}
+		if err := dbtx.Save(item); err != nil {
+			return err
+		}
+	}
+	return dbtx.Delete("order_id", order.ID.String(), nil, &models.InventoryReservation{})
+}
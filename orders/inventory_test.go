@@ -0,0 +1,114 @@
+package orders
+
+import (
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/models/factory"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"testing"
+)
+
+func TestOrderProcessor_inventory(t *testing.T) {
+	op, teardown, err := newMockOrderProcessor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	orderOpen, err := factory.NewOrder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	slug := orderOpen.Listings[0].Listing.Slug
+
+	order := &models.Order{ID: "1234"}
+	order.SetRole(models.RoleVendor)
+	if err := order.PutMessage(&npb.OrderMessage{
+		Signature: []byte("abc"),
+		Message:   mustBuildAny(orderOpen),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	checkItem := func(tx database.Tx, expectedQuantity, expectedReserved int64) {
+		t.Helper()
+		item, err := getInventoryItem(tx, slug, "size:large/color:red")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if item.Quantity != expectedQuantity {
+			t.Errorf("Expected quantity %d, got %d", expectedQuantity, item.Quantity)
+		}
+		if item.Reserved != expectedReserved {
+			t.Errorf("Expected reserved %d, got %d", expectedReserved, item.Reserved)
+		}
+	}
+
+	err = op.db.Update(func(tx database.Tx) error {
+		// Untracked items can be purchased in any amount.
+		if err := op.checkInventory(tx, orderOpen); err != nil {
+			t.Errorf("Untracked inventory check failed: %s", err)
+		}
+
+		if err := tx.Save(&models.InventoryItem{
+			Slug:     slug,
+			SKU:      "size:large/color:red",
+			Quantity: 2,
+		}); err != nil {
+			return err
+		}
+		if err := op.checkInventory(tx, orderOpen); err != nil {
+			t.Errorf("Inventory check failed: %s", err)
+		}
+
+		orderOpen.Items[0].Quantity = "3"
+		if err := op.checkInventory(tx, orderOpen); err == nil {
+			t.Error("Expected inventory check to fail")
+		}
+		orderOpen.Items[0].Quantity = "1"
+
+		if err := op.reserveInventory(tx, order); err != nil {
+			return err
+		}
+		checkItem(tx, 2, 1)
+
+		// Reserving a second time should not change anything.
+		if err := op.reserveInventory(tx, order); err != nil {
+			return err
+		}
+		checkItem(tx, 2, 1)
+
+		if err := op.commitInventory(tx, order); err != nil {
+			return err
+		}
+		checkItem(tx, 1, 0)
+
+		if err := op.releaseInventory(tx, order); err != nil {
+			return err
+		}
+		checkItem(tx, 2, 0)
+
+		var reservations []models.InventoryReservation
+		if err := tx.Read().Where("order_id = ?", order.ID.String()).Find(&reservations).Error; err != nil {
+			return err
+		}
+		if len(reservations) != 0 {
+			t.Errorf("Expected reservations to be deleted, got %d", len(reservations))
+		}
+
+		// Releasing an order that was reserved but never confirmed
+		// only frees the reserved stock.
+		if err := op.reserveInventory(tx, order); err != nil {
+			return err
+		}
+		checkItem(tx, 2, 1)
+		if err := op.releaseInventory(tx, order); err != nil {
+			return err
+		}
+		checkItem(tx, 2, 0)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"path"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/cpacia/multiwallet"
 	"github.com/cpacia/openbazaar3.0/database"
 	"github.com/cpacia/openbazaar3.0/events"
@@ -48,6 +49,15 @@ func newMockOrderProcessor() (*OrderProcessor, func(), error) {
 		return nil, nil, err
 	}
 
+	var dbEscrowKey models.Key
+	err = r.DB().View(func(tx database.Tx) error {
+		return tx.Read().Where("name = ?", "escrow").First(&dbEscrowKey).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	escrowKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), dbEscrowKey.Value)
+
 	ctx := context.Background()
 
 	mn := mocknet.New(ctx)
@@ -88,6 +98,7 @@ func newMockOrderProcessor() (*OrderProcessor, func(), error) {
 			IdentityPrivateKey:   ipfsNode.PrivateKey,
 			Db:                   r.DB(),
 			Messenger:            messenger,
+			EscrowPrivateKey:     escrowKey,
 			Multiwallet:          mw,
 			ExchangeRateProvider: erp,
 			EventBus:             events.NewBus(),
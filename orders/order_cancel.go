@@ -76,6 +76,12 @@ func (op *OrderProcessor) processOrderCancelMessage(dbtx database.Tx, order *mod
 	if order.Role() == models.RoleBuyer {
 		log.Infof("Processed own ORDER_CANCEL for orderID: %s", order.ID)
 	} else if order.Role() == models.RoleVendor {
+		if err := op.releaseInventory(dbtx, order); err != nil {
+			log.Errorf("Error releasing inventory for order %s: %s", order.ID, err)
+		}
+		if err := op.releaseCoupons(dbtx, order); err != nil {
+			log.Errorf("Error releasing coupons for order %s: %s", order.ID, err)
+		}
 		log.Infof("Received ORDER_CANCEL message for order %s", order.ID)
 	}
 
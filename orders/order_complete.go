@@ -90,7 +90,7 @@ func (op *OrderProcessor) processOrderCompleteMessage(dbtx database.Tx, order *m
 	}
 
 	if order.Role() == models.RoleVendor && complete.GetReleaseInfo() != nil && orderOpen.Payment.Method == pb.OrderOpen_Payment_MODERATED {
-		if err := op.releaseEscrowFunds(wallet, orderOpen, complete.GetReleaseInfo()); err != nil {
+		if err := op.releaseVendorEscrowFunds(wallet, order, orderOpen, complete.GetReleaseInfo()); err != nil {
 			log.Errorf("Error releasing funds from escrow during order complete processing: %s", err.Error())
 		}
 	}
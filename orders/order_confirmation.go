@@ -1,11 +1,14 @@
 package orders
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"github.com/cpacia/openbazaar3.0/database"
 	"github.com/cpacia/openbazaar3.0/events"
 	"github.com/cpacia/openbazaar3.0/models"
 	npb "github.com/cpacia/openbazaar3.0/net/pb"
 	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
 	iwallet "github.com/cpacia/wallet-interface"
 	"github.com/golang/protobuf/ptypes"
 	peer "github.com/libp2p/go-libp2p-peer"
@@ -77,8 +80,94 @@ func (op *OrderProcessor) processOrderConfirmationMessage(dbtx database.Tx, orde
 	if order.Role() == models.RoleBuyer {
 		log.Infof("Received ORDER_CONFIRMATION message for order %s", order.ID)
 	} else if order.Role() == models.RoleVendor {
+		if err := op.commitInventory(dbtx, order); err != nil {
+			log.Errorf("Error committing inventory for order %s: %s", order.ID, err)
+		}
 		log.Infof("Processed own ORDER_CONFIRMATION for order %s", order.ID)
 	}
 
 	return event, order.PutMessage(message)
 }
+
+// ConfirmOrder signs an ORDER_CONFIRMATION for the order, processes it and
+// sends it to the buyer. It is used both when the vendor confirms the order
+// and when it is auto-confirmed.
+//
+// If the payment method is CANCELABLE the funds are moved into our wallet. The
+// wallet transaction is returned uncommitted so the caller can commit it once
+// the database transaction succeeds. It is rolled back if this returns an error.
+func (op *OrderProcessor) ConfirmOrder(dbtx database.Tx, order *models.Order, done chan<- struct{}) (iwallet.Tx, error) {
+	orderOpen, err := order.OrderOpenMessage()
+	if err != nil {
+		return nil, err
+	}
+	buyer, err := order.Buyer()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		wTx          iwallet.Tx
+		confirmation = &pb.OrderConfirmation{}
+	)
+	if orderOpen.Payment.Method == pb.OrderOpen_Payment_CANCELABLE {
+		wallet, err := op.multiwallet.WalletForCurrencyCode(orderOpen.Payment.Coin)
+		if err != nil {
+			return nil, err
+		}
+		var txid iwallet.TransactionID
+		wTx, txid, err = op.buildCancelableSweep(wallet, order, orderOpen)
+		if err != nil {
+			return nil, err
+		}
+		confirmation.TransactionID = txid.String()
+	}
+
+	if err := op.sendOrderConfirmation(dbtx, order, buyer, confirmation, done); err != nil {
+		if wTx != nil {
+			wTx.Rollback()
+		}
+		return nil, err
+	}
+	return wTx, nil
+}
+
+// sendOrderConfirmation signs and processes the confirmation and queues it to
+// be sent to the buyer.
+func (op *OrderProcessor) sendOrderConfirmation(dbtx database.Tx, order *models.Order, buyer peer.ID, confirmation *pb.OrderConfirmation, done chan<- struct{}) error {
+	confirmAny, err := ptypes.MarshalAny(confirmation)
+	if err != nil {
+		return err
+	}
+
+	resp := &npb.OrderMessage{
+		OrderID:     order.ID.String(),
+		MessageType: npb.OrderMessage_ORDER_CONFIRMATION,
+		Message:     confirmAny,
+	}
+
+	if err := utils.SignOrderMessage(resp, op.identityPrivateKey); err != nil {
+		return err
+	}
+
+	payload, err := ptypes.MarshalAny(resp)
+	if err != nil {
+		return err
+	}
+
+	messageID := make([]byte, 20)
+	if _, err := rand.Read(messageID); err != nil {
+		return err
+	}
+
+	message := &npb.Message{
+		MessageType: npb.Message_ORDER,
+		MessageID:   hex.EncodeToString(messageID),
+		Payload:     payload,
+	}
+
+	if _, err := op.ProcessMessage(dbtx, op.identity, resp); err != nil {
+		return err
+	}
+	return op.messenger.ReliablySendMessage(dbtx, buyer, message, done)
+}
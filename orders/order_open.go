@@ -18,6 +18,7 @@ import (
 	iwallet "github.com/cpacia/wallet-interface"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/jinzhu/gorm"
 	crypto "github.com/libp2p/go-libp2p-crypto"
 	peer "github.com/libp2p/go-libp2p-peer"
 	"math"
@@ -26,6 +27,12 @@ import (
 	"time"
 )
 
+// orderTotalRateTolerance is the percentage the payment amount in an order
+// may differ from the total the vendor calculates when the order converts
+// between currencies. This allows for the exchange rate changing between
+// the buyer and the vendor calculating the total.
+const orderTotalRateTolerance = 1
+
 func (op *OrderProcessor) processOrderOpenMessage(dbtx database.Tx, order *models.Order, peer peer.ID, message *npb.OrderMessage) (interface{}, error) {
 	order.ID = models.OrderID(message.OrderID)
 
@@ -105,7 +112,11 @@ func (op *OrderProcessor) processOrderOpenMessage(dbtx database.Tx, order *model
 		validationError = true
 	}
 
-	// TODO: if role == Vendor && autoConfirm && < mispaymentBuffer send orderConfirmation.
+	if !validationError && order.Role() == models.RoleVendor {
+		if err := op.redeemCoupons(dbtx, order, orderOpen); err != nil {
+			return nil, err
+		}
+	}
 
 	var event interface{}
 	// TODO: do we want to emit an event in the case of a validation error?
@@ -234,6 +245,19 @@ func (op *OrderProcessor) validateOrderOpen(dbtx database.Tx, order *pb.OrderOpe
 				return errors.New("direct payment address not found in wallet")
 			}
 		}
+
+		// The buyer records the mispayment buffer in the order. We only
+		// agree to it if it is within our own.
+		var prefs models.UserPreferences
+		if err := dbtx.Read().First(&prefs).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+			return err
+		}
+		if order.Payment.MispaymentBuffer > prefs.MisPaymentBuffer {
+			return errors.New("mispayment buffer exceeds our limit")
+		}
+	}
+	if order.Payment.MispaymentBuffer < 0 || order.Payment.MispaymentBuffer > 100 {
+		return errors.New("mispayment buffer out of range")
 	}
 
 	var escrowTimeoutHours uint32
@@ -288,6 +312,17 @@ func (op *OrderProcessor) validateOrderOpen(dbtx database.Tx, order *pb.OrderOpe
 			}
 		}
 
+		// Physical goods must select a shipping option and give an address
+		// so we can calculate the shipping cost ourselves.
+		if listing.Metadata.ContractType == pb.Listing_Metadata_PHYSICAL_GOOD {
+			if item.ShippingOption == nil {
+				return fmt.Errorf("item %d shipping option not selected", i)
+			}
+			if order.Shipping == nil {
+				return errors.New("shipping field is nil")
+			}
+		}
+
 		// Validate shipping option
 		if item.ShippingOption != nil {
 			shippingOpts := make(map[string][]*pb.Listing_ShippingOption_Service)
@@ -310,6 +345,16 @@ func (op *OrderProcessor) validateOrderOpen(dbtx database.Tx, order *pb.OrderOpe
 		}
 	}
 
+	// Make sure we have enough of each item in stock.
+	if role == models.RoleVendor {
+		if err := op.checkInventory(dbtx, order); err != nil {
+			return err
+		}
+		if err := op.checkCouponRedemptions(dbtx, order, orderID); err != nil {
+			return err
+		}
+	}
+
 	// Validate buyer ID
 	if order.BuyerID.Pubkeys == nil {
 		return errors.New("buyer id pubkeys is nil")
@@ -374,6 +419,16 @@ func (op *OrderProcessor) validateOrderOpen(dbtx database.Tx, order *pb.OrderOpe
 		if err != nil {
 			return errors.New("invalid moderator selection")
 		}
+		moderatorFee := order.Payment.ModeratorFee
+		if moderatorFee == nil {
+			return errors.New("moderator fee is missing")
+		}
+		if fixed, ok := new(big.Int).SetString(moderatorFee.Fixed, 10); !ok || fixed.Sign() < 0 {
+			return errors.New("moderator fixed fee not valid")
+		}
+		if moderatorFee.Percentage < 0 || moderatorFee.Percentage > 100 {
+			return errors.New("moderator fee percentage out of range")
+		}
 		moderatorEscrowPubkey, err := btcec.ParsePubKey(order.Payment.ModeratorKey, btcec.S256())
 		if err != nil {
 			return err
@@ -445,6 +500,11 @@ func (op *OrderProcessor) validateOrderOpen(dbtx database.Tx, order *pb.OrderOpe
 		}
 	}
 
+	// Validate the tax breakdown
+	if err := validateOrderTaxes(order); err != nil {
+		return err
+	}
+
 	// Validate rating keys
 	if len(order.RatingKeys) != len(order.Items) {
 		return errors.New("incorrect number of ratings keys")
@@ -455,6 +515,14 @@ func (op *OrderProcessor) validateOrderOpen(dbtx database.Tx, order *pb.OrderOpe
 		}
 	}
 
+	// The buyer calculates the payment amount so we make sure it
+	// matches the total we calculate for the order.
+	if role == models.RoleVendor {
+		if err := op.checkOrderTotal(order); err != nil {
+			return err
+		}
+	}
+
 	// Validate order ID
 	orderHash, err := utils.CalcOrderID(order)
 	if err != nil {
@@ -467,14 +535,105 @@ func (op *OrderProcessor) validateOrderOpen(dbtx database.Tx, order *pb.OrderOpe
 	return nil
 }
 
+// checkOrderTotal returns an error if the payment amount or the tax
+// breakdown in the order do not match the total and taxes we calculate for
+// it. Coupon expiry is checked against the time we received the order rather
+// than the time the buyer says they placed it.
+func (op *OrderProcessor) checkOrderTotal(order *pb.OrderOpen) error {
+	total, taxes, err := calculateOrderTotal(order, op.erp, time.Now())
+	if err != nil {
+		return err
+	}
+	converts, err := orderConvertsCurrency(order)
+	if err != nil {
+		return err
+	}
+	amount := iwallet.NewAmount(order.Payment.Amount)
+	if !amountMatches(amount, total, converts) {
+		return fmt.Errorf("payment amount %s does not match the order total %s", amount, total)
+	}
+	return checkOrderTaxes(order.Taxes, taxes, converts)
+}
+
+// amountMatches returns whether the amount matches the amount we calculate.
+// If the order converts between currencies the amounts may differ by the
+// orderTotalRateTolerance.
+func amountMatches(amount, calculated iwallet.Amount, convertsCurrency bool) bool {
+	diff := amount.Sub(calculated)
+	if diff.Cmp(iwallet.NewAmount(0)) < 0 {
+		diff = calculated.Sub(amount)
+	}
+	tolerance := iwallet.NewAmount(0)
+	if convertsCurrency {
+		f, _ := new(big.Float).SetString(calculated.String())
+		f.Abs(f).Mul(f, big.NewFloat(orderTotalRateTolerance/100.0))
+		t, _ := f.Int(nil)
+		tolerance = iwallet.NewAmount(t)
+	}
+	return diff.Cmp(tolerance) <= 0
+}
+
+// orderConvertsCurrency returns whether any of the listings in the order
+// are priced in a currency other than the payment currency.
+func orderConvertsCurrency(order *pb.OrderOpen) (bool, error) {
+	paymentCurrency, err := models.CurrencyDefinitions.Lookup(order.Payment.Coin)
+	if err != nil {
+		return false, err
+	}
+	for _, sl := range order.Listings {
+		if sl.Listing.Metadata.Format == pb.Listing_Metadata_MARKET_PRICE {
+			return true, nil
+		}
+		pricingCurrency, err := models.CurrencyDefinitions.Lookup(sl.Listing.Metadata.PricingCurrency.Code)
+		if err != nil {
+			return false, err
+		}
+		if pricingCurrency.Code != paymentCurrency.Code {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // CalculateOrderTotal calculates and returns the total for the order with all
 // the provided options.
 func CalculateOrderTotal(order *pb.OrderOpen, erp *wallet.ExchangeRateProvider) (iwallet.Amount, error) {
+	total, _, err := CalculateOrderTotalWithTaxes(order, erp)
+	return total, err
+}
+
+// CalculateOrderTotalWithTaxes calculates the total for the order along with
+// an itemized list of the taxes included in the total. Each listing is taxed
+// using its tax rule for the buyer's shipping country, if any, and shipping is
+// only taxed if the rule says so. Coupon expiry is checked against the time
+// the order was placed.
+func CalculateOrderTotalWithTaxes(order *pb.OrderOpen, erp *wallet.ExchangeRateProvider) (iwallet.Amount, []*pb.OrderOpen_Tax, error) {
+	return calculateOrderTotal(order, erp, orderTime(order))
+}
+
+// calculateOrderTotal calculates the total for the order and the taxes
+// included in it with coupon expiry checked against the given time.
+func calculateOrderTotal(order *pb.OrderOpen, erp *wallet.ExchangeRateProvider, at time.Time) (iwallet.Amount, []*pb.OrderOpen_Tax, error) {
 	var (
 		orderTotal    iwallet.Amount
 		physicalGoods = make(map[string]*pb.Listing)
+		taxes         = newTaxLedger()
 	)
 
+	// Price tiers are selected using the quantity of each listing and
+	// sku across all the items in the order.
+	quantities, err := newOrderQuantities(order)
+	if err != nil {
+		return orderTotal, nil, err
+	}
+
+	// Coupons with a minimum order value are checked against the
+	// subtotal of the items bought from each listing.
+	subtotals, err := listingSubtotals(order)
+	if err != nil {
+		return orderTotal, nil, err
+	}
+
 	// Calculate the price of each item
 	for i, item := range order.Items {
 		// Step one is we just want to get the price, in the payment currency,
@@ -485,40 +644,45 @@ func CalculateOrderTotal(order *pb.OrderOpen, erp *wallet.ExchangeRateProvider)
 		)
 
 		if itemQuantity.Cmp(iwallet.NewAmount(0)) <= 0 {
-			return orderTotal, fmt.Errorf("item %d quantity is not a positive integer", i)
+			return orderTotal, nil, fmt.Errorf("item %d quantity is not a positive integer", i)
 		}
 
 		listing, err := extractListing(item.ListingHash, order.Listings)
 		if err != nil {
-			return orderTotal, fmt.Errorf("listing not found in contract for item %s", item.ListingHash)
+			return orderTotal, nil, fmt.Errorf("listing not found in contract for item %s", item.ListingHash)
 		}
 
 		if listing.Metadata.ContractType == pb.Listing_Metadata_PHYSICAL_GOOD {
 			physicalGoods[item.ListingHash] = listing
 		}
 
+		sku, err := getSelectedSku(listing, item.Options)
+		if err != nil {
+			return orderTotal, nil, err
+		}
+
 		pricingCurrency, err := models.CurrencyDefinitions.Lookup(listing.Metadata.PricingCurrency.Code)
 		if err != nil {
-			return orderTotal, err
+			return orderTotal, nil, err
 		}
 		paymentCurrency, err := models.CurrencyDefinitions.Lookup(order.Payment.Coin)
 		if err != nil {
-			return orderTotal, err
+			return orderTotal, nil, err
 		}
 
 		if listing.Metadata.Format == pb.Listing_Metadata_MARKET_PRICE {
 			cryptoListingCurrency, err := models.CurrencyDefinitions.Lookup(listing.Item.CryptoListingCurrencyCode)
 			if err != nil {
-				return orderTotal, err
+				return orderTotal, nil, err
 			}
 			// To calculate the market price we just use the exchange rate between
 			// the two coins. However in this case we use the item quantity being
 			// purchased as the amount as we want to find the exchange rate of
 			// the given quantity.
 			price := models.NewCurrencyValue(item.Quantity, cryptoListingCurrency)
-			itemTotal, err = convertCurrencyAmount(price, paymentCurrency, erp)
+			itemTotal, err = ConvertCurrencyAmount(price, paymentCurrency, erp)
 			if err != nil {
-				return orderTotal, err
+				return orderTotal, nil, err
 			}
 
 			// Now we add or subtract the price modifier.
@@ -531,61 +695,46 @@ func CalculateOrderTotal(order *pb.OrderOpen, erp *wallet.ExchangeRateProvider)
 			// just set this to 1 to avoid multiplying by the quantity again below.
 			itemQuantity = iwallet.NewAmount(1)
 		} else {
-			price := models.NewCurrencyValue(listing.Item.Price, pricingCurrency)
-			itemTotal, err = convertCurrencyAmount(price, paymentCurrency, erp)
+			// The unit price includes any surcharge on the selected sku and
+			// any quantity discount from the listing's price tiers.
+			price := models.NewCurrencyValue(quantities.unitPrice(item.ListingHash, listing, sku).String(), pricingCurrency)
+			itemTotal, err = ConvertCurrencyAmount(price, paymentCurrency, erp)
 			if err != nil {
-				return orderTotal, err
+				return orderTotal, nil, err
 			}
 		}
 
-		// Add or subtract any surcharge on the selected sku
-		sku, err := getSelectedSku(listing, item.Options)
-		if err != nil {
-			return orderTotal, err
-		}
-		surcharge := iwallet.NewAmount(sku.Surcharge)
-		surchargeValue := models.NewCurrencyValue(surcharge.String(), pricingCurrency)
-		convertedSurcharge, err := convertCurrencyAmount(surchargeValue, paymentCurrency, erp)
-		if err != nil {
-			return orderTotal, err
-		}
-		itemTotal.Add(convertedSurcharge)
-
-		// Subtract any coupons
+		// Subtract any coupons which apply to the selected sku
 		for _, couponCode := range item.CouponCodes {
-			couponHash, err := utils.MultihashSha256([]byte(couponCode))
+			vendorCoupon, _, err := matchCoupon(listing, couponCode)
 			if err != nil {
-				return orderTotal, err
-			}
-			for _, vendorCoupon := range listing.Coupons {
-				if couponCode == vendorCoupon.GetDiscountCode() || couponHash.B58String() == vendorCoupon.GetHash() {
-					if discount := vendorCoupon.GetPriceDiscount(); discount != "" && iwallet.NewAmount(discount).Cmp(iwallet.NewAmount(0)) > 0 {
-						price := models.NewCurrencyValue(discount, pricingCurrency)
-						discountAmount, err := convertCurrencyAmount(price, paymentCurrency, erp)
-						if err != nil {
-							return orderTotal, err
-						}
-						itemTotal = itemTotal.Sub(discountAmount)
-					} else if discount := vendorCoupon.GetPercentDiscount(); discount > 0 {
-						f, _ := new(big.Float).SetString(itemTotal.String())
-						f.Mul(f, big.NewFloat(float64(-discount/100)))
-						discountAmount, _ := f.Int(nil)
-						itemTotal = itemTotal.Add(iwallet.NewAmount(discountAmount))
-					}
+				return orderTotal, nil, err
+			}
+			if vendorCoupon == nil || !couponApplies(vendorCoupon, sku, at, subtotals[item.ListingHash]) {
+				continue
+			}
+			if discount := vendorCoupon.GetPriceDiscount(); discount != "" && iwallet.NewAmount(discount).Cmp(iwallet.NewAmount(0)) > 0 {
+				price := models.NewCurrencyValue(discount, pricingCurrency)
+				discountAmount, err := ConvertCurrencyAmount(price, paymentCurrency, erp)
+				if err != nil {
+					return orderTotal, nil, err
 				}
+				itemTotal = itemTotal.Sub(discountAmount)
+			} else if discount := vendorCoupon.GetPercentDiscount(); discount > 0 {
+				f, _ := new(big.Float).SetString(itemTotal.String())
+				f.Mul(f, big.NewFloat(float64(-discount/100)))
+				discountAmount, _ := f.Int(nil)
+				itemTotal = itemTotal.Add(iwallet.NewAmount(discountAmount))
 			}
 		}
 		// Apply tax
-		for _, tax := range listing.Taxes {
-			for _, taxRegion := range tax.TaxRegions {
-				if order.Shipping.Country == taxRegion {
-					f, _ := new(big.Float).SetString(itemTotal.String())
-					f.Mul(f, big.NewFloat(float64(tax.Percentage/100)))
-					govTheft, _ := f.Int(nil)
-					itemTotal = itemTotal.Add(iwallet.NewAmount(govTheft))
-					break
-				}
-			}
+		rule := taxRule(listing, order.Shipping.Country)
+		if rule != nil {
+			f, _ := new(big.Float).SetString(itemTotal.String())
+			f.Mul(f, big.NewFloat(float64(rule.Percentage/100)))
+			govTheft, _ := f.Int(nil)
+			itemTotal = itemTotal.Add(iwallet.NewAmount(govTheft))
+			taxes.addItemTax(item.ListingHash, rule, iwallet.NewAmount(govTheft).Mul(itemQuantity))
 		}
 
 		// Multiply the item total by the quantity being purchased
@@ -598,27 +747,51 @@ func CalculateOrderTotal(order *pb.OrderOpen, erp *wallet.ExchangeRateProvider)
 	}
 
 	// Add in shipping
-	shippingTotal, err := calculateShippingTotalForListings(order, physicalGoods, erp)
+	shippingTotal, err := calculateShippingTotalForListings(order, physicalGoods, subtotals, taxes, erp)
 	if err != nil {
-		return orderTotal, err
+		return orderTotal, nil, err
 	}
 	orderTotal = orderTotal.Add(shippingTotal)
 
-	return orderTotal, nil
+	return orderTotal, taxes.breakdown(), nil
 }
 
-func calculateShippingTotalForListings(order *pb.OrderOpen, listings map[string]*pb.Listing, erp *wallet.ExchangeRateProvider) (iwallet.Amount, error) {
+// calculateShippingTotalForListings returns the shipping cost of the physical
+// goods in the order. Items whose listing subtotal meets the selected
+// service's free shipping threshold are shipped for free. Items using a
+// service with weight bands are combined into parcels by option and service
+// name and charged by the total weight of the parcel. All other items are
+// charged the primary rate for the first unit of the item with the highest
+// primary rate and the additional item rate for every other unit.
+func calculateShippingTotalForListings(order *pb.OrderOpen, listings map[string]*pb.Listing, subtotals map[string]iwallet.Amount, taxes *taxLedger, erp *wallet.ExchangeRateProvider) (iwallet.Amount, error) {
 	type itemShipping struct {
+		listingHash           string
 		primary               iwallet.Amount
 		secondary             iwallet.Amount
 		quantity              string
 		shippingTaxPercentage float32
+		taxRule               *pb.Listing_Tax
+	}
+	// shippingTax calculates the tax on the shipping rate and records it
+	// against the item's listing.
+	shippingTax := func(s itemShipping, rate iwallet.Amount) iwallet.Amount {
+		tax := calculateShippingTax(s.shippingTaxPercentage, rate)
+		if s.taxRule != nil {
+			taxes.addShippingTax(s.listingHash, s.taxRule, tax)
+		}
+		return tax
 	}
 	var (
 		is            []itemShipping
+		parcels       []*shippingParcel
 		shippingTotal = iwallet.NewAmount(0)
 	)
 
+	paymentCurrency, err := models.CurrencyDefinitions.Lookup(order.Payment.Coin)
+	if err != nil {
+		return shippingTotal, err
+	}
+
 	// First loop through to validate and filter out non-physical items
 	for i, item := range order.Items {
 		if item.Quantity == "" {
@@ -634,10 +807,6 @@ func calculateShippingTotalForListings(order *pb.OrderOpen, listings map[string]
 		if err != nil {
 			return shippingTotal, err
 		}
-		paymentCurrency, err := models.CurrencyDefinitions.Lookup(order.Payment.Coin)
-		if err != nil {
-			return shippingTotal, err
-		}
 
 		// Check selected option exists
 		shippingOptions := make(map[string]*pb.Listing_ShippingOption)
@@ -674,9 +843,44 @@ func calculateShippingTotalForListings(order *pb.OrderOpen, listings map[string]
 			return shippingTotal, errors.New("shipping service not found in listing")
 		}
 
+		if service.FreeShippingThreshold != "" {
+			subtotal, ok := subtotals[item.ListingHash]
+			if ok && subtotal.Cmp(iwallet.NewAmount(service.FreeShippingThreshold)) >= 0 {
+				continue
+			}
+		}
+
+		// Calculate tax percentage
+		var shippingTaxPercentage float32
+		rule := taxRule(listing, order.Shipping.Country)
+		if rule != nil && rule.TaxShipping {
+			shippingTaxPercentage = rule.Percentage / 100
+		} else {
+			rule = nil
+		}
+
+		if len(service.WeightBands) > 0 {
+			name := strings.ToLower(option.Name) + "/" + strings.ToLower(service.Name)
+			var parcel *shippingParcel
+			for _, p := range parcels {
+				if p.name == name {
+					parcel = p
+					break
+				}
+			}
+			if parcel == nil {
+				parcel = newShippingParcel(name)
+				parcels = append(parcels, parcel)
+			}
+			if err := parcel.add(item.ListingHash, listing, item.Quantity, service, pricingCurrency, shippingTaxPercentage, rule); err != nil {
+				return shippingTotal, err
+			}
+			continue
+		}
+
 		// Convert to payment currency
 		price := models.NewCurrencyValue(service.Price, pricingCurrency)
-		primaryTotal, err := convertCurrencyAmount(price, paymentCurrency, erp)
+		primaryTotal, err := ConvertCurrencyAmount(price, paymentCurrency, erp)
 		if err != nil {
 			return shippingTotal, err
 		}
@@ -686,35 +890,33 @@ func calculateShippingTotalForListings(order *pb.OrderOpen, listings map[string]
 		if service.AdditionalItemPrice != "" {
 			if iwallet.NewAmount(service.AdditionalItemPrice).Cmp(iwallet.NewAmount(0)) > 0 {
 				secondaryPrice := models.NewCurrencyValue(service.AdditionalItemPrice, pricingCurrency)
-				secondaryTotal, err = convertCurrencyAmount(secondaryPrice, paymentCurrency, erp)
+				secondaryTotal, err = ConvertCurrencyAmount(secondaryPrice, paymentCurrency, erp)
 				if err != nil {
 					return shippingTotal, err
 				}
 			}
 		}
 
-		// Calculate tax percentage
-		var shippingTaxPercentage float32
-		for _, tax := range listing.Taxes {
-			regions := make(map[pb.CountryCode]bool)
-			for _, taxRegion := range tax.TaxRegions {
-				regions[taxRegion] = true
-			}
-			_, ok := regions[order.Shipping.Country]
-			if ok && tax.TaxShipping {
-				shippingTaxPercentage = tax.Percentage / 100
-			}
-		}
-
 		is = append(is, itemShipping{
+			listingHash:           item.ListingHash,
 			primary:               primaryTotal,
 			secondary:             secondaryTotal,
 			quantity:              item.Quantity,
 			shippingTaxPercentage: shippingTaxPercentage,
+			taxRule:               rule,
 		})
 	}
 
-	// No options to charge shipping on. Return zero.
+	// Each parcel is charged separately from the items with flat rates.
+	for _, parcel := range parcels {
+		parcelTotal, err := parcel.total(paymentCurrency, taxes, erp)
+		if err != nil {
+			return shippingTotal, err
+		}
+		shippingTotal = shippingTotal.Add(parcelTotal)
+	}
+
+	// No flat rate options to charge shipping on.
 	if len(is) == 0 {
 		return shippingTotal, nil
 	}
@@ -722,10 +924,10 @@ func calculateShippingTotalForListings(order *pb.OrderOpen, listings map[string]
 	// Single item. For the first quantity charge the primary. For all others charge the secondary.
 	if len(is) == 1 {
 		shippingTotal = shippingTotal.Add(is[0].primary)
-		shippingTotal = shippingTotal.Add(calculateShippingTax(is[0].shippingTaxPercentage, is[0].primary))
+		shippingTotal = shippingTotal.Add(shippingTax(is[0], is[0].primary))
 		if iwallet.NewAmount(is[0].quantity).Cmp(iwallet.NewAmount(1)) > 0 {
 			shippingTotal = shippingTotal.Add(is[0].secondary.Mul(iwallet.NewAmount(is[0].quantity).Sub(iwallet.NewAmount(1))))
-			shippingTotal = shippingTotal.Add(calculateShippingTax(is[0].shippingTaxPercentage, is[0].secondary.Mul(iwallet.NewAmount(is[0].quantity).Sub(iwallet.NewAmount(1)))))
+			shippingTotal = shippingTotal.Add(shippingTax(is[0], is[0].secondary.Mul(iwallet.NewAmount(is[0].quantity).Sub(iwallet.NewAmount(1)))))
 		}
 		return shippingTotal, nil
 	}
@@ -745,13 +947,13 @@ func calculateShippingTotalForListings(order *pb.OrderOpen, listings map[string]
 			i = x
 		}
 		shippingTotal = shippingTotal.Add(s.secondary.Mul(iwallet.NewAmount(s.quantity)))
-		shippingTotal = shippingTotal.Add(calculateShippingTax(s.shippingTaxPercentage, s.secondary.Mul(iwallet.NewAmount(s.quantity))))
+		shippingTotal = shippingTotal.Add(shippingTax(s, s.secondary.Mul(iwallet.NewAmount(s.quantity))))
 	}
 	shippingTotal = shippingTotal.Sub(is[i].secondary)
-	shippingTotal = shippingTotal.Sub(calculateShippingTax(is[i].shippingTaxPercentage, is[i].secondary))
+	shippingTotal = shippingTotal.Add(shippingTax(is[i], iwallet.NewAmount(0).Sub(is[i].secondary)))
 
 	shippingTotal = shippingTotal.Add(is[i].primary)
-	shippingTotal = shippingTotal.Add(calculateShippingTax(is[i].shippingTaxPercentage, is[i].primary))
+	shippingTotal = shippingTotal.Add(shippingTax(is[i], is[i].primary))
 
 	return shippingTotal, nil
 }
@@ -764,8 +966,8 @@ func calculateShippingTax(shippingTaxPercentage float32, shippingRate iwallet.Am
 	return iwallet.NewAmount(governmentTheft)
 }
 
-// convertCurrencyAmount converts the value of one currency into another using the exchange rate.
-func convertCurrencyAmount(value *models.CurrencyValue, paymentCurrency *models.Currency, erp *wallet.ExchangeRateProvider) (iwallet.Amount, error) {
+// ConvertCurrencyAmount converts the value of one currency into another using the exchange rate.
+func ConvertCurrencyAmount(value *models.CurrencyValue, paymentCurrency *models.Currency, erp *wallet.ExchangeRateProvider) (iwallet.Amount, error) {
 	// If both currency types are the same then just return the value.
 	if value.Currency.Equal(paymentCurrency) {
 		return value.Amount, nil
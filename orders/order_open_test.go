@@ -19,6 +19,7 @@ import (
 	iwallet "github.com/cpacia/wallet-interface"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	crypto "github.com/libp2p/go-libp2p-crypto"
 	peer "github.com/libp2p/go-libp2p-peer"
 	"github.com/multiformats/go-multihash"
@@ -260,7 +261,7 @@ func Test_convertCurrencyAmount(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		amount, err := convertCurrencyAmount(models.NewCurrencyValue(test.amount, original), payment, erp)
+		amount, err := ConvertCurrencyAmount(models.NewCurrencyValue(test.amount, original), payment, erp)
 		if err != nil {
 			t.Errorf("Test %d failed: %s", i, err)
 			continue
@@ -273,6 +274,53 @@ func Test_convertCurrencyAmount(t *testing.T) {
 }
 
 func TestCalculateOrderTotal(t *testing.T) {
+	withCoupon := func(modify func(coupon *pb.Listing_Coupon)) func(order *pb.OrderOpen) error {
+		return func(order *pb.OrderOpen) error {
+			modify(order.Listings[0].Listing.Coupons[0])
+			hash, err := utils.HashListing(order.Listings[0])
+			if err != nil {
+				return err
+			}
+			order.Items[0].ListingHash = hash.B58String()
+			order.Items[0].CouponCodes = []string{
+				"insider",
+			}
+			return nil
+		}
+	}
+	timestampIn := func(d time.Duration) *timestamp.Timestamp {
+		ts, _ := ptypes.TimestampProto(time.Now().Add(d))
+		return ts
+	}
+	withQuantity := func(quantity string, modify func(listing *pb.Listing)) func(order *pb.OrderOpen) error {
+		return func(order *pb.OrderOpen) error {
+			modify(order.Listings[0].Listing)
+			hash, err := utils.HashListing(order.Listings[0])
+			if err != nil {
+				return err
+			}
+			order.Items[0].ListingHash = hash.B58String()
+			order.Items[0].Quantity = quantity
+			return nil
+		}
+	}
+	weightBands := []*pb.Listing_ShippingOption_Service_WeightBand{
+		{MaxGrams: 20, Price: "20"},
+		{MaxGrams: 1000, Price: "40"},
+	}
+	percentTier := func(minQuantity uint64, percent float32) *pb.Listing_Item_PriceTier {
+		return &pb.Listing_Item_PriceTier{
+			MinQuantity: minQuantity,
+			Discount:    &pb.Listing_Item_PriceTier_PercentDiscount{PercentDiscount: percent},
+		}
+	}
+	priceTier := func(minQuantity uint64, price string) *pb.Listing_Item_PriceTier {
+		return &pb.Listing_Item_PriceTier{
+			MinQuantity: minQuantity,
+			Discount:    &pb.Listing_Item_PriceTier_Price{Price: price},
+		}
+	}
+
 	tests := []struct {
 		transform     func(order *pb.OrderOpen) error
 		expectedTotal iwallet.Amount
@@ -290,6 +338,21 @@ func TestCalculateOrderTotal(t *testing.T) {
 			},
 			expectedTotal: iwallet.NewAmount("9152406"),
 		},
+		{
+			// SKU surcharge
+			transform: func(order *pb.OrderOpen) error {
+				for _, sku := range order.Listings[0].Listing.Item.Skus {
+					sku.Surcharge = "50"
+				}
+				hash, err := utils.HashListing(order.Listings[0])
+				if err != nil {
+					return err
+				}
+				order.Items[0].ListingHash = hash.B58String()
+				return nil
+			},
+			expectedTotal: iwallet.NewAmount("7072315"),
+		},
 		{
 			// Additional item shipping
 			transform: func(order *pb.OrderOpen) error {
@@ -348,6 +411,173 @@ func TestCalculateOrderTotal(t *testing.T) {
 			},
 			expectedTotal: iwallet.NewAmount("4784212"),
 		},
+		{
+			// Coupon not expired
+			transform: withCoupon(func(coupon *pb.Listing_Coupon) {
+				coupon.Expiry = timestampIn(time.Hour)
+			}),
+			expectedTotal: iwallet.NewAmount("4784212"),
+		},
+		{
+			// Coupon expired
+			transform: withCoupon(func(coupon *pb.Listing_Coupon) {
+				coupon.Expiry = timestampIn(-time.Hour)
+			}),
+			expectedTotal: iwallet.NewAmount("4992221"),
+		},
+		{
+			// Coupon applies to the selected sku
+			transform: withCoupon(func(coupon *pb.Listing_Coupon) {
+				coupon.Skus = []string{"1"}
+			}),
+			expectedTotal: iwallet.NewAmount("4784212"),
+		},
+		{
+			// Coupon does not apply to the selected sku
+			transform: withCoupon(func(coupon *pb.Listing_Coupon) {
+				coupon.Skus = []string{"2"}
+			}),
+			expectedTotal: iwallet.NewAmount("4992221"),
+		},
+		{
+			// Coupon minimum order value met
+			transform: withCoupon(func(coupon *pb.Listing_Coupon) {
+				coupon.MinimumOrderValue = "100"
+			}),
+			expectedTotal: iwallet.NewAmount("4784212"),
+		},
+		{
+			// Coupon minimum order value not met
+			transform: withCoupon(func(coupon *pb.Listing_Coupon) {
+				coupon.MinimumOrderValue = "101"
+			}),
+			expectedTotal: iwallet.NewAmount("4992221"),
+		},
+		{
+			// Sku surcharge
+			transform: withQuantity("2", func(listing *pb.Listing) {
+				listing.Item.Skus[0].Surcharge = "10"
+			}),
+			expectedTotal: iwallet.NewAmount("9984444"),
+		},
+		{
+			// Price tier not met
+			transform: withQuantity("1", func(listing *pb.Listing) {
+				listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{percentTier(2, 10)}
+			}),
+			expectedTotal: iwallet.NewAmount("4992221"),
+		},
+		{
+			// Percent price tier
+			transform: withQuantity("2", func(listing *pb.Listing) {
+				listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{percentTier(2, 10)}
+			}),
+			expectedTotal: iwallet.NewAmount("8320370"),
+		},
+		{
+			// Fixed price tier
+			transform: withQuantity("2", func(listing *pb.Listing) {
+				listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{priceTier(2, "90")}
+			}),
+			expectedTotal: iwallet.NewAmount("8320370"),
+		},
+		{
+			// Highest tier met is used
+			transform: withQuantity("2", func(listing *pb.Listing) {
+				listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{
+					percentTier(2, 10),
+					priceTier(3, "50"),
+				}
+			}),
+			expectedTotal: iwallet.NewAmount("8320370"),
+		},
+		{
+			// Surcharge added to the listing price tier
+			transform: withQuantity("2", func(listing *pb.Listing) {
+				listing.Item.Skus[0].Surcharge = "50"
+				listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{priceTier(2, "60")}
+			}),
+			expectedTotal: iwallet.NewAmount("9984444"),
+		},
+		{
+			// Sku price tier replaces the surcharge
+			transform: withQuantity("2", func(listing *pb.Listing) {
+				listing.Item.Skus[0].Surcharge = "50"
+				listing.Item.Skus[0].PriceTiers = []*pb.Listing_Item_PriceTier{priceTier(2, "110")}
+			}),
+			expectedTotal: iwallet.NewAmount("9984444"),
+		},
+		{
+			// Sku price tier overrides the listing tier
+			transform: withQuantity("2", func(listing *pb.Listing) {
+				listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{priceTier(2, "50")}
+				listing.Item.Skus[0].PriceTiers = []*pb.Listing_Item_PriceTier{priceTier(2, "90")}
+			}),
+			expectedTotal: iwallet.NewAmount("8320370"),
+		},
+		{
+			// Coupon minimum order value checked after price tiers
+			transform: func(order *pb.OrderOpen) error {
+				order.Listings[0].Listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{priceTier(2, "90")}
+				order.Items[0].Quantity = "2"
+				return withCoupon(func(coupon *pb.Listing_Coupon) {
+					coupon.MinimumOrderValue = "190"
+				})(order)
+			},
+			expectedTotal: iwallet.NewAmount("8320370"),
+		},
+		{
+			// Weight band
+			transform: withQuantity("1", func(listing *pb.Listing) {
+				listing.ShippingOptions[0].Services[0].WeightBands = weightBands
+			}),
+			expectedTotal: iwallet.NewAmount("4992221"),
+		},
+		{
+			// Heavier weight band
+			transform: withQuantity("2", func(listing *pb.Listing) {
+				listing.ShippingOptions[0].Services[0].WeightBands = weightBands
+			}),
+			expectedTotal: iwallet.NewAmount("9984443"),
+		},
+		{
+			// Items combined into one parcel
+			transform: func(order *pb.OrderOpen) error {
+				order.Listings[0].Listing.ShippingOptions[0].Services[0].WeightBands = weightBands
+				hash, err := utils.HashListing(order.Listings[0])
+				if err != nil {
+					return err
+				}
+				order.Items[0].ListingHash = hash.B58String()
+
+				listing2 := proto.Clone(order.Listings[0]).(*pb.SignedListing)
+				listing2.Listing.Item.Title = "abc"
+				hash2, err := utils.HashListing(listing2)
+				if err != nil {
+					return err
+				}
+				item2 := proto.Clone(order.Items[0]).(*pb.OrderOpen_Item)
+				item2.ListingHash = hash2.B58String()
+				order.Listings = append(order.Listings, listing2)
+				order.Items = append(order.Items, item2)
+				return nil
+			},
+			expectedTotal: iwallet.NewAmount("9984443"),
+		},
+		{
+			// Free shipping threshold met
+			transform: withQuantity("1", func(listing *pb.Listing) {
+				listing.ShippingOptions[0].Services[0].FreeShippingThreshold = "100"
+			}),
+			expectedTotal: iwallet.NewAmount("4160185"),
+		},
+		{
+			// Free shipping threshold not met
+			transform: withQuantity("1", func(listing *pb.Listing) {
+				listing.ShippingOptions[0].Services[0].FreeShippingThreshold = "101"
+			}),
+			expectedTotal: iwallet.NewAmount("4992221"),
+		},
 		{
 			// Market price listing
 			transform: func(order *pb.OrderOpen) error {
@@ -400,6 +630,34 @@ func Test_validateOrderOpen(t *testing.T) {
 	}
 	defer teardown()
 
+	// Listings with coupons which expired an hour ago and which expire in
+	// an hour.
+	expiredCoupon := factory.NewSignedListing()
+	expiredCoupon.Listing.Slug = "expired-coupon"
+	expiredCoupon.Listing.Coupons[0].Expiry, _ = ptypes.TimestampProto(time.Now().Add(-time.Hour))
+	activeCoupon := factory.NewSignedListing()
+	activeCoupon.Listing.Slug = "active-coupon"
+	activeCoupon.Listing.Coupons[0].Expiry, _ = ptypes.TimestampProto(time.Now().Add(time.Hour))
+
+	// Listing with a price tier which halves the unit price when buying
+	// two or more.
+	priceTier := factory.NewSignedListing()
+	priceTier.Listing.Slug = "price-tier"
+	priceTier.Listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{
+		{MinQuantity: 2, Discount: &pb.Listing_Item_PriceTier_Price{Price: "50"}},
+	}
+
+	// Listings charging shipping by weight and shipping orders of 100 or
+	// more for free.
+	weightBand := factory.NewSignedListing()
+	weightBand.Listing.Slug = "weight-band"
+	weightBand.Listing.ShippingOptions[0].Services[0].WeightBands = []*pb.Listing_ShippingOption_Service_WeightBand{
+		{MaxGrams: 1000, Price: "60"},
+	}
+	freeShipping := factory.NewSignedListing()
+	freeShipping.Listing.Slug = "free-shipping"
+	freeShipping.Listing.ShippingOptions[0].Services[0].FreeShippingThreshold = "100"
+
 	err = processor.db.Update(func(tx database.Tx) error {
 		sl := factory.NewSignedListing()
 		sl2 := factory.NewSignedListing()
@@ -409,11 +667,134 @@ func Test_validateOrderOpen(t *testing.T) {
 		if err := tx.SetListing(sl); err != nil {
 			return err
 		}
+		if err := tx.SetListing(expiredCoupon); err != nil {
+			return err
+		}
+		if err := tx.SetListing(activeCoupon); err != nil {
+			return err
+		}
+		if err := tx.SetListing(priceTier); err != nil {
+			return err
+		}
+		if err := tx.SetListing(weightBand); err != nil {
+			return err
+		}
+		if err := tx.SetListing(freeShipping); err != nil {
+			return err
+		}
 		return tx.SetListing(sl2)
 	})
 	if err != nil {
 		t.Fatal(err)
 	}
+	newModeratedOrder := func() (*pb.OrderOpen, error) {
+		order, err := factory.NewOrder()
+		if err != nil {
+			return nil, err
+		}
+		priv, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+		chaincode, err := hex.DecodeString(order.Payment.Chaincode)
+		if err != nil {
+			return nil, fmt.Errorf("chaincode parse error: %s", err)
+		}
+		vendorEscrowPubkey, err := btcec.ParsePubKey(order.Listings[0].Listing.VendorID.Pubkeys.Escrow, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+		vendorKey, err := utils.GenerateEscrowPublicKey(vendorEscrowPubkey, chaincode)
+		if err != nil {
+			return nil, err
+		}
+		buyerEscrowPubkey, err := btcec.ParsePubKey(order.BuyerID.Pubkeys.Escrow, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+		buyerKey, err := utils.GenerateEscrowPublicKey(buyerEscrowPubkey, chaincode)
+		if err != nil {
+			return nil, err
+		}
+		moderatorEscrowPubkey := priv.PubKey()
+		moderatorKey, err := utils.GenerateEscrowPublicKey(moderatorEscrowPubkey, chaincode)
+		if err != nil {
+			return nil, err
+		}
+		wal, err := processor.multiwallet.WalletForCurrencyCode("MCK")
+		if err != nil {
+			return nil, err
+		}
+		escrowWallet, ok := wal.(iwallet.EscrowWithTimeout)
+		if !ok {
+			return nil, errors.New("wallet does not support escrow")
+		}
+		address, script, err := escrowWallet.CreateMultisigWithTimeout([]btcec.PublicKey{*buyerKey, *vendorKey, *moderatorKey}, 2, time.Hour*time.Duration(order.Listings[0].Listing.Metadata.EscrowTimeoutHours), *vendorKey)
+		if err != nil {
+			return nil, err
+		}
+
+		order.Payment.Method = pb.OrderOpen_Payment_MODERATED
+		order.Payment.Moderator = "12D3KooWDUcbMF23kLEVAV3ES7ysWiD2GBh87DHDx3buRNDLFpo8"
+		order.Payment.ModeratorKey = priv.PubKey().SerializeCompressed()
+		order.Payment.Address = address.String()
+		order.Payment.Script = hex.EncodeToString(script)
+		order.Payment.ModeratorFee = &pb.OrderOpen_Payment_ModeratorFee{Fixed: "0", Percentage: 10}
+
+		return order, nil
+	}
+
+	// newCouponOrder returns an order placed two hours ago for the listing
+	// using its coupon. The payment amount and taxes are calculated by the
+	// buyer.
+	newCouponOrder := func(sl *pb.SignedListing) (*pb.OrderOpen, error) {
+		order, err := factory.NewOrder()
+		if err != nil {
+			return nil, err
+		}
+		order.Listings[0] = sl
+		hash, err := utils.HashListing(sl)
+		if err != nil {
+			return nil, err
+		}
+		order.Items[0].ListingHash = hash.B58String()
+		order.Items[0].CouponCodes = []string{"insider"}
+		order.Timestamp, err = ptypes.TimestampProto(time.Now().Add(-time.Hour * 2))
+		if err != nil {
+			return nil, err
+		}
+		total, taxes, err := CalculateOrderTotalWithTaxes(order, processor.erp)
+		if err != nil {
+			return nil, err
+		}
+		order.Payment.Amount = total.String()
+		order.Taxes = taxes
+		return order, nil
+	}
+
+	// newListingOrder returns an order for the quantity of the listing. The
+	// payment amount and taxes are calculated by the buyer.
+	newListingOrder := func(sl *pb.SignedListing, quantity string) (*pb.OrderOpen, error) {
+		order, err := factory.NewOrder()
+		if err != nil {
+			return nil, err
+		}
+		order.Listings[0] = sl
+		hash, err := utils.HashListing(sl)
+		if err != nil {
+			return nil, err
+		}
+		order.Items[0].ListingHash = hash.B58String()
+		order.Items[0].Quantity = quantity
+		total, taxes, err := CalculateOrderTotalWithTaxes(order, processor.erp)
+		if err != nil {
+			return nil, err
+		}
+		order.Payment.Amount = total.String()
+		order.Taxes = taxes
+		return order, nil
+	}
+
 	tests := []struct {
 		order   func() (*pb.OrderOpen, error)
 		valid   bool
@@ -1001,62 +1382,98 @@ func Test_validateOrderOpen(t *testing.T) {
 		},
 		{
 			// Valid moderated address
+			order: newModeratedOrder,
+			valid: true,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Missing moderator fee
 			order: func() (*pb.OrderOpen, error) {
-				order, err := factory.NewOrder()
-				if err != nil {
-					return nil, err
-				}
-				priv, err := btcec.NewPrivateKey(btcec.S256())
-				if err != nil {
-					return nil, err
-				}
-				chaincode, err := hex.DecodeString(order.Payment.Chaincode)
-				if err != nil {
-					return nil, fmt.Errorf("chaincode parse error: %s", err)
-				}
-				vendorEscrowPubkey, err := btcec.ParsePubKey(order.Listings[0].Listing.VendorID.Pubkeys.Escrow, btcec.S256())
+				order, err := newModeratedOrder()
 				if err != nil {
 					return nil, err
 				}
-				vendorKey, err := utils.GenerateEscrowPublicKey(vendorEscrowPubkey, chaincode)
-				if err != nil {
-					return nil, err
-				}
-				buyerEscrowPubkey, err := btcec.ParsePubKey(order.BuyerID.Pubkeys.Escrow, btcec.S256())
+				order.Payment.ModeratorFee = nil
+				return order, nil
+			},
+			valid: false,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Invalid moderator fixed fee
+			order: func() (*pb.OrderOpen, error) {
+				order, err := newModeratedOrder()
 				if err != nil {
 					return nil, err
 				}
-				buyerKey, err := utils.GenerateEscrowPublicKey(buyerEscrowPubkey, chaincode)
+				order.Payment.ModeratorFee.Fixed = "-10"
+				return order, nil
+			},
+			valid: false,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Mispayment buffer within our limit
+			order: func() (*pb.OrderOpen, error) {
+				order, err := factory.NewOrder()
 				if err != nil {
 					return nil, err
 				}
-				moderatorEscrowPubkey := priv.PubKey()
-				moderatorKey, err := utils.GenerateEscrowPublicKey(moderatorEscrowPubkey, chaincode)
+				order.Payment.MispaymentBuffer = 1
+				return order, nil
+			},
+			valid: true,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Mispayment buffer exceeds our limit
+			order: func() (*pb.OrderOpen, error) {
+				order, err := factory.NewOrder()
 				if err != nil {
 					return nil, err
 				}
-				wal, err := processor.multiwallet.WalletForCurrencyCode("MCK")
+				order.Payment.MispaymentBuffer = 5
+				return order, nil
+			},
+			valid: false,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Negative mispayment buffer
+			order: func() (*pb.OrderOpen, error) {
+				order, err := factory.NewOrder()
 				if err != nil {
 					return nil, err
 				}
-				escrowWallet, ok := wal.(iwallet.EscrowWithTimeout)
-				if !ok {
-					return nil, errors.New("wallet does not support escrow")
-				}
-				address, script, err := escrowWallet.CreateMultisigWithTimeout([]btcec.PublicKey{*buyerKey, *vendorKey, *moderatorKey}, 2, time.Hour*time.Duration(order.Listings[0].Listing.Metadata.EscrowTimeoutHours), *vendorKey)
+				order.Payment.MispaymentBuffer = -1
+				return order, nil
+			},
+			valid: false,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Moderator fee percentage out of range
+			order: func() (*pb.OrderOpen, error) {
+				order, err := newModeratedOrder()
 				if err != nil {
 					return nil, err
 				}
-
-				order.Payment.Method = pb.OrderOpen_Payment_MODERATED
-				order.Payment.Moderator = "12D3KooWDUcbMF23kLEVAV3ES7ysWiD2GBh87DHDx3buRNDLFpo8"
-				order.Payment.ModeratorKey = priv.PubKey().SerializeCompressed()
-				order.Payment.Address = address.String()
-				order.Payment.Script = hex.EncodeToString(script)
-
+				order.Payment.ModeratorFee.Percentage = 101
 				return order, nil
 			},
-			valid: true,
+			valid: false,
 			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
 				return utils.CalcOrderID(order)
 			},
@@ -1138,6 +1555,7 @@ func Test_validateOrderOpen(t *testing.T) {
 				order.Payment.ModeratorKey = priv.PubKey().SerializeCompressed()
 				order.Payment.Address = address.String()
 				order.Payment.Script = "fasdfad"
+				order.Payment.ModeratorFee = &pb.OrderOpen_Payment_ModeratorFee{Fixed: "0"}
 				return order, nil
 			},
 			valid: false,
@@ -1276,6 +1694,225 @@ func Test_validateOrderOpen(t *testing.T) {
 				return utils.MultihashSha256([]byte{0x00})
 			},
 		},
+		{
+			// Payment amount doesn't match the order total
+			order: func() (*pb.OrderOpen, error) {
+				order, err := factory.NewOrder()
+				if err != nil {
+					return nil, err
+				}
+				order.Payment.Amount = "1"
+				return order, nil
+			},
+			valid: false,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Payment amount outside the exchange rate tolerance
+			order: func() (*pb.OrderOpen, error) {
+				order, err := factory.NewOrder()
+				if err != nil {
+					return nil, err
+				}
+				order.Payment.Amount = "5092221"
+				return order, nil
+			},
+			valid: false,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Payment amount within the exchange rate tolerance
+			order: func() (*pb.OrderOpen, error) {
+				order, err := factory.NewOrder()
+				if err != nil {
+					return nil, err
+				}
+				order.Payment.Amount = "5002221"
+				return order, nil
+			},
+			valid: true,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Coupon still valid when we receive the order
+			order: func() (*pb.OrderOpen, error) {
+				return newCouponOrder(activeCoupon)
+			},
+			valid: true,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Coupon expired before we received the order
+			order: func() (*pb.OrderOpen, error) {
+				return newCouponOrder(expiredCoupon)
+			},
+			valid: false,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Payment amount uses the tier price
+			order: func() (*pb.OrderOpen, error) {
+				return newListingOrder(priceTier, "2")
+			},
+			valid: true,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Payment amount ignores the tier price
+			order: func() (*pb.OrderOpen, error) {
+				order, err := newListingOrder(priceTier, "2")
+				if err != nil {
+					return nil, err
+				}
+				untiered, err := factory.NewOrder()
+				if err != nil {
+					return nil, err
+				}
+				untiered.Items[0].Quantity = "2"
+				total, err := CalculateOrderTotal(untiered, processor.erp)
+				if err != nil {
+					return nil, err
+				}
+				order.Payment.Amount = total.String()
+				return order, nil
+			},
+			valid: false,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Payment amount uses the weight band shipping rate
+			order: func() (*pb.OrderOpen, error) {
+				return newListingOrder(weightBand, "1")
+			},
+			valid: true,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Payment amount uses the flat shipping rate instead of the
+			// weight band
+			order: func() (*pb.OrderOpen, error) {
+				order, err := newListingOrder(weightBand, "1")
+				if err != nil {
+					return nil, err
+				}
+				flat, err := factory.NewOrder()
+				if err != nil {
+					return nil, err
+				}
+				order.Payment.Amount = flat.Payment.Amount
+				return order, nil
+			},
+			valid: false,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Payment amount uses free shipping
+			order: func() (*pb.OrderOpen, error) {
+				return newListingOrder(freeShipping, "1")
+			},
+			valid: true,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Payment amount charges shipping above the free shipping
+			// threshold
+			order: func() (*pb.OrderOpen, error) {
+				order, err := newListingOrder(freeShipping, "1")
+				if err != nil {
+					return nil, err
+				}
+				flat, err := factory.NewOrder()
+				if err != nil {
+					return nil, err
+				}
+				order.Payment.Amount = flat.Payment.Amount
+				return order, nil
+			},
+			valid: false,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Tax breakdown missing
+			order: func() (*pb.OrderOpen, error) {
+				order, err := factory.NewOrder()
+				if err != nil {
+					return nil, err
+				}
+				order.Taxes = nil
+				return order, nil
+			},
+			valid: false,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Tax amount doesn't match our calculation
+			order: func() (*pb.OrderOpen, error) {
+				order, err := factory.NewOrder()
+				if err != nil {
+					return nil, err
+				}
+				order.Taxes[0].Amount = "1"
+				return order, nil
+			},
+			valid: false,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Physical item without a shipping option
+			order: func() (*pb.OrderOpen, error) {
+				order, err := factory.NewOrder()
+				if err != nil {
+					return nil, err
+				}
+				order.Items[0].ShippingOption = nil
+				return order, nil
+			},
+			valid: false,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
+		{
+			// Physical item without a shipping address
+			order: func() (*pb.OrderOpen, error) {
+				order, err := factory.NewOrder()
+				if err != nil {
+					return nil, err
+				}
+				order.Shipping = nil
+				return order, nil
+			},
+			valid: false,
+			orderID: func(order *pb.OrderOpen) (*multihash.Multihash, error) {
+				return utils.CalcOrderID(order)
+			},
+		},
 		{
 			// Len ratings keys doesn't match len items.
 			order: func() (*pb.OrderOpen, error) {
@@ -56,6 +56,12 @@ func (op *OrderProcessor) processOrderRejectMessage(dbtx database.Tx, order *mod
 	if order.Role() == models.RoleBuyer {
 		log.Infof("Received ORDER_REJECT message for order %s", order.ID)
 	} else if order.Role() == models.RoleVendor {
+		if err := op.releaseInventory(dbtx, order); err != nil {
+			log.Errorf("Error releasing inventory for order %s: %s", order.ID, err)
+		}
+		if err := op.releaseCoupons(dbtx, order); err != nil {
+			log.Errorf("Error releasing coupons for order %s: %s", order.ID, err)
+		}
 		log.Infof("Processed own ORDER_REJECT for orderID: %s", order.ID)
 	}
 
@@ -0,0 +1,270 @@
+package orders
+
+import (
+	"encoding/hex"
+	"errors"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	iwallet "github.com/cpacia/wallet-interface"
+	"github.com/golang/protobuf/ptypes"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"math/big"
+)
+
+func (op *OrderProcessor) processPaymentFinalizedMessage(dbtx database.Tx, order *models.Order, peer peer.ID, message *npb.OrderMessage) (interface{}, error) {
+	paymentFinalized := new(pb.PaymentFinalized)
+	if err := ptypes.UnmarshalAny(message.Message, paymentFinalized); err != nil {
+		return nil, err
+	}
+	dup, err := isDuplicate(paymentFinalized, order.SerializedPaymentFinalized)
+	if err != nil {
+		return nil, err
+	}
+	if order.SerializedPaymentFinalized != nil && !dup {
+		log.Errorf("Duplicate PAYMENT_FINALIZED message does not match original for order: %s", order.ID)
+		return nil, ErrChangedMessage
+	} else if dup {
+		return nil, nil
+	}
+
+	if order.SerializedOrderCancel != nil {
+		log.Errorf("Received PAYMENT_FINALIZED message for order %s after ORDER_CANCEL", order.ID)
+		return nil, ErrUnexpectedMessage
+	}
+
+	if order.SerializedOrderReject != nil {
+		log.Errorf("Received PAYMENT_FINALIZED message for order %s after ORDER_REJECT", order.ID)
+		return nil, ErrUnexpectedMessage
+	}
+
+	orderOpen, err := order.OrderOpenMessage()
+	if models.IsMessageNotExistError(err) {
+		return nil, order.ParkMessage(message)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if peer.Pretty() != orderOpen.BuyerID.PeerID {
+		return nil, errors.New("payment finalized message not sent by the buyer")
+	}
+
+	switch orderOpen.Payment.Method {
+	case pb.OrderOpen_Payment_CANCELABLE:
+	case pb.OrderOpen_Payment_MODERATED:
+		if paymentFinalized.ReleaseInfo == nil {
+			return nil, errors.New("payment finalized message is missing escrow release")
+		}
+	default:
+		return nil, errors.New("payment finalized on an order that is not cancelable or moderated")
+	}
+
+	// The vendor moves the funds into their wallet. If this fails the funds
+	// remain in the payment address and the release can be retried by
+	// reprocessing the message.
+	if order.Role() == models.RoleVendor {
+		wallet, err := op.multiwallet.WalletForCurrencyCode(orderOpen.Payment.Coin)
+		if err != nil {
+			return nil, err
+		}
+
+		if orderOpen.Payment.Method == pb.OrderOpen_Payment_MODERATED {
+			if err := op.releaseVendorEscrowFunds(wallet, order, orderOpen, paymentFinalized.ReleaseInfo); err != nil {
+				log.Errorf("Error releasing funds from escrow during payment finalized processing: %s", err.Error())
+			}
+		} else {
+			if err := op.sweepCancelableAddress(wallet, order, orderOpen); err != nil {
+				log.Errorf("Error moving funds from cancelable address during payment finalized processing: %s", err.Error())
+			}
+		}
+	}
+
+	var event interface{}
+	if order.Role() == models.RoleVendor {
+		log.Infof("Received PAYMENT_FINALIZED message for order %s", order.ID)
+		event = &events.VendorFinalizedPayment{
+			OrderID: order.ID.String(),
+		}
+	} else if order.Role() == models.RoleBuyer {
+		log.Infof("Processed own PAYMENT_FINALIZED for order %s", order.ID)
+	}
+
+	return event, order.PutMessage(message)
+}
+
+// releaseVendorEscrowFunds signs the buyer's escrow release with the vendor's
+// key and broadcasts the transaction. The release must pay to the address the
+// vendor used in their fulfillment.
+func (op *OrderProcessor) releaseVendorEscrowFunds(wallet iwallet.Wallet, order *models.Order, orderOpen *pb.OrderOpen, releaseInfo *pb.EscrowRelease) error {
+	escrowWallet, ok := wallet.(iwallet.Escrow)
+	if !ok {
+		return errors.New("wallet for moderated order does not support escrow")
+	}
+
+	fulfillments, err := order.OrderFulfillmentMessages()
+	if err != nil {
+		return err
+	}
+	validAddress := false
+	for _, fulfillment := range fulfillments {
+		if fulfillment.ReleaseInfo != nil && fulfillment.ReleaseInfo.ToAddress == releaseInfo.ToAddress {
+			validAddress = true
+		}
+	}
+	if !validAddress {
+		return errors.New("escrow release does not pay our address")
+	}
+	if _, ok := new(big.Int).SetString(releaseInfo.ToAmount, 10); !ok {
+		return errors.New("invalid payment amount")
+	}
+
+	txn := iwallet.Transaction{
+		To: []iwallet.SpendInfo{
+			{
+				Address: iwallet.NewAddress(releaseInfo.ToAddress, iwallet.CoinType(orderOpen.Payment.Coin)),
+				Amount:  iwallet.NewAmount(releaseInfo.ToAmount),
+			},
+		},
+	}
+	for _, id := range releaseInfo.FromIDs {
+		txn.From = append(txn.From, iwallet.SpendInfo{ID: id})
+	}
+
+	var buyerSigs []iwallet.EscrowSignature
+	for _, sig := range releaseInfo.EscrowSignatures {
+		buyerSigs = append(buyerSigs, iwallet.EscrowSignature{
+			Index:     int(sig.Index),
+			Signature: sig.Signature,
+		})
+	}
+
+	script, err := hex.DecodeString(orderOpen.Payment.Script)
+	if err != nil {
+		return err
+	}
+
+	chainCode, err := hex.DecodeString(orderOpen.Payment.Chaincode)
+	if err != nil {
+		return err
+	}
+
+	vendorKey, err := utils.GenerateEscrowPrivateKey(op.escrowPrivateKey, chainCode)
+	if err != nil {
+		return err
+	}
+
+	vendorSigs, err := escrowWallet.SignMultisigTransaction(txn, *vendorKey, script)
+	if err != nil {
+		return err
+	}
+
+	dbtx, err := wallet.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := escrowWallet.BuildAndSend(dbtx, txn, [][]iwallet.EscrowSignature{buyerSigs, vendorSigs}, script); err != nil {
+		dbtx.Rollback()
+		return err
+	}
+	return dbtx.Commit()
+}
+
+// sweepCancelableAddress moves any unspent funds in a cancelable payment
+// address into the vendor's wallet.
+func (op *OrderProcessor) sweepCancelableAddress(wallet iwallet.Wallet, order *models.Order, orderOpen *pb.OrderOpen) error {
+	wTx, _, err := op.buildCancelableSweep(wallet, order, orderOpen)
+	if err != nil {
+		return err
+	}
+	return wTx.Commit()
+}
+
+// buildCancelableSweep builds the transaction moving the unspent funds in a
+// cancelable payment address into the vendor's wallet. The wallet transaction
+// is returned uncommitted so the caller can roll it back if something else
+// fails.
+func (op *OrderProcessor) buildCancelableSweep(wallet iwallet.Wallet, order *models.Order, orderOpen *pb.OrderOpen) (iwallet.Tx, iwallet.TransactionID, error) {
+	escrowWallet, ok := wallet.(iwallet.Escrow)
+	if !ok {
+		return nil, "", errors.New("wallet for cancelable order does not support escrow")
+	}
+
+	txs, err := order.GetTransactions()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var (
+		txn      iwallet.Transaction
+		totalOut = iwallet.NewAmount(0)
+	)
+	spent := make(map[string]bool)
+	for _, tx := range txs {
+		for _, from := range tx.From {
+			spent[hex.EncodeToString(from.ID)] = true
+		}
+	}
+	for _, tx := range txs {
+		for _, to := range tx.To {
+			if !spent[hex.EncodeToString(to.ID)] && to.Address.String() == orderOpen.Payment.Address {
+				txn.From = append(txn.From, to)
+				totalOut = totalOut.Add(to.Amount)
+			}
+		}
+	}
+	if len(txn.From) == 0 {
+		return nil, "", errors.New("payment address is empty")
+	}
+
+	escrowFee, err := escrowWallet.EstimateEscrowFee(1, iwallet.FlNormal)
+	if err != nil {
+		return nil, "", err
+	}
+	// The escrow fee is calculated as 100% of EstimateEscrowFee for the first input.
+	// Plus 50% of EstimateEscrowFee for each additional input.
+	escrowFee = escrowFee.Add(escrowFee.Div(iwallet.NewAmount(2)).Mul(iwallet.NewAmount(len(txn.From) - 1)))
+
+	toAddress, err := wallet.CurrentAddress()
+	if err != nil {
+		return nil, "", err
+	}
+	txn.To = append(txn.To, iwallet.SpendInfo{
+		Address: toAddress,
+		Amount:  totalOut.Sub(escrowFee),
+	})
+
+	script, err := hex.DecodeString(orderOpen.Payment.Script)
+	if err != nil {
+		return nil, "", err
+	}
+
+	chainCode, err := hex.DecodeString(orderOpen.Payment.Chaincode)
+	if err != nil {
+		return nil, "", err
+	}
+
+	vendorKey, err := utils.GenerateEscrowPrivateKey(op.escrowPrivateKey, chainCode)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sigs, err := escrowWallet.SignMultisigTransaction(txn, *vendorKey, script)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dbtx, err := wallet.Begin()
+	if err != nil {
+		return nil, "", err
+	}
+	txid, err := escrowWallet.BuildAndSend(dbtx, txn, [][]iwallet.EscrowSignature{sigs}, script)
+	if err != nil {
+		dbtx.Rollback()
+		return nil, "", err
+	}
+	return dbtx, txid, nil
+}
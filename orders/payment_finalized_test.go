@@ -0,0 +1,238 @@
+package orders
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	iwallet "github.com/cpacia/wallet-interface"
+	"github.com/libp2p/go-libp2p-crypto"
+	"github.com/libp2p/go-libp2p-peer"
+	"reflect"
+	"testing"
+)
+
+func TestOrderProcessor_processPaymentFinalizedMessage(t *testing.T) {
+	op, teardown, err := newMockOrderProcessor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	_, pub, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buyer, err := peer.IDFromPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orderID := "1234"
+
+	newOrderOpen := func(method pb.OrderOpen_Payment_Method) *pb.OrderOpen {
+		return &pb.OrderOpen{
+			Listings: []*pb.SignedListing{
+				{
+					Listing: &pb.Listing{
+						VendorID: &pb.ID{
+							PeerID: op.identity.Pretty(),
+						},
+						Item: &pb.Listing_Item{
+							Images: []*pb.Listing_Item_Image{
+								{
+									Small: "aaaa",
+									Tiny:  "bbbb",
+								},
+							},
+						},
+					},
+				},
+			},
+			BuyerID: &pb.ID{
+				PeerID: buyer.Pretty(),
+			},
+			Payment: &pb.OrderOpen_Payment{
+				Coin:    iwallet.CtMock,
+				Method:  method,
+				Address: "abc",
+			},
+		}
+	}
+
+	putOrderOpen := func(order *models.Order, method pb.OrderOpen_Payment_Method) error {
+		order.ID = models.OrderID(orderID)
+		order.SetRole(models.RoleVendor)
+		return order.PutMessage(&npb.OrderMessage{
+			Signature:   []byte("abc"),
+			Message:     mustBuildAny(newOrderOpen(method)),
+			MessageType: npb.OrderMessage_ORDER_OPEN,
+		})
+	}
+
+	tests := []struct {
+		setup         func(order *models.Order) error
+		sender        peer.ID
+		finalized     *pb.PaymentFinalized
+		expectedError error
+		expectedEvent interface{}
+		check         func(order *models.Order) error
+	}{
+		{
+			// Cancelable order.
+			setup: func(order *models.Order) error {
+				return putOrderOpen(order, pb.OrderOpen_Payment_CANCELABLE)
+			},
+			sender:        buyer,
+			finalized:     &pb.PaymentFinalized{},
+			expectedError: nil,
+			expectedEvent: &events.VendorFinalizedPayment{
+				OrderID: orderID,
+			},
+			check: func(order *models.Order) error {
+				if order.SerializedPaymentFinalized == nil {
+					return errors.New("payment finalized not saved")
+				}
+				return nil
+			},
+		},
+		{
+			// Moderated order.
+			setup: func(order *models.Order) error {
+				return putOrderOpen(order, pb.OrderOpen_Payment_MODERATED)
+			},
+			sender: buyer,
+			finalized: &pb.PaymentFinalized{
+				ReleaseInfo: &pb.EscrowRelease{
+					ToAddress: "xyz",
+					ToAmount:  "1000",
+				},
+			},
+			expectedError: nil,
+			expectedEvent: &events.VendorFinalizedPayment{
+				OrderID: orderID,
+			},
+		},
+		{
+			// Moderated order missing release info.
+			setup: func(order *models.Order) error {
+				return putOrderOpen(order, pb.OrderOpen_Payment_MODERATED)
+			},
+			sender:        buyer,
+			finalized:     &pb.PaymentFinalized{},
+			expectedError: errors.New("payment finalized message is missing escrow release"),
+			expectedEvent: nil,
+		},
+		{
+			// Direct order.
+			setup: func(order *models.Order) error {
+				return putOrderOpen(order, pb.OrderOpen_Payment_DIRECT)
+			},
+			sender:        buyer,
+			finalized:     &pb.PaymentFinalized{},
+			expectedError: errors.New("payment finalized on an order that is not cancelable or moderated"),
+			expectedEvent: nil,
+		},
+		{
+			// Not sent by the buyer.
+			setup: func(order *models.Order) error {
+				return putOrderOpen(order, pb.OrderOpen_Payment_CANCELABLE)
+			},
+			sender:        op.identity,
+			finalized:     &pb.PaymentFinalized{},
+			expectedError: errors.New("payment finalized message not sent by the buyer"),
+			expectedEvent: nil,
+		},
+		{
+			// Order cancel already exists.
+			setup: func(order *models.Order) error {
+				if err := putOrderOpen(order, pb.OrderOpen_Payment_CANCELABLE); err != nil {
+					return err
+				}
+				order.SerializedOrderCancel = []byte{0x00}
+				return nil
+			},
+			sender:        buyer,
+			finalized:     &pb.PaymentFinalized{},
+			expectedError: ErrUnexpectedMessage,
+			expectedEvent: nil,
+		},
+		{
+			// Duplicate payment finalized.
+			setup: func(order *models.Order) error {
+				if err := putOrderOpen(order, pb.OrderOpen_Payment_CANCELABLE); err != nil {
+					return err
+				}
+				return order.PutMessage(&npb.OrderMessage{
+					Signature:   []byte("abc"),
+					Message:     mustBuildAny(&pb.PaymentFinalized{}),
+					MessageType: npb.OrderMessage_PAYMENT_FINALIZED,
+				})
+			},
+			sender:        buyer,
+			finalized:     &pb.PaymentFinalized{},
+			expectedError: nil,
+			expectedEvent: nil,
+		},
+		{
+			// Out of order.
+			setup: func(order *models.Order) error {
+				order.ID = models.OrderID(orderID)
+				return nil
+			},
+			sender:        buyer,
+			finalized:     &pb.PaymentFinalized{},
+			expectedError: nil,
+			expectedEvent: nil,
+			check: func(order *models.Order) error {
+				parked, err := order.GetParkedMessages()
+				if err != nil {
+					return err
+				}
+				if len(parked) != 1 {
+					return errors.New("message not parked")
+				}
+				return nil
+			},
+		},
+	}
+
+	for i, test := range tests {
+		order := &models.Order{}
+		if err := test.setup(order); err != nil {
+			t.Errorf("Test %d setup error: %s", i, err)
+			continue
+		}
+		orderMsg := &npb.OrderMessage{
+			OrderID:     orderID,
+			MessageType: npb.OrderMessage_PAYMENT_FINALIZED,
+			Message:     mustBuildAny(test.finalized),
+		}
+		err := op.db.Update(func(tx database.Tx) error {
+			event, err := op.processPaymentFinalizedMessage(tx, order, test.sender, orderMsg)
+			if test.expectedError != nil {
+				if err == nil || err.Error() != test.expectedError.Error() {
+					return fmt.Errorf("incorrect error returned. Expected %v, got %v", test.expectedError, err)
+				}
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if !reflect.DeepEqual(event, test.expectedEvent) {
+				return fmt.Errorf("incorrect event returned")
+			}
+			if test.check != nil {
+				return test.check(order)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf("Error executing db update in test %d: %s", i, err)
+		}
+	}
+}
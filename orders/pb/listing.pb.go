@@ -312,23 +312,24 @@ func (m *Listing_Metadata) GetPricingCurrency() *Currency {
 }
 
 type Listing_Item struct {
-	Title                      string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Description                string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	ProcessingTime             string                 `protobuf:"bytes,3,opt,name=processingTime,proto3" json:"processingTime,omitempty"`
-	Nsfw                       bool                   `protobuf:"varint,4,opt,name=nsfw,proto3" json:"nsfw,omitempty"`
-	Tags                       []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
-	Images                     []*Listing_Item_Image  `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty"`
-	Categories                 []string               `protobuf:"bytes,7,rep,name=categories,proto3" json:"categories,omitempty"`
-	Grams                      float32                `protobuf:"fixed32,8,opt,name=grams,proto3" json:"grams,omitempty"`
-	Condition                  string                 `protobuf:"bytes,9,opt,name=condition,proto3" json:"condition,omitempty"`
-	Options                    []*Listing_Item_Option `protobuf:"bytes,10,rep,name=options,proto3" json:"options,omitempty"`
-	Skus                       []*Listing_Item_Sku    `protobuf:"bytes,11,rep,name=skus,proto3" json:"skus,omitempty"`
-	Price                      string                 `protobuf:"bytes,12,opt,name=price,proto3" json:"price,omitempty"`
-	CryptoListingCurrencyCode  string                 `protobuf:"bytes,13,opt,name=cryptoListingCurrencyCode,proto3" json:"cryptoListingCurrencyCode,omitempty"`
-	CryptoListingPriceModifier float32                `protobuf:"fixed32,14,opt,name=cryptoListingPriceModifier,proto3" json:"cryptoListingPriceModifier,omitempty"`
-	XXX_NoUnkeyedLiteral       struct{}               `json:"-"`
-	XXX_unrecognized           []byte                 `json:"-"`
-	XXX_sizecache              int32                  `json:"-"`
+	Title                      string                    `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description                string                    `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	ProcessingTime             string                    `protobuf:"bytes,3,opt,name=processingTime,proto3" json:"processingTime,omitempty"`
+	Nsfw                       bool                      `protobuf:"varint,4,opt,name=nsfw,proto3" json:"nsfw,omitempty"`
+	Tags                       []string                  `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Images                     []*Listing_Item_Image     `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty"`
+	Categories                 []string                  `protobuf:"bytes,7,rep,name=categories,proto3" json:"categories,omitempty"`
+	Grams                      float32                   `protobuf:"fixed32,8,opt,name=grams,proto3" json:"grams,omitempty"`
+	Condition                  string                    `protobuf:"bytes,9,opt,name=condition,proto3" json:"condition,omitempty"`
+	Options                    []*Listing_Item_Option    `protobuf:"bytes,10,rep,name=options,proto3" json:"options,omitempty"`
+	Skus                       []*Listing_Item_Sku       `protobuf:"bytes,11,rep,name=skus,proto3" json:"skus,omitempty"`
+	Price                      string                    `protobuf:"bytes,12,opt,name=price,proto3" json:"price,omitempty"`
+	CryptoListingCurrencyCode  string                    `protobuf:"bytes,13,opt,name=cryptoListingCurrencyCode,proto3" json:"cryptoListingCurrencyCode,omitempty"`
+	CryptoListingPriceModifier float32                   `protobuf:"fixed32,14,opt,name=cryptoListingPriceModifier,proto3" json:"cryptoListingPriceModifier,omitempty"`
+	PriceTiers                 []*Listing_Item_PriceTier `protobuf:"bytes,15,rep,name=priceTiers,proto3" json:"priceTiers,omitempty"`
+	XXX_NoUnkeyedLiteral       struct{}                  `json:"-"`
+	XXX_unrecognized           []byte                    `json:"-"`
+	XXX_sizecache              int32                     `json:"-"`
 }
 
 func (m *Listing_Item) Reset()         { *m = Listing_Item{} }
@@ -454,6 +455,13 @@ func (m *Listing_Item) GetCryptoListingPriceModifier() float32 {
 	return 0
 }
 
+func (m *Listing_Item) GetPriceTiers() []*Listing_Item_PriceTier {
+	if m != nil {
+		return m.PriceTiers
+	}
+	return nil
+}
+
 type Listing_Item_Option struct {
 	Name                 string                         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Description          string                         `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
@@ -561,6 +569,7 @@ type Listing_Item_Sku struct {
 	ProductID            string                        `protobuf:"bytes,2,opt,name=productID,proto3" json:"productID,omitempty"`
 	Quantity             string                        `protobuf:"bytes,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	Surcharge            string                        `protobuf:"bytes,4,opt,name=surcharge,proto3" json:"surcharge,omitempty"`
+	PriceTiers           []*Listing_Item_PriceTier     `protobuf:"bytes,5,rep,name=priceTiers,proto3" json:"priceTiers,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
 	XXX_unrecognized     []byte                        `json:"-"`
 	XXX_sizecache        int32                         `json:"-"`
@@ -619,6 +628,13 @@ func (m *Listing_Item_Sku) GetSurcharge() string {
 	return ""
 }
 
+func (m *Listing_Item_Sku) GetPriceTiers() []*Listing_Item_PriceTier {
+	if m != nil {
+		return m.PriceTiers
+	}
+	return nil
+}
+
 type Listing_Item_Sku_Selection struct {
 	Option               string   `protobuf:"bytes,1,opt,name=option,proto3" json:"option,omitempty"`
 	Variant              string   `protobuf:"bytes,2,opt,name=variant,proto3" json:"variant,omitempty"`
@@ -666,6 +682,94 @@ func (m *Listing_Item_Sku_Selection) GetVariant() string {
 	return ""
 }
 
+type Listing_Item_PriceTier struct {
+	MinQuantity uint64 `protobuf:"varint,1,opt,name=minQuantity,proto3" json:"minQuantity,omitempty"`
+	// Types that are valid to be assigned to Discount:
+	//	*Listing_Item_PriceTier_PercentDiscount
+	//	*Listing_Item_PriceTier_Price
+	Discount             isListing_Item_PriceTier_Discount `protobuf_oneof:"discount"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *Listing_Item_PriceTier) Reset()         { *m = Listing_Item_PriceTier{} }
+func (m *Listing_Item_PriceTier) String() string { return proto.CompactTextString(m) }
+func (*Listing_Item_PriceTier) ProtoMessage()    {}
+func (*Listing_Item_PriceTier) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eb6b76026c8ca063, []int{0, 1, 2}
+}
+
+func (m *Listing_Item_PriceTier) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Listing_Item_PriceTier.Unmarshal(m, b)
+}
+func (m *Listing_Item_PriceTier) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Listing_Item_PriceTier.Marshal(b, m, deterministic)
+}
+func (m *Listing_Item_PriceTier) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Listing_Item_PriceTier.Merge(m, src)
+}
+func (m *Listing_Item_PriceTier) XXX_Size() int {
+	return xxx_messageInfo_Listing_Item_PriceTier.Size(m)
+}
+func (m *Listing_Item_PriceTier) XXX_DiscardUnknown() {
+	xxx_messageInfo_Listing_Item_PriceTier.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Listing_Item_PriceTier proto.InternalMessageInfo
+
+func (m *Listing_Item_PriceTier) GetMinQuantity() uint64 {
+	if m != nil {
+		return m.MinQuantity
+	}
+	return 0
+}
+
+type isListing_Item_PriceTier_Discount interface {
+	isListing_Item_PriceTier_Discount()
+}
+
+type Listing_Item_PriceTier_PercentDiscount struct {
+	PercentDiscount float32 `protobuf:"fixed32,2,opt,name=percentDiscount,proto3,oneof"`
+}
+
+type Listing_Item_PriceTier_Price struct {
+	Price string `protobuf:"bytes,3,opt,name=price,proto3,oneof"`
+}
+
+func (*Listing_Item_PriceTier_PercentDiscount) isListing_Item_PriceTier_Discount() {}
+
+func (*Listing_Item_PriceTier_Price) isListing_Item_PriceTier_Discount() {}
+
+func (m *Listing_Item_PriceTier) GetDiscount() isListing_Item_PriceTier_Discount {
+	if m != nil {
+		return m.Discount
+	}
+	return nil
+}
+
+func (m *Listing_Item_PriceTier) GetPercentDiscount() float32 {
+	if x, ok := m.GetDiscount().(*Listing_Item_PriceTier_PercentDiscount); ok {
+		return x.PercentDiscount
+	}
+	return 0
+}
+
+func (m *Listing_Item_PriceTier) GetPrice() string {
+	if x, ok := m.GetDiscount().(*Listing_Item_PriceTier_Price); ok {
+		return x.Price
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Listing_Item_PriceTier) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Listing_Item_PriceTier_PercentDiscount)(nil),
+		(*Listing_Item_PriceTier_Price)(nil),
+	}
+}
+
 type Listing_Item_Image struct {
 	Filename             string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
 	Original             string   `protobuf:"bytes,2,opt,name=original,proto3" json:"original,omitempty"`
@@ -682,7 +786,7 @@ func (m *Listing_Item_Image) Reset()         { *m = Listing_Item_Image{} }
 func (m *Listing_Item_Image) String() string { return proto.CompactTextString(m) }
 func (*Listing_Item_Image) ProtoMessage()    {}
 func (*Listing_Item_Image) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eb6b76026c8ca063, []int{0, 1, 2}
+	return fileDescriptor_eb6b76026c8ca063, []int{0, 1, 3}
 }
 
 func (m *Listing_Item_Image) XXX_Unmarshal(b []byte) error {
@@ -809,13 +913,15 @@ func (m *Listing_ShippingOption) GetServices() []*Listing_ShippingOption_Service
 }
 
 type Listing_ShippingOption_Service struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	EstimatedDelivery    string   `protobuf:"bytes,2,opt,name=estimatedDelivery,proto3" json:"estimatedDelivery,omitempty"`
-	Price                string   `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
-	AdditionalItemPrice  string   `protobuf:"bytes,4,opt,name=additionalItemPrice,proto3" json:"additionalItemPrice,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Name                  string                                       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	EstimatedDelivery     string                                       `protobuf:"bytes,2,opt,name=estimatedDelivery,proto3" json:"estimatedDelivery,omitempty"`
+	Price                 string                                       `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	AdditionalItemPrice   string                                       `protobuf:"bytes,4,opt,name=additionalItemPrice,proto3" json:"additionalItemPrice,omitempty"`
+	WeightBands           []*Listing_ShippingOption_Service_WeightBand `protobuf:"bytes,5,rep,name=weightBands,proto3" json:"weightBands,omitempty"`
+	FreeShippingThreshold string                                       `protobuf:"bytes,6,opt,name=freeShippingThreshold,proto3" json:"freeShippingThreshold,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}                                     `json:"-"`
+	XXX_unrecognized      []byte                                       `json:"-"`
+	XXX_sizecache         int32                                        `json:"-"`
 }
 
 func (m *Listing_ShippingOption_Service) Reset()         { *m = Listing_ShippingOption_Service{} }
@@ -871,6 +977,71 @@ func (m *Listing_ShippingOption_Service) GetAdditionalItemPrice() string {
 	return ""
 }
 
+func (m *Listing_ShippingOption_Service) GetWeightBands() []*Listing_ShippingOption_Service_WeightBand {
+	if m != nil {
+		return m.WeightBands
+	}
+	return nil
+}
+
+func (m *Listing_ShippingOption_Service) GetFreeShippingThreshold() string {
+	if m != nil {
+		return m.FreeShippingThreshold
+	}
+	return ""
+}
+
+type Listing_ShippingOption_Service_WeightBand struct {
+	MaxGrams             uint64   `protobuf:"varint,1,opt,name=maxGrams,proto3" json:"maxGrams,omitempty"`
+	Price                string   `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Listing_ShippingOption_Service_WeightBand) Reset() {
+	*m = Listing_ShippingOption_Service_WeightBand{}
+}
+func (m *Listing_ShippingOption_Service_WeightBand) String() string {
+	return proto.CompactTextString(m)
+}
+func (*Listing_ShippingOption_Service_WeightBand) ProtoMessage() {}
+func (*Listing_ShippingOption_Service_WeightBand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eb6b76026c8ca063, []int{0, 2, 0, 0}
+}
+
+func (m *Listing_ShippingOption_Service_WeightBand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Listing_ShippingOption_Service_WeightBand.Unmarshal(m, b)
+}
+func (m *Listing_ShippingOption_Service_WeightBand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Listing_ShippingOption_Service_WeightBand.Marshal(b, m, deterministic)
+}
+func (m *Listing_ShippingOption_Service_WeightBand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Listing_ShippingOption_Service_WeightBand.Merge(m, src)
+}
+func (m *Listing_ShippingOption_Service_WeightBand) XXX_Size() int {
+	return xxx_messageInfo_Listing_ShippingOption_Service_WeightBand.Size(m)
+}
+func (m *Listing_ShippingOption_Service_WeightBand) XXX_DiscardUnknown() {
+	xxx_messageInfo_Listing_ShippingOption_Service_WeightBand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Listing_ShippingOption_Service_WeightBand proto.InternalMessageInfo
+
+func (m *Listing_ShippingOption_Service_WeightBand) GetMaxGrams() uint64 {
+	if m != nil {
+		return m.MaxGrams
+	}
+	return 0
+}
+
+func (m *Listing_ShippingOption_Service_WeightBand) GetPrice() string {
+	if m != nil {
+		return m.Price
+	}
+	return ""
+}
+
 type Listing_Tax struct {
 	TaxType              string        `protobuf:"bytes,1,opt,name=taxType,proto3" json:"taxType,omitempty"`
 	TaxRegions           []CountryCode `protobuf:"varint,2,rep,packed,name=taxRegions,proto3,enum=CountryCode" json:"taxRegions,omitempty"`
@@ -943,10 +1114,15 @@ type Listing_Coupon struct {
 	// Types that are valid to be assigned to Discount:
 	//	*Listing_Coupon_PercentDiscount
 	//	*Listing_Coupon_PriceDiscount
-	Discount             isListing_Coupon_Discount `protobuf_oneof:"discount"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	Discount               isListing_Coupon_Discount `protobuf_oneof:"discount"`
+	Expiry                 *timestamp.Timestamp      `protobuf:"bytes,6,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	MaxRedemptions         uint32                    `protobuf:"varint,7,opt,name=maxRedemptions,proto3" json:"maxRedemptions,omitempty"`
+	MaxRedemptionsPerBuyer uint32                    `protobuf:"varint,8,opt,name=maxRedemptionsPerBuyer,proto3" json:"maxRedemptionsPerBuyer,omitempty"`
+	MinimumOrderValue      string                    `protobuf:"bytes,9,opt,name=minimumOrderValue,proto3" json:"minimumOrderValue,omitempty"`
+	Skus                   []string                  `protobuf:"bytes,10,rep,name=skus,proto3" json:"skus,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                  `json:"-"`
+	XXX_unrecognized       []byte                    `json:"-"`
+	XXX_sizecache          int32                     `json:"-"`
 }
 
 func (m *Listing_Coupon) Reset()         { *m = Listing_Coupon{} }
@@ -1055,6 +1231,41 @@ func (m *Listing_Coupon) GetPriceDiscount() string {
 	return ""
 }
 
+func (m *Listing_Coupon) GetExpiry() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expiry
+	}
+	return nil
+}
+
+func (m *Listing_Coupon) GetMaxRedemptions() uint32 {
+	if m != nil {
+		return m.MaxRedemptions
+	}
+	return 0
+}
+
+func (m *Listing_Coupon) GetMaxRedemptionsPerBuyer() uint32 {
+	if m != nil {
+		return m.MaxRedemptionsPerBuyer
+	}
+	return 0
+}
+
+func (m *Listing_Coupon) GetMinimumOrderValue() string {
+	if m != nil {
+		return m.MinimumOrderValue
+	}
+	return ""
+}
+
+func (m *Listing_Coupon) GetSkus() []string {
+	if m != nil {
+		return m.Skus
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Listing_Coupon) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1131,9 +1342,11 @@ func init() {
 	proto.RegisterType((*Listing_Item_Option_Variant)(nil), "Listing.Item.Option.Variant")
 	proto.RegisterType((*Listing_Item_Sku)(nil), "Listing.Item.Sku")
 	proto.RegisterType((*Listing_Item_Sku_Selection)(nil), "Listing.Item.Sku.Selection")
+	proto.RegisterType((*Listing_Item_PriceTier)(nil), "Listing.Item.PriceTier")
 	proto.RegisterType((*Listing_Item_Image)(nil), "Listing.Item.Image")
 	proto.RegisterType((*Listing_ShippingOption)(nil), "Listing.ShippingOption")
 	proto.RegisterType((*Listing_ShippingOption_Service)(nil), "Listing.ShippingOption.Service")
+	proto.RegisterType((*Listing_ShippingOption_Service_WeightBand)(nil), "Listing.ShippingOption.Service.WeightBand")
 	proto.RegisterType((*Listing_Tax)(nil), "Listing.Tax")
 	proto.RegisterType((*Listing_Coupon)(nil), "Listing.Coupon")
 	proto.RegisterType((*SignedListing)(nil), "SignedListing")
@@ -1142,89 +1355,102 @@ func init() {
 func init() { proto.RegisterFile("listing.proto", fileDescriptor_eb6b76026c8ca063) }
 
 var fileDescriptor_eb6b76026c8ca063 = []byte{
-	// 1334 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xdf, 0x6e, 0xdb, 0xb6,
-	0x1a, 0xaf, 0x62, 0xc7, 0x7f, 0x3e, 0x3b, 0x4e, 0xc2, 0xf6, 0x9c, 0xa3, 0xe3, 0x53, 0xb4, 0x39,
-	0x46, 0x57, 0xa4, 0x6b, 0xa7, 0x76, 0xee, 0x4d, 0x81, 0x6e, 0x03, 0x12, 0x3b, 0x6d, 0x8c, 0xa6,
-	0x4b, 0x40, 0xa7, 0xc5, 0xba, 0x9b, 0x82, 0x95, 0x18, 0x85, 0xa8, 0x24, 0x6a, 0x14, 0x95, 0xc6,
-	0xef, 0x30, 0x60, 0xc0, 0x76, 0xb1, 0x27, 0xd9, 0x0b, 0xec, 0x6e, 0x8f, 0xb0, 0xb7, 0x19, 0xf8,
-	0x89, 0x52, 0xec, 0xc4, 0x19, 0x76, 0xc7, 0xef, 0xf7, 0xfd, 0x48, 0x7e, 0xff, 0x49, 0x58, 0x8b,
-	0x44, 0xa6, 0x45, 0x12, 0x7a, 0xa9, 0x92, 0x5a, 0xf6, 0x89, 0x2f, 0xf3, 0x44, 0xab, 0x99, 0x2f,
-	0x03, 0x9e, 0x59, 0xac, 0xeb, 0xcb, 0x38, 0x96, 0x89, 0x95, 0xee, 0x86, 0x52, 0x86, 0x11, 0x7f,
-	0x8c, 0xd2, 0x87, 0xfc, 0xe4, 0xb1, 0x16, 0x31, 0xcf, 0x34, 0x8b, 0xd3, 0x82, 0x30, 0xf8, 0xe3,
-	0x5f, 0xd0, 0x3c, 0x28, 0x0e, 0x25, 0x04, 0xea, 0x59, 0x94, 0x87, 0xae, 0xb3, 0xe5, 0x6c, 0xb7,
-	0x29, 0xae, 0xc9, 0x5d, 0x68, 0x9d, 0xf1, 0x24, 0x90, 0x6a, 0x32, 0x76, 0x57, 0xb6, 0x9c, 0xed,
-	0xce, 0xb0, 0xe6, 0x4d, 0xc6, 0xb4, 0x02, 0xc9, 0x17, 0xd0, 0x8a, 0xb9, 0x66, 0x01, 0xd3, 0xcc,
-	0xad, 0x21, 0x61, 0xd3, 0xb3, 0x07, 0x7a, 0xaf, 0xad, 0x82, 0x56, 0x14, 0xf2, 0x7f, 0xa8, 0x0b,
-	0xcd, 0x63, 0xb7, 0x8e, 0xd4, 0xb5, 0x8a, 0x3a, 0xd1, 0x3c, 0xa6, 0xa8, 0x22, 0x3b, 0xb0, 0x9e,
-	0x9d, 0x8a, 0x34, 0x15, 0x49, 0x78, 0x98, 0x6a, 0x21, 0x93, 0xcc, 0x5d, 0xdd, 0xaa, 0x6d, 0x77,
-	0x86, 0xff, 0xa9, 0xd8, 0xd3, 0x05, 0x3d, 0xbd, 0xcc, 0x27, 0x03, 0x58, 0xd5, 0xec, 0x9c, 0x67,
-	0x6e, 0x03, 0x37, 0x76, 0xab, 0x8d, 0xc7, 0xec, 0x9c, 0x16, 0x2a, 0xf2, 0x00, 0x9a, 0xbe, 0xcc,
-	0x53, 0x73, 0x7c, 0x13, 0x59, 0xeb, 0x15, 0x6b, 0x84, 0x38, 0x2d, 0xf5, 0xe4, 0x0e, 0x40, 0x2c,
-	0x03, 0xae, 0x98, 0x96, 0x2a, 0x73, 0x5b, 0x5b, 0xb5, 0xed, 0x36, 0x9d, 0x43, 0x88, 0x07, 0x44,
-	0x73, 0x15, 0x67, 0x3b, 0x49, 0x30, 0x92, 0x49, 0x20, 0x0a, 0xa3, 0xdb, 0x18, 0xc6, 0x25, 0x1a,
-	0x32, 0x80, 0xae, 0xe2, 0x27, 0x79, 0x12, 0x1c, 0xc9, 0x48, 0xf8, 0x33, 0x17, 0x90, 0xb9, 0x80,
-	0xf5, 0x7f, 0xae, 0x43, 0xab, 0x8c, 0x1f, 0x71, 0xa1, 0x79, 0xc6, 0x55, 0x26, 0x64, 0x82, 0xc9,
-	0x59, 0xa3, 0xa5, 0x48, 0x76, 0xa1, 0xeb, 0xcb, 0x44, 0x2b, 0xe6, 0xeb, 0xe3, 0x59, 0xca, 0x31,
-	0x47, 0xbd, 0xe1, 0x9d, 0x2b, 0x29, 0xf0, 0x46, 0x73, 0x2c, 0xba, 0xb0, 0x87, 0x3c, 0x81, 0xc6,
-	0x89, 0x54, 0x31, 0xd3, 0x98, 0xc0, 0xde, 0xd0, 0xbd, 0xba, 0xfb, 0x05, 0xea, 0xa9, 0xe5, 0x91,
-	0x21, 0x34, 0xf8, 0x79, 0x2a, 0xd4, 0xcc, 0xe6, 0xb1, 0xef, 0x15, 0x75, 0xe6, 0x95, 0x75, 0xe6,
-	0x1d, 0x97, 0x75, 0x46, 0x2d, 0xd3, 0x04, 0x89, 0xf9, 0x3e, 0x4f, 0x35, 0x0f, 0x46, 0xb9, 0x52,
-	0x3c, 0xf1, 0x05, 0x2f, 0x32, 0xdb, 0xa6, 0x4b, 0x34, 0xa4, 0x0f, 0xad, 0x88, 0x25, 0x61, 0xce,
-	0x42, 0xee, 0x36, 0x30, 0x40, 0x95, 0x6c, 0xce, 0xe2, 0x99, 0xaf, 0xe4, 0x27, 0x73, 0x8d, 0xcc,
-	0xf5, 0xbe, 0xcc, 0x95, 0x49, 0xa3, 0x09, 0xcd, 0x12, 0x0d, 0x79, 0x0a, 0xeb, 0xa9, 0x12, 0xbe,
-	0x48, 0x42, 0x7b, 0xc1, 0x0c, 0xb3, 0xd3, 0x19, 0xb6, 0xbd, 0x12, 0xa0, 0x97, 0x19, 0x83, 0x00,
-	0xba, 0xf3, 0x41, 0x23, 0x9b, 0xb0, 0x76, 0xb4, 0xff, 0x6e, 0x3a, 0x19, 0xed, 0x1c, 0xbc, 0x7f,
-	0x79, 0x78, 0x38, 0xde, 0xb8, 0x41, 0x36, 0xa0, 0x3b, 0x9e, 0xbc, 0x9c, 0x1c, 0x97, 0x88, 0x43,
-	0x3a, 0xd0, 0x9c, 0xee, 0xd1, 0xb7, 0x93, 0xd1, 0xde, 0xc6, 0x0a, 0xe9, 0x01, 0x8c, 0x0e, 0x76,
-	0xa6, 0xd3, 0xc9, 0x8b, 0xc9, 0xde, 0x78, 0xa3, 0x46, 0x08, 0xf4, 0x46, 0xf4, 0xdd, 0xd1, 0xf1,
-	0xe1, 0xe8, 0x0d, 0xa5, 0x7b, 0xdf, 0x8e, 0xde, 0x6d, 0xd4, 0x07, 0x0f, 0xa1, 0x51, 0x04, 0x97,
-	0xac, 0x43, 0xe7, 0xc5, 0xe4, 0xbb, 0xbd, 0xf1, 0xfb, 0x23, 0x6a, 0xb6, 0xe3, 0xe9, 0xaf, 0x77,
-	0xe8, 0xab, 0xbd, 0x63, 0x8b, 0x38, 0xfd, 0xdf, 0x5a, 0x50, 0x37, 0x9d, 0x42, 0x6e, 0xc1, 0xaa,
-	0x16, 0x3a, 0xe2, 0xb6, 0x57, 0x0b, 0x81, 0x6c, 0x41, 0x27, 0x30, 0xde, 0x0b, 0x6c, 0x03, 0xac,
-	0x85, 0x36, 0x9d, 0x87, 0xc8, 0x7d, 0xe8, 0xa5, 0x4a, 0xfa, 0x3c, 0xcb, 0x44, 0x12, 0x9a, 0x10,
-	0x61, 0xca, 0xdb, 0xf4, 0x12, 0x6a, 0x46, 0x41, 0x92, 0x9d, 0x7c, 0xc2, 0xf4, 0xb6, 0x28, 0xae,
-	0x0d, 0xa6, 0x59, 0x58, 0xa6, 0x0c, 0xd7, 0xe4, 0x21, 0x34, 0x44, 0xcc, 0xc2, 0xaa, 0xd3, 0x6e,
-	0x2e, 0x34, 0xb4, 0x37, 0x31, 0x3a, 0x6a, 0x29, 0xa6, 0x8d, 0x7c, 0xa6, 0x79, 0x28, 0x95, 0xc9,
-	0x7c, 0xb3, 0x68, 0xa3, 0x0b, 0xc4, 0x38, 0x15, 0x2a, 0x16, 0x9b, 0x0e, 0x73, 0xb6, 0x57, 0x68,
-	0x21, 0x90, 0xdb, 0xd0, 0xf6, 0xcb, 0xd6, 0xb1, 0x3d, 0x75, 0x01, 0x10, 0x0f, 0x9a, 0xd2, 0x0e,
-	0x09, 0x40, 0x0b, 0x6e, 0x2d, 0x5a, 0x60, 0x27, 0x44, 0x49, 0x22, 0x9f, 0x41, 0x3d, 0xfb, 0x98,
-	0x67, 0x6e, 0x07, 0xc9, 0x9b, 0x8b, 0xe4, 0xe9, 0xc7, 0x9c, 0xa2, 0xda, 0x98, 0x62, 0xca, 0x81,
-	0xbb, 0xdd, 0x22, 0xbe, 0x28, 0x90, 0xaf, 0xe0, 0xbf, 0xbe, 0x9a, 0xa5, 0x5a, 0xda, 0x5d, 0x65,
-	0xa9, 0x8c, 0x64, 0xc0, 0xdd, 0x35, 0x64, 0x5e, 0x4f, 0x20, 0xdf, 0x40, 0x7f, 0x41, 0x79, 0x64,
-	0xce, 0x7c, 0x2d, 0x03, 0x71, 0x22, 0xb8, 0x72, 0x7b, 0xe8, 0xf3, 0xdf, 0x30, 0xfa, 0xbf, 0x3b,
-	0xd0, 0x28, 0xdc, 0xc1, 0xf4, 0xb0, 0xb8, 0xcc, 0x3e, 0xae, 0xff, 0x41, 0xf2, 0x9f, 0x41, 0xeb,
-	0x8c, 0x29, 0xc1, 0x12, 0x9d, 0xb9, 0x35, 0xf4, 0xff, 0xf6, 0xb2, 0x60, 0x79, 0x6f, 0x0b, 0x12,
-	0xad, 0xd8, 0xfd, 0x7d, 0x68, 0x5a, 0x70, 0xe9, 0xd5, 0x0f, 0x60, 0x15, 0x53, 0x6c, 0x5f, 0x88,
-	0xa5, 0x45, 0x50, 0x30, 0xfa, 0x7f, 0x3a, 0x50, 0x9b, 0x7e, 0xcc, 0xc9, 0x73, 0x80, 0x8c, 0x47,
-	0xdc, 0x2f, 0x52, 0xe7, 0xa0, 0x35, 0xff, 0xbb, 0x92, 0x0d, 0x6f, 0x5a, 0x72, 0xe8, 0x1c, 0xdd,
-	0x94, 0x44, 0xaa, 0x64, 0x90, 0xfb, 0xda, 0xbe, 0x4a, 0x6d, 0x7a, 0x01, 0x98, 0xc1, 0xf1, 0x43,
-	0xce, 0x12, 0x2d, 0xf4, 0xcc, 0x56, 0x77, 0x25, 0x9b, 0x9d, 0x59, 0xae, 0xfc, 0x53, 0xa6, 0x42,
-	0x8e, 0xc5, 0xdd, 0xa6, 0x17, 0x40, 0xff, 0x6b, 0x68, 0x57, 0x17, 0x92, 0x7f, 0x43, 0xa3, 0x28,
-	0x1a, 0xeb, 0xaa, 0x95, 0x70, 0x16, 0x17, 0xb1, 0xb0, 0x57, 0x97, 0x62, 0xff, 0x57, 0x07, 0x56,
-	0xd1, 0x59, 0x63, 0xc2, 0x89, 0x88, 0xf8, 0x5c, 0xa0, 0x2a, 0xd9, 0xe8, 0xa4, 0x12, 0xa1, 0x48,
-	0x58, 0x64, 0x0f, 0xa8, 0x64, 0x53, 0x76, 0x11, 0x9a, 0x56, 0xd8, 0x5d, 0x08, 0xc6, 0x92, 0x98,
-	0x07, 0x22, 0x8f, 0xad, 0xc5, 0x56, 0x32, 0xec, 0x2c, 0x66, 0x51, 0xe4, 0xae, 0x16, 0x6c, 0x14,
-	0xb0, 0x4d, 0x45, 0x32, 0xb3, 0x33, 0x13, 0xd7, 0xfd, 0x1f, 0x6b, 0xd0, 0x5b, 0x7c, 0x33, 0x97,
-	0xe6, 0xf1, 0x19, 0xd4, 0xf5, 0xc5, 0x23, 0x72, 0xef, 0x9a, 0xe7, 0xb6, 0x12, 0xf1, 0x29, 0xc1,
-	0x1d, 0xe4, 0x3e, 0x34, 0x15, 0x0f, 0x31, 0x97, 0xa6, 0xb2, 0x7a, 0xc3, 0xae, 0x79, 0x44, 0xcd,
-	0xdf, 0xc4, 0x94, 0x3e, 0x2d, 0x95, 0xe4, 0x39, 0xb4, 0x32, 0xae, 0xce, 0x84, 0xcf, 0x33, 0xb7,
-	0x8e, 0x49, 0xbf, 0x7b, 0xed, 0x2d, 0x05, 0x8f, 0x56, 0x1b, 0xfa, 0xbf, 0x38, 0xd0, 0xb4, 0xe8,
-	0x52, 0xf3, 0x1f, 0xc1, 0x26, 0xcf, 0xb4, 0x88, 0x99, 0xe6, 0xc1, 0x98, 0x47, 0xe2, 0x8c, 0xab,
-	0x99, 0x0d, 0xf1, 0x55, 0xc5, 0x45, 0x8b, 0xd7, 0xe6, 0x5b, 0xfc, 0x09, 0xdc, 0x64, 0x41, 0x31,
-	0x5b, 0x58, 0x64, 0xca, 0x10, 0x7b, 0xd0, 0x06, 0x7e, 0x99, 0x6a, 0xf0, 0x25, 0x74, 0xe7, 0x03,
-	0x62, 0xa6, 0xf6, 0xc1, 0xa1, 0x79, 0x23, 0x8e, 0x26, 0xa3, 0x57, 0x6f, 0x8e, 0x36, 0x6e, 0x5c,
-	0x1e, 0xec, 0x4e, 0xff, 0x27, 0x07, 0x6a, 0xc7, 0xec, 0xdc, 0x94, 0x92, 0x66, 0xe7, 0xf8, 0x6e,
-	0x17, 0x7e, 0x94, 0x22, 0x79, 0x04, 0xa0, 0xd9, 0x39, 0xb5, 0x21, 0x5d, 0x59, 0x12, 0xd2, 0x39,
-	0xbd, 0x69, 0x7d, 0xcd, 0xce, 0x4b, 0x2b, 0xd0, 0xa1, 0x16, 0x9d, 0x87, 0xcc, 0xe8, 0x4d, 0xb9,
-	0xf2, 0x79, 0xa2, 0x99, 0x2d, 0xfc, 0x15, 0x3a, 0x87, 0xe0, 0x6c, 0x29, 0x7e, 0x3d, 0xd7, 0x3c,
-	0x2d, 0xb7, 0xa0, 0x7e, 0xca, 0xb2, 0xd3, 0x22, 0x9c, 0xfb, 0x37, 0x28, 0x4a, 0xe4, 0x1e, 0x74,
-	0x03, 0x91, 0xe1, 0x2f, 0x14, 0x67, 0x60, 0xcd, 0x6a, 0x17, 0x50, 0xf2, 0x39, 0xac, 0xdb, 0xab,
-	0xc6, 0x16, 0x2e, 0x2c, 0xd8, 0x77, 0xe8, 0x65, 0x05, 0xb9, 0x0f, 0x6b, 0x98, 0x88, 0x8a, 0x89,
-	0xb5, 0xbd, 0xef, 0xd0, 0x45, 0x78, 0xb7, 0x01, 0x75, 0xf3, 0xeb, 0xdd, 0x05, 0x68, 0x95, 0x77,
-	0x0d, 0x7c, 0x58, 0x9b, 0x8a, 0x30, 0xe1, 0x41, 0xf9, 0xa1, 0x1d, 0x40, 0xd3, 0x7e, 0x98, 0xd1,
-	0x99, 0xce, 0xb0, 0x55, 0x16, 0x1b, 0x2d, 0x15, 0x64, 0x03, 0x6a, 0xbe, 0x08, 0x6c, 0x99, 0x98,
-	0x25, 0xce, 0x08, 0x11, 0x26, 0x4c, 0xe7, 0xaa, 0xf0, 0xa8, 0x4b, 0x2f, 0x80, 0xdd, 0xfa, 0xf7,
-	0x2b, 0xe9, 0x87, 0x0f, 0x0d, 0xfc, 0xe8, 0x3c, 0xfd, 0x2b, 0x00, 0x00, 0xff, 0xff, 0x9c, 0x4d,
-	0xa1, 0x69, 0x91, 0x0b, 0x00, 0x00,
+	// 1537 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x57, 0xdb, 0x6e, 0xdb, 0x46,
+	0x13, 0xb6, 0x0e, 0xd6, 0x61, 0x74, 0xb0, 0xb3, 0xc9, 0x9f, 0x9f, 0x65, 0x83, 0xc4, 0x15, 0x52,
+	0xc3, 0x39, 0x94, 0x49, 0x95, 0xa2, 0x0d, 0x90, 0x36, 0x80, 0x2d, 0x39, 0x91, 0x10, 0xa7, 0x56,
+	0x57, 0x4a, 0xda, 0xf4, 0x26, 0xd8, 0x90, 0x6b, 0x7a, 0x11, 0x1e, 0xd4, 0xe5, 0xd2, 0x91, 0x2e,
+	0xfa, 0x0c, 0x05, 0x72, 0xd3, 0x3e, 0x41, 0x1f, 0xa4, 0x4f, 0xd0, 0x8b, 0x02, 0x7d, 0x9d, 0x62,
+	0x97, 0x4b, 0x8a, 0x92, 0xe5, 0x36, 0x77, 0x3b, 0x33, 0xdf, 0x2e, 0x77, 0x67, 0xe6, 0x9b, 0x19,
+	0x42, 0xcb, 0x63, 0x91, 0x60, 0x81, 0x6b, 0x4d, 0x79, 0x28, 0x42, 0x13, 0xd9, 0x61, 0x1c, 0x08,
+	0x3e, 0xb7, 0x43, 0x87, 0x46, 0x5a, 0xd7, 0xb4, 0x43, 0xdf, 0x0f, 0x03, 0x2d, 0xdd, 0x70, 0xc3,
+	0xd0, 0xf5, 0xe8, 0x3d, 0x25, 0xbd, 0x89, 0x4f, 0xee, 0x09, 0xe6, 0xd3, 0x48, 0x10, 0x7f, 0x9a,
+	0x00, 0x3a, 0x7f, 0x99, 0x50, 0x3d, 0x4a, 0x0e, 0x45, 0x08, 0xca, 0x91, 0x17, 0xbb, 0x46, 0x61,
+	0xa7, 0xb0, 0x57, 0xc7, 0x6a, 0x8d, 0x6e, 0x40, 0xed, 0x8c, 0x06, 0x4e, 0xc8, 0x87, 0x7d, 0xa3,
+	0xb8, 0x53, 0xd8, 0x6b, 0x74, 0x4b, 0xd6, 0xb0, 0x8f, 0x33, 0x25, 0xfa, 0x0c, 0x6a, 0x3e, 0x15,
+	0xc4, 0x21, 0x82, 0x18, 0x25, 0x05, 0xb8, 0x64, 0xe9, 0x03, 0xad, 0xe7, 0xda, 0x80, 0x33, 0x08,
+	0xfa, 0x04, 0xca, 0x4c, 0x50, 0xdf, 0x28, 0x2b, 0x68, 0x2b, 0x83, 0x0e, 0x05, 0xf5, 0xb1, 0x32,
+	0xa1, 0x7d, 0xd8, 0x8a, 0x4e, 0xd9, 0x74, 0xca, 0x02, 0xf7, 0x78, 0x2a, 0x58, 0x18, 0x44, 0xc6,
+	0xe6, 0x4e, 0x69, 0xaf, 0xd1, 0xfd, 0x7f, 0x86, 0x1e, 0x2f, 0xd9, 0xf1, 0x2a, 0x1e, 0x75, 0x60,
+	0x53, 0x90, 0x19, 0x8d, 0x8c, 0x8a, 0xda, 0xd8, 0xcc, 0x36, 0x4e, 0xc8, 0x0c, 0x27, 0x26, 0x74,
+	0x0b, 0xaa, 0x76, 0x18, 0x4f, 0xe5, 0xf1, 0x55, 0x85, 0xda, 0xca, 0x50, 0x3d, 0xa5, 0xc7, 0xa9,
+	0x1d, 0x5d, 0x07, 0xf0, 0x43, 0x87, 0x72, 0x22, 0x42, 0x1e, 0x19, 0xb5, 0x9d, 0xd2, 0x5e, 0x1d,
+	0xe7, 0x34, 0xc8, 0x02, 0x24, 0x28, 0xf7, 0xa3, 0xfd, 0xc0, 0xe9, 0x85, 0x81, 0xc3, 0x92, 0x4b,
+	0xd7, 0x95, 0x1b, 0xd7, 0x58, 0x50, 0x07, 0x9a, 0x9c, 0x9e, 0xc4, 0x81, 0x33, 0x0a, 0x3d, 0x66,
+	0xcf, 0x0d, 0x50, 0xc8, 0x25, 0x9d, 0xf9, 0xbe, 0x0c, 0xb5, 0xd4, 0x7f, 0xc8, 0x80, 0xea, 0x19,
+	0xe5, 0x11, 0x0b, 0x03, 0x15, 0x9c, 0x16, 0x4e, 0x45, 0x74, 0x00, 0x4d, 0x3b, 0x0c, 0x04, 0x27,
+	0xb6, 0x98, 0xcc, 0xa7, 0x54, 0xc5, 0xa8, 0xdd, 0xbd, 0x7e, 0x2e, 0x04, 0x56, 0x2f, 0x87, 0xc2,
+	0x4b, 0x7b, 0xd0, 0x7d, 0xa8, 0x9c, 0x84, 0xdc, 0x27, 0x42, 0x05, 0xb0, 0xdd, 0x35, 0xce, 0xef,
+	0x7e, 0xa2, 0xec, 0x58, 0xe3, 0x50, 0x17, 0x2a, 0x74, 0x36, 0x65, 0x7c, 0xae, 0xe3, 0x68, 0x5a,
+	0x49, 0x9e, 0x59, 0x69, 0x9e, 0x59, 0x93, 0x34, 0xcf, 0xb0, 0x46, 0x4a, 0x27, 0x11, 0xdb, 0xa6,
+	0x53, 0x41, 0x9d, 0x5e, 0xcc, 0x39, 0x0d, 0x6c, 0x46, 0x93, 0xc8, 0xd6, 0xf1, 0x1a, 0x0b, 0x32,
+	0xa1, 0xe6, 0x91, 0xc0, 0x8d, 0x89, 0x4b, 0x8d, 0x8a, 0x72, 0x50, 0x26, 0xcb, 0xb3, 0x68, 0x64,
+	0xf3, 0xf0, 0x9d, 0xfc, 0x4c, 0x18, 0x8b, 0x41, 0x18, 0x73, 0x19, 0x46, 0xe9, 0x9a, 0x35, 0x16,
+	0xf4, 0x00, 0xb6, 0xa6, 0x9c, 0xd9, 0x2c, 0x70, 0xf5, 0x07, 0xe6, 0x2a, 0x3a, 0x8d, 0x6e, 0xdd,
+	0x4a, 0x15, 0x78, 0x15, 0xd1, 0x71, 0xa0, 0x99, 0x77, 0x1a, 0xba, 0x04, 0xad, 0xd1, 0xe0, 0xd5,
+	0x78, 0xd8, 0xdb, 0x3f, 0x7a, 0xfd, 0xf4, 0xf8, 0xb8, 0xbf, 0xbd, 0x81, 0xb6, 0xa1, 0xd9, 0x1f,
+	0x3e, 0x1d, 0x4e, 0x52, 0x4d, 0x01, 0x35, 0xa0, 0x3a, 0x3e, 0xc4, 0x2f, 0x87, 0xbd, 0xc3, 0xed,
+	0x22, 0x6a, 0x03, 0xf4, 0x8e, 0xf6, 0xc7, 0xe3, 0xe1, 0x93, 0xe1, 0x61, 0x7f, 0xbb, 0x84, 0x10,
+	0xb4, 0x7b, 0xf8, 0xd5, 0x68, 0x72, 0xdc, 0x7b, 0x81, 0xf1, 0xe1, 0xb7, 0xbd, 0x57, 0xdb, 0xe5,
+	0xce, 0x1d, 0xa8, 0x24, 0xce, 0x45, 0x5b, 0xd0, 0x78, 0x32, 0xfc, 0xe1, 0xb0, 0xff, 0x7a, 0x84,
+	0xe5, 0x76, 0x75, 0xfa, 0xf3, 0x7d, 0xfc, 0xec, 0x70, 0xa2, 0x35, 0x05, 0xf3, 0x77, 0x80, 0xb2,
+	0x64, 0x0a, 0xba, 0x02, 0x9b, 0x82, 0x09, 0x8f, 0x6a, 0xae, 0x26, 0x02, 0xda, 0x81, 0x86, 0x23,
+	0x5f, 0xcf, 0x14, 0x0d, 0x54, 0x2e, 0xd4, 0x71, 0x5e, 0x85, 0x76, 0xa1, 0x3d, 0xe5, 0xa1, 0x4d,
+	0xa3, 0x88, 0x05, 0xae, 0x74, 0x91, 0x0a, 0x79, 0x1d, 0xaf, 0x68, 0x65, 0x29, 0x08, 0xa2, 0x93,
+	0x77, 0x2a, 0xbc, 0x35, 0xac, 0xd6, 0x52, 0x27, 0x88, 0x9b, 0x86, 0x4c, 0xad, 0xd1, 0x1d, 0xa8,
+	0x30, 0x9f, 0xb8, 0x19, 0xd3, 0x2e, 0x2f, 0x11, 0xda, 0x1a, 0x4a, 0x1b, 0xd6, 0x10, 0x49, 0x23,
+	0x9b, 0x08, 0xea, 0x86, 0x9c, 0xd1, 0x84, 0x74, 0x75, 0x9c, 0xd3, 0xc8, 0x47, 0xb9, 0x9c, 0xf8,
+	0x92, 0x61, 0x85, 0xbd, 0x22, 0x4e, 0x04, 0x74, 0x0d, 0xea, 0x76, 0x4a, 0x1d, 0xcd, 0xa9, 0x85,
+	0x02, 0x59, 0x50, 0x0d, 0x75, 0x91, 0x00, 0x75, 0x83, 0x2b, 0xcb, 0x37, 0xd0, 0x15, 0x22, 0x05,
+	0xa1, 0x4f, 0xa1, 0x1c, 0xbd, 0x8d, 0x23, 0xa3, 0xb1, 0x53, 0x5a, 0x2a, 0x55, 0x0a, 0x3c, 0x7e,
+	0x1b, 0x63, 0x65, 0x96, 0x57, 0x91, 0xe9, 0x40, 0x8d, 0x66, 0xe2, 0x5f, 0x25, 0xa0, 0xaf, 0xe1,
+	0x23, 0x9b, 0xcf, 0xa7, 0x22, 0xd4, 0xbb, 0xd2, 0x54, 0xe9, 0x85, 0x0e, 0x35, 0x5a, 0x0a, 0x79,
+	0x31, 0x00, 0x3d, 0x06, 0x73, 0xc9, 0x38, 0x92, 0x67, 0x3e, 0x0f, 0x1d, 0x76, 0xc2, 0x28, 0x37,
+	0xda, 0xea, 0xcd, 0xff, 0x82, 0x40, 0x5f, 0x01, 0xa8, 0x6b, 0x4c, 0x18, 0xe5, 0x91, 0xb1, 0xb5,
+	0x52, 0x12, 0xd5, 0x03, 0x46, 0xa9, 0x1d, 0xe7, 0xa0, 0xe6, 0x1f, 0x05, 0xa8, 0x24, 0x7e, 0x50,
+	0x71, 0x25, 0x7e, 0x9a, 0x36, 0x6a, 0xfd, 0x01, 0x59, 0xf3, 0x10, 0x6a, 0x67, 0x84, 0x33, 0x12,
+	0x88, 0xc8, 0x28, 0xa9, 0xef, 0x5e, 0x5b, 0xe7, 0x65, 0xeb, 0x65, 0x02, 0xc2, 0x19, 0xda, 0x1c,
+	0x40, 0x55, 0x2b, 0xd7, 0x7e, 0xfa, 0x16, 0x6c, 0xaa, 0xdc, 0xd0, 0xad, 0x65, 0x6d, 0xf6, 0x24,
+	0x08, 0xf3, 0x7d, 0x11, 0x4a, 0xe3, 0xb7, 0x31, 0x7a, 0x04, 0x10, 0x51, 0x8f, 0xda, 0x49, 0xcc,
+	0x0b, 0xea, 0x36, 0x1f, 0x9f, 0x0b, 0xa3, 0x35, 0x4e, 0x31, 0x38, 0x07, 0x97, 0xb9, 0x34, 0xe5,
+	0xa1, 0x13, 0xdb, 0x42, 0xb7, 0xb3, 0x3a, 0x5e, 0x28, 0x64, 0xc5, 0xf9, 0x29, 0x26, 0x81, 0x60,
+	0x62, 0xae, 0x69, 0x91, 0xc9, 0x72, 0x67, 0x14, 0x73, 0xfb, 0x94, 0x70, 0x97, 0x2a, 0x56, 0xd4,
+	0xf1, 0x42, 0xb1, 0x12, 0x9a, 0xcd, 0x0f, 0x0f, 0xcd, 0x37, 0x50, 0xcf, 0x6e, 0x8a, 0xae, 0x42,
+	0x25, 0x49, 0x53, 0xed, 0x23, 0x2d, 0xa9, 0xea, 0x9f, 0x38, 0x51, 0xdf, 0x39, 0x15, 0xcd, 0x9f,
+	0xa1, 0x9e, 0x9d, 0x2b, 0xe3, 0xe8, 0xb3, 0xe0, 0xbb, 0xf4, 0x05, 0xf2, 0x8c, 0x32, 0xce, 0xab,
+	0xd0, 0x6d, 0xd8, 0x9a, 0x52, 0x6e, 0xd3, 0x40, 0xf4, 0x59, 0xa4, 0x66, 0x07, 0x75, 0x60, 0x71,
+	0xb0, 0x81, 0x57, 0x0d, 0xe8, 0x6a, 0xca, 0x00, 0xe5, 0x89, 0xc1, 0x86, 0xe6, 0xc0, 0x01, 0x40,
+	0xcd, 0xd1, 0x18, 0xf3, 0xd7, 0x02, 0x6c, 0xaa, 0x20, 0x49, 0xd7, 0x9d, 0x30, 0x8f, 0xe6, 0x02,
+	0x9c, 0xc9, 0xd2, 0x16, 0x72, 0xe6, 0xb2, 0x80, 0x78, 0xfa, 0xfe, 0x99, 0x2c, 0x79, 0xe6, 0x29,
+	0x97, 0x26, 0xfe, 0x4e, 0x04, 0xe9, 0x08, 0x9f, 0x3a, 0x2c, 0xf6, 0xb5, 0xa7, 0xb5, 0x24, 0xd1,
+	0x91, 0x4f, 0x3c, 0xcf, 0xd8, 0x4c, 0xd0, 0x4a, 0x50, 0x75, 0x89, 0x05, 0x73, 0xdd, 0x24, 0xd4,
+	0xda, 0xfc, 0xb3, 0x0c, 0xed, 0xe5, 0x21, 0x61, 0x6d, 0xfe, 0x3d, 0x84, 0xb2, 0x58, 0x74, 0xcd,
+	0x9b, 0x17, 0xcc, 0x17, 0x99, 0xa8, 0x7a, 0xa7, 0xda, 0x81, 0x76, 0xa1, 0xca, 0xa9, 0xab, 0x72,
+	0x50, 0x32, 0xa2, 0xdd, 0x6d, 0x5a, 0xbd, 0x64, 0x18, 0x93, 0x5c, 0xc7, 0xa9, 0x11, 0x3d, 0x82,
+	0x5a, 0x44, 0xf9, 0x19, 0xb3, 0x69, 0x64, 0x94, 0x55, 0x5e, 0xdc, 0xb8, 0xf0, 0x2b, 0x09, 0x0e,
+	0x67, 0x1b, 0xcc, 0xbf, 0x8b, 0x50, 0xd5, 0xda, 0xb5, 0xd7, 0xbf, 0x0b, 0x97, 0x68, 0x24, 0x98,
+	0x4f, 0x04, 0x75, 0xfa, 0xd4, 0x63, 0x67, 0x94, 0xcf, 0xb5, 0x8b, 0xcf, 0x1b, 0x16, 0x35, 0xad,
+	0x94, 0xaf, 0x69, 0xf7, 0xe1, 0x32, 0x71, 0x92, 0x62, 0x4a, 0x3c, 0x99, 0xa9, 0x2a, 0xa1, 0xb4,
+	0xe3, 0xd7, 0x99, 0xd0, 0x11, 0x34, 0xde, 0x51, 0xe6, 0x9e, 0x8a, 0x03, 0x12, 0x38, 0x69, 0xb6,
+	0xdf, 0xfe, 0x8f, 0x57, 0x59, 0xdf, 0x67, 0x5b, 0x70, 0x7e, 0x3b, 0xfa, 0x02, 0xfe, 0x77, 0xc2,
+	0x29, 0xcd, 0x5c, 0x7c, 0xca, 0x69, 0x74, 0x1a, 0x7a, 0x8e, 0x0e, 0xe7, 0x7a, 0xa3, 0xf9, 0x18,
+	0x60, 0x71, 0xa0, 0xcc, 0x30, 0x9f, 0xcc, 0x9e, 0xaa, 0xde, 0x91, 0xa4, 0x7d, 0x26, 0x2f, 0x5e,
+	0x5d, 0xcc, 0xbd, 0xba, 0xf3, 0x39, 0x34, 0xf3, 0x41, 0x95, 0xad, 0xf6, 0xe8, 0x58, 0x36, 0xf6,
+	0xd1, 0xb0, 0xf7, 0xec, 0xc5, 0x68, 0x7b, 0x63, 0xb5, 0x1b, 0x17, 0xcc, 0x5f, 0x0a, 0x50, 0x9a,
+	0x90, 0x99, 0x64, 0xa3, 0x20, 0x33, 0xb9, 0x4b, 0xc7, 0x22, 0x15, 0xd1, 0x5d, 0x00, 0x41, 0x66,
+	0x58, 0xa7, 0x45, 0x71, 0x4d, 0x5a, 0xe4, 0xec, 0x92, 0xae, 0x82, 0xcc, 0xd2, 0x5b, 0xa8, 0xa0,
+	0xd4, 0x70, 0x5e, 0x25, 0xfb, 0xa5, 0x66, 0x25, 0xd1, 0x45, 0xa7, 0x88, 0x73, 0x1a, 0xf3, 0xb7,
+	0x12, 0x54, 0x92, 0x51, 0xf5, 0x82, 0x79, 0xe0, 0x0a, 0x94, 0x4f, 0x49, 0x74, 0x9a, 0x3c, 0x7d,
+	0xb0, 0x81, 0x95, 0x84, 0x6e, 0x42, 0x33, 0x65, 0xb0, 0x6a, 0x5c, 0x29, 0xc1, 0x97, 0xb4, 0xeb,
+	0x6a, 0x85, 0xba, 0xc1, 0xa0, 0x70, 0xbe, 0x56, 0xec, 0x42, 0x4b, 0xb9, 0x35, 0x43, 0x2a, 0x7e,
+	0x0e, 0x0a, 0x78, 0x59, 0x9d, 0x1b, 0x1b, 0x2b, 0x1f, 0x3c, 0x36, 0xee, 0x42, 0xdb, 0x97, 0x4e,
+	0x73, 0xa8, 0xaf, 0xfb, 0x7c, 0x32, 0xe6, 0xad, 0x68, 0xd1, 0x97, 0x70, 0x75, 0x59, 0x33, 0xa2,
+	0xfc, 0x20, 0x9e, 0x53, 0xae, 0xa6, 0x89, 0x16, 0xbe, 0xc0, 0x2a, 0x39, 0xe4, 0xb3, 0x80, 0xf9,
+	0xb1, 0x7f, 0xcc, 0x1d, 0xca, 0x5f, 0x12, 0x2f, 0xa6, 0x7a, 0xcc, 0x38, 0x6f, 0x40, 0x48, 0x8f,
+	0x0f, 0x90, 0xcc, 0x40, 0x72, 0x7d, 0x50, 0x81, 0xb2, 0xfc, 0x01, 0xcb, 0x57, 0xc6, 0x8e, 0x0d,
+	0xad, 0x31, 0x73, 0x03, 0xea, 0xa4, 0xff, 0x56, 0x1d, 0xa8, 0xea, 0x7f, 0x37, 0x15, 0xa2, 0x46,
+	0xb7, 0x96, 0x12, 0x06, 0xa7, 0x06, 0xb4, 0x0d, 0x25, 0x9b, 0x39, 0x3a, 0x51, 0xe5, 0x52, 0x75,
+	0x1d, 0xe6, 0x06, 0x44, 0xc4, 0x3c, 0x89, 0x53, 0x13, 0x2f, 0x14, 0x07, 0xe5, 0x1f, 0x8b, 0xd3,
+	0x37, 0x6f, 0x2a, 0xca, 0x79, 0x0f, 0xfe, 0x19, 0x00, 0x5d, 0x57, 0x85, 0x67, 0x1c, 0x0e, 0x00,
+	0x00,
 }
@@ -74,6 +74,31 @@ func (OrderReject_RejectType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_e0f5d4cf0fc9e41b, []int{1, 0}
 }
 
+type DisputeOpen_Party int32
+
+const (
+	DisputeOpen_BUYER  DisputeOpen_Party = 0
+	DisputeOpen_VENDOR DisputeOpen_Party = 1
+)
+
+var DisputeOpen_Party_name = map[int32]string{
+	0: "BUYER",
+	1: "VENDOR",
+}
+
+var DisputeOpen_Party_value = map[string]int32{
+	"BUYER":  0,
+	"VENDOR": 1,
+}
+
+func (x DisputeOpen_Party) String() string {
+	return proto.EnumName(DisputeOpen_Party_name, int32(x))
+}
+
+func (DisputeOpen_Party) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e0f5d4cf0fc9e41b, []int{9, 0}
+}
+
 type OrderOpen struct {
 	Listings             []*SignedListing     `protobuf:"bytes,1,rep,name=listings,proto3" json:"listings,omitempty"`
 	RefundAddress        string               `protobuf:"bytes,2,opt,name=refundAddress,proto3" json:"refundAddress,omitempty"`
@@ -84,6 +109,7 @@ type OrderOpen struct {
 	Payment              *OrderOpen_Payment   `protobuf:"bytes,7,opt,name=payment,proto3" json:"payment,omitempty"`
 	RatingKeys           [][]byte             `protobuf:"bytes,8,rep,name=ratingKeys,proto3" json:"ratingKeys,omitempty"`
 	AlternateContactInfo string               `protobuf:"bytes,9,opt,name=alternateContactInfo,proto3" json:"alternateContactInfo,omitempty"`
+	Taxes                []*OrderOpen_Tax     `protobuf:"bytes,10,rep,name=taxes,proto3" json:"taxes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -177,6 +203,13 @@ func (m *OrderOpen) GetAlternateContactInfo() string {
 	return ""
 }
 
+func (m *OrderOpen) GetTaxes() []*OrderOpen_Tax {
+	if m != nil {
+		return m.Taxes
+	}
+	return nil
+}
+
 type OrderOpen_Shipping struct {
 	ShipTo               string      `protobuf:"bytes,1,opt,name=shipTo,proto3" json:"shipTo,omitempty"`
 	Address              string      `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
@@ -446,18 +479,20 @@ func (m *OrderOpen_Item_ShippingOption) GetService() string {
 }
 
 type OrderOpen_Payment struct {
-	Method               OrderOpen_Payment_Method `protobuf:"varint,1,opt,name=method,proto3,enum=OrderOpen_Payment_Method" json:"method,omitempty"`
-	Moderator            string                   `protobuf:"bytes,2,opt,name=moderator,proto3" json:"moderator,omitempty"`
-	Amount               string                   `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	Chaincode            string                   `protobuf:"bytes,4,opt,name=chaincode,proto3" json:"chaincode,omitempty"`
-	Address              string                   `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
-	Script               string                   `protobuf:"bytes,6,opt,name=script,proto3" json:"script,omitempty"`
-	ModeratorKey         []byte                   `protobuf:"bytes,7,opt,name=moderatorKey,proto3" json:"moderatorKey,omitempty"`
-	Coin                 string                   `protobuf:"bytes,8,opt,name=coin,proto3" json:"coin,omitempty"`
-	EscrowReleaseFee     string                   `protobuf:"bytes,9,opt,name=escrowReleaseFee,proto3" json:"escrowReleaseFee,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
-	XXX_unrecognized     []byte                   `json:"-"`
-	XXX_sizecache        int32                    `json:"-"`
+	Method               OrderOpen_Payment_Method        `protobuf:"varint,1,opt,name=method,proto3,enum=OrderOpen_Payment_Method" json:"method,omitempty"`
+	Moderator            string                          `protobuf:"bytes,2,opt,name=moderator,proto3" json:"moderator,omitempty"`
+	Amount               string                          `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Chaincode            string                          `protobuf:"bytes,4,opt,name=chaincode,proto3" json:"chaincode,omitempty"`
+	Address              string                          `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	Script               string                          `protobuf:"bytes,6,opt,name=script,proto3" json:"script,omitempty"`
+	ModeratorKey         []byte                          `protobuf:"bytes,7,opt,name=moderatorKey,proto3" json:"moderatorKey,omitempty"`
+	Coin                 string                          `protobuf:"bytes,8,opt,name=coin,proto3" json:"coin,omitempty"`
+	EscrowReleaseFee     string                          `protobuf:"bytes,9,opt,name=escrowReleaseFee,proto3" json:"escrowReleaseFee,omitempty"`
+	ModeratorFee         *OrderOpen_Payment_ModeratorFee `protobuf:"bytes,10,opt,name=moderatorFee,proto3" json:"moderatorFee,omitempty"`
+	MispaymentBuffer     float32                         `protobuf:"fixed32,11,opt,name=mispaymentBuffer,proto3" json:"mispaymentBuffer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
 }
 
 func (m *OrderOpen_Payment) Reset()         { *m = OrderOpen_Payment{} }
@@ -548,6 +583,140 @@ func (m *OrderOpen_Payment) GetEscrowReleaseFee() string {
 	return ""
 }
 
+func (m *OrderOpen_Payment) GetModeratorFee() *OrderOpen_Payment_ModeratorFee {
+	if m != nil {
+		return m.ModeratorFee
+	}
+	return nil
+}
+
+func (m *OrderOpen_Payment) GetMispaymentBuffer() float32 {
+	if m != nil {
+		return m.MispaymentBuffer
+	}
+	return 0
+}
+
+// ModeratorFee is the moderator's fee at the time the order
+// was placed. It is used when paying out a dispute.
+type OrderOpen_Payment_ModeratorFee struct {
+	Fixed                string   `protobuf:"bytes,1,opt,name=fixed,proto3" json:"fixed,omitempty"`
+	Percentage           float32  `protobuf:"fixed32,2,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderOpen_Payment_ModeratorFee) Reset()         { *m = OrderOpen_Payment_ModeratorFee{} }
+func (m *OrderOpen_Payment_ModeratorFee) String() string { return proto.CompactTextString(m) }
+func (*OrderOpen_Payment_ModeratorFee) ProtoMessage()    {}
+func (*OrderOpen_Payment_ModeratorFee) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0f5d4cf0fc9e41b, []int{0, 2, 0}
+}
+
+func (m *OrderOpen_Payment_ModeratorFee) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderOpen_Payment_ModeratorFee.Unmarshal(m, b)
+}
+func (m *OrderOpen_Payment_ModeratorFee) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderOpen_Payment_ModeratorFee.Marshal(b, m, deterministic)
+}
+func (m *OrderOpen_Payment_ModeratorFee) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderOpen_Payment_ModeratorFee.Merge(m, src)
+}
+func (m *OrderOpen_Payment_ModeratorFee) XXX_Size() int {
+	return xxx_messageInfo_OrderOpen_Payment_ModeratorFee.Size(m)
+}
+func (m *OrderOpen_Payment_ModeratorFee) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderOpen_Payment_ModeratorFee.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderOpen_Payment_ModeratorFee proto.InternalMessageInfo
+
+func (m *OrderOpen_Payment_ModeratorFee) GetFixed() string {
+	if m != nil {
+		return m.Fixed
+	}
+	return ""
+}
+
+func (m *OrderOpen_Payment_ModeratorFee) GetPercentage() float32 {
+	if m != nil {
+		return m.Percentage
+	}
+	return 0
+}
+
+type OrderOpen_Tax struct {
+	ListingHash          string   `protobuf:"bytes,1,opt,name=listingHash,proto3" json:"listingHash,omitempty"`
+	TaxType              string   `protobuf:"bytes,2,opt,name=taxType,proto3" json:"taxType,omitempty"`
+	Percentage           float32  `protobuf:"fixed32,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	Amount               string   `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	ShippingAmount       string   `protobuf:"bytes,5,opt,name=shippingAmount,proto3" json:"shippingAmount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderOpen_Tax) Reset()         { *m = OrderOpen_Tax{} }
+func (m *OrderOpen_Tax) String() string { return proto.CompactTextString(m) }
+func (*OrderOpen_Tax) ProtoMessage()    {}
+func (*OrderOpen_Tax) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0f5d4cf0fc9e41b, []int{0, 3}
+}
+
+func (m *OrderOpen_Tax) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderOpen_Tax.Unmarshal(m, b)
+}
+func (m *OrderOpen_Tax) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderOpen_Tax.Marshal(b, m, deterministic)
+}
+func (m *OrderOpen_Tax) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderOpen_Tax.Merge(m, src)
+}
+func (m *OrderOpen_Tax) XXX_Size() int {
+	return xxx_messageInfo_OrderOpen_Tax.Size(m)
+}
+func (m *OrderOpen_Tax) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderOpen_Tax.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderOpen_Tax proto.InternalMessageInfo
+
+func (m *OrderOpen_Tax) GetListingHash() string {
+	if m != nil {
+		return m.ListingHash
+	}
+	return ""
+}
+
+func (m *OrderOpen_Tax) GetTaxType() string {
+	if m != nil {
+		return m.TaxType
+	}
+	return ""
+}
+
+func (m *OrderOpen_Tax) GetPercentage() float32 {
+	if m != nil {
+		return m.Percentage
+	}
+	return 0
+}
+
+func (m *OrderOpen_Tax) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *OrderOpen_Tax) GetShippingAmount() string {
+	if m != nil {
+		return m.ShippingAmount
+	}
+	return ""
+}
+
 type OrderReject struct {
 	Type                 OrderReject_RejectType `protobuf:"varint,1,opt,name=type,proto3,enum=OrderReject_RejectType" json:"type,omitempty"`
 	Reason               string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
@@ -1132,6 +1301,7 @@ type Rating struct {
 	CustomerService      uint32               `protobuf:"varint,11,opt,name=customerService,proto3" json:"customerService,omitempty"`
 	Review               string               `protobuf:"bytes,12,opt,name=review,proto3" json:"review,omitempty"`
 	RatingSignature      []byte               `protobuf:"bytes,13,opt,name=ratingSignature,proto3" json:"ratingSignature,omitempty"`
+	SignatureVersion     uint32               `protobuf:"varint,14,opt,name=signatureVersion,proto3" json:"signatureVersion,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -1253,10 +1423,23 @@ func (m *Rating) GetRatingSignature() []byte {
 	return nil
 }
 
+func (m *Rating) GetSignatureVersion() uint32 {
+	if m != nil {
+		return m.SignatureVersion
+	}
+	return 0
+}
+
 type DisputeOpen struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	OpenedBy             DisputeOpen_Party    `protobuf:"varint,2,opt,name=openedBy,proto3,enum=DisputeOpen_Party" json:"openedBy,omitempty"`
+	Reason               string               `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Contract             []byte               `protobuf:"bytes,4,opt,name=contract,proto3" json:"contract,omitempty"`
+	Evidence             []string             `protobuf:"bytes,5,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	PayoutAddress        string               `protobuf:"bytes,6,opt,name=payoutAddress,proto3" json:"payoutAddress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *DisputeOpen) Reset()         { *m = DisputeOpen{} }
@@ -1284,10 +1467,56 @@ func (m *DisputeOpen) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DisputeOpen proto.InternalMessageInfo
 
+func (m *DisputeOpen) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *DisputeOpen) GetOpenedBy() DisputeOpen_Party {
+	if m != nil {
+		return m.OpenedBy
+	}
+	return DisputeOpen_BUYER
+}
+
+func (m *DisputeOpen) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *DisputeOpen) GetContract() []byte {
+	if m != nil {
+		return m.Contract
+	}
+	return nil
+}
+
+func (m *DisputeOpen) GetEvidence() []string {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+func (m *DisputeOpen) GetPayoutAddress() string {
+	if m != nil {
+		return m.PayoutAddress
+	}
+	return ""
+}
+
 type DisputeUpdate struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Contract             []byte               `protobuf:"bytes,2,opt,name=contract,proto3" json:"contract,omitempty"`
+	Evidence             []string             `protobuf:"bytes,3,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	PayoutAddress        string               `protobuf:"bytes,4,opt,name=payoutAddress,proto3" json:"payoutAddress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *DisputeUpdate) Reset()         { *m = DisputeUpdate{} }
@@ -1315,11 +1544,44 @@ func (m *DisputeUpdate) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DisputeUpdate proto.InternalMessageInfo
 
+func (m *DisputeUpdate) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *DisputeUpdate) GetContract() []byte {
+	if m != nil {
+		return m.Contract
+	}
+	return nil
+}
+
+func (m *DisputeUpdate) GetEvidence() []string {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+func (m *DisputeUpdate) GetPayoutAddress() string {
+	if m != nil {
+		return m.PayoutAddress
+	}
+	return ""
+}
+
 type DisputeClose struct {
-	TransactionID        string   `protobuf:"bytes,1,opt,name=transactionID,proto3" json:"transactionID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	TransactionID        string               `protobuf:"bytes,1,opt,name=transactionID,proto3" json:"transactionID,omitempty"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Resolution           string               `protobuf:"bytes,3,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	BuyerPercentage      float32              `protobuf:"fixed32,4,opt,name=buyerPercentage,proto3" json:"buyerPercentage,omitempty"`
+	VendorPercentage     float32              `protobuf:"fixed32,5,opt,name=vendorPercentage,proto3" json:"vendorPercentage,omitempty"`
+	ReleaseInfo          *EscrowPayout        `protobuf:"bytes,6,opt,name=releaseInfo,proto3" json:"releaseInfo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *DisputeClose) Reset()         { *m = DisputeClose{} }
@@ -1354,15 +1616,53 @@ func (m *DisputeClose) GetTransactionID() string {
 	return ""
 }
 
+func (m *DisputeClose) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *DisputeClose) GetResolution() string {
+	if m != nil {
+		return m.Resolution
+	}
+	return ""
+}
+
+func (m *DisputeClose) GetBuyerPercentage() float32 {
+	if m != nil {
+		return m.BuyerPercentage
+	}
+	return 0
+}
+
+func (m *DisputeClose) GetVendorPercentage() float32 {
+	if m != nil {
+		return m.VendorPercentage
+	}
+	return 0
+}
+
+func (m *DisputeClose) GetReleaseInfo() *EscrowPayout {
+	if m != nil {
+		return m.ReleaseInfo
+	}
+	return nil
+}
+
 type Refund struct {
 	// Types that are valid to be assigned to RefundInfo:
 	//	*Refund_TransactionID
 	//	*Refund_ReleaseInfo
-	RefundInfo           isRefund_RefundInfo `protobuf_oneof:"refundInfo"`
-	Amount               string              `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	RefundInfo isRefund_RefundInfo `protobuf_oneof:"refundInfo"`
+	Amount     string              `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Overpayment is set when only the amount paid in excess of
+	// the order total is being refunded. The order stays open.
+	Overpayment          bool     `protobuf:"varint,4,opt,name=overpayment,proto3" json:"overpayment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Refund) Reset()         { *m = Refund{} }
@@ -1434,6 +1734,13 @@ func (m *Refund) GetAmount() string {
 	return ""
 }
 
+func (m *Refund) GetOverpayment() bool {
+	if m != nil {
+		return m.Overpayment
+	}
+	return false
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Refund) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1482,9 +1789,10 @@ func (m *PaymentSent) GetTransactionID() string {
 }
 
 type PaymentFinalized struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ReleaseInfo          *EscrowRelease `protobuf:"bytes,1,opt,name=releaseInfo,proto3" json:"releaseInfo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *PaymentFinalized) Reset()         { *m = PaymentFinalized{} }
@@ -1512,6 +1820,13 @@ func (m *PaymentFinalized) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PaymentFinalized proto.InternalMessageInfo
 
+func (m *PaymentFinalized) GetReleaseInfo() *EscrowRelease {
+	if m != nil {
+		return m.ReleaseInfo
+	}
+	return nil
+}
+
 type EscrowRelease struct {
 	EscrowSignatures     []*Signature `protobuf:"bytes,1,rep,name=escrowSignatures,proto3" json:"escrowSignatures,omitempty"`
 	FromIDs              [][]byte     `protobuf:"bytes,2,rep,name=fromIDs,proto3" json:"fromIDs,omitempty"`
@@ -1575,6 +1890,108 @@ func (m *EscrowRelease) GetToAmount() string {
 	return ""
 }
 
+type EscrowPayout struct {
+	EscrowSignatures     []*Signature           `protobuf:"bytes,1,rep,name=escrowSignatures,proto3" json:"escrowSignatures,omitempty"`
+	FromIDs              [][]byte               `protobuf:"bytes,2,rep,name=fromIDs,proto3" json:"fromIDs,omitempty"`
+	Outputs              []*EscrowPayout_Output `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *EscrowPayout) Reset()         { *m = EscrowPayout{} }
+func (m *EscrowPayout) String() string { return proto.CompactTextString(m) }
+func (*EscrowPayout) ProtoMessage()    {}
+func (*EscrowPayout) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0f5d4cf0fc9e41b, []int{16}
+}
+
+func (m *EscrowPayout) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EscrowPayout.Unmarshal(m, b)
+}
+func (m *EscrowPayout) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EscrowPayout.Marshal(b, m, deterministic)
+}
+func (m *EscrowPayout) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EscrowPayout.Merge(m, src)
+}
+func (m *EscrowPayout) XXX_Size() int {
+	return xxx_messageInfo_EscrowPayout.Size(m)
+}
+func (m *EscrowPayout) XXX_DiscardUnknown() {
+	xxx_messageInfo_EscrowPayout.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EscrowPayout proto.InternalMessageInfo
+
+func (m *EscrowPayout) GetEscrowSignatures() []*Signature {
+	if m != nil {
+		return m.EscrowSignatures
+	}
+	return nil
+}
+
+func (m *EscrowPayout) GetFromIDs() [][]byte {
+	if m != nil {
+		return m.FromIDs
+	}
+	return nil
+}
+
+func (m *EscrowPayout) GetOutputs() []*EscrowPayout_Output {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+type EscrowPayout_Output struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Amount               string   `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EscrowPayout_Output) Reset()         { *m = EscrowPayout_Output{} }
+func (m *EscrowPayout_Output) String() string { return proto.CompactTextString(m) }
+func (*EscrowPayout_Output) ProtoMessage()    {}
+func (*EscrowPayout_Output) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0f5d4cf0fc9e41b, []int{16, 0}
+}
+
+func (m *EscrowPayout_Output) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EscrowPayout_Output.Unmarshal(m, b)
+}
+func (m *EscrowPayout_Output) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EscrowPayout_Output.Marshal(b, m, deterministic)
+}
+func (m *EscrowPayout_Output) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EscrowPayout_Output.Merge(m, src)
+}
+func (m *EscrowPayout_Output) XXX_Size() int {
+	return xxx_messageInfo_EscrowPayout_Output.Size(m)
+}
+func (m *EscrowPayout_Output) XXX_DiscardUnknown() {
+	xxx_messageInfo_EscrowPayout_Output.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EscrowPayout_Output proto.InternalMessageInfo
+
+func (m *EscrowPayout_Output) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *EscrowPayout_Output) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
 type Signature struct {
 	From                 []byte   `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
 	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
@@ -1588,7 +2005,7 @@ func (m *Signature) Reset()         { *m = Signature{} }
 func (m *Signature) String() string { return proto.CompactTextString(m) }
 func (*Signature) ProtoMessage()    {}
 func (*Signature) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0f5d4cf0fc9e41b, []int{16}
+	return fileDescriptor_e0f5d4cf0fc9e41b, []int{17}
 }
 
 func (m *Signature) XXX_Unmarshal(b []byte) error {
@@ -1641,7 +2058,7 @@ func (m *PaymentSentList) Reset()         { *m = PaymentSentList{} }
 func (m *PaymentSentList) String() string { return proto.CompactTextString(m) }
 func (*PaymentSentList) ProtoMessage()    {}
 func (*PaymentSentList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0f5d4cf0fc9e41b, []int{17}
+	return fileDescriptor_e0f5d4cf0fc9e41b, []int{18}
 }
 
 func (m *PaymentSentList) XXX_Unmarshal(b []byte) error {
@@ -1681,7 +2098,7 @@ func (m *PaymentSentList_Message) Reset()         { *m = PaymentSentList_Message
 func (m *PaymentSentList_Message) String() string { return proto.CompactTextString(m) }
 func (*PaymentSentList_Message) ProtoMessage()    {}
 func (*PaymentSentList_Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0f5d4cf0fc9e41b, []int{17, 0}
+	return fileDescriptor_e0f5d4cf0fc9e41b, []int{18, 0}
 }
 
 func (m *PaymentSentList_Message) XXX_Unmarshal(b []byte) error {
@@ -1727,7 +2144,7 @@ func (m *FulfillmentList) Reset()         { *m = FulfillmentList{} }
 func (m *FulfillmentList) String() string { return proto.CompactTextString(m) }
 func (*FulfillmentList) ProtoMessage()    {}
 func (*FulfillmentList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0f5d4cf0fc9e41b, []int{18}
+	return fileDescriptor_e0f5d4cf0fc9e41b, []int{19}
 }
 
 func (m *FulfillmentList) XXX_Unmarshal(b []byte) error {
@@ -1767,7 +2184,7 @@ func (m *FulfillmentList_Message) Reset()         { *m = FulfillmentList_Message
 func (m *FulfillmentList_Message) String() string { return proto.CompactTextString(m) }
 func (*FulfillmentList_Message) ProtoMessage()    {}
 func (*FulfillmentList_Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0f5d4cf0fc9e41b, []int{18, 0}
+	return fileDescriptor_e0f5d4cf0fc9e41b, []int{19, 0}
 }
 
 func (m *FulfillmentList_Message) XXX_Unmarshal(b []byte) error {
@@ -1813,7 +2230,7 @@ func (m *RefundList) Reset()         { *m = RefundList{} }
 func (m *RefundList) String() string { return proto.CompactTextString(m) }
 func (*RefundList) ProtoMessage()    {}
 func (*RefundList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0f5d4cf0fc9e41b, []int{19}
+	return fileDescriptor_e0f5d4cf0fc9e41b, []int{20}
 }
 
 func (m *RefundList) XXX_Unmarshal(b []byte) error {
@@ -1853,7 +2270,7 @@ func (m *RefundList_Message) Reset()         { *m = RefundList_Message{} }
 func (m *RefundList_Message) String() string { return proto.CompactTextString(m) }
 func (*RefundList_Message) ProtoMessage()    {}
 func (*RefundList_Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0f5d4cf0fc9e41b, []int{19, 0}
+	return fileDescriptor_e0f5d4cf0fc9e41b, []int{20, 0}
 }
 
 func (m *RefundList_Message) XXX_Unmarshal(b []byte) error {
@@ -1891,12 +2308,15 @@ func (m *RefundList_Message) GetSignature() []byte {
 func init() {
 	proto.RegisterEnum("OrderOpen_Payment_Method", OrderOpen_Payment_Method_name, OrderOpen_Payment_Method_value)
 	proto.RegisterEnum("OrderReject_RejectType", OrderReject_RejectType_name, OrderReject_RejectType_value)
+	proto.RegisterEnum("DisputeOpen_Party", DisputeOpen_Party_name, DisputeOpen_Party_value)
 	proto.RegisterType((*OrderOpen)(nil), "OrderOpen")
 	proto.RegisterType((*OrderOpen_Shipping)(nil), "OrderOpen.Shipping")
 	proto.RegisterType((*OrderOpen_Item)(nil), "OrderOpen.Item")
 	proto.RegisterType((*OrderOpen_Item_Option)(nil), "OrderOpen.Item.Option")
 	proto.RegisterType((*OrderOpen_Item_ShippingOption)(nil), "OrderOpen.Item.ShippingOption")
 	proto.RegisterType((*OrderOpen_Payment)(nil), "OrderOpen.Payment")
+	proto.RegisterType((*OrderOpen_Payment_ModeratorFee)(nil), "OrderOpen.Payment.ModeratorFee")
+	proto.RegisterType((*OrderOpen_Tax)(nil), "OrderOpen.Tax")
 	proto.RegisterType((*OrderReject)(nil), "OrderReject")
 	proto.RegisterType((*OrderConfirmation)(nil), "OrderConfirmation")
 	proto.RegisterType((*OrderCancel)(nil), "OrderCancel")
@@ -1916,6 +2336,8 @@ func init() {
 	proto.RegisterType((*PaymentSent)(nil), "PaymentSent")
 	proto.RegisterType((*PaymentFinalized)(nil), "PaymentFinalized")
 	proto.RegisterType((*EscrowRelease)(nil), "EscrowRelease")
+	proto.RegisterType((*EscrowPayout)(nil), "EscrowPayout")
+	proto.RegisterType((*EscrowPayout_Output)(nil), "EscrowPayout.Output")
 	proto.RegisterType((*Signature)(nil), "Signature")
 	proto.RegisterType((*PaymentSentList)(nil), "PaymentSentList")
 	proto.RegisterType((*PaymentSentList_Message)(nil), "PaymentSentList.Message")
@@ -1928,108 +2350,132 @@ func init() {
 func init() { proto.RegisterFile("orders.proto", fileDescriptor_e0f5d4cf0fc9e41b) }
 
 var fileDescriptor_e0f5d4cf0fc9e41b = []byte{
-	// 1638 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0x5b, 0x6f, 0xdb, 0x46,
-	0x16, 0xb6, 0x2c, 0x5b, 0x97, 0xa3, 0x6b, 0x26, 0x81, 0x97, 0x2b, 0x64, 0x13, 0x2f, 0x91, 0x0d,
-	0x8c, 0xbd, 0xd0, 0x59, 0x3b, 0x08, 0xb2, 0xc0, 0x22, 0x0b, 0x59, 0x92, 0x61, 0x6d, 0x1c, 0x3b,
-	0x18, 0x39, 0x79, 0xd8, 0x05, 0x1a, 0xd0, 0xe4, 0x58, 0x66, 0x4a, 0x72, 0x98, 0xe1, 0xd0, 0xa9,
-	0xfa, 0x07, 0x0a, 0xf4, 0xa9, 0x2f, 0x7d, 0xea, 0x7f, 0xe8, 0x4b, 0xff, 0x49, 0x5b, 0xf4, 0xa5,
-	0x0f, 0xfd, 0x2b, 0xc5, 0x0c, 0x87, 0x57, 0x29, 0xb1, 0xf3, 0xa4, 0x39, 0xd7, 0x39, 0xe7, 0xf0,
-	0x3b, 0xe7, 0x8c, 0xa0, 0x4d, 0x99, 0x4d, 0x58, 0x68, 0x04, 0x8c, 0x72, 0x3a, 0xb8, 0x3f, 0xa7,
-	0x74, 0xee, 0x92, 0x5d, 0x49, 0x9d, 0x47, 0x17, 0xbb, 0xdc, 0xf1, 0x48, 0xc8, 0x4d, 0x2f, 0x50,
-	0x0a, 0xc8, 0xa2, 0x91, 0xcf, 0xd9, 0xc2, 0xa2, 0x36, 0x49, 0x8c, 0x3a, 0xae, 0x13, 0x72, 0xc7,
-	0x9f, 0x2b, 0xb2, 0x6d, 0x51, 0xcf, 0xa3, 0x7e, 0x4c, 0xe9, 0xbf, 0x00, 0x34, 0x4f, 0xc5, 0x15,
-	0xa7, 0x01, 0xf1, 0xd1, 0x5f, 0xa1, 0xa1, 0x94, 0x43, 0xad, 0xb2, 0x5d, 0xdd, 0x69, 0xed, 0x75,
-	0x8d, 0x99, 0x33, 0xf7, 0x89, 0x7d, 0x1c, 0xb3, 0x71, 0x2a, 0x47, 0x0f, 0xa0, 0xc3, 0xc8, 0x45,
-	0xe4, 0xdb, 0x43, 0xdb, 0x66, 0x24, 0x0c, 0xb5, 0xf5, 0xed, 0xca, 0x4e, 0x13, 0x17, 0x99, 0x68,
-	0x17, 0x1a, 0xe1, 0xa5, 0x13, 0x04, 0x8e, 0x3f, 0xd7, 0xaa, 0xdb, 0x95, 0x9d, 0xd6, 0xde, 0x6d,
-	0x23, 0xbd, 0xcf, 0x98, 0x29, 0x11, 0x4e, 0x95, 0xd0, 0x9f, 0xa0, 0x7e, 0x1e, 0x2d, 0x08, 0x9b,
-	0x8e, 0xb5, 0x0d, 0xa9, 0x5f, 0x35, 0xa6, 0x63, 0x9c, 0xf0, 0xd0, 0x53, 0x68, 0xa6, 0x39, 0x6b,
-	0x9b, 0x52, 0x61, 0x60, 0xc4, 0x55, 0x31, 0x92, 0xaa, 0x18, 0x67, 0x89, 0x06, 0xce, 0x94, 0xd1,
-	0x5f, 0x60, 0xd3, 0xe1, 0xc4, 0x0b, 0xb5, 0x9a, 0x4c, 0xac, 0x97, 0x0b, 0x63, 0xca, 0x89, 0x87,
-	0x63, 0x29, 0xfa, 0x3b, 0xd4, 0x03, 0x73, 0xe1, 0x11, 0x9f, 0x6b, 0x75, 0xe9, 0x1e, 0xe5, 0x14,
-	0x5f, 0xc6, 0x12, 0x9c, 0xa8, 0xa0, 0x7b, 0x00, 0xcc, 0x14, 0xf5, 0x78, 0x4e, 0x16, 0xa1, 0xd6,
-	0xd8, 0xae, 0xee, 0xb4, 0x71, 0x8e, 0x83, 0xf6, 0xe0, 0x8e, 0xe9, 0x72, 0xc2, 0x7c, 0x93, 0x93,
-	0x11, 0xf5, 0xb9, 0x69, 0xf1, 0xa9, 0x7f, 0x41, 0xb5, 0xa6, 0xac, 0xd5, 0x4a, 0xd9, 0xe0, 0xc7,
-	0x0a, 0x34, 0x92, 0xc2, 0xa0, 0x2d, 0xa8, 0x89, 0xd2, 0x9c, 0x51, 0xad, 0x22, 0x4d, 0x14, 0x85,
-	0x34, 0xa8, 0x9b, 0x85, 0xba, 0x27, 0x24, 0x42, 0xb0, 0x61, 0x39, 0x7c, 0x21, 0xab, 0xdd, 0xc4,
-	0xf2, 0x8c, 0xee, 0xc0, 0x66, 0xc8, 0x4d, 0x4e, 0x64, 0x49, 0x9b, 0x38, 0x26, 0x44, 0xf0, 0x01,
-	0x0d, 0xb9, 0xe9, 0x8e, 0xa8, 0x4d, 0x64, 0x31, 0x9b, 0x38, 0xc7, 0x41, 0x0f, 0xa1, 0xae, 0xe0,
-	0xa4, 0xd5, 0xb6, 0x2b, 0x3b, 0xdd, 0xbd, 0xb6, 0x31, 0x8a, 0x69, 0x21, 0xc6, 0x89, 0x10, 0xe9,
-	0xd0, 0x56, 0x97, 0x9f, 0x50, 0x4e, 0x42, 0x59, 0xb7, 0x26, 0x2e, 0xf0, 0x06, 0xdf, 0x54, 0x61,
-	0x43, 0x94, 0x19, 0x6d, 0x43, 0x4b, 0x41, 0xe8, 0xc8, 0x0c, 0x2f, 0x55, 0x56, 0x79, 0x16, 0x1a,
-	0x40, 0xe3, 0x5d, 0x64, 0xfa, 0x5c, 0x24, 0x11, 0xe7, 0x96, 0xd2, 0xe8, 0x11, 0xd4, 0x69, 0xc0,
-	0x1d, 0xea, 0x87, 0x5a, 0x55, 0x7e, 0xc6, 0xad, 0xd2, 0x67, 0x34, 0x4e, 0xa5, 0x18, 0x27, 0x6a,
-	0xe8, 0x10, 0xba, 0x09, 0xb6, 0x62, 0x91, 0x82, 0xd5, 0xbd, 0xb2, 0xe1, 0xac, 0xa0, 0x85, 0x4b,
-	0x56, 0xa2, 0xac, 0x1e, 0xf1, 0xa8, 0x2a, 0x93, 0x3c, 0x8b, 0x5c, 0x2c, 0x1a, 0x05, 0xd4, 0x17,
-	0xf5, 0x88, 0x81, 0xd5, 0xc4, 0x79, 0x16, 0x7a, 0x08, 0x5d, 0x05, 0x95, 0xa4, 0x4b, 0xe2, 0xe2,
-	0x94, 0xb8, 0x83, 0x3d, 0xa8, 0x65, 0xf7, 0xf8, 0xa6, 0x47, 0x54, 0x61, 0xe4, 0x59, 0x7c, 0xbe,
-	0x2b, 0xd3, 0x8d, 0x88, 0x2a, 0x47, 0x4c, 0x0c, 0x9e, 0x41, 0x77, 0xb6, 0x14, 0xe3, 0x92, 0xad,
-	0x06, 0xf5, 0x90, 0xb0, 0x2b, 0xc7, 0x4a, 0xac, 0x13, 0x72, 0xf0, 0xeb, 0x3a, 0xd4, 0x15, 0xa0,
-	0xd1, 0x3f, 0xa1, 0xe6, 0x11, 0x7e, 0x49, 0x6d, 0x69, 0xdb, 0xdd, 0xfb, 0xe3, 0x32, 0xe8, 0x8d,
-	0x17, 0x52, 0x01, 0x2b, 0x45, 0x74, 0x17, 0x9a, 0x1e, 0xb5, 0x09, 0x33, 0x39, 0x65, 0xca, 0x75,
-	0xc6, 0x10, 0xb8, 0x35, 0x3d, 0x81, 0x0f, 0x85, 0x43, 0x45, 0x09, 0x2b, 0xeb, 0xd2, 0x74, 0x7c,
-	0x31, 0xa0, 0x14, 0x1a, 0x33, 0x46, 0x1e, 0xd5, 0x9b, 0x45, 0x54, 0x8b, 0x3e, 0xb0, 0x98, 0x13,
-	0x70, 0x09, 0x45, 0xd1, 0x07, 0x92, 0x12, 0xd8, 0x4b, 0x2f, 0x7d, 0x4e, 0x16, 0xb2, 0xbc, 0x6d,
-	0x5c, 0xe0, 0xc9, 0x8e, 0xa0, 0x8e, 0xaf, 0x35, 0x54, 0x47, 0x50, 0x47, 0x4c, 0xba, 0x3e, 0x09,
-	0x2d, 0x46, 0xdf, 0x63, 0xe2, 0x12, 0x33, 0x24, 0x87, 0x84, 0xa8, 0xa6, 0x5c, 0xe2, 0xeb, 0xfb,
-	0x50, 0x8b, 0x73, 0x47, 0x00, 0xb5, 0xf1, 0x14, 0x4f, 0x46, 0x67, 0xfd, 0x35, 0xd4, 0x05, 0x18,
-	0x0d, 0x4f, 0x46, 0x93, 0xe3, 0xe1, 0xc1, 0xf1, 0xa4, 0x5f, 0x41, 0x1d, 0x68, 0xbe, 0x38, 0x1d,
-	0x4f, 0xf0, 0xf0, 0x6c, 0x32, 0xee, 0xaf, 0xeb, 0x5f, 0x55, 0xa0, 0x25, 0x6b, 0x88, 0xc9, 0x5b,
-	0x62, 0x71, 0xf4, 0x37, 0xd8, 0xe0, 0x8b, 0x80, 0xa8, 0xfa, 0xfe, 0xc1, 0xc8, 0xc9, 0x8c, 0xf8,
-	0xe7, 0x6c, 0x11, 0x10, 0x2c, 0x95, 0x44, 0xb6, 0x8c, 0x98, 0x21, 0xf5, 0x55, 0x61, 0x15, 0xa5,
-	0xef, 0x03, 0x64, 0xba, 0xa8, 0x07, 0xad, 0x57, 0xb3, 0x09, 0x7e, 0x83, 0x27, 0xff, 0x8d, 0x43,
-	0xba, 0x03, 0xfd, 0xd7, 0xc3, 0xe3, 0xe9, 0x78, 0x78, 0x36, 0x3d, 0x3d, 0x79, 0x33, 0xc1, 0xf8,
-	0x14, 0xf7, 0x2b, 0xfa, 0xbf, 0xe0, 0x96, 0xbc, 0x6c, 0x44, 0xfd, 0x0b, 0x87, 0x79, 0xa6, 0x84,
-	0xca, 0x03, 0xe8, 0x70, 0x66, 0xfa, 0xa1, 0x69, 0x09, 0x72, 0x3a, 0x56, 0x98, 0x29, 0x32, 0xf5,
-	0x7d, 0x95, 0xc3, 0xc8, 0xf4, 0x2d, 0xe2, 0xde, 0xd0, 0xe8, 0x29, 0xf4, 0xb1, 0x9c, 0x80, 0x62,
-	0x73, 0x98, 0x3c, 0x62, 0x44, 0x2c, 0x8b, 0x8d, 0xd0, 0x49, 0x97, 0x4a, 0xdf, 0x28, 0x29, 0x60,
-	0x29, 0xd5, 0x3d, 0xe8, 0x95, 0x04, 0xe2, 0xdb, 0x85, 0x6e, 0x34, 0x4f, 0x20, 0x2d, 0xce, 0x02,
-	0x43, 0xe9, 0x88, 0x95, 0x05, 0x6a, 0xe3, 0x8c, 0x81, 0x76, 0xa0, 0x77, 0x45, 0x7c, 0x9b, 0xb2,
-	0xd4, 0x89, 0x84, 0x60, 0x1b, 0x97, 0xd9, 0xfa, 0x6f, 0x9b, 0xd0, 0x97, 0xe9, 0x1d, 0x46, 0xee,
-	0x85, 0xe3, 0xba, 0xb2, 0x13, 0x46, 0xd0, 0xbe, 0xc8, 0xc8, 0x24, 0xe2, 0xfb, 0x46, 0x59, 0xd1,
-	0x50, 0x67, 0x62, 0xcb, 0xed, 0x51, 0x30, 0x42, 0x8f, 0xa0, 0xc5, 0x62, 0xfc, 0xc8, 0x69, 0xbf,
-	0x2e, 0x27, 0x4e, 0xd7, 0x98, 0xe4, 0x91, 0x85, 0xf3, 0x2a, 0x83, 0x9f, 0x36, 0xa0, 0x53, 0xf0,
-	0x28, 0xb2, 0x14, 0x1b, 0x69, 0xea, 0xdb, 0xe4, 0x0b, 0x99, 0x7e, 0x07, 0x67, 0x0c, 0xd9, 0xea,
-	0x94, 0x27, 0x3d, 0x2d, 0xcf, 0xe8, 0x33, 0xe8, 0x07, 0x97, 0x8b, 0xd0, 0xb1, 0x4c, 0x77, 0x4c,
-	0x5c, 0xe7, 0x8a, 0xb0, 0x85, 0xda, 0xb9, 0x8f, 0xae, 0x09, 0xdf, 0x78, 0x59, 0xb2, 0x3b, 0x5a,
-	0xc3, 0x4b, 0xbe, 0xd0, 0xff, 0xa1, 0x67, 0x3b, 0x73, 0x87, 0xe7, 0xdc, 0xc7, 0xb3, 0x74, 0xf7,
-	0x3a, 0xf7, 0xe3, 0xa2, 0xd9, 0xd1, 0x1a, 0x2e, 0x7b, 0x42, 0x01, 0x6c, 0x59, 0x6c, 0x11, 0x70,
-	0x6a, 0x45, 0x8c, 0x11, 0xdf, 0x5a, 0xa4, 0x77, 0xc4, 0x5b, 0xfe, 0xc9, 0x75, 0x77, 0x8c, 0x56,
-	0x5a, 0x1f, 0xad, 0xe1, 0x0f, 0xf8, 0x1d, 0x9c, 0x41, 0xbf, 0x9c, 0xb6, 0x9c, 0x96, 0x62, 0xa6,
-	0x12, 0xa6, 0x10, 0x97, 0x90, 0x62, 0x92, 0x73, 0x66, 0x5a, 0x9f, 0x3b, 0xfe, 0xfc, 0x24, 0xf2,
-	0xce, 0x49, 0x32, 0xf3, 0x4a, 0xdc, 0xc1, 0x7f, 0xa0, 0x57, 0xca, 0x16, 0xf5, 0xa1, 0x1a, 0x31,
-	0x57, 0x39, 0x14, 0x47, 0xb1, 0xe2, 0x02, 0x33, 0x0c, 0xdf, 0x53, 0x66, 0x27, 0x2b, 0x2e, 0xa1,
-	0x07, 0xcf, 0x60, 0x6b, 0x75, 0x2a, 0x37, 0x6b, 0xbf, 0x03, 0x80, 0x86, 0xad, 0x2c, 0x74, 0x1b,
-	0x3a, 0xaa, 0xf5, 0xbd, 0xc0, 0x25, 0x9c, 0xa0, 0x3f, 0x43, 0x3d, 0xee, 0x94, 0x04, 0xd8, 0x75,
-	0xd5, 0x8a, 0x38, 0xe1, 0x7f, 0x3a, 0x76, 0xf5, 0x9f, 0xab, 0x50, 0x8b, 0xbd, 0xa0, 0xfb, 0xd0,
-	0x88, 0xbb, 0x4c, 0x45, 0xa7, 0x9e, 0x6f, 0x29, 0x13, 0x19, 0xd0, 0x4c, 0xdb, 0x50, 0xf9, 0x5e,
-	0x9e, 0x06, 0x99, 0x4a, 0xfe, 0x39, 0x58, 0x5d, 0xf1, 0x1c, 0xbc, 0x0b, 0x4d, 0x79, 0x3c, 0x11,
-	0x6b, 0x4f, 0xad, 0x93, 0x94, 0x21, 0xca, 0x2c, 0x09, 0x71, 0xd7, 0xa6, 0x9c, 0x01, 0x29, 0x5d,
-	0x7c, 0x48, 0xd6, 0x3e, 0xe5, 0x21, 0xa9, 0x41, 0x9d, 0x5e, 0x11, 0x66, 0xba, 0xae, 0xdc, 0x36,
-	0x1d, 0x9c, 0x90, 0x42, 0xf2, 0x2e, 0x32, 0x5d, 0xf1, 0x70, 0x69, 0xc4, 0x12, 0x45, 0x8a, 0x97,
-	0x82, 0x4d, 0xe2, 0x95, 0x25, 0x9e, 0x20, 0x4d, 0x29, 0xcd, 0xb3, 0xc4, 0xc7, 0x4d, 0x3e, 0xdb,
-	0x2c, 0x20, 0xc4, 0xd6, 0x40, 0xea, 0x14, 0x99, 0x62, 0xb8, 0x59, 0x51, 0xc8, 0xa9, 0x47, 0xd8,
-	0x4c, 0x6d, 0xf5, 0x96, 0xd4, 0x2b, 0xb3, 0xe3, 0x15, 0x72, 0xe5, 0x90, 0xf7, 0x5a, 0x3b, 0x59,
-	0x21, 0x82, 0x12, 0x1e, 0x58, 0xb1, 0xdc, 0x5a, 0x27, 0x1e, 0x8f, 0x25, 0xb6, 0xde, 0x81, 0xd6,
-	0xd8, 0x09, 0x83, 0x88, 0x13, 0xf1, 0x0c, 0xd0, 0x7b, 0xd0, 0x51, 0xe4, 0xab, 0xc0, 0x36, 0x39,
-	0xd1, 0x1f, 0x43, 0x5b, 0x31, 0x46, 0x2e, 0x0d, 0xc9, 0x0d, 0xb7, 0xc3, 0xd7, 0x15, 0xa8, 0x61,
-	0xf9, 0x17, 0x01, 0x3d, 0x5c, 0x69, 0x70, 0xb4, 0x56, 0x32, 0x41, 0x7b, 0x37, 0x40, 0xe4, 0xd1,
-	0x5a, 0x01, 0x93, 0x1f, 0x7a, 0x7f, 0x1c, 0xb4, 0x01, 0xe2, 0x3f, 0x28, 0x12, 0xb9, 0xfb, 0xd0,
-	0x52, 0xaf, 0x9b, 0x99, 0x98, 0xfd, 0x37, 0xcb, 0x00, 0x41, 0x5f, 0x19, 0x1d, 0x3a, 0xbe, 0xe9,
-	0x3a, 0x5f, 0x12, 0x5b, 0xff, 0xae, 0x02, 0x9d, 0x42, 0x3c, 0xe8, 0x49, 0xf2, 0xc0, 0xc8, 0xb6,
-	0xa0, 0x6a, 0x39, 0x30, 0x32, 0xa4, 0x2f, 0xe9, 0x08, 0x0c, 0x5d, 0x30, 0xea, 0x4d, 0xc7, 0xe2,
-	0x61, 0x2f, 0xfe, 0x4e, 0x24, 0xa4, 0xc0, 0x3a, 0xa7, 0xc9, 0x33, 0x32, 0xce, 0x2a, 0x63, 0x08,
-	0xac, 0x73, 0x3a, 0x8c, 0x53, 0x8e, 0x1b, 0x21, 0xa5, 0xf5, 0x19, 0x34, 0x0b, 0x1b, 0x55, 0x78,
-	0x94, 0xb9, 0xb5, 0xb1, 0x3c, 0x0b, 0xd7, 0x61, 0x0a, 0x07, 0xb5, 0x51, 0x53, 0x86, 0x78, 0x7e,
-	0x3a, 0x72, 0x0b, 0x55, 0x25, 0xd4, 0x62, 0x42, 0xff, 0xbe, 0x02, 0xbd, 0x5c, 0xf1, 0xc4, 0x1f,
-	0x44, 0xf4, 0x18, 0x1a, 0x1e, 0x09, 0x43, 0x73, 0x9e, 0x26, 0xab, 0x19, 0x25, 0x1d, 0xe3, 0x45,
-	0xac, 0x80, 0x53, 0xcd, 0x01, 0x81, 0xba, 0x62, 0xa2, 0x7f, 0x03, 0x0a, 0x32, 0x7d, 0xc5, 0x55,
-	0x93, 0xa4, 0x9d, 0x77, 0x85, 0x57, 0xe8, 0x7d, 0x3c, 0x0d, 0xfd, 0x87, 0x0a, 0xf4, 0x72, 0xeb,
-	0xe3, 0x83, 0x01, 0x97, 0x74, 0x56, 0x04, 0xfc, 0x36, 0x0b, 0x78, 0x08, 0x28, 0xb7, 0xf9, 0x8b,
-	0x01, 0xdf, 0x5a, 0x5a, 0x59, 0x78, 0x85, 0xf2, 0x35, 0x51, 0x7f, 0x5b, 0x11, 0x6f, 0x3e, 0x81,
-	0x58, 0x19, 0xf0, 0xee, 0x52, 0xc0, 0xb7, 0x8d, 0x4c, 0xbc, 0x22, 0xd6, 0xd7, 0x59, 0xac, 0xff,
-	0x48, 0xfe, 0xb1, 0x17, 0xc3, 0xac, 0x2b, 0x07, 0xb8, 0x28, 0xfd, 0x78, 0x5c, 0x07, 0x1b, 0xff,
-	0x5b, 0x0f, 0xce, 0xcf, 0x6b, 0x72, 0x54, 0xee, 0xff, 0x1e, 0x00, 0x00, 0xff, 0xff, 0x3c, 0xfa,
-	0xcc, 0x92, 0xa7, 0x10, 0x00, 0x00,
+	// 2018 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xcd, 0x6f, 0xdb, 0xc8,
+	0x15, 0x37, 0x25, 0x59, 0x1f, 0x4f, 0x1f, 0xd6, 0xce, 0x06, 0x29, 0x2b, 0x6c, 0x13, 0x57, 0x48,
+	0x03, 0xa3, 0x1f, 0x74, 0xea, 0x14, 0x8b, 0x6d, 0x51, 0x6c, 0x61, 0x4b, 0x0a, 0xac, 0x6e, 0x62,
+	0x07, 0x63, 0x27, 0x40, 0x5b, 0xa0, 0x0b, 0x86, 0x1c, 0x29, 0xdc, 0x92, 0x1c, 0x2e, 0x39, 0x74,
+	0xac, 0x9e, 0x7a, 0xeb, 0xa9, 0x40, 0x51, 0x60, 0x4f, 0x3d, 0xf5, 0xd4, 0x4b, 0xd1, 0x4b, 0xff,
+	0x8d, 0x9e, 0xda, 0x43, 0x8f, 0xfd, 0x57, 0x8a, 0x37, 0x33, 0xa4, 0x48, 0x4a, 0x89, 0x9d, 0x02,
+	0x7b, 0x12, 0xdf, 0xef, 0xbd, 0x99, 0x79, 0xef, 0xcd, 0xfb, 0x1a, 0x41, 0x8f, 0xc7, 0x2e, 0x8b,
+	0x13, 0x2b, 0x8a, 0xb9, 0xe0, 0xa3, 0xfb, 0x4b, 0xce, 0x97, 0x3e, 0x3b, 0x94, 0xd4, 0xab, 0x74,
+	0x71, 0x28, 0xbc, 0x80, 0x25, 0xc2, 0x0e, 0x22, 0x2d, 0x40, 0x1c, 0x9e, 0x86, 0x22, 0x5e, 0x39,
+	0xdc, 0x65, 0xd9, 0xa2, 0xbe, 0xef, 0x25, 0xc2, 0x0b, 0x97, 0x9a, 0xec, 0x39, 0x3c, 0x08, 0x78,
+	0xa8, 0xa8, 0xf1, 0x7f, 0xfa, 0xd0, 0x39, 0xc7, 0x23, 0xce, 0x23, 0x16, 0x92, 0xef, 0x42, 0x5b,
+	0x0b, 0x27, 0xa6, 0xb1, 0x5f, 0x3f, 0xe8, 0x1e, 0x0d, 0xac, 0x0b, 0x6f, 0x19, 0x32, 0xf7, 0xa9,
+	0x82, 0x69, 0xce, 0x27, 0x0f, 0xa0, 0x1f, 0xb3, 0x45, 0x1a, 0xba, 0xc7, 0xae, 0x1b, 0xb3, 0x24,
+	0x31, 0x6b, 0xfb, 0xc6, 0x41, 0x87, 0x96, 0x41, 0x72, 0x08, 0xed, 0xe4, 0xb5, 0x17, 0x45, 0x5e,
+	0xb8, 0x34, 0xeb, 0xfb, 0xc6, 0x41, 0xf7, 0xe8, 0x43, 0x2b, 0x3f, 0xcf, 0xba, 0xd0, 0x2c, 0x9a,
+	0x0b, 0x91, 0x6f, 0x41, 0xeb, 0x55, 0xba, 0x62, 0xf1, 0x7c, 0x6a, 0x36, 0xa4, 0x7c, 0xdd, 0x9a,
+	0x4f, 0x69, 0x86, 0x91, 0x4f, 0xa0, 0x93, 0xdb, 0x6c, 0xee, 0x4a, 0x81, 0x91, 0xa5, 0xbc, 0x62,
+	0x65, 0x5e, 0xb1, 0x2e, 0x33, 0x09, 0xba, 0x16, 0x26, 0xdf, 0x81, 0x5d, 0x4f, 0xb0, 0x20, 0x31,
+	0x9b, 0xd2, 0xb0, 0xbd, 0x82, 0x1a, 0x73, 0xc1, 0x02, 0xaa, 0xb8, 0xe4, 0xfb, 0xd0, 0x8a, 0xec,
+	0x55, 0xc0, 0x42, 0x61, 0xb6, 0xe4, 0xf6, 0xa4, 0x20, 0xf8, 0x5c, 0x71, 0x68, 0x26, 0x42, 0xee,
+	0x01, 0xc4, 0x36, 0xfa, 0xe3, 0x33, 0xb6, 0x4a, 0xcc, 0xf6, 0x7e, 0xfd, 0xa0, 0x47, 0x0b, 0x08,
+	0x39, 0x82, 0x3b, 0xb6, 0x2f, 0x58, 0x1c, 0xda, 0x82, 0x4d, 0x78, 0x28, 0x6c, 0x47, 0xcc, 0xc3,
+	0x05, 0x37, 0x3b, 0xd2, 0x57, 0x5b, 0x79, 0xe4, 0x01, 0xec, 0x0a, 0xfb, 0x9a, 0x25, 0x26, 0xe8,
+	0x1b, 0x58, 0x9f, 0x7f, 0x69, 0x5f, 0x53, 0xc5, 0x1c, 0xfd, 0xcb, 0x80, 0x76, 0xe6, 0x3e, 0x72,
+	0x17, 0x9a, 0xe8, 0xc0, 0x4b, 0x6e, 0x1a, 0x72, 0x63, 0x4d, 0x11, 0x13, 0x5a, 0x76, 0xe9, 0x76,
+	0x32, 0x92, 0x10, 0x68, 0x38, 0x9e, 0x58, 0xc9, 0x3b, 0xe9, 0x50, 0xf9, 0x4d, 0xee, 0xc0, 0x6e,
+	0x22, 0x6c, 0xc1, 0xa4, 0xe3, 0x3b, 0x54, 0x11, 0x68, 0x62, 0xc4, 0x13, 0x61, 0xfb, 0x13, 0xee,
+	0x32, 0xe9, 0xf2, 0x0e, 0x2d, 0x20, 0xe4, 0x21, 0xb4, 0x74, 0xd0, 0x99, 0xcd, 0x7d, 0xe3, 0x60,
+	0x70, 0xd4, 0xb3, 0x26, 0x8a, 0x46, 0x36, 0xcd, 0x98, 0x64, 0x0c, 0x3d, 0x7d, 0xf8, 0x19, 0x17,
+	0x2c, 0x91, 0xde, 0xed, 0xd0, 0x12, 0x36, 0xfa, 0x63, 0x1d, 0x1a, 0x78, 0x19, 0x64, 0x1f, 0xba,
+	0x3a, 0xd0, 0x4e, 0xed, 0xe4, 0xb5, 0xb6, 0xaa, 0x08, 0x91, 0x11, 0xb4, 0xbf, 0x4c, 0xed, 0x50,
+	0xa0, 0x11, 0xca, 0xb6, 0x9c, 0x26, 0x8f, 0xa0, 0xc5, 0x23, 0xe1, 0xf1, 0x30, 0x31, 0xeb, 0xd2,
+	0x87, 0x77, 0x2b, 0x97, 0x6d, 0x9d, 0x4b, 0x36, 0xcd, 0xc4, 0xc8, 0x13, 0x18, 0x64, 0x11, 0xa8,
+	0x58, 0x3a, 0xf8, 0xee, 0x55, 0x17, 0x5e, 0x94, 0xa4, 0x68, 0x65, 0x15, 0xba, 0x35, 0x60, 0x01,
+	0xd7, 0x6e, 0x92, 0xdf, 0x68, 0x8b, 0xc3, 0xd3, 0x88, 0x87, 0xe8, 0x0f, 0x15, 0x7e, 0x1d, 0x5a,
+	0x84, 0xc8, 0x43, 0x18, 0xe8, 0x80, 0xca, 0x72, 0x49, 0x39, 0xa7, 0x82, 0x8e, 0x8e, 0xa0, 0xb9,
+	0x3e, 0x27, 0xb4, 0x03, 0xa6, 0x1d, 0x23, 0xbf, 0xf1, 0xfa, 0xae, 0x6c, 0x3f, 0x65, 0xda, 0x1d,
+	0x8a, 0x18, 0x7d, 0x0a, 0x83, 0x8b, 0x0d, 0x1d, 0x37, 0xd6, 0x9a, 0xd0, 0x4a, 0x58, 0x7c, 0xe5,
+	0x39, 0xd9, 0xea, 0x8c, 0x1c, 0xfd, 0xa9, 0x01, 0x2d, 0x1d, 0xf6, 0xe4, 0x87, 0xd0, 0x0c, 0x98,
+	0x78, 0xcd, 0x5d, 0xb9, 0x76, 0x70, 0xf4, 0xcd, 0xcd, 0xd4, 0xb0, 0x9e, 0x49, 0x01, 0xaa, 0x05,
+	0xc9, 0x47, 0xd0, 0x09, 0xb8, 0xcb, 0x62, 0x5b, 0xf0, 0x58, 0x6f, 0xbd, 0x06, 0x30, 0x6e, 0xed,
+	0x00, 0xe3, 0x43, 0xc7, 0xa1, 0xa6, 0x70, 0x95, 0xf3, 0xda, 0xf6, 0x42, 0x2c, 0x63, 0x3a, 0x1a,
+	0xd7, 0x40, 0x31, 0xaa, 0x77, 0xcb, 0x51, 0x8d, 0x79, 0xe0, 0xc4, 0x5e, 0x24, 0xcc, 0xa6, 0xce,
+	0x03, 0x49, 0x61, 0xec, 0xe5, 0x87, 0x7e, 0xc6, 0x56, 0xd2, 0xbd, 0x3d, 0x5a, 0xc2, 0x64, 0x46,
+	0x70, 0x2f, 0x34, 0xdb, 0x3a, 0x23, 0xb8, 0x87, 0xf5, 0x70, 0xc8, 0x12, 0x27, 0xe6, 0x6f, 0x28,
+	0xf3, 0x99, 0x9d, 0xb0, 0x27, 0x8c, 0xe9, 0xd4, 0xdd, 0xc0, 0xc9, 0xa4, 0x70, 0x06, 0xca, 0x81,
+	0x0c, 0xa0, 0xfb, 0xdb, 0x5c, 0x54, 0x10, 0xa3, 0xa5, 0x45, 0x78, 0x60, 0xe0, 0x25, 0xfa, 0xda,
+	0x4f, 0xd2, 0xc5, 0x82, 0xc5, 0x66, 0x77, 0xdf, 0x38, 0xa8, 0xd1, 0x0d, 0x7c, 0x34, 0x85, 0x5e,
+	0x71, 0x27, 0xbc, 0xff, 0x85, 0x77, 0xcd, 0x5c, 0x7d, 0xb1, 0x8a, 0x90, 0xe9, 0xcb, 0x62, 0x87,
+	0x85, 0xc2, 0x5e, 0xaa, 0xcb, 0xad, 0xd1, 0x02, 0x32, 0x7e, 0x0c, 0x4d, 0x75, 0x65, 0x04, 0xa0,
+	0x39, 0x9d, 0xd3, 0xd9, 0xe4, 0x72, 0xb8, 0x43, 0x06, 0x00, 0x93, 0xe3, 0xb3, 0xc9, 0xec, 0xe9,
+	0xf1, 0xc9, 0xd3, 0xd9, 0xd0, 0x20, 0x7d, 0xe8, 0x3c, 0x3b, 0x9f, 0xce, 0xe8, 0xf1, 0xe5, 0x6c,
+	0x3a, 0xac, 0x8d, 0xfe, 0x62, 0x40, 0xfd, 0xd2, 0xbe, 0xbe, 0x45, 0x9a, 0x9a, 0xd0, 0x12, 0xf6,
+	0xf5, 0xe5, 0x2a, 0xca, 0x03, 0x4b, 0x93, 0x15, 0xc5, 0xea, 0x55, 0xc5, 0x0a, 0xb1, 0xd1, 0x28,
+	0xc5, 0xc6, 0xc3, 0x75, 0xaa, 0x1e, 0x2b, 0xbe, 0x0a, 0x82, 0x0a, 0x3a, 0xfe, 0xbd, 0x01, 0x5d,
+	0xe9, 0x7b, 0xca, 0xbe, 0x60, 0x8e, 0x20, 0xdf, 0x83, 0x86, 0x40, 0x35, 0x54, 0xe8, 0x7e, 0xc3,
+	0x2a, 0xf0, 0x2c, 0xf5, 0x83, 0x6a, 0x51, 0x29, 0x84, 0x87, 0xc7, 0xcc, 0x4e, 0x78, 0xa8, 0xb5,
+	0xd6, 0xd4, 0xf8, 0x31, 0xc0, 0x5a, 0x96, 0xec, 0x41, 0xf7, 0xc5, 0xc5, 0x8c, 0x7e, 0x4e, 0x67,
+	0x3f, 0x57, 0x6e, 0xbb, 0x03, 0xc3, 0x97, 0xc7, 0x4f, 0xe7, 0xd3, 0xe3, 0xcb, 0xf9, 0xf9, 0xd9,
+	0xe7, 0x33, 0x4a, 0xcf, 0xe9, 0xd0, 0x18, 0xff, 0x18, 0x3e, 0x90, 0x87, 0x4d, 0x78, 0xb8, 0xf0,
+	0xe2, 0xc0, 0x96, 0x59, 0xf8, 0x00, 0xfa, 0x22, 0xb6, 0xc3, 0xc4, 0x76, 0x90, 0x9c, 0x4f, 0xb5,
+	0xf3, 0xca, 0xe0, 0xf8, 0xb1, 0xb6, 0x61, 0x62, 0x87, 0x0e, 0xf3, 0x6f, 0xb9, 0xe8, 0x13, 0x18,
+	0x52, 0xd9, 0x82, 0xb0, 0x75, 0xdb, 0x22, 0x8d, 0x19, 0x76, 0xeb, 0x46, 0xe2, 0xe5, 0x5d, 0x7d,
+	0x68, 0x55, 0x04, 0xa8, 0xe4, 0x8e, 0x03, 0xd8, 0xab, 0x30, 0x30, 0x2d, 0x12, 0x3f, 0x5d, 0x66,
+	0xd5, 0x02, 0xbf, 0x31, 0x3d, 0xf3, 0x1e, 0x27, 0x1d, 0xd4, 0xa3, 0x6b, 0x80, 0x1c, 0xc0, 0xde,
+	0x15, 0x0b, 0x5d, 0x1e, 0xe7, 0x9b, 0xc8, 0xdb, 0xed, 0xd1, 0x2a, 0x3c, 0xfe, 0xef, 0x2e, 0x0c,
+	0xa5, 0x79, 0x4f, 0x52, 0x7f, 0xe1, 0xf9, 0xbe, 0x2c, 0x32, 0x13, 0xe8, 0x2d, 0xd6, 0x64, 0xa6,
+	0xf1, 0x7d, 0xab, 0x2a, 0x68, 0xe9, 0x6f, 0xe6, 0xca, 0xf6, 0x5d, 0x5a, 0x44, 0x1e, 0x41, 0x37,
+	0x56, 0xa9, 0x29, 0xdb, 0x6d, 0x4d, 0xe6, 0xe2, 0xc0, 0x9a, 0x15, 0x93, 0x96, 0x16, 0x45, 0x46,
+	0xff, 0x6e, 0x40, 0xbf, 0xb4, 0x23, 0x5a, 0x89, 0x23, 0xc1, 0x3c, 0x74, 0xd9, 0xb5, 0x34, 0xbf,
+	0x4f, 0xd7, 0x80, 0xac, 0xa2, 0x5c, 0x64, 0x51, 0x2d, 0xbf, 0xc9, 0xaf, 0x61, 0x18, 0xbd, 0x5e,
+	0x25, 0x9e, 0x63, 0xfb, 0x53, 0xe6, 0x7b, 0x57, 0x2c, 0x5e, 0xe9, 0xa1, 0xe7, 0xd1, 0x0d, 0xea,
+	0x5b, 0xcf, 0x2b, 0xeb, 0x4e, 0x77, 0xe8, 0xc6, 0x5e, 0xe4, 0x57, 0xb0, 0xe7, 0x7a, 0x4b, 0x4f,
+	0x14, 0xb6, 0x57, 0x6d, 0xea, 0xf0, 0xa6, 0xed, 0xa7, 0xe5, 0x65, 0xa7, 0x3b, 0xb4, 0xba, 0x13,
+	0x89, 0xe0, 0xae, 0x13, 0xaf, 0x22, 0xc1, 0x9d, 0x34, 0x8e, 0x59, 0xe8, 0xac, 0xf2, 0x33, 0xd4,
+	0x98, 0xf5, 0xf1, 0x4d, 0x67, 0x4c, 0xb6, 0xae, 0x3e, 0xdd, 0xa1, 0x6f, 0xd9, 0x77, 0x74, 0x09,
+	0xc3, 0xaa, 0xd9, 0xb2, 0x11, 0x61, 0x1e, 0xb3, 0x58, 0x47, 0x5c, 0x46, 0x62, 0xde, 0x8b, 0xd8,
+	0x76, 0x7e, 0xe3, 0x85, 0xcb, 0xb3, 0x34, 0x78, 0xc5, 0xb2, 0x76, 0x52, 0x41, 0x47, 0x3f, 0x83,
+	0xbd, 0x8a, 0xb5, 0x64, 0x08, 0xf5, 0x34, 0xf6, 0xf5, 0x86, 0xf8, 0x89, 0xd3, 0x43, 0x64, 0x27,
+	0xc9, 0x1b, 0x1e, 0xbb, 0xd9, 0xf4, 0x90, 0xd1, 0xa3, 0x4f, 0xe1, 0xee, 0x76, 0x53, 0x6e, 0x97,
+	0x7e, 0x27, 0x00, 0x6d, 0x57, 0xaf, 0x18, 0xbb, 0xd0, 0xd7, 0xa9, 0x1f, 0x44, 0x3e, 0x13, 0x8c,
+	0x7c, 0x1b, 0x5a, 0x2a, 0x53, 0xb2, 0xc0, 0x6e, 0xe9, 0x54, 0xa4, 0x19, 0xfe, 0xfe, 0xb1, 0x3b,
+	0xfe, 0x5d, 0x03, 0x9a, 0x6a, 0x17, 0x72, 0x1f, 0xda, 0x2a, 0xcb, 0xb4, 0x76, 0x7a, 0x7e, 0xce,
+	0x41, 0x62, 0x41, 0x27, 0x4f, 0x43, 0xbd, 0xf7, 0x66, 0x35, 0x58, 0x8b, 0x14, 0xe7, 0xf1, 0xfa,
+	0x96, 0x79, 0xfc, 0x23, 0xe8, 0xc8, 0xcf, 0x33, 0x9c, 0x28, 0x74, 0xa7, 0xce, 0x01, 0x74, 0xb3,
+	0x24, 0xf0, 0xac, 0x5d, 0x59, 0x03, 0x72, 0xba, 0x3c, 0xc9, 0x37, 0xdf, 0x67, 0x92, 0x37, 0xa1,
+	0xc5, 0xaf, 0x58, 0x6c, 0xfb, 0xbe, 0x6c, 0xe4, 0x7d, 0x9a, 0x91, 0xc8, 0xf9, 0x32, 0xb5, 0x7d,
+	0x9c, 0x09, 0xdb, 0x8a, 0xa3, 0x49, 0xec, 0x54, 0x2e, 0x53, 0xd3, 0x00, 0x4e, 0x77, 0x1d, 0xc9,
+	0x2d, 0x42, 0x78, 0xb9, 0xd9, 0xb5, 0x5d, 0x44, 0x8c, 0xb9, 0xb2, 0x81, 0xf7, 0x69, 0x19, 0xc4,
+	0xe2, 0xe6, 0xa4, 0x89, 0xe0, 0x01, 0x8b, 0x2f, 0xf4, 0xc0, 0xd4, 0x95, 0x72, 0x55, 0x58, 0xb5,
+	0x90, 0x2b, 0x8f, 0xbd, 0x31, 0x7b, 0x59, 0x0b, 0x41, 0x0a, 0x77, 0x88, 0xcb, 0xee, 0x36, 0xfb,
+	0xaa, 0x3c, 0x56, 0x60, 0x1c, 0x06, 0x92, 0x8c, 0x78, 0xc9, 0xe2, 0x04, 0x15, 0x1f, 0xc8, 0xc3,
+	0x36, 0xf0, 0xf1, 0x1f, 0x6a, 0xd0, 0x9d, 0x7a, 0x49, 0x94, 0x0a, 0x26, 0x5f, 0x72, 0x25, 0xef,
+	0x1a, 0xef, 0xe3, 0x5d, 0x0b, 0xda, 0x3c, 0x62, 0x21, 0x73, 0x4f, 0x54, 0x6d, 0x1f, 0x1c, 0x11,
+	0xab, 0xb0, 0xb3, 0xf5, 0xdc, 0x8e, 0xc5, 0x8a, 0xe6, 0x32, 0x85, 0x56, 0x59, 0x2f, 0xb6, 0x4a,
+	0xbc, 0x7b, 0x87, 0x87, 0x98, 0x9c, 0xaa, 0x83, 0xf7, 0x68, 0x4e, 0x23, 0x8f, 0x5d, 0x79, 0x2e,
+	0x0b, 0x1d, 0x7c, 0x51, 0xe0, 0x3c, 0x9c, 0xd3, 0x78, 0x0f, 0x91, 0xbd, 0xe2, 0x69, 0x3e, 0x0b,
+	0xab, 0x51, 0xae, 0x0c, 0x8e, 0xef, 0xc1, 0xae, 0x54, 0x84, 0x74, 0x60, 0xf7, 0xe4, 0xc5, 0x2f,
+	0x66, 0x74, 0xb8, 0x83, 0x03, 0xcc, 0xcb, 0xd9, 0xd9, 0x54, 0xf6, 0xdc, 0xbf, 0x1a, 0xd0, 0xd7,
+	0x5a, 0xbf, 0x88, 0x5c, 0x7c, 0xc7, 0xfc, 0xff, 0x1e, 0x29, 0x5a, 0x52, 0x7b, 0x87, 0x25, 0xf5,
+	0x9b, 0x2c, 0x69, 0x6c, 0xb3, 0xe4, 0xab, 0x1a, 0xf4, 0xb4, 0xa6, 0x13, 0x9f, 0x27, 0xec, 0x76,
+	0x55, 0xa6, 0x6c, 0x4e, 0xed, 0x7d, 0xcc, 0xc1, 0x37, 0x2b, 0x4b, 0xb8, 0x9f, 0x0a, 0x2f, 0xbf,
+	0xb4, 0x02, 0x82, 0x01, 0x2a, 0x93, 0xf4, 0xf9, 0x7a, 0x3a, 0x6b, 0xc8, 0xe9, 0xac, 0x0a, 0x63,
+	0x80, 0xaa, 0x42, 0x51, 0x10, 0xdd, 0x55, 0xd3, 0x6a, 0x15, 0x27, 0x87, 0xe5, 0xaa, 0xa6, 0x12,
+	0xbe, 0xaf, 0xab, 0xda, 0x73, 0xe9, 0x91, 0x72, 0x51, 0xfb, 0x9b, 0x01, 0x4d, 0x2a, 0xff, 0x4b,
+	0x20, 0x0f, 0xb7, 0x7a, 0xe4, 0x74, 0xa7, 0xea, 0x93, 0xa3, 0x5b, 0x54, 0xce, 0xd3, 0x9d, 0xd2,
+	0x31, 0x6f, 0x7d, 0x82, 0xec, 0x43, 0x17, 0xab, 0x4a, 0xf6, 0x5f, 0x00, 0x7a, 0xa0, 0x4d, 0x8b,
+	0xd0, 0x49, 0x0f, 0xfd, 0x88, 0xfa, 0x49, 0x75, 0x1f, 0x43, 0x57, 0xcf, 0xf7, 0x17, 0x38, 0xc5,
+	0xdc, 0x6e, 0x52, 0x9b, 0xc2, 0x50, 0x2f, 0x7a, 0xe2, 0x85, 0xb6, 0xef, 0xfd, 0x96, 0xb9, 0xd5,
+	0xf2, 0x6f, 0xdc, 0x5c, 0xfe, 0xff, 0x6c, 0x40, 0xbf, 0xc4, 0x26, 0x1f, 0x67, 0xef, 0x96, 0xf5,
+	0x04, 0xa8, 0xdb, 0x0d, 0x58, 0x39, 0x44, 0x37, 0x64, 0xb0, 0x7e, 0x2e, 0x62, 0x1e, 0xcc, 0xa7,
+	0xf8, 0x7f, 0x01, 0xfe, 0x97, 0x91, 0x91, 0x58, 0xe7, 0x05, 0xcf, 0xe2, 0x58, 0x79, 0x6a, 0x0d,
+	0x60, 0x16, 0x08, 0x7e, 0x5c, 0x9c, 0xd6, 0x73, 0x7a, 0xfc, 0x4f, 0x03, 0x7a, 0xc5, 0x5b, 0xfe,
+	0x1a, 0x94, 0xb3, 0xa0, 0xc5, 0x53, 0x11, 0xa5, 0x22, 0x7b, 0xef, 0xdf, 0x29, 0xc5, 0x95, 0x75,
+	0x2e, 0x99, 0x34, 0x13, 0x1a, 0xfd, 0x04, 0x9a, 0x0a, 0x2a, 0x3e, 0x25, 0x8d, 0x8d, 0xa7, 0xa4,
+	0x8e, 0x8b, 0x5a, 0x31, 0x2e, 0xc6, 0x17, 0xd0, 0x29, 0x0d, 0xc7, 0xa8, 0x83, 0x5c, 0xdb, 0xa3,
+	0xf2, 0x1b, 0x3d, 0x95, 0x57, 0xe7, 0x6c, 0x38, 0xce, 0x01, 0x7c, 0xa4, 0x79, 0x72, 0xa0, 0xac,
+	0xcb, 0x42, 0xae, 0x88, 0xf1, 0xdf, 0x0d, 0xd8, 0x2b, 0x44, 0x0f, 0xfe, 0xd9, 0x46, 0x7e, 0x04,
+	0xed, 0x80, 0x25, 0x89, 0xbd, 0xcc, 0xdd, 0x63, 0x5a, 0x15, 0x19, 0xeb, 0x99, 0x12, 0xa0, 0xb9,
+	0xe4, 0x88, 0x41, 0x4b, 0x83, 0xe4, 0xa7, 0x40, 0xa2, 0xb5, 0xbc, 0x46, 0x75, 0x3c, 0xf5, 0x8a,
+	0x5b, 0xd1, 0x2d, 0x72, 0xef, 0x36, 0x63, 0xfc, 0x0f, 0x03, 0xf6, 0x0a, 0x93, 0xe0, 0x5b, 0x15,
+	0xae, 0xc8, 0x6c, 0x51, 0xf8, 0x8b, 0xb5, 0xc2, 0xc7, 0x40, 0x0a, 0x43, 0x7c, 0x59, 0xe1, 0x0f,
+	0x36, 0xa6, 0x4f, 0xba, 0x45, 0xf8, 0x06, 0xad, 0xbf, 0x32, 0xf0, 0xf9, 0x86, 0x29, 0x2b, 0x15,
+	0x3e, 0xdc, 0x50, 0xf8, 0x43, 0x6b, 0xcd, 0xde, 0xa2, 0xeb, 0xcb, 0xb5, 0xae, 0x3f, 0xc8, 0xfe,
+	0xfd, 0x2c, 0xab, 0xd9, 0xd2, 0x1b, 0xd0, 0x32, 0xf7, 0xdd, 0x7a, 0x9d, 0x34, 0x7e, 0x59, 0x8b,
+	0x5e, 0xbd, 0x6a, 0xca, 0xb2, 0xfd, 0xf8, 0x7f, 0x03, 0x00, 0x12, 0x7f, 0x77, 0x12, 0xf3, 0x15,
+	0x00, 0x00,
 }
@@ -0,0 +1,127 @@
+package orders
+
+import (
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	iwallet "github.com/cpacia/wallet-interface"
+	"math/big"
+)
+
+// orderQuantities holds the total quantity bought of each listing and of each
+// listing SKU in an order. An order may contain the same listing or SKU more
+// than once, for example with different coupons, so the quantities are summed
+// across items when selecting a price tier.
+type orderQuantities struct {
+	listings map[string]iwallet.Amount
+	skus     map[string]iwallet.Amount
+}
+
+// newOrderQuantities sums the item quantities in the order by listing hash
+// and by SKU.
+func newOrderQuantities(order *pb.OrderOpen) (*orderQuantities, error) {
+	q := &orderQuantities{
+		listings: make(map[string]iwallet.Amount),
+		skus:     make(map[string]iwallet.Amount),
+	}
+	for _, item := range order.Items {
+		listing, err := extractListing(item.ListingHash, order.Listings)
+		if err != nil {
+			return nil, err
+		}
+		sku, err := getSelectedSku(listing, item.Options)
+		if err != nil {
+			return nil, err
+		}
+		quantity := iwallet.NewAmount(item.Quantity)
+		q.listings[item.ListingHash] = q.listing(item.ListingHash).Add(quantity)
+		q.skus[skuKey(item.ListingHash, sku)] = q.sku(item.ListingHash, sku).Add(quantity)
+	}
+	return q, nil
+}
+
+// listing returns the total quantity bought of the listing.
+func (q *orderQuantities) listing(listingHash string) iwallet.Amount {
+	quantity, ok := q.listings[listingHash]
+	if !ok {
+		return iwallet.NewAmount(0)
+	}
+	return quantity
+}
+
+// sku returns the total quantity bought of the listing SKU.
+func (q *orderQuantities) sku(listingHash string, sku *pb.Listing_Item_Sku) iwallet.Amount {
+	quantity, ok := q.skus[skuKey(listingHash, sku)]
+	if !ok {
+		return iwallet.NewAmount(0)
+	}
+	return quantity
+}
+
+// unitPrice returns the price of a single unit of the selected SKU in the
+// pricing currency of the listing. This is the item price plus the SKU
+// surcharge with the matching price tier applied.
+//
+// Tiers set on the SKU take precedence over those set on the listing. SKU
+// tiers are selected using the quantity of the SKU in the order whereas
+// listing tiers use the quantity of all the listing's SKUs. Price tiers are
+// only used by physical goods.
+//
+// A fixed price set by a listing tier replaces the item price and the SKU
+// surcharge is still added to it, so SKUs keep their relative prices. A fixed
+// price set by a SKU tier is the full unit price of the SKU and the surcharge
+// is not added. A percent discount is applied to the item price plus the
+// surcharge.
+func (q *orderQuantities) unitPrice(listingHash string, listing *pb.Listing, sku *pb.Listing_Item_Sku) iwallet.Amount {
+	basePrice, surcharge := iwallet.NewAmount(listing.Item.Price), iwallet.NewAmount(0)
+	if sku.Surcharge != "" {
+		surcharge = iwallet.NewAmount(sku.Surcharge)
+	}
+	price := basePrice.Add(surcharge)
+	if listing.Metadata.ContractType != pb.Listing_Metadata_PHYSICAL_GOOD {
+		return price
+	}
+
+	tiers, quantity, skuTiers := listing.Item.PriceTiers, q.listing(listingHash), false
+	if len(sku.PriceTiers) > 0 {
+		tiers, quantity, skuTiers = sku.PriceTiers, q.sku(listingHash, sku), true
+	}
+	tier := selectPriceTier(tiers, quantity)
+	if tier == nil {
+		return price
+	}
+	if tierPrice := tier.GetPrice(); tierPrice != "" {
+		if skuTiers {
+			return iwallet.NewAmount(tierPrice)
+		}
+		return iwallet.NewAmount(tierPrice).Add(surcharge)
+	}
+	if discount := tier.GetPercentDiscount(); discount > 0 {
+		f, _ := new(big.Float).SetString(price.String())
+		f.Mul(f, big.NewFloat(float64(discount/100)))
+		discountAmount, _ := f.Int(nil)
+		price = price.Sub(iwallet.NewAmount(discountAmount))
+	}
+	return price
+}
+
+// selectPriceTier returns the tier with the highest minimum quantity which
+// the quantity meets or nil if there is no such tier.
+func selectPriceTier(tiers []*pb.Listing_Item_PriceTier, quantity iwallet.Amount) *pb.Listing_Item_PriceTier {
+	var selected *pb.Listing_Item_PriceTier
+	for _, tier := range tiers {
+		if quantity.Cmp(iwallet.NewAmount(tier.MinQuantity)) < 0 {
+			continue
+		}
+		if selected == nil || tier.MinQuantity > selected.MinQuantity {
+			selected = tier
+		}
+	}
+	return selected
+}
+
+// skuKey returns the key used to look up the quantity of the SKU.
+func skuKey(listingHash string, sku *pb.Listing_Item_Sku) string {
+	if sku.ProductID != "" {
+		return listingHash + "/" + sku.ProductID
+	}
+	return listingHash + "/" + sku.String()
+}
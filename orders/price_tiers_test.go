@@ -0,0 +1,154 @@
+package orders
+
+import (
+	"github.com/cpacia/openbazaar3.0/models/factory"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	iwallet "github.com/cpacia/wallet-interface"
+	"testing"
+)
+
+func Test_selectPriceTier(t *testing.T) {
+	tiers := []*pb.Listing_Item_PriceTier{
+		{MinQuantity: 10, Discount: &pb.Listing_Item_PriceTier_PercentDiscount{PercentDiscount: 5}},
+		{MinQuantity: 100, Discount: &pb.Listing_Item_PriceTier_Price{Price: "50"}},
+	}
+	tests := []struct {
+		quantity    uint64
+		minQuantity uint64
+	}{
+		{quantity: 1, minQuantity: 0},
+		{quantity: 9, minQuantity: 0},
+		{quantity: 10, minQuantity: 10},
+		{quantity: 99, minQuantity: 10},
+		{quantity: 100, minQuantity: 100},
+		{quantity: 1000, minQuantity: 100},
+	}
+	for _, test := range tests {
+		tier := selectPriceTier(tiers, iwallet.NewAmount(test.quantity))
+		if test.minQuantity == 0 {
+			if tier != nil {
+				t.Errorf("Quantity %d: expected no tier, got %d", test.quantity, tier.MinQuantity)
+			}
+			continue
+		}
+		if tier == nil || tier.MinQuantity != test.minQuantity {
+			t.Errorf("Quantity %d: expected tier %d, got %v", test.quantity, test.minQuantity, tier)
+		}
+	}
+}
+
+func Test_orderQuantitiesUnitPrice(t *testing.T) {
+	order, err := factory.NewOrder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	listing := order.Listings[0].Listing
+	listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{
+		{MinQuantity: 3, Discount: &pb.Listing_Item_PriceTier_Price{Price: "70"}},
+	}
+	listing.Item.Skus[0].PriceTiers = []*pb.Listing_Item_PriceTier{
+		{MinQuantity: 2, Discount: &pb.Listing_Item_PriceTier_PercentDiscount{PercentDiscount: 10}},
+	}
+	hash, err := utils.HashListing(order.Listings[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	order.Items[0].ListingHash = hash.B58String()
+
+	// The second sku has no tiers of its own so it uses the listing tiers
+	// with the quantity of the whole listing.
+	second := *order.Items[0]
+	second.Options = []*pb.OrderOpen_Item_Option{
+		{Name: "size", Value: "small"},
+		{Name: "color", Value: "green"},
+	}
+	order.Items = append(order.Items, &second)
+
+	quantities, err := newOrderQuantities(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quantities.unitPrice(hash.B58String(), listing, listing.Item.Skus[0]).Cmp(iwallet.NewAmount(100)) != 0 {
+		t.Error("Expected sku tier not to apply to a single unit")
+	}
+	if quantities.unitPrice(hash.B58String(), listing, listing.Item.Skus[1]).Cmp(iwallet.NewAmount(100)) != 0 {
+		t.Error("Expected listing tier not to apply to two units")
+	}
+
+	// Buying the first sku in a separate item counts towards its tier.
+	order.Items = append(order.Items, order.Items[0])
+	quantities, err = newOrderQuantities(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price := quantities.unitPrice(hash.B58String(), listing, listing.Item.Skus[0]); price.Cmp(iwallet.NewAmount(90)) != 0 {
+		t.Errorf("Incorrect sku tier price: expected 90, got %s", price)
+	}
+	if price := quantities.unitPrice(hash.B58String(), listing, listing.Item.Skus[1]); price.Cmp(iwallet.NewAmount(70)) != 0 {
+		t.Errorf("Incorrect listing tier price: expected 70, got %s", price)
+	}
+}
+
+func Test_orderQuantitiesUnitPriceSurcharge(t *testing.T) {
+	tests := []struct {
+		name          string
+		listingTier   *pb.Listing_Item_PriceTier
+		skuTier       *pb.Listing_Item_PriceTier
+		expectedPrice uint64
+	}{
+		{
+			name:          "No tier",
+			expectedPrice: 120,
+		},
+		{
+			name:          "Listing price tier",
+			listingTier:   &pb.Listing_Item_PriceTier{Discount: &pb.Listing_Item_PriceTier_Price{Price: "70"}},
+			expectedPrice: 90,
+		},
+		{
+			name:          "Listing percent tier",
+			listingTier:   &pb.Listing_Item_PriceTier{Discount: &pb.Listing_Item_PriceTier_PercentDiscount{PercentDiscount: 10}},
+			expectedPrice: 108,
+		},
+		{
+			name:          "Sku price tier",
+			listingTier:   &pb.Listing_Item_PriceTier{Discount: &pb.Listing_Item_PriceTier_Price{Price: "70"}},
+			skuTier:       &pb.Listing_Item_PriceTier{Discount: &pb.Listing_Item_PriceTier_Price{Price: "80"}},
+			expectedPrice: 80,
+		},
+		{
+			name:          "Sku percent tier",
+			skuTier:       &pb.Listing_Item_PriceTier{Discount: &pb.Listing_Item_PriceTier_PercentDiscount{PercentDiscount: 10}},
+			expectedPrice: 108,
+		},
+	}
+	for _, test := range tests {
+		order, err := factory.NewOrder()
+		if err != nil {
+			t.Fatal(err)
+		}
+		listing := order.Listings[0].Listing
+		sku := listing.Item.Skus[0]
+		sku.Surcharge = "20"
+		if test.listingTier != nil {
+			listing.Item.PriceTiers = []*pb.Listing_Item_PriceTier{test.listingTier}
+		}
+		if test.skuTier != nil {
+			sku.PriceTiers = []*pb.Listing_Item_PriceTier{test.skuTier}
+		}
+		hash, err := utils.HashListing(order.Listings[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		order.Items[0].ListingHash = hash.B58String()
+
+		quantities, err := newOrderQuantities(order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if price := quantities.unitPrice(order.Items[0].ListingHash, listing, sku); price.Cmp(iwallet.NewAmount(test.expectedPrice)) != 0 {
+			t.Errorf("%s: expected unit price %d, got %s", test.name, test.expectedPrice, price)
+		}
+	}
+}
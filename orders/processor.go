@@ -79,6 +79,19 @@ func NewOrderProcessor(cfg *Config) *OrderProcessor {
 // orders. When we find one we record the payment.
 func (op *OrderProcessor) Start() {
 	go op.checkForMorePayments()
+	go func() {
+		op.checkEscrowTimeouts(time.Now())
+		escrowTicker := time.NewTicker(escrowTimeoutCheckInterval)
+		for {
+			select {
+			case <-escrowTicker.C:
+				op.checkEscrowTimeouts(time.Now())
+			case <-op.shutdown:
+				escrowTicker.Stop()
+				return
+			}
+		}
+	}()
 	ticker := time.NewTicker(rescanTransactionsInterval)
 	for _, wallet := range op.multiwallet {
 		go func(w iwallet.Wallet) {
@@ -124,10 +137,12 @@ func (op *OrderProcessor) ProcessMessage(dbtx database.Tx, peer peer.ID, message
 	err = dbtx.Read().Where("id = ?", message.OrderID).First(&order).Error
 	if err != nil && !gorm.IsRecordNotFoundError(err) {
 		return nil, err
-	} else if gorm.IsRecordNotFoundError(err) && message.MessageType != npb.OrderMessage_ORDER_OPEN {
+	} else if gorm.IsRecordNotFoundError(err) && message.MessageType != npb.OrderMessage_ORDER_OPEN &&
+		message.MessageType != npb.OrderMessage_DISPUTE_OPEN {
 		// Order does not exist in the DB and the message type is not an order open. This can happen
 		// in the case where we download offline messages out of order. In this case we will park
-		// the message so that we can try again later if we receive other messages.
+		// the message so that we can try again later if we receive other messages. A dispute open
+		// is allowed through as it is the first message a moderator sees for the order.
 		log.Warningf("Received %s message from peer %d for an order that does not exist yet", message.MessageType, peer.Pretty())
 		order.ID = models.OrderID(message.OrderID)
 		if err := order.ParkMessage(message); err != nil {
@@ -139,15 +154,112 @@ func (op *OrderProcessor) ProcessMessage(dbtx database.Tx, peer peer.ID, message
 	orderCopy := order
 	event, err = op.processMessage(dbtx, &order, peer, message)
 	if err != nil {
-		if err := orderCopy.PutErrorMessage(message); err != nil {
-			return nil, dbtx.Save(&orderCopy)
+		log.Errorf("Error processing %s message for order %s: %s", message.MessageType, message.OrderID, err)
+		if err := orderCopy.PutErrorMessage(message, err); err != nil {
+			return nil, err
 		}
+		return nil, dbtx.Save(&orderCopy)
+	}
+
+	// This message may be the one a parked message was waiting on.
+	if err := op.processParkedMessages(dbtx, &order); err != nil {
+		return nil, err
 	}
 
 	// Save changes to the database.
 	return event, dbtx.Save(&order)
 }
 
+// ReprocessMessages removes the parked and errored messages from the order
+// and runs them back through the processor. Messages which are still out of
+// order will be parked again and those that fail will be put back into the
+// errored messages with the new error. Any events are emitted onto the bus
+// after the transaction is committed.
+func (op *OrderProcessor) ReprocessMessages(dbtx database.Tx, orderID models.OrderID) error {
+	var order models.Order
+	if err := dbtx.Read().Where("id = ?", orderID.String()).First(&order).Error; err != nil {
+		return err
+	}
+
+	parked, err := order.GetParkedMessages()
+	if err != nil {
+		return err
+	}
+	errored, err := order.GetErroredMessages()
+	if err != nil {
+		return err
+	}
+	order.ParkedMessages = nil
+	order.ErroredMessages = nil
+
+	for _, message := range append(errored, parked...) {
+		if err := op.retryMessage(dbtx, &order, message); err != nil {
+			return err
+		}
+	}
+	if err := op.processParkedMessages(dbtx, &order); err != nil {
+		return err
+	}
+	return dbtx.Save(&order)
+}
+
+// processParkedMessages retries each of the order's parked messages. Processing
+// one parked message may allow another to be processed so we keep going until a
+// pass is made without any of the parked messages being processed.
+func (op *OrderProcessor) processParkedMessages(dbtx database.Tx, order *models.Order) error {
+	for {
+		parked, err := order.GetParkedMessages()
+		if err != nil {
+			return err
+		}
+		if len(parked) == 0 {
+			return nil
+		}
+
+		order.ParkedMessages = nil
+		for _, message := range parked {
+			if err := op.retryMessage(dbtx, order, message); err != nil {
+				return err
+			}
+		}
+
+		stillParked, err := order.GetParkedMessages()
+		if err != nil {
+			return err
+		}
+		if len(stillParked) >= len(parked) {
+			return nil
+		}
+	}
+}
+
+// retryMessage processes a message that was previously parked or errored. If
+// the message is still out of order the handler will park it again. If it fails
+// the order is left as it was and the message is put into the errored messages.
+func (op *OrderProcessor) retryMessage(dbtx database.Tx, order *models.Order, message *npb.OrderMessage) error {
+	sender, err := messageSender(order, message)
+	if models.IsMessageNotExistError(err) {
+		// We can't tell who sent the message until we have the order open.
+		return order.ParkMessage(message)
+	} else if err != nil {
+		return order.PutErrorMessage(message, err)
+	}
+
+	orderCopy := *order
+	event, err := op.processMessage(dbtx, order, sender, message)
+	if err != nil {
+		log.Errorf("Error reprocessing %s message for order %s: %s", message.MessageType, order.ID, err)
+		*order = orderCopy
+		return order.PutErrorMessage(message, err)
+	}
+	if event != nil {
+		dbtx.RegisterCommitHook(func() {
+			op.bus.Emit(event)
+		})
+	}
+	return nil
+}
+
 // ProcessACK loads the order from the database and sets the ACK for the message type.
 func (op *OrderProcessor) ProcessACK(tx database.Tx, om *models.OutgoingMessage) error {
 	message := new(npb.Message)
@@ -220,6 +332,14 @@ func (op *OrderProcessor) processMessage(dbtx database.Tx, order *models.Order,
 		event, err = op.processOrderFulfillmentMessage(dbtx, order, peer, message)
 	case npb.OrderMessage_ORDER_COMPLETE:
 		event, err = op.processOrderCompleteMessage(dbtx, order, peer, message)
+	case npb.OrderMessage_DISPUTE_OPEN:
+		event, err = op.processDisputeOpenMessage(dbtx, order, peer, message)
+	case npb.OrderMessage_DISPUTE_UPDATE:
+		event, err = op.processDisputeUpdateMessage(dbtx, order, peer, message)
+	case npb.OrderMessage_DISPUTE_CLOSE:
+		event, err = op.processDisputeCloseMessage(dbtx, order, peer, message)
+	case npb.OrderMessage_PAYMENT_FINALIZED:
+		event, err = op.processPaymentFinalizedMessage(dbtx, order, peer, message)
 	default:
 		return nil, errors.New("unknown order message type")
 	}
@@ -242,8 +362,46 @@ func isDuplicate(message proto.Message, serialized []byte) (bool, error) {
 	return bytes.Equal([]byte(ser), serialized), nil
 }
 
+// messageSender returns the participant in the order who signed the message.
+// Parked and errored messages are stored without the peer that sent them so we
+// check the signature against each of the buyer, vendor and moderator.
+func messageSender(order *models.Order, message *npb.OrderMessage) (peer.ID, error) {
+	orderOpen, err := order.OrderOpenMessage()
+	if err != nil {
+		return "", err
+	}
+
+	var participants []string
+	if orderOpen.BuyerID != nil {
+		participants = append(participants, orderOpen.BuyerID.PeerID)
+	}
+	if len(orderOpen.Listings) > 0 && orderOpen.Listings[0].Listing != nil && orderOpen.Listings[0].Listing.VendorID != nil {
+		participants = append(participants, orderOpen.Listings[0].Listing.VendorID.PeerID)
+	}
+	if orderOpen.Payment != nil && orderOpen.Payment.Moderator != "" {
+		participants = append(participants, orderOpen.Payment.Moderator)
+	}
+
+	for _, participant := range participants {
+		pid, err := peer.IDB58Decode(participant)
+		if err != nil {
+			continue
+		}
+		if verifyOrderMessageSignature(pid, message) == nil {
+			return pid, nil
+		}
+	}
+	return "", errors.New("message is not signed by a participant in the order")
+}
+
 func verifyOrderMessageSignature(peer peer.ID, message *npb.OrderMessage) error {
 	peerPubkey, err := peer.ExtractPublicKey()
+	if err != nil {
+		return err
+	}
+	if peerPubkey == nil {
+		return errors.New("public key not embedded in peer ID")
+	}
 
 	msgCpy := *message
 	msgCpy.Signature = nil
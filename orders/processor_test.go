@@ -0,0 +1,199 @@
+package orders
+
+import (
+	"crypto/rand"
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	iwallet "github.com/cpacia/wallet-interface"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	crypto "github.com/libp2p/go-libp2p-crypto"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"testing"
+	"time"
+)
+
+func TestOrderProcessor_ProcessMessageParkedAndErrored(t *testing.T) {
+	op, teardown, err := newMockOrderProcessor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	vendorPriv, vendorPub, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vendorPeer, err := peer.IDFromPublicKey(vendorPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := op.bus.Subscribe(&events.OrderFulfillment{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orderOpen := &pb.OrderOpen{
+		Listings: []*pb.SignedListing{
+			{
+				Listing: &pb.Listing{
+					VendorID: &pb.ID{PeerID: vendorPeer.Pretty()},
+					Item: &pb.Listing_Item{
+						Images: []*pb.Listing_Item_Image{{Small: "aaaa", Tiny: "bbbb"}},
+					},
+				},
+			},
+		},
+		BuyerID: &pb.ID{PeerID: op.identity.Pretty()},
+		Payment: &pb.OrderOpen_Payment{Coin: iwallet.CtMock},
+	}
+
+	newMessage := func(orderID string, messageType npb.OrderMessage_MessageType, msg proto.Message) *npb.OrderMessage {
+		a, err := ptypes.MarshalAny(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		message := &npb.OrderMessage{
+			OrderID:     orderID,
+			MessageType: messageType,
+			Message:     a,
+		}
+		if err := utils.SignOrderMessage(message, vendorPriv); err != nil {
+			t.Fatal(err)
+		}
+		return message
+	}
+	saveOrderOpen := func(orderID string) {
+		err := op.db.Update(func(tx database.Tx) error {
+			var order models.Order
+			if err := tx.Read().Where("id = ?", orderID).First(&order).Error; err != nil {
+				order.ID = models.OrderID(orderID)
+			}
+			order.SetRole(models.RoleBuyer)
+			if err := order.PutMessage(&npb.OrderMessage{
+				OrderID:     orderID,
+				MessageType: npb.OrderMessage_ORDER_OPEN,
+				Message:     mustBuildAny(orderOpen),
+			}); err != nil {
+				return err
+			}
+			return tx.Save(&order)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	process := func(message *npb.OrderMessage) interface{} {
+		var event interface{}
+		err := op.db.Update(func(tx database.Tx) error {
+			var err error
+			event, err = op.ProcessMessage(tx, vendorPeer, message)
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return event
+	}
+	loadOrder := func(orderID string) *models.Order {
+		var order models.Order
+		err := op.db.View(func(tx database.Tx) error {
+			return tx.Read().Where("id = ?", orderID).First(&order).Error
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &order
+	}
+
+	saveOrderOpen("1234")
+
+	// The fulfillment is parked as we don't have the confirmation yet.
+	if event := process(newMessage("1234", npb.OrderMessage_ORDER_FULFILLMENT, &pb.OrderFulfillment{})); event != nil {
+		t.Errorf("Expected nil event for parked message, got %v", event)
+	}
+	parked, err := loadOrder("1234").GetParkedMessages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parked) != 1 {
+		t.Fatalf("Expected 1 parked message, got %d", len(parked))
+	}
+
+	// Processing the confirmation should also process the parked fulfillment.
+	if _, ok := process(newMessage("1234", npb.OrderMessage_ORDER_CONFIRMATION, &pb.OrderConfirmation{})).(*events.OrderConfirmation); !ok {
+		t.Error("Expected order confirmation event")
+	}
+	select {
+	case <-sub.Out():
+	case <-time.After(time.Second * 10):
+		t.Fatal("Timeout waiting on fulfillment event")
+	}
+
+	order := loadOrder("1234")
+	if _, err := order.OrderFulfillmentMessages(); err != nil {
+		t.Errorf("Parked fulfillment was not processed: %s", err)
+	}
+	parked, err = order.GetParkedMessages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parked) != 0 {
+		t.Errorf("Expected 0 parked messages, got %d", len(parked))
+	}
+
+	// A changed confirmation errors and is saved with the error.
+	changed := newMessage("1234", npb.OrderMessage_ORDER_CONFIRMATION, &pb.OrderConfirmation{TransactionID: "abc"})
+	if event := process(changed); event != nil {
+		t.Errorf("Expected nil event for errored message, got %v", event)
+	}
+	messages, err := loadOrder("1234").Messages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages.Errored) != 1 || messages.Errored[0].Error != ErrChangedMessage.Error() {
+		t.Fatalf("Incorrect errored messages: %v", messages.Errored)
+	}
+
+	// Reprocessing the errored message replaces its error rather than adding another.
+	err = op.db.Update(func(tx database.Tx) error {
+		return op.ReprocessMessages(tx, "1234")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages, err = loadOrder("1234").Messages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages.Errored) != 1 || messages.Errored[0].Error != ErrChangedMessage.Error() {
+		t.Errorf("Incorrect errored messages after reprocessing: %v", messages.Errored)
+	}
+
+	// A message for an order we don't have yet is parked until reprocessed.
+	process(newMessage("5678", npb.OrderMessage_ORDER_CONFIRMATION, &pb.OrderConfirmation{}))
+	saveOrderOpen("5678")
+
+	err = op.db.Update(func(tx database.Tx) error {
+		return op.ReprocessMessages(tx, "5678")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	order = loadOrder("5678")
+	if _, err := order.OrderConfirmationMessage(); err != nil {
+		t.Errorf("Parked confirmation was not processed: %s", err)
+	}
+	parked, err = order.GetParkedMessages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parked) != 0 {
+		t.Errorf("Expected 0 parked messages, got %d", len(parked))
+	}
+}
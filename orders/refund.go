@@ -68,9 +68,27 @@ func (op *OrderProcessor) processRefundMessage(dbtx database.Tx, order *models.O
 	if order.Role() == models.RoleBuyer {
 		log.Infof("Received REFUND message for order %s", order.ID)
 	} else if order.Role() == models.RoleVendor {
+		if !refund.Overpayment {
+			if err := op.releaseInventory(dbtx, order); err != nil {
+				log.Errorf("Error releasing inventory for order %s: %s", order.ID, err)
+			}
+		}
 		log.Infof("Processed own REFUND for order %s", order.ID)
 	}
 
+	if refund.Overpayment {
+		return &events.OverpaymentRefund{
+			OrderID: order.ID.String(),
+			Amount:  refund.Amount,
+			Thumbnail: events.Thumbnail{
+				Tiny:  orderOpen.Listings[0].Listing.Item.Images[0].Tiny,
+				Small: orderOpen.Listings[0].Listing.Item.Images[0].Small,
+			},
+			VendorHandle: orderOpen.Listings[0].Listing.VendorID.Handle,
+			VendorID:     orderOpen.Listings[0].Listing.VendorID.PeerID,
+		}, nil
+	}
+
 	event := &events.Refund{
 		OrderID: order.ID.String(),
 		Thumbnail: events.Thumbnail{
@@ -220,4 +220,48 @@ func TestOrderProcessor_processRefundMessage(t *testing.T) {
 			t.Errorf("Error executing db update in test %d: %s", i, err)
 		}
 	}
+
+	// Overpayment refunds emit their own event.
+	overpaymentAny, err := ptypes.MarshalAny(&pb.Refund{
+		RefundInfo:  &pb.Refund_TransactionID{TransactionID: txs[0].ID.String()},
+		Amount:      "1000",
+		Overpayment: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := &models.Order{ID: "5678", PaymentAddress: addr.String()}
+	if err := order.PutMessage(&npb.OrderMessage{
+		Signature: []byte("abc"),
+		Message:   mustBuildAny(orderOpen),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	err = op.db.Update(func(tx database.Tx) error {
+		event, err := op.processRefundMessage(tx, order, remotePeer, &npb.OrderMessage{
+			OrderID:     "5678",
+			MessageType: npb.OrderMessage_REFUND,
+			Message:     overpaymentAny,
+		})
+		if err != nil {
+			return err
+		}
+		expected := &events.OverpaymentRefund{
+			OrderID: "5678",
+			Amount:  "1000",
+			Thumbnail: events.Thumbnail{
+				Tiny:  tinyImageHash,
+				Small: smallImageHash,
+			},
+			VendorHandle: vendorHandle,
+			VendorID:     vendorPeerID,
+		}
+		if !reflect.DeepEqual(event, expected) {
+			return fmt.Errorf("incorrect event returned: %v", event)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
 }
@@ -0,0 +1,94 @@
+package orders
+
+import (
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/wallet"
+	iwallet "github.com/cpacia/wallet-interface"
+	"math/big"
+)
+
+// shippingParcel is a group of items shipped together using weight based
+// rates. Items from any of the vendor's listings are combined into the same
+// parcel when they select a shipping option and service of the same name.
+type shippingParcel struct {
+	name  string
+	grams *big.Float
+	rates []parcelRate
+}
+
+// parcelRate is the weight band table of one of the items in a parcel.
+type parcelRate struct {
+	listingHash           string
+	bands                 []*pb.Listing_ShippingOption_Service_WeightBand
+	pricingCurrency       *models.Currency
+	shippingTaxPercentage float32
+	taxRule               *pb.Listing_Tax
+}
+
+// newShippingParcel returns an empty parcel.
+func newShippingParcel(name string) *shippingParcel {
+	return &shippingParcel{
+		name:  name,
+		grams: new(big.Float),
+	}
+}
+
+// add adds the weight of the item to the parcel.
+func (p *shippingParcel) add(listingHash string, listing *pb.Listing, quantity string, service *pb.Listing_ShippingOption_Service, pricingCurrency *models.Currency, shippingTaxPercentage float32, taxRule *pb.Listing_Tax) error {
+	q, ok := new(big.Float).SetString(quantity)
+	if !ok {
+		return fmt.Errorf("invalid quantity %s", quantity)
+	}
+	p.grams.Add(p.grams, q.Mul(q, big.NewFloat(float64(listing.Item.Grams))))
+	p.rates = append(p.rates, parcelRate{
+		listingHash:           listingHash,
+		bands:                 service.WeightBands,
+		pricingCurrency:       pricingCurrency,
+		shippingTaxPercentage: shippingTaxPercentage,
+		taxRule:               taxRule,
+	})
+	return nil
+}
+
+// total returns the cost, including tax, of shipping the parcel. The rate
+// for the combined weight is looked up in the table of each item in the
+// parcel and the highest rate is charged. The tax is recorded against the
+// listing whose rate was charged.
+func (p *shippingParcel) total(paymentCurrency *models.Currency, taxes *taxLedger, erp *wallet.ExchangeRateProvider) (iwallet.Amount, error) {
+	var (
+		highest     = iwallet.NewAmount(0)
+		highestRate parcelRate
+	)
+	for _, rate := range p.rates {
+		price, err := weightBandPrice(rate.bands, p.grams)
+		if err != nil {
+			return highest, fmt.Errorf("shipping service %s: %s", p.name, err)
+		}
+		converted, err := ConvertCurrencyAmount(models.NewCurrencyValue(price, rate.pricingCurrency), paymentCurrency, erp)
+		if err != nil {
+			return highest, err
+		}
+		if converted.Cmp(highest) > 0 {
+			highest = converted
+			highestRate = rate
+		}
+	}
+	tax := calculateShippingTax(highestRate.shippingTaxPercentage, highest)
+	if highestRate.taxRule != nil {
+		taxes.addShippingTax(highestRate.listingHash, highestRate.taxRule, tax)
+	}
+	return highest.Add(tax), nil
+}
+
+// weightBandPrice returns the price of the first band, in increasing order
+// of weight, which the weight fits in.
+func weightBandPrice(bands []*pb.Listing_ShippingOption_Service_WeightBand, grams *big.Float) (string, error) {
+	for _, band := range bands {
+		if grams.Cmp(new(big.Float).SetUint64(band.MaxGrams)) <= 0 {
+			return band.Price, nil
+		}
+	}
+	return "", fmt.Errorf("weight of %sg exceeds the largest weight band", grams.Text('f', -1))
+}
@@ -0,0 +1,41 @@
+package orders
+
+import (
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"math/big"
+	"testing"
+)
+
+func Test_weightBandPrice(t *testing.T) {
+	bands := []*pb.Listing_ShippingOption_Service_WeightBand{
+		{MaxGrams: 500, Price: "5"},
+		{MaxGrams: 2000, Price: "10"},
+	}
+	tests := []struct {
+		grams    float64
+		price    string
+		overflow bool
+	}{
+		{grams: 0, price: "5"},
+		{grams: 500, price: "5"},
+		{grams: 500.5, price: "10"},
+		{grams: 2000, price: "10"},
+		{grams: 2001, overflow: true},
+	}
+	for _, test := range tests {
+		price, err := weightBandPrice(bands, big.NewFloat(test.grams))
+		if test.overflow {
+			if err == nil {
+				t.Errorf("%vg: expected error", test.grams)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%vg: unexpected error: %s", test.grams, err)
+			continue
+		}
+		if price != test.price {
+			t.Errorf("%vg: expected price %s, got %s", test.grams, test.price, price)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	iwallet "github.com/cpacia/wallet-interface"
+)
+
+// taxRule returns the listing's tax rule for the country or nil if the
+// listing does not charge tax there. A rule naming the country is used
+// before a rule covering all countries. Listings are validated so that
+// their rules never overlap.
+func taxRule(listing *pb.Listing, country pb.CountryCode) *pb.Listing_Tax {
+	var all *pb.Listing_Tax
+	for _, tax := range listing.Taxes {
+		for _, region := range tax.TaxRegions {
+			if region == country {
+				return tax
+			}
+			if region == pb.CountryCode_ALL && all == nil {
+				all = tax
+			}
+		}
+	}
+	return all
+}
+
+// listingTax is the tax charged on the items and shipping of a listing.
+type listingTax struct {
+	rule     *pb.Listing_Tax
+	amount   iwallet.Amount
+	shipping iwallet.Amount
+}
+
+// taxLedger records the taxes included in an order total by listing so
+// that they can be itemized in the order.
+type taxLedger struct {
+	hashes []string
+	taxes  map[string]*listingTax
+}
+
+// newTaxLedger returns an empty ledger.
+func newTaxLedger() *taxLedger {
+	return &taxLedger{
+		taxes: make(map[string]*listingTax),
+	}
+}
+
+// addItemTax records tax charged on items from the listing.
+func (l *taxLedger) addItemTax(listingHash string, rule *pb.Listing_Tax, amount iwallet.Amount) {
+	tax := l.entry(listingHash, rule)
+	tax.amount = tax.amount.Add(amount)
+}
+
+// addShippingTax records tax charged on the shipping of the listing. The
+// amount may be negative.
+func (l *taxLedger) addShippingTax(listingHash string, rule *pb.Listing_Tax, amount iwallet.Amount) {
+	tax := l.entry(listingHash, rule)
+	tax.shipping = tax.shipping.Add(amount)
+}
+
+func (l *taxLedger) entry(listingHash string, rule *pb.Listing_Tax) *listingTax {
+	tax, ok := l.taxes[listingHash]
+	if !ok {
+		tax = &listingTax{
+			rule:     rule,
+			amount:   iwallet.NewAmount(0),
+			shipping: iwallet.NewAmount(0),
+		}
+		l.taxes[listingHash] = tax
+		l.hashes = append(l.hashes, listingHash)
+	}
+	return tax
+}
+
+// breakdown returns the recorded taxes in the order the listings were
+// first seen.
+func (l *taxLedger) breakdown() []*pb.OrderOpen_Tax {
+	var taxes []*pb.OrderOpen_Tax
+	for _, hash := range l.hashes {
+		tax := l.taxes[hash]
+		taxes = append(taxes, &pb.OrderOpen_Tax{
+			ListingHash:    hash,
+			TaxType:        tax.rule.TaxType,
+			Percentage:     tax.rule.Percentage,
+			Amount:         tax.amount.String(),
+			ShippingAmount: tax.shipping.String(),
+		})
+	}
+	return taxes
+}
+
+// validateOrderTaxes checks the tax breakdown in the order against the tax
+// rules of the listings for the shipping country. The amounts are in the
+// payment coin and depend on the exchange rate used by the buyer so only
+// their format is checked here. The vendor compares them to the taxes it
+// calculates in checkOrderTaxes.
+func validateOrderTaxes(order *pb.OrderOpen) error {
+	seen := make(map[string]bool)
+	for _, tax := range order.Taxes {
+		if seen[tax.ListingHash] {
+			return fmt.Errorf("duplicate tax for listing %s", tax.ListingHash)
+		}
+		seen[tax.ListingHash] = true
+
+		listing, err := extractListing(tax.ListingHash, order.Listings)
+		if err != nil {
+			return fmt.Errorf("tax listing %s not found in order", tax.ListingHash)
+		}
+		rule := taxRule(listing, order.Shipping.GetCountry())
+		if rule == nil {
+			return fmt.Errorf("listing %s does not charge tax in %s", tax.ListingHash, order.Shipping.GetCountry())
+		}
+		if rule.TaxType != tax.TaxType || rule.Percentage != tax.Percentage {
+			return fmt.Errorf("tax for listing %s does not match the listing tax rule", tax.ListingHash)
+		}
+		if !validateBigString(tax.Amount) || !validateBigString(tax.ShippingAmount) {
+			return errors.New("tax amount not valid")
+		}
+	}
+	return nil
+}
+
+// checkOrderTaxes returns an error if the tax breakdown in the order does
+// not itemize the same taxes we calculate for it. If the order converts
+// between currencies the amounts may differ by the orderTotalRateTolerance.
+// The breakdown is expected to have been validated by validateOrderTaxes so
+// it contains each listing at most once.
+func checkOrderTaxes(taxes, calculated []*pb.OrderOpen_Tax, convertsCurrency bool) error {
+	if len(taxes) != len(calculated) {
+		return fmt.Errorf("order itemizes %d taxes but we calculate %d", len(taxes), len(calculated))
+	}
+	byListing := make(map[string]*pb.OrderOpen_Tax)
+	for _, tax := range taxes {
+		byListing[tax.ListingHash] = tax
+	}
+	for _, expected := range calculated {
+		tax, ok := byListing[expected.ListingHash]
+		if !ok {
+			return fmt.Errorf("tax for listing %s missing from order", expected.ListingHash)
+		}
+		if !amountMatches(iwallet.NewAmount(tax.Amount), iwallet.NewAmount(expected.Amount), convertsCurrency) {
+			return fmt.Errorf("tax amount %s for listing %s does not match our tax %s", tax.Amount, tax.ListingHash, expected.Amount)
+		}
+		if !amountMatches(iwallet.NewAmount(tax.ShippingAmount), iwallet.NewAmount(expected.ShippingAmount), convertsCurrency) {
+			return fmt.Errorf("shipping tax amount %s for listing %s does not match our tax %s", tax.ShippingAmount, tax.ListingHash, expected.ShippingAmount)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package orders
+
+import (
+	"github.com/cpacia/openbazaar3.0/models/factory"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	"github.com/cpacia/openbazaar3.0/wallet"
+	iwallet "github.com/cpacia/wallet-interface"
+	"testing"
+)
+
+func Test_taxRule(t *testing.T) {
+	listing := &pb.Listing{
+		Taxes: []*pb.Listing_Tax{
+			{
+				TaxType:    "VAT",
+				Percentage: 20,
+				TaxRegions: []pb.CountryCode{pb.CountryCode_ALL},
+			},
+			{
+				TaxType:    "Sales tax",
+				Percentage: 7,
+				TaxRegions: []pb.CountryCode{pb.CountryCode_UNITED_STATES, pb.CountryCode_CANADA},
+			},
+		},
+	}
+
+	tests := []struct {
+		country  pb.CountryCode
+		expected string
+	}{
+		{country: pb.CountryCode_UNITED_STATES, expected: "Sales tax"},
+		{country: pb.CountryCode_CANADA, expected: "Sales tax"},
+		{country: pb.CountryCode_FRANCE, expected: "VAT"},
+	}
+	for _, test := range tests {
+		rule := taxRule(listing, test.country)
+		if rule == nil || rule.TaxType != test.expected {
+			t.Errorf("%s: expected rule %s, got %v", test.country, test.expected, rule)
+		}
+	}
+
+	listing.Taxes = listing.Taxes[1:]
+	if rule := taxRule(listing, pb.CountryCode_FRANCE); rule != nil {
+		t.Errorf("Expected no rule, got %s", rule.TaxType)
+	}
+}
+
+func TestCalculateOrderTotalWithTaxes(t *testing.T) {
+	erp, err := wallet.NewMockExchangeRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order, err := factory.NewOrder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	total, taxes, err := CalculateOrderTotalWithTaxes(order, erp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(taxes) != 1 {
+		t.Fatalf("Expected 1 tax, got %d", len(taxes))
+	}
+	if taxes[0].ListingHash != order.Items[0].ListingHash {
+		t.Errorf("Incorrect listing hash: expected %s, got %s", order.Items[0].ListingHash, taxes[0].ListingHash)
+	}
+	if taxes[0].TaxType != "Sales tax" || taxes[0].Percentage != 7 {
+		t.Errorf("Incorrect tax rule: got %s %f", taxes[0].TaxType, taxes[0].Percentage)
+	}
+
+	// The itemized taxes should make up the difference between the total
+	// with and without taxes.
+	untaxed, err := factory.NewOrder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	untaxed.Listings[0].Listing.Taxes = nil
+	hash, err := utils.HashListing(untaxed.Listings[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	untaxed.Items[0].ListingHash = hash.B58String()
+	untaxedTotal, noTaxes, err := CalculateOrderTotalWithTaxes(untaxed, erp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(noTaxes) != 0 {
+		t.Errorf("Expected no taxes, got %d", len(noTaxes))
+	}
+	taxTotal := iwallet.NewAmount(taxes[0].Amount).Add(iwallet.NewAmount(taxes[0].ShippingAmount))
+	if iwallet.NewAmount(taxes[0].ShippingAmount).Cmp(iwallet.NewAmount(0)) <= 0 {
+		t.Error("Expected shipping to be taxed")
+	}
+	if untaxedTotal.Add(taxTotal).Cmp(total) != 0 {
+		t.Errorf("Itemized taxes do not add up: expected %s, got %s", total.Sub(untaxedTotal), taxTotal)
+	}
+
+	// Buyers shipping to a country without a tax rule are not taxed.
+	order.Shipping.Country = pb.CountryCode_CANADA
+	order.Listings[0].Listing.ShippingOptions[0].Regions = []pb.CountryCode{pb.CountryCode_ALL}
+	hash, err = utils.HashListing(order.Listings[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	order.Items[0].ListingHash = hash.B58String()
+	_, taxes, err = CalculateOrderTotalWithTaxes(order, erp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(taxes) != 0 {
+		t.Errorf("Expected no taxes, got %d", len(taxes))
+	}
+}
+
+func TestCalculateOrderTotalWithTaxesAllRegions(t *testing.T) {
+	erp, err := wallet.NewMockExchangeRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A rule covering all regions taxes buyers in countries without a
+	// rule of their own.
+	order, err := factory.NewOrder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	listing := order.Listings[0].Listing
+	listing.Taxes = append(listing.Taxes, &pb.Listing_Tax{
+		TaxType:     "VAT",
+		Percentage:  20,
+		TaxShipping: true,
+		TaxRegions:  []pb.CountryCode{pb.CountryCode_ALL},
+	})
+	hash, err := utils.HashListing(order.Listings[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	order.Items[0].ListingHash = hash.B58String()
+
+	tests := []struct {
+		country pb.CountryCode
+		taxType string
+	}{
+		{country: pb.CountryCode_UNITED_STATES, taxType: "Sales tax"},
+		{country: pb.CountryCode_CANADA, taxType: "VAT"},
+	}
+	for _, test := range tests {
+		order.Shipping.Country = test.country
+		_, taxes, err := CalculateOrderTotalWithTaxes(order, erp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(taxes) != 1 {
+			t.Errorf("%s: expected 1 tax, got %d", test.country, len(taxes))
+			continue
+		}
+		if taxes[0].TaxType != test.taxType {
+			t.Errorf("%s: expected %s, got %s", test.country, test.taxType, taxes[0].TaxType)
+		}
+		if iwallet.NewAmount(taxes[0].Amount).Cmp(iwallet.NewAmount(0)) <= 0 || iwallet.NewAmount(taxes[0].ShippingAmount).Cmp(iwallet.NewAmount(0)) <= 0 {
+			t.Errorf("%s: expected items and shipping to be taxed", test.country)
+		}
+	}
+}
+
+func Test_checkOrderTaxes(t *testing.T) {
+	calculated := []*pb.OrderOpen_Tax{
+		{ListingHash: "a", TaxType: "Sales tax", Percentage: 7, Amount: "1000", ShippingAmount: "100"},
+	}
+	tests := []struct {
+		name     string
+		taxes    []*pb.OrderOpen_Tax
+		converts bool
+		valid    bool
+	}{
+		{
+			name:  "Matching taxes",
+			taxes: []*pb.OrderOpen_Tax{{ListingHash: "a", Amount: "1000", ShippingAmount: "100"}},
+			valid: true,
+		},
+		{
+			name:  "No breakdown",
+			taxes: nil,
+			valid: false,
+		},
+		{
+			name:  "Listing missing",
+			taxes: []*pb.OrderOpen_Tax{{ListingHash: "b", Amount: "1000", ShippingAmount: "100"}},
+			valid: false,
+		},
+		{
+			name: "Extra listing",
+			taxes: []*pb.OrderOpen_Tax{
+				{ListingHash: "a", Amount: "1000", ShippingAmount: "100"},
+				{ListingHash: "b", Amount: "1000", ShippingAmount: "100"},
+			},
+			valid: false,
+		},
+		{
+			name:  "Incorrect amount",
+			taxes: []*pb.OrderOpen_Tax{{ListingHash: "a", Amount: "990", ShippingAmount: "100"}},
+			valid: false,
+		},
+		{
+			name:  "Incorrect shipping amount",
+			taxes: []*pb.OrderOpen_Tax{{ListingHash: "a", Amount: "1000", ShippingAmount: "0"}},
+			valid: false,
+		},
+		{
+			name:     "Amount within exchange rate tolerance",
+			taxes:    []*pb.OrderOpen_Tax{{ListingHash: "a", Amount: "990", ShippingAmount: "100"}},
+			converts: true,
+			valid:    true,
+		},
+		{
+			name:     "Amount outside exchange rate tolerance",
+			taxes:    []*pb.OrderOpen_Tax{{ListingHash: "a", Amount: "989", ShippingAmount: "100"}},
+			converts: true,
+			valid:    false,
+		},
+	}
+
+	for _, test := range tests {
+		err := checkOrderTaxes(test.taxes, calculated, test.converts)
+		if test.valid && err != nil {
+			t.Errorf("%s: failed when it should not have: %s", test.name, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: did not fail when it should have", test.name)
+		}
+	}
+}
+
+func Test_validateOrderTaxes(t *testing.T) {
+	erp, err := wallet.NewMockExchangeRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		transform func(order *pb.OrderOpen)
+		valid     bool
+	}{
+		{
+			name:      "Valid breakdown",
+			transform: func(order *pb.OrderOpen) {},
+			valid:     true,
+		},
+		{
+			name: "No breakdown",
+			transform: func(order *pb.OrderOpen) {
+				order.Taxes = nil
+			},
+			valid: true,
+		},
+		{
+			name: "Duplicate listing",
+			transform: func(order *pb.OrderOpen) {
+				order.Taxes = append(order.Taxes, order.Taxes[0])
+			},
+			valid: false,
+		},
+		{
+			name: "Unknown listing",
+			transform: func(order *pb.OrderOpen) {
+				order.Taxes[0].ListingHash = "abc"
+			},
+			valid: false,
+		},
+		{
+			name: "Incorrect percentage",
+			transform: func(order *pb.OrderOpen) {
+				order.Taxes[0].Percentage = 5
+			},
+			valid: false,
+		},
+		{
+			name: "Not taxed in country",
+			transform: func(order *pb.OrderOpen) {
+				order.Shipping.Country = pb.CountryCode_CANADA
+			},
+			valid: false,
+		},
+		{
+			name: "Invalid amount",
+			transform: func(order *pb.OrderOpen) {
+				order.Taxes[0].Amount = "abc"
+			},
+			valid: false,
+		},
+	}
+
+	for _, test := range tests {
+		order, err := factory.NewOrder()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, order.Taxes, err = CalculateOrderTotalWithTaxes(order, erp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.transform(order)
+		err = validateOrderTaxes(order)
+		if test.valid && err != nil {
+			t.Errorf("%s: failed when it should not have: %s", test.name, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: did not fail when it should have", test.name)
+		}
+	}
+}
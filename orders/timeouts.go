@@ -0,0 +1,146 @@
+package orders
+
+import (
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	iwallet "github.com/cpacia/wallet-interface"
+	"time"
+)
+
+const escrowTimeoutCheckInterval = time.Hour
+
+// escrowTimeoutStages are the fractions of the escrow timeout which must elapse
+// before each notification is sent. The final stage is the expiry itself at
+// which point the vendor may release the funds to themselves.
+var escrowTimeoutStages = []float64{0.5, 0.9, 1.0}
+
+// checkEscrowTimeouts loads the open moderated orders and emits notifications
+// for any that have crossed a new escrow timeout stage. The buyer is warned that
+// the window to open a dispute is closing, the moderator that a dispute needs to
+// be decided, and the vendor is told when the funds can be claimed.
+func (op *OrderProcessor) checkEscrowTimeouts(now time.Time) {
+	err := op.db.Update(func(dbtx database.Tx) error {
+		var orders []models.Order
+		if err := dbtx.Read().Where("open = ?", true).Find(&orders).Error; err != nil {
+			return err
+		}
+
+		for _, order := range orders {
+			orderOpen, err := order.OrderOpenMessage()
+			if err != nil {
+				log.Errorf("Error loading orderOpen message %s", err)
+				continue
+			}
+			if orderOpen.Payment.Method != pb.OrderOpen_Payment_MODERATED {
+				continue
+			}
+			if order.SerializedOrderComplete != nil || order.SerializedPaymentFinalized != nil ||
+				order.SerializedRefunds != nil || order.SerializedDisputeClosed != nil ||
+				order.SerializedOrderCancel != nil {
+
+				continue
+			}
+
+			disputed := order.SerializedDisputeOpen != nil
+			if !disputed && (order.SerializedOrderFulfillments == nil || order.Role() == models.RoleModerator) {
+				continue
+			}
+
+			wallet, err := op.multiwallet.WalletForCurrencyCode(orderOpen.Payment.Coin)
+			if err != nil {
+				log.Errorf("Error loading wallet for order %s: %s", order.ID, err)
+				continue
+			}
+			if _, ok := wallet.(iwallet.EscrowWithTimeout); !ok {
+				continue
+			}
+
+			timeout, err := order.EscrowTimeout()
+			if err != nil || timeout == 0 {
+				continue
+			}
+			funded, err := order.FundingTimestamp()
+			if err != nil {
+				continue
+			}
+
+			stage := escrowTimeoutStage(funded, timeout, now)
+			if stage <= order.EscrowTimeoutNotifications {
+				continue
+			}
+
+			var expiresIn uint
+			if expires := funded.Add(timeout); expires.After(now) {
+				expiresIn = uint(expires.Sub(now).Seconds())
+			}
+			thumbnail := events.Thumbnail{
+				Tiny:  orderOpen.Listings[0].Listing.Item.Images[0].Tiny,
+				Small: orderOpen.Listings[0].Listing.Item.Images[0].Small,
+			}
+
+			var event interface{}
+			switch order.Role() {
+			case models.RoleBuyer:
+				if disputed {
+					event = &events.BuyerDisputeExpiry{
+						OrderID:   order.ID.String(),
+						ExpiresIn: expiresIn,
+						Thumbnail: thumbnail,
+					}
+				} else {
+					event = &events.BuyerDisputeTimeout{
+						OrderID:   order.ID.String(),
+						ExpiresIn: expiresIn,
+						Thumbnail: thumbnail,
+					}
+				}
+			case models.RoleVendor:
+				// The vendor only needs to know once the funds can be claimed.
+				if stage == len(escrowTimeoutStages) && order.SerializedOrderFulfillments != nil {
+					event = &events.VendorDisputeTimeout{
+						OrderID:   order.ID.String(),
+						ExpiresIn: expiresIn,
+						Thumbnail: thumbnail,
+					}
+				}
+			case models.RoleModerator:
+				event = &events.ModeratorDisputeExpiry{
+					CaseID:    order.ID.String(),
+					ExpiresIn: expiresIn,
+					Thumbnail: thumbnail,
+				}
+			}
+
+			order.EscrowTimeoutNotifications = stage
+			if err := dbtx.Save(&order); err != nil {
+				return err
+			}
+
+			if event != nil {
+				log.Infof("Escrow timeout stage %d reached for order %s", stage, order.ID)
+				dbtx.RegisterCommitHook(func() {
+					op.bus.Emit(event)
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("Error checking escrow timeouts: %s", err)
+	}
+}
+
+// escrowTimeoutStage returns the number of escrow timeout stages which have
+// elapsed at the given time.
+func escrowTimeoutStage(funded time.Time, timeout time.Duration, now time.Time) int {
+	elapsed := now.Sub(funded)
+	stage := 0
+	for _, s := range escrowTimeoutStages {
+		if elapsed >= time.Duration(float64(timeout)*s) {
+			stage++
+		}
+	}
+	return stage
+}
@@ -0,0 +1,245 @@
+package orders
+
+import (
+	"github.com/cpacia/openbazaar3.0/database"
+	"github.com/cpacia/openbazaar3.0/events"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/models/factory"
+	npb "github.com/cpacia/openbazaar3.0/net/pb"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	iwallet "github.com/cpacia/wallet-interface"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOrderProcessor_checkEscrowTimeouts(t *testing.T) {
+	var (
+		now     = time.Now()
+		timeout = time.Hour * 1080
+	)
+	tests := []struct {
+		role          models.OrderRole
+		elapsed       time.Duration
+		setup         func(order *models.Order)
+		expectedStage int
+		expectedEvent func(thumbnail events.Thumbnail) interface{}
+	}{
+		{
+			// Buyer before first stage.
+			role:          models.RoleBuyer,
+			elapsed:       timeout / 10,
+			expectedStage: 0,
+			expectedEvent: func(thumbnail events.Thumbnail) interface{} { return nil },
+		},
+		{
+			// Buyer first stage.
+			role:          models.RoleBuyer,
+			elapsed:       timeout * 6 / 10,
+			expectedStage: 1,
+			expectedEvent: func(thumbnail events.Thumbnail) interface{} {
+				return &events.BuyerDisputeTimeout{
+					OrderID:   "1234",
+					ExpiresIn: uint((timeout * 4 / 10).Seconds()),
+					Thumbnail: thumbnail,
+				}
+			},
+		},
+		{
+			// Buyer first stage already notified.
+			role:    models.RoleBuyer,
+			elapsed: timeout * 6 / 10,
+			setup: func(order *models.Order) {
+				order.EscrowTimeoutNotifications = 1
+			},
+			expectedStage: 1,
+			expectedEvent: func(thumbnail events.Thumbnail) interface{} { return nil },
+		},
+		{
+			// Buyer with open dispute.
+			role:    models.RoleBuyer,
+			elapsed: timeout * 95 / 100,
+			setup: func(order *models.Order) {
+				order.SerializedDisputeOpen = []byte{0x00}
+			},
+			expectedStage: 2,
+			expectedEvent: func(thumbnail events.Thumbnail) interface{} {
+				return &events.BuyerDisputeExpiry{
+					OrderID:   "1234",
+					ExpiresIn: uint((timeout * 5 / 100).Seconds()),
+					Thumbnail: thumbnail,
+				}
+			},
+		},
+		{
+			// Vendor is not notified before expiry.
+			role:          models.RoleVendor,
+			elapsed:       timeout * 6 / 10,
+			expectedStage: 1,
+			expectedEvent: func(thumbnail events.Thumbnail) interface{} { return nil },
+		},
+		{
+			// Vendor after expiry.
+			role:          models.RoleVendor,
+			elapsed:       timeout + time.Hour,
+			expectedStage: 3,
+			expectedEvent: func(thumbnail events.Thumbnail) interface{} {
+				return &events.VendorDisputeTimeout{
+					OrderID:   "1234",
+					ExpiresIn: 0,
+					Thumbnail: thumbnail,
+				}
+			},
+		},
+		{
+			// Moderator with open dispute.
+			role:    models.RoleModerator,
+			elapsed: timeout * 6 / 10,
+			setup: func(order *models.Order) {
+				order.SerializedOrderFulfillments = nil
+				order.SerializedDisputeOpen = []byte{0x00}
+			},
+			expectedStage: 1,
+			expectedEvent: func(thumbnail events.Thumbnail) interface{} {
+				return &events.ModeratorDisputeExpiry{
+					CaseID:    "1234",
+					ExpiresIn: uint((timeout * 4 / 10).Seconds()),
+					Thumbnail: thumbnail,
+				}
+			},
+		},
+		{
+			// Completed orders are not tracked.
+			role:    models.RoleBuyer,
+			elapsed: timeout + time.Hour,
+			setup: func(order *models.Order) {
+				order.SerializedOrderComplete = []byte{0x00}
+			},
+			expectedStage: 0,
+			expectedEvent: func(thumbnail events.Thumbnail) interface{} { return nil },
+		},
+	}
+
+	for i, test := range tests {
+		op, teardown, err := newMockOrderProcessor()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		orderOpen, err := factory.NewOrder()
+		if err != nil {
+			t.Fatal(err)
+		}
+		orderOpen.Payment.Method = pb.OrderOpen_Payment_MODERATED
+		orderOpen.Payment.Address = "abcd"
+		orderOpen.Listings[0].Listing.Metadata.EscrowTimeoutHours = uint32(timeout.Hours())
+
+		order := models.Order{
+			ID:                          "1234",
+			Open:                        true,
+			PaymentAddress:              "abcd",
+			SerializedOrderFulfillments: []byte{0x00},
+		}
+		order.SetRole(test.role)
+		if err := order.PutMessage(&npb.OrderMessage{
+			Signature: []byte("abc"),
+			Message:   mustBuildAny(orderOpen),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if err := order.PutTransaction(iwallet.Transaction{
+			ID: "5678",
+			To: []iwallet.SpendInfo{
+				{
+					Address: iwallet.NewAddress("abcd", iwallet.CtMock),
+					Amount:  iwallet.NewAmount(10000),
+				},
+			},
+			Timestamp: now.Add(-test.elapsed),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if test.setup != nil {
+			test.setup(&order)
+		}
+
+		err = op.db.Update(func(tx database.Tx) error {
+			return tx.Save(&order)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sub, err := op.bus.Subscribe([]interface{}{
+			&events.BuyerDisputeTimeout{},
+			&events.BuyerDisputeExpiry{},
+			&events.VendorDisputeTimeout{},
+			&events.ModeratorDisputeExpiry{},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		op.checkEscrowTimeouts(now)
+
+		thumbnail := events.Thumbnail{
+			Tiny:  orderOpen.Listings[0].Listing.Item.Images[0].Tiny,
+			Small: orderOpen.Listings[0].Listing.Item.Images[0].Small,
+		}
+		expected := test.expectedEvent(thumbnail)
+		if expected != nil {
+			select {
+			case event := <-sub.Out():
+				if !reflect.DeepEqual(event, expected) {
+					t.Errorf("Test %d: incorrect event returned. Expected %v, got %v", i, expected, event)
+				}
+			case <-time.After(time.Second * 10):
+				t.Errorf("Test %d: timed out waiting on event", i)
+			}
+		} else {
+			select {
+			case event := <-sub.Out():
+				t.Errorf("Test %d: unexpected event %v", i, event)
+			case <-time.After(time.Millisecond * 100):
+			}
+		}
+
+		var saved models.Order
+		err = op.db.View(func(tx database.Tx) error {
+			return tx.Read().Where("id = ?", "1234").First(&saved).Error
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if saved.EscrowTimeoutNotifications != test.expectedStage {
+			t.Errorf("Test %d: expected stage %d, got %d", i, test.expectedStage, saved.EscrowTimeoutNotifications)
+		}
+
+		sub.Close()
+		teardown()
+	}
+}
+
+func Test_escrowTimeoutStage(t *testing.T) {
+	var (
+		funded  = time.Now()
+		timeout = time.Hour * 100
+	)
+	tests := []struct {
+		elapsed  time.Duration
+		expected int
+	}{
+		{0, 0},
+		{time.Hour * 49, 0},
+		{time.Hour * 50, 1},
+		{time.Hour * 90, 2},
+		{time.Hour * 100, 3},
+		{time.Hour * 500, 3},
+	}
+	for i, test := range tests {
+		stage := escrowTimeoutStage(funded, timeout, funded.Add(test.elapsed))
+		if stage != test.expected {
+			t.Errorf("Test %d: expected stage %d, got %d", i, test.expected, stage)
+		}
+	}
+}
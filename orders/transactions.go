@@ -152,7 +152,9 @@ func (op *OrderProcessor) processIncomingPayment(dbtx database.Tx, order *models
 
 	case models.RoleVendor:
 		if funded {
-			// TODO: mark vendor inventory downwards is not wasFunded.
+			if err := op.reserveInventory(dbtx, order); err != nil {
+				log.Errorf("Error reserving inventory for order %s: %s", order.ID, err)
+			}
 
 			if err := op.sendRatingSignatures(dbtx, order, orderOpen); err != nil {
 				log.Errorf("Error sending rating signature message: %s", err)
@@ -178,10 +180,60 @@ func (op *OrderProcessor) processIncomingPayment(dbtx database.Tx, order *models
 				})
 			})
 			log.Infof("Payment detected: Order %s fully funded", order.ID)
+
+			if order.CanConfirm(op.identity) {
+				orderID := order.ID
+				dbtx.RegisterCommitHook(func() {
+					go op.autoConfirmOrder(orderID)
+				})
+			}
 		} else {
 			log.Infof("Payment detected: Order %s partially funded", order.ID)
 		}
 	}
+	return op.checkForMispayment(dbtx, order, orderOpen, funded)
+}
+
+// checkForMispayment emits an OrderUnderpaid event if the order has not been
+// funded or an OrderOverpaid event if more than the order total has been paid.
+func (op *OrderProcessor) checkForMispayment(dbtx database.Tx, order *models.Order, orderOpen *pb.OrderOpen, funded bool) error {
+	fundingTotal, err := order.FundingTotal()
+	if err != nil {
+		return err
+	}
+
+	if !funded {
+		owed, err := order.AmountOwed()
+		if err != nil {
+			return err
+		}
+		dbtx.RegisterCommitHook(func() {
+			op.bus.Emit(&events.OrderUnderpaid{
+				OrderID:      order.ID.String(),
+				FundingTotal: fundingTotal.String(),
+				AmountOwed:   owed.String(),
+				CoinType:     orderOpen.Payment.Coin,
+			})
+		})
+		log.Infof("Order %s underpaid by %s", order.ID, owed)
+		return nil
+	}
+
+	overpayment, err := order.Overpayment()
+	if err != nil {
+		return err
+	}
+	if overpayment.Cmp(iwallet.NewAmount(0)) > 0 {
+		dbtx.RegisterCommitHook(func() {
+			op.bus.Emit(&events.OrderOverpaid{
+				OrderID:      order.ID.String(),
+				FundingTotal: fundingTotal.String(),
+				Overpayment:  overpayment.String(),
+				CoinType:     orderOpen.Payment.Coin,
+			})
+		})
+		log.Infof("Order %s overpaid by %s", order.ID, overpayment)
+	}
 	return nil
 }
 
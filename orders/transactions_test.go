@@ -10,6 +10,7 @@ import (
 	"github.com/cpacia/openbazaar3.0/orders/pb"
 	"github.com/cpacia/openbazaar3.0/wallet"
 	iwallet "github.com/cpacia/wallet-interface"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -57,7 +58,7 @@ func TestOrderProcessor_processWalletTransaction(t *testing.T) {
 				ID: "5678",
 				To: []iwallet.SpendInfo{
 					{
-						ID:      nil,
+						ID:      make([]byte, 36),
 						Address: iwallet.NewAddress("abcd", iwallet.CtMock),
 						Amount:  iwallet.NewAmount(4992221),
 					},
@@ -134,7 +135,7 @@ func TestOrderProcessor_processWalletTransaction(t *testing.T) {
 				ID: "5678",
 				To: []iwallet.SpendInfo{
 					{
-						ID:      nil,
+						ID:      make([]byte, 36),
 						Address: iwallet.NewAddress("abcd", iwallet.CtMock),
 						Amount:  iwallet.NewAmount(4992221),
 					},
@@ -380,3 +381,117 @@ func TestOrderProcessor_checkForMorePayments(t *testing.T) {
 		}
 	}
 }
+
+func TestOrderProcessor_processIncomingPaymentMispayment(t *testing.T) {
+	tests := []struct {
+		name          string
+		amount        iwallet.Amount
+		expectFunded  bool
+		expectedEvent interface{}
+	}{
+		{
+			name:         "Within mispayment buffer",
+			amount:       iwallet.NewAmount(4950000),
+			expectFunded: true,
+		},
+		{
+			name:         "Underpaid",
+			amount:       iwallet.NewAmount(4000000),
+			expectFunded: false,
+			expectedEvent: &events.OrderUnderpaid{
+				OrderID:      "1234",
+				FundingTotal: "4000000",
+				AmountOwed:   "992221",
+				CoinType:     iwallet.CtMock,
+			},
+		},
+		{
+			name:         "Overpaid",
+			amount:       iwallet.NewAmount(5000000),
+			expectFunded: true,
+			expectedEvent: &events.OrderOverpaid{
+				OrderID:      "1234",
+				FundingTotal: "5000000",
+				Overpayment:  "7779",
+				CoinType:     iwallet.CtMock,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		op, teardown, err := newMockOrderProcessor()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = op.db.Update(func(tx database.Tx) error {
+			orderOpen, err := factory.NewOrder()
+			if err != nil {
+				return err
+			}
+			orderOpen.Payment.Address = "abcd"
+			orderOpen.Payment.MispaymentBuffer = 1
+			order := models.Order{
+				ID:             "1234",
+				PaymentAddress: "abcd",
+			}
+			order.SetRole(models.RoleVendor)
+			if err := order.PutMessage(&npb.OrderMessage{
+				Signature: []byte("abc"),
+				Message:   mustBuildAny(orderOpen),
+			}); err != nil {
+				return err
+			}
+			return tx.Save(&order)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sub, err := op.bus.Subscribe([]interface{}{&events.OrderUnderpaid{}, &events.OrderOverpaid{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		op.processWalletTransaction(iwallet.Transaction{
+			ID: "5678",
+			To: []iwallet.SpendInfo{
+				{
+					ID:      make([]byte, 36),
+					Address: iwallet.NewAddress("abcd", iwallet.CtMock),
+					Amount:  test.amount,
+				},
+			},
+		})
+
+		var order models.Order
+		err = op.db.View(func(tx database.Tx) error {
+			return tx.Read().Where("id = ?", "1234").First(&order).Error
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		funded, err := order.IsFunded()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if funded != test.expectFunded {
+			t.Errorf("%s: expected funded %t, got %t", test.name, test.expectFunded, funded)
+		}
+
+		select {
+		case event := <-sub.Out():
+			if test.expectedEvent == nil {
+				t.Errorf("%s: unexpected event %v", test.name, event)
+			} else if !reflect.DeepEqual(event, test.expectedEvent) {
+				t.Errorf("%s: expected event %v, got %v", test.name, test.expectedEvent, event)
+			}
+		case <-time.After(time.Millisecond * 500):
+			if test.expectedEvent != nil {
+				t.Errorf("%s: timed out waiting on event", test.name)
+			}
+		}
+		sub.Close()
+		teardown()
+	}
+}
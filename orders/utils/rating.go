@@ -10,6 +10,11 @@ import (
 	peer "github.com/libp2p/go-libp2p-peer"
 )
 
+// RatingSignatureVersion is the version of the rating signature scheme used
+// when creating ratings. Version 1 signs the SHA256 hash of the serialized
+// rating.
+const RatingSignatureVersion = 1
+
 // ValidateRating returns an error if the rating is invalid, otherwise nil.
 func ValidateRating(rating *pb.Rating) error {
 	if rating.VendorID == nil || rating.VendorID.Pubkeys == nil {
@@ -105,8 +110,7 @@ func ValidateRating(rating *pb.Rating) error {
 		return err
 	}
 	hashed := sha256.Sum256(ser)
-	valid = sig.Verify(hashed[:], ratingKey)
-	if !valid {
+	if !sig.Verify(hashed[:], ratingKey) {
 		return errors.New("invalid rating signature")
 	}
 
@@ -108,6 +108,28 @@ func TestValidateRating(t *testing.T) {
 	}
 	rating.RatingSignature = signature.Serialize()
 
+	// resign signs the rating with the given signature version, either over
+	// the hash of the serialization or, like older nodes, the serialization.
+	resign := func(version uint32, hash bool) *pb.Rating {
+		cpy := proto.Clone(rating).(*pb.Rating)
+		cpy.RatingSignature = nil
+		cpy.SignatureVersion = version
+		ser, err := proto.Marshal(cpy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash {
+			hashed := sha256.Sum256(ser)
+			ser = hashed[:]
+		}
+		signature, err := ratingKey.Sign(ser)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cpy.RatingSignature = signature.Serialize()
+		return cpy
+	}
+
 	tests := []struct {
 		name  string
 		setup func() *pb.Rating
@@ -121,6 +143,38 @@ func TestValidateRating(t *testing.T) {
 			},
 			valid: true,
 		},
+		{
+			name: "valid versioned rating",
+			setup: func() *pb.Rating {
+				return resign(RatingSignatureVersion, true)
+			},
+			valid: true,
+		},
+		{
+			name: "unversioned rating signing the serialization",
+			setup: func() *pb.Rating {
+				return resign(0, false)
+			},
+			valid: false,
+		},
+		{
+			name: "tampered unversioned rating signing the serialization",
+			setup: func() *pb.Rating {
+				// Signing the serialization only covers its first 32 bytes
+				// so a change to the review would go unnoticed.
+				cpy := resign(0, false)
+				cpy.Review = "tampered"
+				return cpy
+			},
+			valid: false,
+		},
+		{
+			name: "versioned rating signing the serialization",
+			setup: func() *pb.Rating {
+				return resign(RatingSignatureVersion, false)
+			},
+			valid: false,
+		},
 		{
 			name: "vendor ID is nil",
 			setup: func() *pb.Rating {
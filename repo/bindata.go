@@ -68,7 +68,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _sampleOpenbazaarConf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x5a\x5b\x73\x1b\xb7\x92\x7e\xe7\xaf\xe8\x87\x9c\x3a\xbb\x55\xf2\x50\xa2\x2d\xf9\xc2\xd5\x56\xd1\x96\x6c\xeb\x44\x91\x58\x12\x7d\x89\xde\xc0\x41\x0f\x07\x2b\x0c\x30\x01\x30\xa4\x98\xad\xcd\x6f\xdf\xea\x06\x30\x43\x2a\x76\x1e\x4e\x59\x15\xfb\xc1\x12\x2e\xdd\x8d\xbe\x7e\xdd\xe3\x29\x3c\xfb\xa1\x7f\x46\x53\x38\x13\x41\x80\xc7\x10\x94\x59\xf9\xd1\x0f\x67\x30\x9a\xc2\xa2\x46\x90\xca\x61\x19\xac\xdb\x42\xb0\xe0\x83\x75\x08\x92\x19\x77\x65\x0d\xc2\x43\xa8\x11\x6c\x8b\x66\x29\x7e\x17\xc2\xf1\xde\x52\x78\x3c\x00\xd5\x56\x1e\x1a\x0c\x82\x96\x0e\x40\x18\x39\x9a\x42\xdb\x2d\xb5\x2a\xf9\x54\x91\x19\x60\x25\x3a\x1d\x40\x79\xf8\x63\x5c\xec\x90\xb2\x06\xe6\xd7\xb7\x17\x5f\xe1\xfa\x16\xfd\x01\xfc\x74\x79\xfd\x6e\x76\x39\x9b\xcf\xcf\x66\x8b\xd9\xf8\xba\x45\xf3\xb6\x3f\xf7\x45\x19\x69\x37\xfe\x60\x34\x85\x3f\xc6\x97\x6a\xe9\x84\xdb\x8e\x67\x6d\xab\x55\x29\x82\xb2\x06\x6e\xbb\xb6\xb5\x2e\x3c\xba\xf6\x8b\x28\xe1\xfa\x96\x65\x83\x9f\x6a\xdb\xe0\x78\x8f\xfd\x68\x0a\x73\x2d\xcc\xeb\x02\xe0\xdc\xac\x95\xb3\xa6\x41\x13\x60\x2d\x9c\x12\x4b\x8d\x1e\x84\x43\xc0\x87\x56\x18\x89\x12\xbc\x25\x5d\x6c\xa1\x11\x5b\x58\x22\x74\x1e\x65\x01\x70\x75\xbd\x38\x7f\x93\xe5\x1b\x4d\x01\xbf\x4b\x28\x6c\x5b\x55\x0a\xad\xb7\xf0\x8f\xcf\xb3\x9b\x8b\xd9\xdb\xcb\xf3\x7f\x1c\xc0\xb2\x0b\x89\x6c\xe7\x03\xd1\x15\x65\x89\xde\xa3\x84\x8d\x0a\xf5\x68\x0a\x3f\xe5\xc3\x50\xa3\xc3\x02\x60\xa6\xbd\x3d\x80\x3f\x48\x9f\xbd\x6c\xc1\xee\xab\x6f\x47\x67\x64\x06\x32\x87\x54\xee\x74\x4f\xff\xa3\xd1\x8f\xf7\xa9\x29\x5c\x61\xd8\x58\x77\xff\xb4\x7e\xfb\xc9\x23\x04\xf4\xc1\x60\xa0\xe7\xa5\x1f\x4f\x8f\x78\xcf\xa8\x35\x3a\x2f\x34\xcc\x75\xb7\x62\xd3\xcf\xb5\xd8\xc2\x7f\x7c\x9a\x9b\xf9\x7f\x82\xe8\x82\x6d\x44\x48\x96\x20\x6d\x44\x17\xd7\xca\x07\x34\x40\x4e\x04\x76\x19\x84\x32\x24\x3a\xed\xe0\x43\x40\x67\x84\x86\x8b\x39\x08\x29\x1d\x7a\x0f\x95\xb3\x0d\xf8\xe8\x73\x28\x41\xe2\x5a\x95\xe8\x0b\x58\xd4\xca\x83\x6d\xd9\x25\xa5\xf2\xd1\xf8\x8a\x85\x34\xb6\x6b\x4d\x1b\x65\xfc\xd5\x76\xec\x46\xbe\xc5\x52\x55\x5b\xb0\x06\xc1\x3a\x68\x28\xf8\xfc\x46\xb8\x26\x33\x42\x4f\xa6\x4d\xb2\x59\x03\x95\x75\xa0\x4c\x69\x1b\x65\x56\x60\xa2\xaa\x47\x53\x28\xad\x31\x58\x12\x57\x96\x01\x3d\xee\x10\x20\x47\x25\xc7\x52\x06\x04\xac\x85\x56\x12\x9a\x4e\x07\x45\x27\x88\x60\x23\x58\x3e\xe6\x4b\x6b\xa7\x63\xd5\xbe\x18\x1f\x16\xfc\x77\x1c\xca\x76\xfc\xe2\xf0\xf0\xe8\xf1\x89\x93\xf1\x9b\x37\xdf\xdd\xdc\xbf\xfe\xfa\xf0\xf0\x78\xcc\xc1\xf1\x6d\x0a\x79\x3f\xe5\x8b\x95\x08\xb8\x11\xdb\x5e\xd7\x2c\x6c\xab\xf1\x01\x3d\x2c\x6d\xa8\xd9\x28\x17\xf3\xf7\xb7\xfd\xc9\xd9\xfc\x82\xed\xbc\x9f\xaa\x46\x53\xde\xb0\x6b\x74\xbc\xe3\x45\xd3\xab\x85\xb5\xb4\xc3\xc1\xd7\x49\x43\xdf\xd7\x4f\x62\x36\x3c\xf1\x68\xf2\x92\x1f\x79\x94\xd5\x30\xa1\x17\xbc\xb5\x36\xf8\x20\xda\x1d\x03\x50\xf0\xb3\x11\x82\x85\xff\xb1\xca\xb0\x34\xc9\x78\x05\x5c\x1b\xf0\x41\xb8\x10\x57\xad\x44\xd8\x28\xad\xa1\x11\xf7\x38\x9a\x82\xed\xc2\xca\x92\xb1\x77\x4c\x4c\x74\xe8\xf0\x92\x59\x39\xd1\x42\x8b\xe8\x3c\xab\xa0\xa3\xc8\xa8\xb1\xa1\x33\x52\xf9\x92\x5f\x6f\x43\x8d\xa4\x8e\x78\xec\x91\x00\xa3\xe9\x40\x68\x78\xdc\x43\xc1\x7f\x7b\x0b\x8f\xdb\x49\x3b\x3e\x9a\x9c\x3d\xff\xd9\xda\x2f\xf3\xbb\xe7\x0f\x6f\xaf\x6e\x3e\x3c\xbc\xa8\xea\x9b\x65\xf5\xeb\xac\xfc\xfa\xa9\x2e\xef\xea\xc5\xdd\xe4\xf2\xdd\xfd\xbf\x5e\xbe\xb8\xff\xd7\xd7\x0f\xd5\xef\xaf\x17\x9f\x2f\x17\xa4\x93\x5b\xae\x2a\x24\x5e\x65\xdd\x46\x38\x09\x1e\xdd\x9a\x45\xde\x51\x8d\xc3\x12\xd5\x1a\xa1\x41\xef\xc5\x0a\x3d\x6c\x6a\x72\xfa\xaa\xd2\xca\x60\x01\x73\x44\x77\x71\xc6\x5e\xc4\x51\xa3\x50\x72\x46\x8c\xea\x5a\x22\x65\x9c\xfc\xb6\xd6\xd9\x4a\xe9\xc8\x92\x1f\xcf\x8a\xf5\xf1\x68\xac\x71\x99\xcb\x68\xca\x89\x36\x2a\x4d\x55\x31\x17\x97\xc2\x18\x1b\xb2\xce\xa3\xbe\x95\x67\x22\x39\xbe\x76\x5f\x10\x48\xd0\xdf\x3a\x74\x5b\x0a\xf8\xd1\xb4\x77\xc6\xc1\x9c\xd2\x6e\x8c\xb6\x42\x0e\xaf\xe3\x14\x42\x5c\x8b\xd1\xd4\x9b\x2a\xd2\x3b\xfd\x77\x55\xfc\x83\x73\xec\x14\x16\xd6\x3d\x6d\x0e\x3f\xfd\xa1\x7f\x46\x53\xf8\xde\x9f\x2f\xb3\x9b\xab\x8b\xab\x0f\xf0\xec\x19\x9c\xcd\xae\x3e\x9c\xdf\xc0\xdd\xf5\xd5\x39\xfd\x9a\x76\x46\x53\xd8\x81\x0d\x1d\x27\xdd\x9c\x2f\x28\x64\xe0\xe2\x8c\x13\xaf\x20\xe7\x41\xef\x63\x9a\xbd\xa8\x60\x6b\xbb\x7d\x1f\xc1\x1d\x42\x94\xf2\x53\x2d\xc4\x35\x67\xef\x12\xb3\x7f\x96\x1a\x85\x3b\xa0\xfb\x0e\x1c\xee\x97\x96\x04\x2f\x5a\x74\x8d\x30\x68\x82\x26\xc4\xd1\xb6\x31\x46\xe8\x46\x0a\x64\x92\x8a\xfc\x6c\xad\xbc\x5a\x6a\xa4\xdd\x18\xdf\xf6\x51\x82\x49\x82\x92\xa3\x2a\x13\xd0\x48\x4a\x27\xc1\x72\xaa\x20\x2b\x07\x0b\x8d\xf0\x54\x46\x58\x9e\x41\x14\x16\x30\xe2\x92\xab\xf3\xcf\xe7\x37\x29\x4f\xed\xe8\x8a\x22\xc7\x76\x01\x3a\x4f\x34\x17\xd6\x15\x70\x65\x43\x7e\x2f\x89\x31\x9a\x42\xa5\x9c\x0f\xf1\x6e\xc1\x0c\x33\xd2\x29\xad\xa9\xd4\xaa\x73\x28\x73\xea\x92\x74\x0b\xd7\xe8\xb6\x40\x14\x35\xc6\x6b\x5d\x9b\x5f\x41\xb1\x55\x96\x4a\xa2\x09\x5c\xbf\x79\x1b\xe5\x5f\xca\x14\x9f\xf1\xcb\xa7\xdb\x05\x48\xd4\x18\x30\xbe\x93\x41\x6e\x0f\x7e\x53\xd0\xc6\x17\x52\xd2\x2c\xe0\x8c\x0e\xb3\xae\x6a\x7c\x74\x3a\xc6\x74\x65\x5d\xb9\x6b\xf1\xac\x54\x3a\x58\x55\xe8\xd0\x84\xc1\x56\x05\x17\x7d\xbe\xa7\x2d\x1d\x32\x5b\xae\xeb\x14\x5f\x07\x60\x9d\x44\xc7\xff\x42\x69\x95\xf1\x2c\x72\x2d\xd6\xe4\x85\x6b\x94\x94\x98\x68\x45\x5a\xf0\xb6\xf8\xf1\xc1\x93\xe2\xbd\xe9\xd3\x55\xd4\x83\x30\x80\xcd\x12\x25\x21\x4c\xda\x97\x02\x1b\x6b\x28\xbb\x3e\x6c\x63\x29\xee\xb1\x08\x67\xda\x6f\xd4\x2a\x2a\x61\xb9\x00\x13\x89\xde\x2b\x19\x29\x31\x43\x0e\x38\xda\xc3\x87\x52\x77\x5e\xad\x51\x6f\x99\x1e\xa5\xe0\x3e\x5a\xd8\x77\x5d\x06\x7c\xd6\x45\x20\x75\xd6\x09\x16\xb6\xbc\xdf\x11\x9e\x10\x74\x1b\x06\xd9\xf6\x4a\x67\x6d\x5d\xb7\xaa\xa3\xf4\xc4\x74\x76\x75\x36\x30\x19\x4d\x07\x36\x94\xe7\x1d\x56\xdc\x0f\x75\x42\xef\x30\x51\x9e\x50\x3f\xb4\x4e\xad\x45\xc0\x02\xae\xbf\x55\xa3\x53\x55\x1a\x4d\xa1\x11\x12\x07\x25\xec\x3f\x06\x3a\xa3\x29\xe8\x83\xd0\xf7\x29\x2c\x45\xac\x1a\xae\x33\x86\x56\x76\x95\xb2\xc4\x5a\x71\x97\x45\x91\x46\xb0\x3e\xcb\x15\x95\xf1\x83\x73\xf4\x14\xae\x48\x90\xdb\x54\x04\xe0\x19\x63\xa6\xca\x6a\x6d\x37\x24\x59\x84\xb9\x4f\xd7\x98\x9a\xae\x59\x12\x78\xa9\xc0\xa1\x6f\xad\x49\x60\x78\x23\x54\xe0\x74\xcc\xf0\xa0\x11\xac\xb7\x8b\xf9\xd5\x2d\x57\x60\xd5\xa3\x70\xe5\x41\x40\x70\x42\xa2\xad\x2a\x02\x39\x18\x36\x88\x31\x37\x8a\xb2\xec\x9c\x28\xb7\x44\x9c\x7e\xe7\xda\xdd\x57\x6d\xdf\x22\x4a\xd2\xaf\x6a\x8d\xff\xad\xb3\xae\x6b\x4e\x19\xdb\x9d\x45\x44\xcf\x87\x28\xd0\x6d\x15\x19\xcf\xbb\xa5\xef\x96\x31\xc2\x5b\x67\x97\x62\xa9\xb7\xb0\x11\x86\xab\x82\x4c\xe0\x21\x86\x70\x44\x22\x24\x1c\xbb\x0c\x31\x49\x3f\xd2\xd9\x25\xe6\x07\x09\xd0\xc2\xad\x76\x95\xb0\xfb\x44\xea\xcb\x55\x88\x3e\x46\x82\xb0\x0f\x35\xd8\xd8\xf8\x0a\x7a\xad\x30\x72\xa3\x64\xa8\x63\xeb\x41\x2f\x69\x7d\x74\x13\x02\xc5\x9f\x6e\x2e\x73\xb6\xaa\x62\xe4\xd5\xc2\xac\x10\x9c\x08\xa4\xc0\x5f\x28\x43\x53\x7a\xb6\xae\xc9\x95\xed\xad\x0a\x94\x9a\x66\x6b\x74\x62\x85\x3b\xc0\x38\x5f\xa6\xbb\xad\xb3\x6b\x25\xd1\x9d\xd6\x21\xb4\xfe\xcd\x78\x1c\x54\x79\x8f\x6e\xa7\xeb\x2c\xac\x5b\x8d\x45\xab\x76\xf5\x49\x85\x75\x27\x8d\x3a\xd4\x82\x92\x7a\xd5\x19\x0e\x26\xa1\x55\xd8\x12\x1b\x8a\xea\x1e\xfc\xb3\x1e\xc9\x64\xf1\xb7\x98\x57\x94\x59\x45\xc3\x55\xde\x1a\xbd\x4d\x0f\x6e\x5b\x34\x12\x04\x94\xb6\xe1\xbe\x3c\xbd\xa8\xf3\xe8\x40\xac\x68\x25\xe3\xc6\x61\x7a\x31\x24\xfb\x62\x34\xed\x44\xba\x7a\x9a\xfe\x7d\x92\x70\x23\xc3\xfc\x2d\xd1\x96\xdb\xd1\x8d\xf2\x35\x29\x07\x0d\x9b\xe5\xf6\xf6\x32\x83\x09\x12\x6d\xc8\x6e\x43\x84\xd5\x6a\x55\x13\x42\x71\x18\x15\x23\x91\x9c\x4f\x0d\x88\x23\xa7\x31\x8e\x2b\x86\xb8\x44\x52\x80\xc3\xc6\x06\xf2\xf6\xb2\x56\x06\xc9\x9f\x2b\xa1\x74\xe7\x30\xbb\x25\x31\x27\xff\xa6\xc2\x4c\x3a\xa0\x82\x49\x6d\x72\xb0\xbb\x90\x8b\xec\x5f\x5a\x13\x9c\xd5\x43\x74\x1d\x50\xea\xd7\x1d\xe3\x1c\xe9\x84\xea\x05\xd8\x08\xad\x63\x01\xf1\x5e\x47\xdf\x58\x0c\xdc\xb6\xb9\x3e\x1b\x8c\x60\x4b\x68\x6f\xfb\x16\x9d\xdd\x43\x84\x9a\x73\x50\xdf\x86\x96\xc8\x65\x52\xc2\x3d\x6e\x81\x5a\x0e\x32\x10\x45\x14\x0b\x43\xbb\xaa\x52\x25\x55\x89\xc8\x94\x56\xe8\xd8\xe9\x98\x68\x8d\x83\x1d\x7b\xaf\x0b\x5a\x8d\xfb\xf7\xb8\xfd\xf3\xf6\x3d\x6e\x73\x4e\x1c\xfc\x21\xf5\x1d\xb0\x14\x5e\x95\x20\xba\x50\x43\xe9\x90\x80\x91\x12\xda\xb3\x0c\xd9\x70\xc9\x1c\xd9\xba\x9d\xe7\x16\xa5\xa3\xae\x25\xa4\xf9\x19\xe3\x36\x22\x28\xc2\x00\xfa\x48\x31\xfc\x52\xd2\x0e\xd5\xc9\xfd\x3b\x8c\x38\x9d\x0d\x58\x92\xf0\xbd\x49\xa3\x95\x0b\xb8\x08\xff\xf4\x51\x85\xe4\x24\xbb\x3e\x32\xb0\x61\xb4\xb4\x4f\x94\xb0\x23\x81\x06\x03\xda\x96\x42\xd7\xd6\x87\xc8\x88\x36\x42\xea\xe6\x5a\x67\x57\x4e\x34\xa9\x89\x8a\x13\xb3\x6c\xe4\xd9\xfc\x82\x27\x8f\xe2\x9e\xfa\xaf\xfc\xa8\xac\x8b\x56\x78\xbf\xb1\x4e\xc2\x12\xc9\xa9\x32\x14\xa5\xed\x1a\x1f\x00\x4d\x69\x09\xed\xdc\x7e\x9c\x4d\x8e\x4f\xa0\x16\xbe\x06\x5b\xa5\x41\x90\x28\x03\xc1\x8d\x4c\x62\x88\x02\x99\x1c\x33\x69\x23\xf9\x4a\x62\xb4\xa9\xa9\x13\x55\x01\xbc\x0a\x9e\x3b\x56\x46\x19\xd1\x7d\x18\x01\xb3\xe3\x14\xf0\x85\xea\x19\x2b\x9f\x44\x17\x86\xe5\x75\xf8\x5b\x87\x3e\x0c\xce\x49\x74\xf3\xf5\xce\x3c\x23\x09\x39\xe6\x7a\x7e\xb9\x8a\xb1\xec\xb9\x37\x2e\x6d\xd3\x0a\x17\xdd\xba\xdf\x8c\xd0\x92\xa7\x8a\xa3\xa9\x68\x15\xe5\x43\x23\x1a\x3c\x15\x5a\x95\xc8\x4b\x99\xea\xe9\x31\xbe\x7a\xf5\xe2\xd5\xeb\x57\x52\x4c\x5e\x1d\xbe\x78\x79\x74\x7c\x24\x0f\xf1\xf8\xa4\x7a\x25\xcb\x93\xc9\xeb\xc9\xcb\x97\xcf\x4f\x0e\x9f\xcb\x43\x79\x22\xc4\x72\x29\xe5\xc9\x44\x1c\x1d\x61\xf5\x72\x72\x24\x8f\x8e\x5f\x4c\xe4\x2b\xce\xc3\x9e\x5e\x25\x34\x8f\xd3\x02\xb5\xfa\x14\x4a\x83\xff\x72\x3b\x25\x0c\x7b\x45\x69\xed\xbd\x62\xef\xa6\xee\xe0\x91\xaf\x2e\xb8\xaf\x68\x9d\x6a\x84\xdb\xc6\xe3\x22\x55\xb2\x90\x4c\x42\x3f\xf7\x5e\xc2\x1e\x90\x7e\xeb\x47\x7f\xc3\xd0\x25\x7a\x2c\xc3\xca\x3d\x13\x92\x27\xc1\x17\xa4\x0a\x4e\x50\x74\xf0\xdf\xe8\x08\x44\x23\x66\xeb\xc8\x75\x2d\x74\x97\x3a\x3c\xe5\x93\x69\xa9\x12\x77\x81\xca\x2a\xbb\xad\x88\x6e\xaa\x52\xc1\x71\x96\xa0\x68\x74\x84\xa6\x21\xc3\x69\x4a\x86\x29\xd5\xc7\xe1\x7b\x7c\x0e\xf1\xef\x4d\x1d\x53\xd9\xf6\x71\xf8\xf7\x1e\xa0\x7c\xb4\x67\xd4\xe1\xe9\xaf\x5f\xaf\xee\xef\x9a\xf7\xbf\xdf\x7d\x78\xdf\xdc\x7d\xbc\xaa\xef\x3e\x5e\x35\xc3\xda\x5d\x5d\x4e\x6e\x9a\xbb\xe6\xfd\xfd\xdd\x2a\x77\x02\xe4\xb3\x01\xa9\x3b\xc9\xb3\x96\x72\xa7\x2d\x44\x7f\x00\x6d\x9c\x5a\x37\xbd\xf7\x50\x5a\x42\xa9\xda\xd3\xc9\xab\xe2\xc5\x71\x71\xf2\xb2\x38\x7a\x79\xbc\xbb\xfe\x7c\x52\x4c\x9e\xbf\x2e\x8e\x0e\x5f\x17\x47\xc7\x9c\x7a\xdf\x5d\xdf\xdc\xf2\x10\x9b\xab\x8d\x84\xe5\x36\x7f\x2a\xa0\x36\x31\x8f\x4f\x79\xac\x13\xf6\x52\x5f\xb0\x50\x09\xed\x89\xaf\xb1\xa5\x75\x09\xd7\x5c\xec\xa7\xb9\x58\x35\xfa\xb9\x4d\x82\x57\xdc\x64\x0a\x82\x86\xa9\xd6\x13\x50\xc9\xb3\xbd\x83\x34\x3e\x53\xdc\xb6\xc4\x29\x2e\x59\x25\x43\xad\x2c\x52\x4c\x38\x89\x09\x87\x29\x1a\xd9\x5a\x65\x82\x27\xd5\x95\x75\x3e\x11\x7b\x2a\x55\x6d\x47\xd3\x3c\x0e\xfa\xa7\x4f\xe0\x3f\x36\x2e\x21\x62\x18\x26\x4f\x88\x25\x89\x5d\x61\xa0\xc2\xb8\x8a\x50\x84\x9c\x39\x8d\xb1\xd2\x44\x81\xc7\x59\xc5\x68\x1a\x1f\x91\xc4\x7f\xa2\x2e\xe0\x0b\x57\xcd\xbf\x07\x99\x9c\x9b\x1e\x70\x0f\x0c\x63\x19\x8f\x31\x4e\x06\x53\x66\x07\x3d\xf2\x40\x95\x9a\xeb\x64\x2c\x99\x8f\xf3\xa0\x2f\x66\x43\xaa\x54\x94\xfa\xf2\x08\x2e\x75\x8c\x28\xa1\xec\x9c\x43\x53\x12\xc4\xa6\x5a\x24\xca\x3a\x37\xe9\xc5\x28\xf9\x69\x24\x77\xfa\xf6\xdd\xc7\xc7\x2b\x8b\x77\x8f\x56\x2e\xff\xb4\x72\x77\xfe\x6e\x34\xdd\x5f\x3a\x5f\x7c\x7c\x12\xb3\xc5\xa1\xeb\xcc\x48\x78\x9f\x86\xae\xb7\x11\x7f\xfd\x7d\x86\xec\x81\x20\x89\xf6\x4c\x18\xf9\x6c\x7f\x1e\x9c\xda\xf7\x3f\x07\xae\xad\x2a\x74\x69\x70\x1b\xfb\x9b\xdd\x8b\xaa\xc4\x7e\x26\x3e\x8c\xd5\x1f\x8f\x7d\x97\x08\x22\xcf\xc9\x3a\x5f\x0f\x83\xd8\x38\x14\xc3\x44\x35\x0f\x9c\x77\x66\xea\xa1\xb6\x1e\xbf\x43\xca\x61\x70\x0a\xd7\xd1\x45\xf7\x27\xd7\xa1\xc6\x2d\x7f\x9b\x69\x28\x4d\x97\xf7\x14\xdf\x3c\xc9\x4e\x50\x2b\xf7\xa8\xad\xdd\xa0\x8b\xcd\x48\xaa\x27\x05\xdc\xf4\xb0\x59\xf9\xac\x1c\x5f\xdb\x4e\x73\xfe\xef\x3f\x24\x2e\x31\x62\x0f\x86\xd4\x4b\xfb\x10\x47\xd9\x02\xb4\x0d\xd4\x32\x46\xca\x71\xae\x65\xb9\x6b\x13\x3e\x21\x0c\xbe\x4b\xab\xb1\x09\x15\xb0\xb2\x56\x82\x44\xa1\xe9\x62\xfa\x7c\x1b\x1d\x75\x67\x38\xdd\x0f\xf3\xbf\x61\xbc\x38\x0a\x17\x3b\xa3\xc8\x28\x0d\x07\x51\xcc\x5e\x19\x94\xb6\x9d\x6b\x6d\xec\x9f\x1d\xa6\x6f\xb8\x2c\x06\xf3\x7d\x9c\xc8\x07\x52\x14\xd6\x91\x52\x66\x99\x21\x03\x52\x4a\x25\xda\xca\xe5\x81\x9b\xcf\xb5\xc9\x9b\x8a\x96\xfa\xd1\xfa\xdb\xf3\xdb\x72\x12\x6e\xcd\xfa\xf3\x0d\x36\x3f\x7b\x7f\xf6\x8b\xfa\xf9\xf2\x0e\x7f\xae\x3e\xdd\xd4\x9b\xaf\x62\x73\xf7\x45\x28\xfb\x9b\x9f\x3f\x5f\x1f\x6d\x9e\x24\x32\xcf\x70\xd9\xad\x9e\x24\xca\x98\x32\x68\xbb\x5a\x91\xf3\x68\x5c\xa3\x26\x2c\xfc\x99\x3f\x6a\xf1\xaf\xd1\x4a\xff\x2b\xe9\x20\xf5\x49\x95\x3d\x20\x70\xa1\x4a\x3c\x80\x8d\x70\xe4\x74\x07\x80\xce\x59\x77\x00\xa5\x53\x0c\x96\xfe\x6f\x34\x25\x9a\x7c\xff\x94\xae\xfc\xc5\x7f\x1d\xd0\x76\xd5\xf7\x41\xda\xae\xfe\xf4\xd1\x79\xac\xed\xaa\xff\xd2\xc7\x1f\x5b\xf3\xe7\x9f\xf4\x91\x93\x7c\xe4\xe3\x62\x31\xef\xbf\xe1\x24\x04\xec\x0b\x88\x77\xd2\xf2\x4e\xc6\xe0\xe1\xce\x90\xef\xf9\x23\xce\xf0\x19\x36\xa1\xa7\xfe\xa3\xd1\x23\x3a\xca\xc4\x49\x06\x1d\x25\x4f\xe2\x91\x5d\xff\x0d\x5e\x04\x06\x08\x6f\xc6\xe3\xbe\x1b\x79\xf3\x5f\xe9\x2a\x49\xff\xdf\x63\xd6\xe4\xb8\xa5\xb5\x38\xe2\x4f\x1d\x6f\xc1\x08\x95\x0f\x9e\x9e\x1c\x9e\x70\xe8\x7c\x71\x2a\x20\xbc\x9b\x7f\xea\xb9\xa7\xac\x35\x7c\xd1\xe2\x56\x80\xb2\x46\xdb\xe5\xdb\xe3\xd0\xb4\x3b\xff\x6f\xa1\xa0\xf5\xd1\xff\x07\x00\x00\xff\xff\x80\xfd\xc5\x73\x6f\x22\x00\x00")
+var _sampleOpenbazaarConf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc5\x5a\x6b\x53\x1b\x49\xb2\xfd\xee\x5f\xd1\xe1\x98\x8d\xbd\x1b\x81\x25\xc0\x06\xbf\xae\x36\x2e\x06\x6c\x33\x83\x81\x40\xf8\x31\xdc\xd8\x0f\xa5\xee\x92\xd4\x4b\xbf\xa6\xbb\x5a\x42\xb3\xb1\xf3\xdb\xf7\x9c\xcc\xaa\xee\x06\x7b\xe6\xc3\x86\x89\xc1\x0f\xa4\xea\xaa\xac\xac\x7c\x9e\xcc\xea\xd7\xd1\x93\xef\xfa\xf3\xe8\x75\x74\x64\x9c\x89\x1a\xeb\x5c\x5a\x2c\x1a\x7c\xff\xce\x1b\x80\xe2\xd5\xd2\x46\x49\x5a\xdb\xd8\x95\xf5\x26\x72\x65\xd4\xe0\x03\x86\x64\xe3\x36\x5e\x46\xa6\x89\x1c\xe6\x94\x95\x2d\x66\xe6\x57\x63\x6a\x79\x36\x33\x8d\xdd\x8a\xd2\x6a\xde\x44\xb9\x75\x86\x43\x5b\x91\x29\x12\x50\xac\xda\x59\x96\xc6\x32\x6b\x14\x36\xb0\x73\xd3\x66\x2e\x4a\x9b\xe8\xb7\xf1\x68\x40\xaa\x2c\xa2\x8b\xf3\xe9\xc9\x97\xe8\x7c\x6a\x9b\xad\xe8\x87\xd3\xf3\xc3\x83\xd3\x83\x8b\x8b\xa3\x83\xab\x83\xf1\x39\xe6\xbd\xe9\xe6\x7d\x4e\x8b\xa4\x5c\x37\x5b\x20\xf9\xdb\xf8\x34\x9d\xd5\xa6\xde\x8c\x0f\xaa\x0a\x7b\x19\x97\x62\xc2\xb4\xad\xaa\xb2\x76\xf7\x96\x7d\x30\x31\x88\x0b\x6f\xd1\x0f\xcb\x32\xb7\xe3\x3b\xdb\x83\xda\x45\x66\x8a\x97\xa3\x28\x3a\x2e\x56\x69\x5d\x16\xb9\x2d\x5c\xb4\x32\x75\x6a\x66\x99\x6d\x22\x03\x61\xd8\xdb\x0a\xcb\x6d\x12\x35\x25\x65\xb1\x89\x72\xb3\x89\x66\x36\x6a\x1b\x9b\x60\xe1\xd9\xf9\xd5\xf1\xab\xc0\x1f\x08\xda\xdf\x25\xe4\x36\x15\xb8\xcd\xb2\x4d\xf4\x97\x4f\x07\x97\x27\x07\x6f\x4e\x8f\xff\xb2\x15\xcd\x5a\xe7\xc9\xb6\x8d\x23\x5d\x13\xc7\xb6\x01\xed\x68\x9d\xba\x25\x08\xfe\x10\x26\x47\x4b\x5b\x5b\xec\x78\x90\x35\xe5\x56\xf4\x1b\xe5\xd9\xf1\x06\xd5\xdd\x11\xdf\x40\x66\x54\x03\xd5\x01\x3d\x4f\xee\xc8\xff\xd1\xa3\xef\x6f\x53\xaf\xa3\x33\xeb\xd6\x65\x7d\xf3\xb0\x76\xfb\xb1\x81\x38\x6d\xe3\x0a\xeb\x78\x3c\xff\x71\xb2\x23\xcf\x8a\x74\x65\xeb\xc6\x64\xd0\x6d\xbb\x10\xd5\x43\xc9\x9b\xe8\x7f\x3e\x5e\x14\x17\x7f\x8b\x4c\xeb\xca\x1c\x36\xa3\x9a\xa0\x34\xd4\xc4\xb3\xb4\x71\xb6\x88\x68\x44\x51\x39\x73\x26\x2d\xc8\x3a\x9f\xd8\x5b\x67\xeb\x02\xf4\x4e\x2e\x22\x93\x24\x35\xb4\x13\xcd\xeb\x32\x87\x87\x88\xcd\x41\xfc\x89\x5d\xa5\xd0\xda\x08\xf6\x0e\xad\x94\x95\x98\x64\x92\x36\xaa\xfc\x54\x98\x2c\xca\xb6\x2a\x2a\xe5\xf1\xe7\xb2\x15\x33\x6a\x2a\x1b\xa7\x73\xb0\x51\xc0\xc7\xea\x28\xa7\xf3\x35\x6b\x53\xe7\x61\x23\xac\x86\x6a\x3d\x6f\xa0\x39\xc7\xac\xb4\x88\xcb\x1c\xa2\x8d\x0a\x15\x35\xe8\xc5\x65\x51\xc0\x89\xb1\xab\xf0\x60\x21\x9e\x9e\x00\x0d\x95\x86\x95\x16\x91\x81\x49\x66\x69\x02\x5b\xcb\x5c\xca\x19\x24\x08\x69\x90\x3f\xd9\x97\x63\x93\x71\x5a\x3d\x1b\x6f\x8f\xe4\xcf\xd8\xc5\xd5\xf8\xd9\xf6\xf6\xce\xfd\x19\xfb\xe3\x57\xaf\x7e\xf7\xe1\xdd\xe5\x2f\xb7\xb7\xf7\xc6\xe2\x1c\xdf\xa6\x10\x9e\xfb\x78\xb1\x30\xce\xae\x21\x9c\x20\x6b\x61\xb6\xca\xec\x2d\xce\x32\x2b\xdd\x52\x94\x72\x72\xf1\x76\xda\xcd\x3c\xb8\x38\x11\x3d\xdf\x0d\x55\x20\xc7\x07\x25\xac\x41\x9e\x34\x26\xef\xc4\x22\x52\x1a\xec\xd0\x2c\xbd\x84\x7e\x5f\x3e\x7e\xb3\xfe\x88\x3b\xbb\xcf\xe5\x90\x3b\x41\x0c\xbb\x3c\xc1\x9b\xb2\x74\x8d\x33\xd5\x40\x01\x74\x7e\x51\x02\x54\xf9\xcf\x12\x9b\x90\x1b\xaf\xbc\x51\x74\x5e\x20\xea\x9a\xda\xe9\x68\x99\x58\x38\x7e\x96\xc1\x3c\x6e\x2c\xc8\x95\xad\x5b\x94\x54\xf6\x40\xc5\xa4\xc3\xc9\x33\xd9\xaa\xc6\x5e\x95\x85\xc5\x8b\x08\x5a\x7a\xc6\xd2\xe6\x9c\x03\x03\x8c\xe5\xf4\x10\x9a\xa5\x38\x74\xda\x3d\x06\x30\xde\x11\xea\x0f\x77\x3b\x92\x3f\x9d\x86\xc7\xd5\x6e\x85\x13\x1f\x3d\xfd\xa9\x2c\x3f\x5f\x5c\x3f\xbd\x7d\x73\x76\xf9\xee\xf6\xd9\x7c\x79\x39\x9b\xff\x7c\x10\x7f\xf9\xb8\x8c\xaf\x97\x57\xd7\xbb\xa7\x87\x37\x3f\x3e\x7f\x76\xf3\xe3\x97\x77\xf3\x5f\x5f\x5e\x7d\x3a\xbd\xa2\x4c\xa6\x92\x55\xc8\x1e\xc4\x09\x13\x40\x2c\xb5\xf5\x4a\x58\x1e\x88\x06\x69\xc8\xc2\x75\x91\x52\x9a\xc6\x2c\x20\xb7\xf5\x92\x46\x3f\x9f\x67\x69\x81\xb8\x77\x01\xe6\x4f\x8e\xc4\x8a\xc4\x6b\x52\xac\x62\x44\x54\x71\x41\x7b\x88\x38\xe1\x6c\x55\x5d\xce\xd3\x4c\xb7\x94\xc3\x8b\x60\x1b\x9d\xaa\x39\x2e\xec\x02\x7a\x0c\xb4\x2a\xb4\x74\xae\xb1\x38\x36\x45\x51\xba\x20\x73\x95\x37\x1c\x9b\x44\x82\x7f\x0d\x4f\xe0\xc8\xe8\x2f\xad\xad\x37\x74\x78\x50\x0c\xc6\xd8\xab\x13\x91\xb8\xc8\x4a\x93\xf4\xa7\x93\x10\xc2\x5d\xa1\x81\xa6\x98\x2b\xbd\xc9\x7f\x2b\xe2\xef\x1e\xc7\xaf\x10\x69\x1e\x34\x86\x4f\xbe\xeb\x0f\x08\xfe\xde\xcf\xe7\x83\xcb\xb3\x93\xb3\x77\x38\x42\x74\x74\x70\xf6\xee\xf8\x32\xba\x3e\x3f\x3b\xe6\x57\xff\x04\x6b\x07\xb0\xa1\x95\xa0\x1b\xe2\x05\x5d\x26\x3a\x39\x92\xc0\x6b\x68\x3c\x50\x9f\x86\xd9\x93\x79\xb4\x41\x18\xbf\x63\x23\x76\x40\x88\x21\xdf\xe7\x42\xbb\x92\xe8\x1d\xdb\x60\x9f\x71\x66\x4d\xbd\xc5\xf5\x35\xcc\xfe\x6e\x6a\xf1\xf0\xa2\xb2\x08\x3c\x05\x50\x44\x46\xc4\x51\x55\xea\x23\x5c\xe1\x1d\x99\x5c\xd1\xce\x56\x69\x93\xc2\xea\xf8\x54\xfd\xbb\xbc\x17\x60\x3c\xa3\x34\xd4\xb4\x40\x1e\x49\x18\x4e\x30\x9b\xa1\x82\x5a\xc6\xc7\xdc\x34\x4c\x23\xc2\x4f\xcf\x8a\x30\xa8\xb8\xe4\xec\xf8\x13\xe4\xa6\x71\x6a\x20\x2b\x7a\x0e\x22\x14\x48\x91\x26\x88\x8d\xa2\x33\xf8\x8d\x3f\x2f\xd9\x00\xd5\x79\x5a\x83\x82\xac\x1d\xc9\x86\x01\xe9\x40\x74\xf3\x74\xd1\xd6\x38\x9a\x0f\x5d\x09\x57\x61\x35\xc0\x28\x29\xe2\x54\xb2\xac\xad\xc2\x29\xe8\x5b\x71\x9c\x26\x90\x8b\xe4\x6f\x79\x8c\x65\x7f\xc4\x93\x1e\xe3\xc3\xc7\xe9\x15\xf2\x74\x66\x9d\xd5\x73\x0a\xc8\xed\xc0\xaf\x77\x5a\x3d\x21\x83\xe6\x28\x3a\xe2\x64\x91\xd5\xd2\xde\x9b\xad\x3e\x0d\x9b\x88\x87\x1a\x0f\x42\xe5\xc4\xf9\x1c\xa1\xa9\x70\xbd\xae\x46\x92\xf4\x65\x5d\x56\x72\x52\xb1\x91\xbc\x4e\xff\xda\x42\xf2\x4f\xa0\x3a\xfe\x86\x54\x00\x3d\x84\xe5\xa5\x59\xd1\x0a\x57\x38\x5f\xaa\x3a\x4c\x80\xd0\xcb\xd1\xf7\x77\x1e\xef\xef\x79\x17\xae\x54\x0e\x06\xca\xc8\x67\x36\x21\xc2\xe4\xf3\xc4\xd8\x1c\x0a\x42\x74\xbd\xdd\x68\x2a\xee\xb0\x88\x44\xda\x6f\xe4\x2a\xa6\xb0\x90\x80\x49\xa2\xb3\x4a\x41\x4a\xb2\xa1\x38\x1c\x9f\xd9\xdb\x38\x83\xd6\x56\x16\x8a\x25\x3d\x86\xe0\xce\x5b\xc4\x76\xeb\x00\xf8\xca\x5a\x81\xd4\x51\x6b\x84\xd9\xf8\x66\xc0\x3c\x11\x74\xe5\x7a\xde\xee\xa4\xce\x65\x59\xb7\x8b\xa5\x72\xcf\x4d\x0f\xce\x8e\xfa\x4d\x40\xb1\xdb\x86\x71\xbe\xb6\x73\xa9\x87\xb0\xcb\x60\x13\x30\x0e\xd4\x0f\x31\xa4\x2b\x60\x02\x64\xf0\x6f\xe5\x68\x9f\x95\x40\x11\x80\xc7\xf6\x42\xb8\x7b\x98\xa8\x2d\x32\x3a\x3d\xac\xf9\xc6\xbb\xa5\xd1\xac\x51\xb7\x45\xc1\x91\xa1\x50\x66\x76\x99\x4a\x95\x45\x4f\x23\xac\x0f\x7c\xa9\x30\xbe\x3f\x96\x27\x23\x53\x9f\x04\xa2\x27\x82\x99\xe6\x65\x96\x95\x6b\x72\xa6\x30\xf7\xe1\x0a\xd3\xa2\x85\xed\x01\xbc\xcc\x11\x22\x9b\x0a\x3b\x29\x18\x5e\x9b\xd4\x49\x38\x16\x78\x00\x98\x44\x5e\x4e\x2e\xce\xa6\x92\x81\xd3\x0e\x85\xe3\xaf\x89\x00\x6a\x12\x0b\x08\x41\x90\x03\xcb\xb3\x56\x63\x23\x2c\xa4\xad\x4d\xbc\x21\x71\x7e\x97\xdc\xdd\x65\x6d\xe0\x0b\x94\x76\xb4\x85\xaa\x68\x7e\x69\x61\x30\xf9\x44\xb0\xdd\x91\x22\x7a\x99\x44\x47\xc7\x6a\xd9\xf8\xa2\x9d\x35\xed\x4c\x3d\x1c\xce\x31\xc3\x24\x84\x08\x53\x48\x56\x48\x3c\x78\x50\x17\x56\x24\x42\xe6\xc4\x64\xb8\x89\xff\xc8\xb9\x08\x8a\xfe\x40\x26\xca\x4c\xbd\x18\x0a\x61\x78\x44\xd6\xe5\x90\x82\xd8\x18\x19\x11\x1b\xca\xe1\x9c\x7a\x0a\x9e\x16\xbf\xd6\x69\xe2\x96\x5a\x7a\xf0\x24\x55\xa3\x66\x42\x50\xfc\xf1\xf2\x34\x44\xab\xb9\x7a\xde\xd2\x14\xd8\xae\x86\x41\x43\x80\x1f\x18\xa1\x19\x9e\x81\x7e\x43\x66\x7b\x93\x3a\x86\xa6\x03\x18\x32\xf0\xcb\x00\x18\x87\xc5\x5c\x8b\xd3\xaf\x10\x9d\xeb\xc9\xd2\xb9\xaa\x79\x35\x1e\xa3\xd0\xba\x41\x30\xed\x51\xf9\xa8\xac\x17\x63\x53\xa5\x43\x79\x32\xb1\x0e\xc2\x68\x6d\x33\xc3\xa0\x3e\x6f\x0b\x71\x26\x40\x72\xb7\xe1\x36\xf4\xea\x0e\xfc\x8b\x1c\xa9\x32\xfd\xa6\x71\x05\xa2\x53\xc5\xcd\x9b\xb2\xc8\x36\xfe\xc0\xc8\x9e\x98\x67\x70\xa2\x5c\xea\x72\x7f\x22\x9c\x1e\x49\x7d\xc1\x91\x80\x1b\xfb\xee\x45\x1f\xec\x41\xb0\x35\x7e\xe9\xc4\xff\x7e\x10\x77\xa3\x62\xfe\x14\x6f\x0b\xe5\xe8\x3a\x45\x1d\x04\xe1\xd8\x42\xd4\x32\x9d\x9e\x06\x30\x41\xd6\xfa\xe8\xd6\x7b\xd8\x32\x5d\x2c\x89\x50\x20\x2a\x11\x0c\x32\x05\xc5\xdf\x23\x8e\x10\xc6\xc4\xaf\x04\xe2\x92\xa4\xc1\x82\xbc\x74\xb4\xf6\x18\x21\xcd\xd2\x9e\xe7\x26\xcd\xda\xda\x06\xb3\xe4\xe6\xb4\x6f\x26\x66\xca\x80\x09\x93\x65\x32\x1e\x0f\x20\x17\xf5\x8f\xaf\xae\x2e\xb3\xde\xbb\xb6\x18\xfa\xb3\x56\x70\x4e\x52\xa3\x92\x0f\x0c\xac\x41\x49\x13\x48\xd3\x64\x6a\x1b\x57\xfd\x6e\x9b\x90\x9f\x0b\xab\x60\x0b\xc1\xb5\xec\x4a\x74\x31\x0f\xe3\x96\x12\x83\xba\x32\x34\xb6\x92\x26\x93\xe8\x06\x55\x03\x4b\x0e\x2a\x88\x1e\x25\xcc\xf0\x29\x0a\x95\x98\x59\x42\x37\xe5\x08\xa7\x4d\xc6\xa4\x35\x76\xe5\x18\x83\x23\x8e\xea\x73\x90\xf9\xfa\x31\x06\x43\x4c\xec\xed\xc1\xd7\x1d\x70\xf4\x06\x16\x6b\x5a\x30\x14\x03\x49\xc1\x36\x53\xf0\x2d\x3c\x04\xc5\x79\x75\x04\xed\xe2\xbc\x14\x6a\xcb\xaa\xc5\xf9\xfe\x99\xe0\x36\x12\x34\xae\x07\x7d\x14\x8c\x9c\x94\xd2\x61\x9e\xbc\xbb\x46\x10\x67\x8d\x65\x31\x99\xef\x54\xaa\x5a\x06\x60\x73\x7f\x6d\x54\x84\x34\x92\xa1\x8d\xf4\xdb\x08\x5a\xba\x4b\x94\xd8\x91\xa0\xa1\x00\x4c\x8a\x4d\xb6\x2c\x1b\xa7\x1b\xf1\x81\xf3\xd5\x1c\xb6\x5d\xd4\x26\xf7\x45\x94\x76\xcc\x82\x92\x71\x62\xe9\x3c\xa2\x7e\x26\x82\xf0\x87\x0a\xb2\xa8\x4c\xd3\x20\x50\xb0\x21\x42\xa3\x0a\x50\x94\x8f\x97\xf6\x16\x96\x1f\x97\x44\x3b\xd3\xf7\x07\xbb\x7b\xfb\x40\x60\x10\x59\x39\xf7\x8d\x20\x13\x3b\xc2\x8d\x40\xa2\xf7\x82\xc4\x1b\xa6\x97\x86\xb7\x15\xbf\xd1\x7a\xc9\x4a\x14\xd1\xba\x49\x5d\x23\x15\xab\xa0\x0c\x35\x1f\x41\xc0\x62\x38\xa3\xe8\x33\xf3\x99\x08\x9f\xac\x03\x7b\x91\xdf\xda\x22\xe4\x37\xae\x37\x4e\xd2\x0d\xcb\xdb\xe2\x09\x39\x14\x9f\xeb\xf6\x0b\x59\x4c\x78\x0f\xb5\x31\x24\x5f\x99\x5a\xcd\xba\x7b\xa8\xd0\x52\xba\x8a\x8f\x5e\x23\x24\x33\x1e\x16\x28\x7a\x26\x08\xb8\xb1\x95\xa1\x40\x75\xb2\x67\x5f\xbc\x78\xf6\xe2\xe5\x8b\xc4\xec\xbe\xd8\x7e\xf6\x7c\x67\x6f\x27\xd9\xb6\x7b\xfb\xf3\x17\x49\xbc\xbf\xfb\x72\xf7\xf9\xf3\xa7\xfb\xdb\x4f\x93\xed\x64\xdf\x98\xd9\x2c\x49\xf6\x77\xcd\xce\x8e\x9d\x3f\xdf\xdd\x49\x76\xf6\x9e\xed\x26\x2f\x24\x0e\xb3\x45\x01\x93\x90\x76\x9a\x63\xa9\x4f\x57\xea\xed\x57\xca\x29\xcc\xa0\x55\xc4\x65\x79\x93\x8a\x75\xb3\x3a\xb8\x67\xab\x57\x52\x57\x00\x7f\xe5\x06\x09\x4f\xa6\x1b\x9f\xc9\x9c\x57\x09\x3f\x77\x56\x22\x16\xe0\xbf\x75\xad\xbf\xbe\xe9\xa2\x16\x2b\xb0\xf2\x8e\x0a\x69\x49\xd1\x67\xcb\x0c\x4e\x28\xda\xdb\xaf\x1a\x02\x69\x68\xb4\xd6\x5d\x57\x26\x6b\x7d\x85\x87\x6f\xaa\x5a\x66\x62\xd4\x22\x48\x8d\x62\xb6\x46\xcd\x34\xf5\x09\xa7\x2e\x09\x45\xd5\x10\xf2\x9c\x8a\x63\xab\x23\x84\x7a\x6d\xbe\xeb\x71\xb8\x7f\xa7\x6a\x0d\x65\x9b\xfb\xee\xdf\x59\x40\xda\xa8\x3e\x55\x86\x93\x9f\xbf\x9c\xdd\x5c\xe7\x6f\x7f\xbd\x7e\xf7\x36\xbf\x7e\x7f\xb6\xc4\xbf\xbc\x1f\xbb\x5e\xc6\xbb\x97\x39\x3e\xdf\x5c\x2f\x42\x25\x40\x9b\x75\x96\xd5\x49\xe8\xb5\xc4\x83\xb2\x90\xbd\xfa\x4a\xbb\xd6\x79\x67\x3d\x0c\x4b\x36\x49\xab\xc9\xee\x8b\xd1\xb3\xbd\xd1\xfe\xf3\xd1\xce\xf3\xbd\xe1\xf8\xd3\xdd\xd1\xee\xd3\x97\xa3\x9d\x6d\xfc\xdb\x93\xd0\x7b\x78\x7e\x39\x95\x26\xb6\x64\x1b\x78\xe4\x26\x5c\x15\xb0\x4c\x0c\xed\x53\x69\xeb\xb8\x3b\xa1\x0f\x6a\x9a\x23\xb0\x70\xdf\xa2\x8c\xcb\xda\xe3\x9a\x93\xbb\x61\x4e\xb3\x46\xd7\xb7\xf1\xf0\x4a\x8a\x4c\x43\x68\xe8\x73\x3d\x81\x4a\xe8\xed\x6d\xf9\xf6\x59\x2a\x65\x8b\x76\x71\xa9\x95\x00\xb5\x02\x4b\x1a\x70\xfc\x26\xe2\xa6\xb0\x8a\x0a\xe0\xc8\xb1\x69\x95\xf2\xde\x44\x67\x68\x4d\x85\xf4\xf1\xe8\x75\x68\x07\xfd\xb5\xf1\xe0\x5f\x0b\x17\xa7\x18\x46\xc8\x13\xb1\x78\xb6\xe7\xd6\x31\x31\x2e\x14\x8a\xd0\x98\x7d\x1b\xcb\x77\x14\xa4\x9d\x85\xf3\xeb\x21\x3c\xfb\x0f\x54\x05\x7c\x96\xac\xf9\xe7\x20\x93\xe3\xa2\x03\xdc\xfd\x86\x9a\xc6\xd5\xc7\xa9\x30\xf8\x52\x8f\x1e\xa5\xa1\xca\xe2\xda\x2b\x2b\x09\xd3\xa5\xd1\xa7\xd1\x90\x99\x8a\xa1\x2f\xb4\xe0\x7c\xc5\x88\x11\x94\x05\x28\xdb\x63\x42\x6c\xe6\x22\xa0\x93\x50\xa4\x8f\x1e\x79\x3b\x55\x72\x93\x37\x87\xef\xef\x8f\x5c\x1d\xde\x1b\x39\xfd\x6a\xe4\xfa\xf8\xf0\xd1\xeb\xbb\x43\xc7\x57\xef\x1f\x44\x6d\xda\x74\x3d\x80\x71\xbd\xf5\x4d\xd7\xa9\xe2\xaf\x3f\x4f\x91\x1d\x10\x24\x6b\x4f\x60\xf7\x4f\xee\xf6\x83\x7d\xf9\xfe\xb5\xe3\x96\x6c\xa7\xf8\xc6\xad\xd6\x37\xc3\x85\xbc\x7a\x09\x89\xad\x6f\xab\xdf\x6f\xfb\xf2\x6a\x2d\xf4\xc9\xd8\xef\xef\x1a\xb1\xda\x14\xb3\x9e\x6a\x68\x38\x0f\x7a\xea\x6e\xc9\x86\xcd\xb7\x49\xd5\xd6\xa1\x20\x5b\xa9\x89\xde\xed\x5c\xb3\x93\x2c\x77\x33\x39\xc3\x74\x7c\x43\xff\x96\x4e\xb6\x87\x5a\xa1\x46\xad\x10\x21\x6b\x2d\x46\x7c\x3e\x19\x45\x97\x1d\x6c\xc6\x14\x2f\x9c\x66\x59\xb6\x99\xc4\xff\xee\x22\x71\x66\x15\x7b\x08\xa4\x9e\x95\xb7\xda\xca\x46\xed\x88\x74\x81\x92\x51\x29\x6b\x5f\xab\x94\xaa\xcd\x34\x1e\x61\xc8\x5a\x8e\x6a\x11\x6a\x50\x83\x96\xbc\xc7\x42\x54\xc2\x42\x7f\x7d\xab\x86\x3a\x68\x4e\x77\xcd\xfc\x6f\x28\x4f\x5b\xe1\x66\xd0\x8a\x54\x6e\xc4\x89\x34\x7a\x05\x50\x5a\xb5\x75\x55\x6a\xfd\x5c\x5b\x7f\x87\x2b\x6c\xc8\xbe\xf7\x03\x79\x4f\x8a\x6e\xad\x94\xc2\x96\x01\x32\x58\x86\x54\xd2\x4e\xeb\xd0\x70\x6b\x42\x6e\x02\xff\x1c\xea\x5a\xeb\x6f\x8e\xa7\xf1\xae\x9b\x16\xab\x4f\x97\x36\xff\xa9\x69\x8e\x3e\xa4\x3f\x9d\x5e\xdb\x9f\xe6\x1f\x2f\x97\xeb\x2f\x66\x7d\xfd\xd9\xa4\xe5\x2f\xcd\xc5\xd3\xd5\xce\xfa\x41\x3c\xf3\xb0\x36\xeb\x6c\xe0\x88\x0f\xef\x70\xb1\xdf\xb1\xbb\x59\x1a\xb8\x57\x77\x4b\x24\xee\x75\xbf\x7d\x8c\xac\x0c\x62\xd2\x1f\x09\xc4\x8e\xde\x5f\x89\x26\x81\xc7\x93\xd2\xbb\xc8\x3a\xf4\xaf\xba\x80\x02\x82\x80\x5b\xd5\x52\x6f\xda\xfc\x85\x4c\xe3\xbd\x37\x34\x3e\x43\xff\xc5\x6f\xcd\xc6\x4e\x5a\x24\x6a\x49\x31\xec\x26\xdc\xba\x9b\xc1\xed\x18\xd5\x2d\xea\x97\x34\x4e\xa3\xe9\xba\xb2\xa0\xe6\x29\x79\x10\x0c\x6b\xe8\xb6\xe2\x3a\xb9\x25\x32\x35\x09\x77\xe6\xed\x65\xe3\xcb\x41\x7a\xb1\xb9\x4d\xf3\x36\x1f\xb4\x5e\x3c\x51\xe2\x0e\xe6\xe5\x3e\x6f\x04\x21\xa5\x3e\x57\x08\x2d\x50\x96\xdf\xb3\x36\x59\xf0\x52\x7a\x7b\x9b\x94\xdf\x23\xa6\x95\x73\x5e\xe3\xb2\x82\xe5\xf3\x7b\xb7\x70\x32\x26\xd0\x00\x40\x72\xb2\xb3\x0c\x8b\xb2\x52\xda\x41\xca\x66\xe2\x83\xa0\x02\xcc\x86\xad\x16\xc9\x63\xb6\x81\xa0\xa7\xfe\xec\xb5\x55\x28\x21\xcc\x12\xee\x2c\x50\x03\x4b\x4d\x15\x5b\x2f\x15\x2f\x5c\x41\x17\xbc\xb1\xce\x12\x71\x1f\xa3\xe0\x35\x70\x03\x49\x20\x68\x4c\x76\x9f\x2d\x1f\x06\x58\xd8\xd9\x12\x10\xf5\x61\xfd\x40\x7a\x98\x52\x3b\xaa\x64\x2e\xce\xa7\x57\x0a\x01\x7f\x9c\x9e\x9f\x49\xb7\x83\x9a\x5b\x7b\x5e\x3e\x5e\x9e\x8e\xa2\x37\x1d\x12\xf5\x97\x11\xb2\x5e\x5b\x5d\xec\x41\x1d\xc2\x20\xaf\x10\x84\x7d\xdf\xfb\x0d\x2a\xd5\x9b\x4b\xbd\xbd\x24\xfa\x86\x8d\x15\x8a\x61\x45\x0d\x0d\x4b\x86\x86\x59\xc0\xb3\xa1\x0e\xe2\x81\x3c\x3b\x71\x3e\x6a\x37\x95\x89\x15\x0e\x6a\xab\x8a\x2f\xff\xa0\x6a\x93\x5e\x98\x80\x71\x98\xa2\x56\xc1\xc8\x00\x6c\xd3\xbd\x35\xf0\x29\xfa\x53\x02\xb0\xbe\xf2\x9d\x41\x36\x5d\x24\x29\x25\x3e\xfe\xde\x56\x65\xa1\x6d\x01\x49\x43\xc8\x6f\x5d\x8d\xe8\xd7\x6d\x60\x64\x0b\xef\x20\xa0\xb6\x4a\xed\x9a\x22\x72\xd1\x78\xb5\x33\x2e\x67\x63\x2f\x9c\x66\xdc\xef\x23\xd7\x23\xc3\x3d\x6f\xb4\xe5\xae\xef\x52\x80\x9a\x5c\x98\x11\x73\x59\x4b\xeb\xf6\x24\xba\xbe\xa0\xbd\x35\x79\x85\xaa\x17\xe7\x1c\xf3\xc1\x1f\xcf\xd0\xeb\x91\xe8\xcc\xae\xcf\xf9\x69\x4b\xfe\x7f\xdb\x32\x6b\xeb\xe7\xc3\x92\x73\x09\x5f\x82\x17\x37\x70\x6c\xeb\xba\xcb\xe5\x26\x5d\x28\x6f\x41\xd1\x95\xd9\xf0\x3e\xd6\xbf\x09\xf0\xfe\xc3\xc1\xe1\x13\x5f\xf4\xfb\x98\xe4\x2b\x6f\xb9\x1e\x4f\x36\x41\xad\xe1\x88\x5f\x9e\xf4\x90\xf3\xc9\x14\xc4\x8d\x63\xf3\x6a\x69\x0d\x3d\x09\xd6\xf5\xb8\x59\x1a\x50\x9b\x3c\x0e\xf1\x50\x0a\x1c\xbd\x11\x1b\x76\x1a\xb8\x73\x2f\x1f\xe5\x7a\xf2\x75\x0b\xdc\xa5\xb9\x46\x20\xe3\x9c\xcd\x21\x6a\xd3\x2b\x6f\xa6\xb7\x14\x8b\x74\x45\x8b\x6c\x2b\x86\x70\x79\xe7\xc4\x13\xa5\x1b\xeb\x2a\x54\x4a\xdb\x0f\xe2\xca\xc7\x39\x4c\xf1\x61\x1d\xf9\x84\x66\x1b\x4d\x3f\x5c\x5d\x04\x40\x24\x2a\x71\x5b\x2c\x8e\xb5\xcb\xa6\x55\x33\xdf\xd9\xca\xc5\x33\x02\x28\x0c\x57\xab\x69\xb8\x92\x94\xe7\x67\x77\x96\x55\xb5\x95\x2b\xbb\x58\x6f\xf8\xbf\x9e\x72\x45\x9f\x1b\x4c\x83\x4b\xe6\xa9\x96\x15\x52\xf2\x89\x4f\xca\xee\xea\xfe\x07\x2b\x10\x50\x38\xc4\x27\xaf\xa2\xff\xef\xcc\x37\x1a\xda\x2f\xbb\xe0\x28\xa7\x2d\xed\x89\xef\xb7\x31\xb8\x7c\x50\x20\xf9\x0f\x80\x97\x1c\xde\xa0\xe8\x8b\x1f\x47\x03\xaf\x78\xb5\xf7\xe2\xb9\x4e\xe8\x1a\x37\xfa\xb5\x6b\xda\xe8\x57\x66\xab\x89\xa4\x8d\xff\x1b\xac\x0e\xe9\x85\xbe\x61\xe3\xd6\x77\x13\xfb\x1e\x6f\x90\xdd\x40\xe0\xa3\xe8\x93\xbc\x1d\xd3\xf5\x27\xa4\x95\xf5\xaf\x02\xe1\x65\x4b\xdb\x29\x2e\x6b\xb6\x22\xfc\xf7\xef\xc0\x38\x08\xa7\x6e\x33\x09\x0f\x35\x20\xdf\xd7\x56\x0c\x07\xc1\xa6\x36\xe9\x3b\x38\x9a\xf4\x18\x09\x8b\x8d\xfa\x9d\x2b\x91\x4c\x09\xfd\x74\x45\x3e\x03\x94\x4e\xf4\xbe\x8e\x77\x66\xfe\xba\x38\x49\x01\xbf\x69\xfa\xa2\x3e\xfd\xd6\xe5\xd4\x3d\x39\x34\xe5\xdb\x23\xf5\x2e\x59\x06\x93\x09\xaf\x80\x88\x22\x9d\x5c\xb0\x14\x35\xbc\x3a\x32\x73\x85\x98\x9e\xbb\xb0\x47\x0c\x72\xf0\x44\x56\xdf\x4a\xbf\x33\x9e\x88\x2e\xc7\x9b\x8c\x0e\x7b\x10\x66\xd5\x69\x92\x00\x04\x20\x16\xe0\x59\x4c\x87\x55\x60\x44\xfd\xf5\x9b\xa8\xd1\x6c\xf9\x92\xc4\xab\xad\x8b\x7f\x23\x87\xaf\x6c\x2e\xf8\xe3\xf2\xeb\x56\x88\x4c\xb2\xf7\x93\x7e\xef\xe1\x15\x77\x2a\x90\xe1\x1b\x17\xda\xa3\xe8\x98\x59\x30\xac\xd2\xbe\x28\x72\xa0\xf4\xe6\x11\xcb\xda\xd9\x3f\x31\xf1\xb1\xaa\x04\x84\x1e\x33\x26\x3e\xee\xe6\x8f\x1e\x24\xa8\x1c\xd9\x59\xbb\x78\x90\x58\x22\x94\x99\xa6\x16\x54\x40\x86\xa4\x4a\xd4\xe6\xcd\x5b\xbe\xaa\x65\xfc\x2b\xe1\x44\x8a\x76\x5e\x6a\x9c\x89\x61\xea\x28\x7a\x58\x9c\x6d\x45\xd0\x26\x5f\x2f\x88\x61\xe3\x2c\xc8\xfe\x4d\x64\x5b\x2e\x64\xfd\x84\x4b\xfe\xe0\x15\x5b\x49\x91\xfe\xbe\x00\x9f\xbf\x7a\x39\x73\x8c\xc1\xee\x8d\x38\x79\x29\x31\x64\x32\xff\x32\x20\xed\xe2\xfd\x15\x7c\x33\xbc\xeb\xe4\xf3\x15\x12\xda\x10\x71\x0f\x2b\x6b\xb9\x04\xed\xfb\x22\x62\xe9\xfd\xeb\x8a\xbe\xcb\xd8\xbd\x5c\x75\x8f\x4e\x5a\xe8\x8d\x1f\xa7\x76\x48\xa1\x7b\x57\x15\x2e\xc5\xbc\x8d\xb4\xdd\x75\xed\x5f\xfd\xaf\x5f\x4a\xee\xff\x3e\x16\x49\x8e\x2b\x8e\x29\x10\xf5\x37\x43\x23\xe9\xe4\xca\xc4\xc9\xfe\xf6\xbe\xa0\xf0\xcf\x10\xa8\x8d\x0e\x2f\x3e\x76\xbb\xfb\x60\xd4\xbf\xf9\x25\x2d\x73\x56\xd7\x55\x1b\x56\x8f\xe1\x06\x83\xf7\x7b\x47\x1c\x7f\xf4\x1f\xe8\x31\xa1\x42\x97\x2d\x00\x00")
 
 func sampleOpenbazaarConfBytes() ([]byte, error) {
 	return bindataRead(
@@ -83,7 +83,7 @@ func sampleOpenbazaarConf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sample-openbazaar.conf", size: 8815, mode: os.FileMode(420), modTime: time.Unix(1583004073, 0)}
+	info := bindataFileInfo{name: "sample-openbazaar.conf", size: 11671, mode: os.FileMode(420), modTime: time.Unix(1792341788, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
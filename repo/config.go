@@ -16,6 +16,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -60,49 +61,63 @@ var (
 //
 // See loadConfig for details on the configuration load process.
 type Config struct {
-	ShowVersion            bool     `short:"v" long:"version" description:"Display version information and exit"`
-	ConfigFile             string   `short:"C" long:"configfile" description:"Path to configuration file"`
-	DataDir                string   `short:"d" long:"datadir" description:"Directory to store data"`
-	LogDir                 string   `long:"logdir" description:"Directory to log output."`
-	LogLevel               string   `short:"l" long:"loglevel" description:"set the logging level [debug, info, notice, warning, error, critical]" default:"info"`
-	BoostrapAddrs          []string `long:"bootstrapaddr" description:"Override the default bootstrap addresses with the provided values"`
-	SwarmAddrs             []string `long:"swarmaddr" description:"Override the default swarm addresses with the provided values"`
-	GatewayAddr            string   `long:"gatewayaddr" description:"Override the default gateway address with the provided value"`
-	StoreAndForwardServers []string `long:"snfserver" description:"A peerID of a store and forward server to use for receiving messages while offline."`
-	Testnet                bool     `short:"t" long:"testnet" description:"Use the test network"`
-	DisableNATPortMap      bool     `long:"noupnp" description:"Disable use of upnp."`
-	IPNSQuorum             uint     `long:"ipnsquorum" description:"The size of the IPNS quorum to use. Smaller is faster but less up-to-date." default:"2"`
-	IPNSResolver           string   `long:"ipnsresolver" description:"If a URL is provided here the node will resolve IPNS records by querying this server instead of using the peer-to-peer network."`
-	NoIPNSPubsub           bool     `long:"noipnsps" description:"Disable use of IPNS pubsub."`
-	ExchangeRateProviders  []string `long:"exchangerateprovider" description:"API URL to use for exchange rates. Must conform to the BitcoinAverage format." default:"https://ticker.openbazaar.org/api"`
-	UseSSL                 bool     `long:"ssl" description:"Use SSL on the API"`
-	SSLCertFile            string   `long:"sslcertfile" description:"Path to the SSL certificate file"`
-	SSLKeyFile             string   `long:"sslkeyfile" description:"Path to the SSL key file"`
-	APIUsername            string   `short:"u" long:"apiusername" description:"The username to use with the API authentication"`
-	APIPassword            string   `short:"P" long:"apipassword" description:"The password to use with the API authentication"`
-	APICookie              string   `long:"apicookie" description:"A cookie to use for authentication in addition or in place of the un/pw. If set the cookie must be put in the request header."`
-	APIAllowedIPs          []string `long:"allowedip" description:"Only allow API connections from these IP addresses"`
-	APINoCors              bool     `long:"nocors" description:"Disable CORS on API responses"`
-	APIPublicGateway       bool     `long:"publicgateway" description:"When this option is used only public GET methods will be allowed in the API"`
-	Profile                string   `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
-	CPUProfile             string   `long:"cpuprofile" description:"Write CPU profile to the specified file"`
-	IPFSOnly               bool     `long:"ipfsonly" description:"Disable all OpenBazaar functionality except the IPFS networking."`
-	EnabledWallets         []string `long:"enabledwallet" description:"Only enable wallets in this list. Available wallets: [BTC, BCH, LTC, ZEC, ETH]"`
-	UserAgentComment       string   `long:"uacomment" description:"Comment to add to the user agent."`
-	EnableSNFServer        bool     `long:"enablesnfserver" description:"Enable this node to operate as a store-and-forward server."`
-	SNFServerPeers         []string `long:"snfpeer" description:"A list of other store-and-forward servers to replicate snf data to. This is only used when the snf server is enabled."`
-	Tor                    bool     `long:"tor" description:"Proxy all incoming and outgoing connections over the Tor network exclusively."`
-	DualStack              bool     `long:"dualstack" description:"Listen for incoming connections via Tor in addition to via the clearnet. This mode is not private."`
+	ShowVersion            bool          `short:"v" long:"version" description:"Display version information and exit"`
+	ConfigFile             string        `short:"C" long:"configfile" description:"Path to configuration file"`
+	DataDir                string        `short:"d" long:"datadir" description:"Directory to store data"`
+	LogDir                 string        `long:"logdir" description:"Directory to log output."`
+	LogLevel               string        `short:"l" long:"loglevel" description:"set the logging level [debug, info, notice, warning, error, critical]" default:"info"`
+	BoostrapAddrs          []string      `long:"bootstrapaddr" description:"Override the default bootstrap addresses with the provided values"`
+	SwarmAddrs             []string      `long:"swarmaddr" description:"Override the default swarm addresses with the provided values"`
+	GatewayAddr            string        `long:"gatewayaddr" description:"Override the default gateway address with the provided value"`
+	StoreAndForwardServers []string      `long:"snfserver" description:"A peerID of a store and forward server to use for receiving messages while offline."`
+	Testnet                bool          `short:"t" long:"testnet" description:"Use the test network"`
+	DisableNATPortMap      bool          `long:"noupnp" description:"Disable use of upnp."`
+	IPNSQuorum             uint          `long:"ipnsquorum" description:"The size of the IPNS quorum to use. Smaller is faster but less up-to-date." default:"2"`
+	IPNSResolver           string        `long:"ipnsresolver" description:"If a URL is provided here the node will resolve IPNS records by querying this server instead of using the peer-to-peer network."`
+	NoIPNSPubsub           bool          `long:"noipnsps" description:"Disable use of IPNS pubsub."`
+	ExchangeRateProviders  []string      `long:"exchangerateprovider" description:"API URL to use for exchange rates. Must conform to the BitcoinAverage format." default:"https://ticker.openbazaar.org/api"`
+	UseSSL                 bool          `long:"ssl" description:"Use SSL on the API"`
+	SSLCertFile            string        `long:"sslcertfile" description:"Path to the SSL certificate file"`
+	SSLKeyFile             string        `long:"sslkeyfile" description:"Path to the SSL key file"`
+	APIUsername            string        `short:"u" long:"apiusername" description:"The username to use with the API authentication"`
+	APIPassword            string        `short:"P" long:"apipassword" description:"The password to use with the API authentication"`
+	APICookie              string        `long:"apicookie" description:"A cookie to use for authentication in addition or in place of the un/pw. If set the cookie must be put in the request header."`
+	APIAllowedIPs          []string      `long:"allowedip" description:"Only allow API connections from these IP addresses"`
+	APINoCors              bool          `long:"nocors" description:"Disable CORS on API responses"`
+	APIPublicGateway       bool          `long:"publicgateway" description:"When this option is used only public GET methods will be allowed in the API"`
+	Profile                string        `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
+	CPUProfile             string        `long:"cpuprofile" description:"Write CPU profile to the specified file"`
+	IPFSOnly               bool          `long:"ipfsonly" description:"Disable all OpenBazaar functionality except the IPFS networking."`
+	EnabledWallets         []string      `long:"enabledwallet" description:"Only enable wallets in this list. Available wallets: [BTC, BCH, LTC, ZEC, ETH]"`
+	UserAgentComment       string        `long:"uacomment" description:"Comment to add to the user agent."`
+	EnableSNFServer        bool          `long:"enablesnfserver" description:"Enable this node to operate as a store-and-forward server."`
+	SNFServerPeers         []string      `long:"snfpeer" description:"A list of other store-and-forward servers to replicate snf data to. This is only used when the snf server is enabled."`
+	EnableCrawler          bool          `long:"enablecrawler" description:"Crawl the network for stores and cache their profiles and listings so this node can serve as a store directory."`
+	CrawlBudget            int           `long:"crawlbudget" description:"The maximum number of stores to fetch in each crawl. This is only used when the crawler is enabled." default:"100"`
+	CrawlInterval          time.Duration `long:"crawlinterval" description:"How often to crawl the network. This is only used when the crawler is enabled." default:"1h"`
+	CrawlMaxAge            time.Duration `long:"crawlmaxage" description:"How long a crawled store is considered fresh before it is fetched again. This is only used when the crawler is enabled." default:"24h"`
+	Webhooks               []string      `long:"webhook" description:"A URL to POST node events to. The URL may be followed by a space and a comma separated list of event types to only send those events. Otherwise all events except ChatTyping and BlockReceived are sent."`
+	WebhookSecret          string        `long:"webhooksecret" description:"The secret used to sign webhook payloads with HMAC-SHA256. Required if any webhooks are set."`
+	WebhookMaxAttempts     int           `long:"webhookmaxattempts" description:"The number of times to attempt a webhook delivery before giving up." default:"10"`
+	SMTPServer             string        `long:"smtpserver" description:"The host:port of the SMTP server used to send email notifications to the address in the emailNotifications preference."`
+	SMTPUsername           string        `long:"smtpusername" description:"The username to authenticate to the SMTP server with."`
+	SMTPPassword           string        `long:"smtppassword" description:"The password to authenticate to the SMTP server with."`
+	SMTPFrom               string        `long:"smtpfrom" description:"The address email notifications are sent from."`
+	SMTPSecurity           string        `long:"smtpsecurity" description:"How to secure the connection to the SMTP server [none, starttls, tls]" default:"starttls"`
+	EmailDigestInterval    time.Duration `long:"emaildigestinterval" description:"How long to collect email notifications for before sending them in a single digest." default:"5m"`
+	EmailChatDelay         time.Duration `long:"emailchatdelay" description:"How long a chat message must go unread before it is emailed." default:"15m"`
+	Tor                    bool          `long:"tor" description:"Proxy all incoming and outgoing connections over the Tor network exclusively."`
+	DualStack              bool          `long:"dualstack" description:"Listen for incoming connections via Tor in addition to via the clearnet. This mode is not private."`
 }
 
 // LoadConfig initializes and parses the config using a config file and command
 // line options.
 //
 // The configuration proceeds as follows:
-// 	1) Start with a default config with sane settings
-// 	2) Pre-parse the command line to check for an alternative config file
-// 	3) Load configuration file overwriting defaults with any specified options
-// 	4) Parse CLI options and overwrite/add any specified options
+//  1. Start with a default config with sane settings
+//  2. Pre-parse the command line to check for an alternative config file
+//  3. Load configuration file overwriting defaults with any specified options
+//  4. Parse CLI options and overwrite/add any specified options
 //
 // The above results in OpenBazaar functioning properly without any config settings
 // while still allowing the user to override settings with config files and
@@ -467,9 +467,18 @@ func autoMigrateDatabase(db database.Database) error {
 		&models.IncomingMessage{},
 		&models.ChatMessage{},
 		&models.NotificationRecord{},
+		&models.WebhookDelivery{},
 		&models.FollowerStat{},
 		&models.FollowSequence{},
 		&models.Coupon{},
+		&models.CouponRedemption{},
+		&models.InventoryItem{},
+		&models.InventoryReservation{},
+		&models.ListingVersion{},
+		&models.SearchPeer{},
+		&models.SearchListing{},
+		&models.SearchTerm{},
+		&models.CrawledStore{},
 		&models.Event{},
 		&models.Order{},
 		&models.TransactionMetadata{},
@@ -483,6 +492,37 @@ func autoMigrateDatabase(db database.Database) error {
 				return err
 			}
 		}
+
+		// Orders saved before the derived order columns existed need
+		// to be saved again to populate them.
+		var orders []models.Order
+		if err := tx.Read().Where("current_state = ? OR current_state IS NULL", "").Find(&orders).Error; err != nil {
+			return err
+		}
+		for i := range orders {
+			if err := tx.Save(&orders[i]); err != nil {
+				return err
+			}
+		}
+
+		// Notifications saved before the type column existed need their
+		// type parsed from the notification.
+		var notifications []models.NotificationRecord
+		if err := tx.Read().Where("type = ? OR type IS NULL", "").Find(&notifications).Error; err != nil {
+			return err
+		}
+		for i := range notifications {
+			var notification struct {
+				Type string `json:"type"`
+			}
+			if err := json.Unmarshal(notifications[i].Notification, &notification); err != nil || notification.Type == "" {
+				continue
+			}
+			notifications[i].Type = notification.Type
+			if err := tx.Save(&notifications[i]); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 }
@@ -99,3 +99,54 @@ func TestNewRepoWithCustomMnemonicSeed(t *testing.T) {
 		t.Errorf("Failed to set correct mnemonic. Expected %s, got %s", mnemonic, string(dbSeed.Value))
 	}
 }
+
+func TestRepo_migrateNotificationTypes(t *testing.T) {
+	var dir = path.Join(os.TempDir(), "openbazaar", "migrateNotificationTypesTest")
+	r, err := NewRepo(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.DestroyRepo()
+
+	records := []models.NotificationRecord{
+		{ID: "1", Notification: []byte(`{"notificationID": "1", "type": "NewOrder"}`)},
+		{ID: "2", Notification: []byte(`{"notificationID": "2"}`)},
+		{ID: "3", Type: "OrderFunded", Notification: []byte(`{"notificationID": "3", "type": "OrderFunded"}`)},
+	}
+	err = r.db.Update(func(tx database.Tx) error {
+		for i := range records {
+			if err := tx.Save(&records[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := autoMigrateDatabase(r.db); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"1": "NewOrder",
+		"2": "",
+		"3": "OrderFunded",
+	}
+	err = r.db.View(func(tx database.Tx) error {
+		for id, typ := range expected {
+			var record models.NotificationRecord
+			if err := tx.Read().Where("id = ?", id).First(&record).Error; err != nil {
+				return err
+			}
+			if record.Type != typ {
+				t.Errorf("Notification %s: expected type %q, got %q", id, typ, record.Type)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
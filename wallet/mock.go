@@ -148,6 +148,10 @@ type MockWallet struct {
 	watchedAddrs map[iwallet.Address]struct{}
 	transactions map[iwallet.TransactionID]iwallet.Transaction
 
+	// chainTxs holds every transaction seen on the network. It's used
+	// to answer address queries the way a blockchain indexer would.
+	chainTxs map[iwallet.TransactionID]iwallet.Transaction
+
 	utxos map[string]mockUtxo
 
 	blockchainInfo iwallet.BlockInfo
@@ -172,6 +176,7 @@ func NewMockWallet() *MockWallet {
 		addrs:        make(map[iwallet.Address]bool),
 		watchedAddrs: make(map[iwallet.Address]struct{}),
 		transactions: make(map[iwallet.TransactionID]iwallet.Transaction),
+		chainTxs:     make(map[iwallet.TransactionID]iwallet.Transaction),
 		utxos:        make(map[string]mockUtxo),
 		incoming:     make(chan iwallet.Transaction),
 		block:        make(chan iwallet.BlockInfo),
@@ -338,12 +343,13 @@ func (w *MockWallet) Start() {
 						watched = true
 					}
 				}
+				defaultTime := time.Time{}
+				if tx.Timestamp == defaultTime {
+					tx.Timestamp = time.Now()
+				}
+				w.chainTxs[tx.ID] = tx
 				if relevant || watched {
 					tx.Value = total
-					defaultTime := time.Time{}
-					if tx.Timestamp == defaultTime {
-						tx.Timestamp = time.Now()
-					}
 					w.transactions[tx.ID] = tx
 					for _, sub := range w.txSubs {
 						sub <- tx
@@ -364,6 +370,13 @@ func (w *MockWallet) Start() {
 						w.transactions[txid] = txn
 					}
 				}
+				for txid, txn := range w.chainTxs {
+					if txn.Height == 0 {
+						txn.Height = blockInfo.Height
+						txn.BlockInfo = &blockInfo
+						w.chainTxs[txid] = txn
+					}
+				}
 				for op, utxo := range w.utxos {
 					if utxo.height == 0 {
 						utxo.height = blockInfo.Height
@@ -613,7 +626,7 @@ func (w *MockWallet) GetAddressTransactions(addr iwallet.Address) ([]iwallet.Tra
 
 	var txs []iwallet.Transaction
 txloop:
-	for _, tx := range w.transactions {
+	for _, tx := range w.chainTxs {
 		for _, in := range tx.From {
 			if in.Address.String() == addr.String() {
 				txs = append(txs, tx)